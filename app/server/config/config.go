@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math"
 	"os"
+	"path/filepath"
+	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/encoding/prototext"
@@ -31,6 +33,14 @@ func makeDefaultPushdownConfig() *config.TPushdownConfig {
 	}
 }
 
+func makeDefaultPostgreSQLSplittingConfig() *config.TPostgreSQLConfig_TSplitting {
+	return &config.TPostgreSQLConfig_TSplitting{
+		Enabled:       false,
+		PagesPerSplit: 131072, // 1 GB with default 8 KB pages
+		MaxSplitCount: 32,
+	}
+}
+
 // TODO: use reflection to generalize datasource setting code
 //
 //nolint:gocyclo,funlen
@@ -39,6 +49,10 @@ func fillServerConfigDefaults(c *config.TServerConfig) {
 		c.ConnectorServer.MaxRecvMessageSize = math.MaxInt32
 	}
 
+	if c.ConnectorServer.MaxConcurrentSplits == 0 {
+		c.ConnectorServer.MaxConcurrentSplits = 4
+	}
+
 	if c.Paging == nil {
 		c.Paging = &config.TPagingConfig{
 			BytesPerPage:          4 * 1024 * 1024,
@@ -80,6 +94,14 @@ func fillServerConfigDefaults(c *config.TServerConfig) {
 		c.Datasources.Clickhouse.Pushdown = makeDefaultPushdownConfig()
 	}
 
+	if c.Datasources.Clickhouse.Splitting == nil {
+		c.Datasources.Clickhouse.Splitting = &config.TClickHouseConfig_TSplitting{
+			SplitByPartitions: false,
+			SplitByShards:     false,
+			MaxSplitCount:     32,
+		}
+	}
+
 	// Greenplum
 
 	if c.Datasources.Greenplum == nil {
@@ -171,6 +193,10 @@ func fillServerConfigDefaults(c *config.TServerConfig) {
 		}
 	}
 
+	if c.Datasources.Redis.ListTablesMaxScannedKeys == 0 {
+		c.Datasources.Redis.ListTablesMaxScannedKeys = 1_000_000
+	}
+
 	if c.Datasources.Redis.ExponentialBackoff == nil {
 		c.Datasources.Redis.ExponentialBackoff = makeDefaultExponentialBackoffConfig()
 	}
@@ -187,10 +213,54 @@ func fillServerConfigDefaults(c *config.TServerConfig) {
 		}
 	}
 
+	if c.Datasources.Opensearch.PointInTimeKeepAlive == "" {
+		c.Datasources.Opensearch.PointInTimeKeepAlive = "5m"
+	}
+
 	if c.Datasources.Opensearch.ExponentialBackoff == nil {
 		c.Datasources.Opensearch.ExponentialBackoff = makeDefaultExponentialBackoffConfig()
 	}
 
+	// Prometheus
+
+	if c.Datasources.Prometheus == nil {
+		c.Datasources.Prometheus = &config.TPrometheusConfig{
+			RequestTimeout: "30s",
+			MaxQueryRange:  "504h",
+			SplitDuration:  "1h",
+		}
+	}
+
+	if c.Datasources.Prometheus.ExponentialBackoff == nil {
+		c.Datasources.Prometheus.ExponentialBackoff = makeDefaultExponentialBackoffConfig()
+	}
+
+	// Iceberg
+
+	if c.Datasources.Iceberg == nil {
+		c.Datasources.Iceberg = &config.TIcebergConfig{
+			BatchSize: 4096,
+		}
+	}
+
+	if c.Datasources.Iceberg.ExponentialBackoff == nil {
+		c.Datasources.Iceberg.ExponentialBackoff = makeDefaultExponentialBackoffConfig()
+	}
+
+	// Connection pool
+
+	if c.Datasources.ConnectionPool == nil {
+		// the pool is disabled until it has been tested under production load
+		c.Datasources.ConnectionPool = &config.TConnectionPoolConfig{
+			Enabled:                  false,
+			MaxConnectionsPerKey:     32,
+			MaxIdleConnectionsPerKey: 4,
+			IdleTimeout:              "1m",
+			MaxLifetime:              "30m",
+			HealthCheckPeriod:        "10s",
+		}
+	}
+
 	// PostgreSQL
 
 	if c.Datasources.Postgresql == nil {
@@ -207,6 +277,10 @@ func fillServerConfigDefaults(c *config.TServerConfig) {
 		c.Datasources.Postgresql.Pushdown = makeDefaultPushdownConfig()
 	}
 
+	if c.Datasources.Postgresql.Splitting == nil {
+		c.Datasources.Postgresql.Splitting = makeDefaultPostgreSQLSplittingConfig()
+	}
+
 	// YDB
 
 	if c.Datasources.Ydb == nil {
@@ -262,6 +336,7 @@ func fillYdbConfigDefaults(c *config.TYdbConfig) {
 	if c.Splitting == nil {
 		c.Splitting = &config.TYdbConfig_TSplitting{
 			EnabledOnColumnShards: false,
+			EnabledOnDataShards:   false,
 		}
 	}
 
@@ -382,7 +457,12 @@ func validatePprofServerConfig(c *config.TPprofServerConfig) error {
 	return nil
 }
 
-const maxInterconnectMessageSize = 50 * 1024 * 1024
+const (
+	maxInterconnectMessageSize = 50 * 1024 * 1024
+
+	// the length of the output of SHA-256 used to sign the continuation tokens
+	minContinuationTokenSecretLength = 32
+)
 
 func validatePagingConfig(c *config.TPagingConfig) error {
 	if c == nil {
@@ -398,6 +478,10 @@ func validatePagingConfig(c *config.TPagingConfig) error {
 		return fmt.Errorf("`bytes_per_page` limit exceeds the limits of interconnect system used by YDB engine")
 	}
 
+	if c.ContinuationTokenSecret != "" && len(c.ContinuationTokenSecret) < minContinuationTokenSecretLength {
+		return fmt.Errorf("`continuation_token_secret` must be at least %d bytes long", minContinuationTokenSecretLength)
+	}
+
 	return nil
 }
 
@@ -458,6 +542,18 @@ func validateDatasourcesConfig(c *config.TDatasourcesConfig) error {
 		return fmt.Errorf("validate `redis`: %w", err)
 	}
 
+	if err := validatePrometheusConfig(c.Prometheus); err != nil {
+		return fmt.Errorf("validate `prometheus`: %w", err)
+	}
+
+	if err := validateIcebergConfig(c.Iceberg); err != nil {
+		return fmt.Errorf("validate `iceberg`: %w", err)
+	}
+
+	if err := validateConnectionPoolConfig(c.ConnectionPool); err != nil {
+		return fmt.Errorf("validate `connection_pool`: %w", err)
+	}
+
 	return nil
 }
 
@@ -583,6 +679,10 @@ func validateRedisConfig(c *config.TRedisConfig) error {
 		return fmt.Errorf("validate `count_docs_to_deduce_schema`: can't be zero")
 	}
 
+	if c.ListTablesMaxScannedKeys == 0 {
+		return fmt.Errorf("validate `list_tables_max_scanned_keys`: can't be zero")
+	}
+
 	if err := validateExponentialBackoff(c.ExponentialBackoff); err != nil {
 		return fmt.Errorf("validate `exponential_backoff`: %v", err)
 	}
@@ -725,10 +825,111 @@ func validateOpenSearchConfig(c *config.TOpenSearchConfig) error {
 		return fmt.Errorf("validate `batch_size`, must be greater than zero")
 	}
 
+	if _, err := common.DurationFromString(c.PointInTimeKeepAlive); err != nil {
+		return fmt.Errorf("validate `point_in_time_keep_alive`: %v", err)
+	}
+
+	if err := validateExponentialBackoff(c.ExponentialBackoff); err != nil {
+		return fmt.Errorf("validate `exponential_backoff`: %v", err)
+	}
+
+	return nil
+}
+
+func validatePrometheusConfig(c *config.TPrometheusConfig) error {
+	if c == nil {
+		return nil
+	}
+
+	if _, err := common.DurationFromString(c.RequestTimeout); err != nil {
+		return fmt.Errorf("validate `request_timeout`: %v", err)
+	}
+
+	maxQueryRange, err := common.DurationFromString(c.MaxQueryRange)
+	if err != nil {
+		return fmt.Errorf("validate `max_query_range`: %v", err)
+	}
+
+	splitDuration, err := common.DurationFromString(c.SplitDuration)
+	if err != nil {
+		return fmt.Errorf("validate `split_duration`: %v", err)
+	}
+
+	if maxQueryRange <= 0 {
+		return fmt.Errorf("validate `max_query_range`, must be greater than zero")
+	}
+
+	if splitDuration <= 0 {
+		return fmt.Errorf("validate `split_duration`, must be greater than zero")
+	}
+
+	if err := validateExponentialBackoff(c.ExponentialBackoff); err != nil {
+		return fmt.Errorf("validate `exponential_backoff`: %v", err)
+	}
+
+	return nil
+}
+
+func validateIcebergConfig(c *config.TIcebergConfig) error {
+	if c == nil {
+		return nil
+	}
+
+	if c.BatchSize == 0 {
+		return fmt.Errorf("validate `batch_size`, must be greater than zero")
+	}
+
 	if err := validateExponentialBackoff(c.ExponentialBackoff); err != nil {
 		return fmt.Errorf("validate `exponential_backoff`: %v", err)
 	}
 
+	for _, dir := range c.AllowedLocalWarehouses {
+		if !filepath.IsAbs(dir) {
+			return fmt.Errorf("validate `allowed_local_warehouses`, '%s' is not an absolute path", dir)
+		}
+	}
+
+	return nil
+}
+
+const minConnectionPoolIdleTimeout = time.Second
+
+func validateConnectionPoolConfig(c *config.TConnectionPoolConfig) error {
+	if c == nil || !c.Enabled {
+		return nil
+	}
+
+	if c.MaxIdleConnectionsPerKey == 0 {
+		return fmt.Errorf("validate `max_idle_connections_per_key`, must be greater than zero")
+	}
+
+	idleTimeout, err := common.DurationFromString(c.IdleTimeout)
+	if err != nil {
+		return fmt.Errorf("validate `idle_timeout`: %v", err)
+	}
+
+	if idleTimeout < minConnectionPoolIdleTimeout {
+		return fmt.Errorf("validate `idle_timeout`, must be at least %v", minConnectionPoolIdleTimeout)
+	}
+
+	maxLifetime, err := common.DurationFromString(c.MaxLifetime)
+	if err != nil {
+		return fmt.Errorf("validate `max_lifetime`: %v", err)
+	}
+
+	if maxLifetime <= 0 {
+		return fmt.Errorf("validate `max_lifetime`, must be positive")
+	}
+
+	healthCheckPeriod, err := common.DurationFromString(c.HealthCheckPeriod)
+	if err != nil {
+		return fmt.Errorf("validate `health_check_period`: %v", err)
+	}
+
+	if healthCheckPeriod < 0 {
+		return fmt.Errorf("validate `health_check_period`, must not be negative")
+	}
+
 	return nil
 }
 
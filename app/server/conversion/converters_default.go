@@ -70,6 +70,25 @@ func (boolConverter) Convert(in *bool) (uint8, error) {
 	return 0, nil
 }
 
+// SignedToUnsigned returns the converter used by the data sources having no unsigned integer types
+// to read the values of the columns, that are expected to be unsigned (e.g. the results of datetime functions).
+// The values out of the range of the unsigned type are rejected.
+func SignedToUnsigned[IN int16 | int32 | int64, OUT uint8 | uint16]() ValuePtrConverter[IN, OUT] {
+	return signedToUnsignedConverter[IN, OUT]{}
+}
+
+type signedToUnsignedConverter[IN int16 | int32 | int64, OUT uint8 | uint16] struct{}
+
+func (signedToUnsignedConverter[IN, OUT]) Convert(in *IN) (OUT, error) {
+	out := OUT(*in)
+
+	if *in < 0 || IN(out) != *in {
+		return 0, fmt.Errorf("value %d is out of range of %T", *in, out)
+	}
+
+	return out, nil
+}
+
 type stringToBytesConverter struct{}
 
 func (stringToBytesConverter) Convert(in *string) ([]byte, error) { return []byte(*in), nil }
@@ -97,6 +97,34 @@ func FuzzDateToStringConverter(f *testing.F) {
 	})
 }
 
+func TestSignedToUnsignedConverter(t *testing.T) {
+	toUint8 := SignedToUnsigned[int16, uint8]()
+
+	for _, in := range []int16{0, 12, math.MaxUint8} {
+		out, err := toUint8.Convert(&in)
+		require.NoError(t, err)
+		require.Equal(t, uint8(in), out)
+	}
+
+	for _, in := range []int16{-1, math.MaxUint8 + 1, math.MinInt16} {
+		_, err := toUint8.Convert(&in)
+		require.Error(t, err)
+	}
+
+	toUint16 := SignedToUnsigned[int64, uint16]()
+
+	for _, in := range []int64{0, 2024, math.MaxUint16} {
+		out, err := toUint16.Convert(&in)
+		require.NoError(t, err)
+		require.Equal(t, uint16(in), out)
+	}
+
+	for _, in := range []int64{-2024, math.MaxUint16 + 1, math.MaxInt64} {
+		_, err := toUint16.Convert(&in)
+		require.Error(t, err)
+	}
+}
+
 func TestTimestampToStringConverter(t *testing.T) {
 	testCases := []time.Time{
 		time.Date(math.MaxInt, math.MaxInt, math.MaxInt, math.MaxInt, math.MaxInt, math.MaxInt, math.MaxInt, time.UTC),
@@ -3,9 +3,11 @@ package server
 import (
 	"context"
 	"fmt"
+	"regexp"
 
 	"github.com/apache/arrow/go/v13/arrow/memory"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service "github.com/ydb-platform/fq-connector-go/api/service"
@@ -13,17 +15,23 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource/iceberg"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource/nosql/mongodb"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource/nosql/opensearch"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource/nosql/prometheus"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource/nosql/redis"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 	"github.com/ydb-platform/fq-connector-go/app/server/observation"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/app/server/streaming"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
 	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
 )
 
+const listTablesBatchSize = 1000
+
 type DataSourceCollection struct {
 	rdbms               datasource.Factory[any]
 	memoryAllocator     memory.Allocator
@@ -91,12 +99,189 @@ func (dsc *DataSourceCollection) DescribeTable(
 			dsc.queryLoggerFactory.Make(logger),
 		)
 
+		return ds.DescribeTable(ctx, logger, request)
+	case api_common.EGenericDataSourceKind_PROMETHEUS:
+		prometheusCfg := dsc.cfg.Datasources.Prometheus
+		ds := prometheus.NewDataSource(
+			&retry.RetrierSet{
+				MakeConnection: retry.NewRetrierFromConfig(prometheusCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
+				Query:          retry.NewRetrierFromConfig(prometheusCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
+			},
+			prometheusCfg,
+			logger,
+			dsc.converterCollection,
+			dsc.queryLoggerFactory.Make(logger),
+		)
+
+		return ds.DescribeTable(ctx, logger, request)
+	case api_common.EGenericDataSourceKind_ICEBERG:
+		icebergCfg := dsc.cfg.Datasources.Iceberg
+		ds := iceberg.NewDataSource(
+			&retry.RetrierSet{
+				MakeConnection: retry.NewRetrierFromConfig(icebergCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
+				Query:          retry.NewRetrierFromConfig(icebergCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
+			},
+			icebergCfg,
+			dsc.converterCollection,
+			dsc.queryLoggerFactory.Make(logger),
+		)
+
 		return ds.DescribeTable(ctx, logger, request)
 	default:
 		return nil, fmt.Errorf("unsupported data source type '%v': %w", kind, common.ErrDataSourceNotSupported)
 	}
 }
 
+func (dsc *DataSourceCollection) ListTables(
+	logger *zap.Logger,
+	stream api_service.Connector_ListTablesServer,
+	request *api_service_protos.TListTablesRequest,
+) error {
+	kind := request.GetDataSourceInstance().GetKind()
+
+	var (
+		response *api_service_protos.TListTablesResponse
+		err      error
+	)
+
+	switch kind {
+	case api_common.EGenericDataSourceKind_CLICKHOUSE, api_common.EGenericDataSourceKind_POSTGRESQL,
+		api_common.EGenericDataSourceKind_YDB, api_common.EGenericDataSourceKind_MS_SQL_SERVER,
+		api_common.EGenericDataSourceKind_MYSQL, api_common.EGenericDataSourceKind_GREENPLUM,
+		api_common.EGenericDataSourceKind_ORACLE, api_common.EGenericDataSourceKind_LOGGING:
+		ds, makeErr := dsc.rdbms.Make(logger, kind)
+		if makeErr != nil {
+			return fmt.Errorf("make data source: %w", makeErr)
+		}
+
+		response, err = ds.ListTables(stream.Context(), logger, request)
+	case api_common.EGenericDataSourceKind_MONGO_DB:
+		mongoDbCfg := dsc.cfg.Datasources.Mongodb
+		ds := mongodb.NewDataSource(
+			&retry.RetrierSet{
+				MakeConnection: retry.NewRetrierFromConfig(mongoDbCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
+				Query:          retry.NewRetrierFromConfig(mongoDbCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
+			},
+			dsc.converterCollection,
+			mongoDbCfg,
+			dsc.queryLoggerFactory.Make(logger),
+		)
+
+		response, err = ds.ListTables(stream.Context(), logger, request)
+	case api_common.EGenericDataSourceKind_REDIS:
+		redisCfg := dsc.cfg.Datasources.Redis
+		ds := redis.NewDataSource(
+			&retry.RetrierSet{
+				MakeConnection: retry.NewRetrierFromConfig(redisCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
+				Query:          retry.NewRetrierFromConfig(redisCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
+			},
+			redisCfg,
+			dsc.converterCollection,
+			dsc.queryLoggerFactory.Make(logger),
+		)
+
+		response, err = ds.ListTables(stream.Context(), logger, request)
+	case api_common.EGenericDataSourceKind_OPENSEARCH:
+		openSearchCfg := dsc.cfg.Datasources.Opensearch
+		ds := opensearch.NewDataSource(
+			&retry.RetrierSet{
+				MakeConnection: retry.NewRetrierFromConfig(openSearchCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
+				Query:          retry.NewRetrierFromConfig(openSearchCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
+			},
+			openSearchCfg,
+			logger,
+			dsc.converterCollection,
+			dsc.queryLoggerFactory.Make(logger),
+		)
+
+		response, err = ds.ListTables(stream.Context(), logger, request)
+	case api_common.EGenericDataSourceKind_PROMETHEUS:
+		prometheusCfg := dsc.cfg.Datasources.Prometheus
+		ds := prometheus.NewDataSource(
+			&retry.RetrierSet{
+				MakeConnection: retry.NewRetrierFromConfig(prometheusCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
+				Query:          retry.NewRetrierFromConfig(prometheusCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
+			},
+			prometheusCfg,
+			logger,
+			dsc.converterCollection,
+			dsc.queryLoggerFactory.Make(logger),
+		)
+
+		response, err = ds.ListTables(stream.Context(), logger, request)
+	case api_common.EGenericDataSourceKind_ICEBERG:
+		icebergCfg := dsc.cfg.Datasources.Iceberg
+		ds := iceberg.NewDataSource(
+			&retry.RetrierSet{
+				MakeConnection: retry.NewRetrierFromConfig(icebergCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
+				Query:          retry.NewRetrierFromConfig(icebergCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
+			},
+			icebergCfg,
+			dsc.converterCollection,
+			dsc.queryLoggerFactory.Make(logger),
+		)
+
+		response, err = ds.ListTables(stream.Context(), logger, request)
+	default:
+		return fmt.Errorf("unsupported data source type '%v': %w", kind, common.ErrDataSourceNotSupported)
+	}
+
+	if err != nil {
+		return fmt.Errorf("list tables: %w", err)
+	}
+
+	tables, err := filterTables(response.GetTables(), request.GetPattern())
+	if err != nil {
+		return fmt.Errorf("filter tables: %w", err)
+	}
+
+	return sendListTablesResponses(stream, tables)
+}
+
+// filterTables keeps only the tables matching the (optional) regular expression
+func filterTables(tables []string, pattern string) ([]string, error) {
+	if pattern == "" {
+		return tables, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("compile pattern '%s': %w", pattern, err)
+	}
+
+	dst := make([]string, 0, len(tables))
+
+	for _, table := range tables {
+		if re.MatchString(table) {
+			dst = append(dst, table)
+		}
+	}
+
+	return dst, nil
+}
+
+// sendListTablesResponses splits the list of tables into the batches of limited size,
+// so that the response messages do not exceed the GRPC message size limit.
+func sendListTablesResponses(stream api_service.Connector_ListTablesServer, tables []string) error {
+	for {
+		batchSize := min(len(tables), listTablesBatchSize)
+
+		response := &api_service_protos.TListTablesResponse{
+			Tables: tables[:batchSize],
+			Error:  common.NewSuccess(),
+		}
+
+		if err := stream.Send(response); err != nil {
+			return fmt.Errorf("stream send: %w", err)
+		}
+
+		tables = tables[batchSize:]
+		if len(tables) == 0 {
+			return nil
+		}
+	}
+}
+
 func (dsc *DataSourceCollection) ListSplits(
 	logger *zap.Logger,
 	stream api_service.Connector_ListSplitsServer,
@@ -169,6 +354,41 @@ func (dsc *DataSourceCollection) ListSplits(
 
 			streamer := streaming.NewListSplitsStreamer(logger, stream, ds, request, slct)
 
+			if err := streamer.Run(); err != nil {
+				return fmt.Errorf("run streamer: %w", err)
+			}
+		case api_common.EGenericDataSourceKind_PROMETHEUS:
+			prometheusCfg := dsc.cfg.Datasources.Prometheus
+			ds := prometheus.NewDataSource(
+				&retry.RetrierSet{
+					MakeConnection: retry.NewRetrierFromConfig(prometheusCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
+					Query:          retry.NewRetrierFromConfig(prometheusCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
+				},
+				prometheusCfg,
+				logger,
+				dsc.converterCollection,
+				dsc.queryLoggerFactory.Make(logger),
+			)
+
+			streamer := streaming.NewListSplitsStreamer(logger, stream, ds, request, slct)
+
+			if err := streamer.Run(); err != nil {
+				return fmt.Errorf("run streamer: %w", err)
+			}
+		case api_common.EGenericDataSourceKind_ICEBERG:
+			icebergCfg := dsc.cfg.Datasources.Iceberg
+			ds := iceberg.NewDataSource(
+				&retry.RetrierSet{
+					MakeConnection: retry.NewRetrierFromConfig(icebergCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
+					Query:          retry.NewRetrierFromConfig(icebergCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
+				},
+				icebergCfg,
+				dsc.converterCollection,
+				dsc.queryLoggerFactory.Make(logger),
+			)
+
+			streamer := streaming.NewListSplitsStreamer(logger, stream, ds, request, slct)
+
 			if err := streamer.Run(); err != nil {
 				return fmt.Errorf("run streamer: %w", err)
 			}
@@ -242,6 +462,35 @@ func (dsc *DataSourceCollection) ReadSplit(
 			dsc.queryLoggerFactory.Make(logger),
 		)
 
+		return doReadSplit(
+			logger, stream, request, split, ds, dsc.memoryAllocator, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
+	case api_common.EGenericDataSourceKind_PROMETHEUS:
+		prometheusCfg := dsc.cfg.Datasources.Prometheus
+		ds := prometheus.NewDataSource(
+			&retry.RetrierSet{
+				MakeConnection: retry.NewRetrierFromConfig(prometheusCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
+				Query:          retry.NewRetrierFromConfig(prometheusCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
+			},
+			prometheusCfg,
+			logger,
+			dsc.converterCollection,
+			dsc.queryLoggerFactory.Make(logger),
+		)
+
+		return doReadSplit(
+			logger, stream, request, split, ds, dsc.memoryAllocator, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
+	case api_common.EGenericDataSourceKind_ICEBERG:
+		icebergCfg := dsc.cfg.Datasources.Iceberg
+		ds := iceberg.NewDataSource(
+			&retry.RetrierSet{
+				MakeConnection: retry.NewRetrierFromConfig(icebergCfg.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
+				Query:          retry.NewRetrierFromConfig(icebergCfg.ExponentialBackoff, retry.ErrorCheckerNoop),
+			},
+			icebergCfg,
+			dsc.converterCollection,
+			dsc.queryLoggerFactory.Make(logger),
+		)
+
 		return doReadSplit(
 			logger, stream, request, split, ds, dsc.memoryAllocator, dsc.readLimiterFactory, dsc.observationStorage, dsc.cfg)
 
@@ -270,6 +519,17 @@ func doReadSplit[T paging.Acceptor](
 
 	logger.Debug("split reading started", common.SelectToFields(split.Select)...)
 
+	// Resume reading from the last delivered page if the client asks for it
+	continuationToken, err := paging.MakeContinuationToken(split, request.GetContinuation(), cfg.Paging.GetContinuationTokenSecret())
+	if err != nil {
+		return fmt.Errorf("make continuation token: %w", err)
+	}
+
+	// Filter the rows on the connector side if the data source cannot push the predicate down entirely;
+	// LIMIT is applied to the filtered rows then
+	limit := split.Select.GetLimit()
+	request, split, filter := makeResidualFilter(logger, request, split, dataSource, memoryAllocator)
+
 	columnarBufferFactory, err := paging.NewColumnarBufferFactory[T](
 		logger,
 		memoryAllocator,
@@ -285,6 +545,9 @@ func doReadSplit[T paging.Acceptor](
 		cfg.Paging,
 		columnarBufferFactory,
 		readLimiterFactory.MakeReadLimiter(logger, split.Select.DataSourceInstance.Kind),
+		continuationToken,
+		filter,
+		limit,
 	)
 
 	streamer := streaming.NewReadSplitsStreamer(
@@ -328,19 +591,64 @@ func doReadSplit[T paging.Acceptor](
 	return nil
 }
 
+// makeResidualFilter builds the filter for the conjuncts of the WHERE clause that the data source cannot push down.
+// If there are any, the request and the split passed to the data source are modified:
+// LIMIT is removed, since the sinks apply it after filtering, and mandatory filtering
+// becomes optional when the connector is able to evaluate the rest of the predicate by itself.
+func makeResidualFilter[T paging.Acceptor](
+	logger *zap.Logger,
+	request *api_service_protos.TReadSplitsRequest,
+	split *api_service_protos.TSplit,
+	dataSource datasource.DataSource[T],
+	memoryAllocator memory.Allocator,
+) (*api_service_protos.TReadSplitsRequest, *api_service_protos.TSplit, *filtering.Filter) {
+	var checkPushdown datasource.PredicateChecker
+
+	if maker, ok := dataSource.(datasource.PredicateCheckerMaker); ok {
+		checkPushdown = maker.MakePredicateChecker(logger, split.Select.DataSourceInstance.Kind)
+	}
+
+	filter, pushedEntirely, evaluatedEntirely := filtering.NewResidualFilter(logger, memoryAllocator, split.Select, checkPushdown)
+	if pushedEntirely || filter == nil {
+		return request, split, nil
+	}
+
+	if request.Filtering == api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY {
+		if !evaluatedEntirely {
+			// let the data source reject the request
+			return request, split, nil
+		}
+
+		request = proto.Clone(request).(*api_service_protos.TReadSplitsRequest)
+		request.Filtering = api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL
+	}
+
+	if split.Select.Limit != nil {
+		split = proto.Clone(split).(*api_service_protos.TSplit)
+		split.Select.Limit = nil
+	}
+
+	logger.Debug("the rows will be filtered on the connector side")
+
+	return request, split, filter
+}
+
 func (dsc *DataSourceCollection) Close() error {
 	return dsc.rdbms.Close()
 }
 
 func NewDataSourceCollection(
+	logger *zap.Logger,
 	queryLoggerFactory common.QueryLoggerFactory,
 	memoryAllocator memory.Allocator,
 	readLimiterFactory *paging.ReadLimiterFactory,
 	converterCollection conversion.Collection,
 	observationStorage observation.Storage,
 	cfg *config.TServerConfig,
+	registry metrics.Registry,
 ) (*DataSourceCollection, error) {
-	rdbmsFactory, err := rdbms.NewDataSourceFactory(cfg.Datasources, queryLoggerFactory, converterCollection, observationStorage)
+	rdbmsFactory, err := rdbms.NewDataSourceFactory(
+		logger, cfg.Datasources, queryLoggerFactory, converterCollection, observationStorage, registry)
 	if err != nil {
 		return nil, fmt.Errorf("new data source factory: %w", err)
 	}
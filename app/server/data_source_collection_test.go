@@ -0,0 +1,163 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api_service "github.com/ydb-platform/fq-connector-go/api/service"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestFilterTables(t *testing.T) {
+	tables := []string{"users", "users_archive", "orders", "Orders_2024"}
+
+	testCases := []struct {
+		name     string
+		pattern  string
+		expected []string
+		err      bool
+	}{
+		{
+			name:     "empty pattern",
+			pattern:  "",
+			expected: tables,
+		},
+		{
+			name:     "substring",
+			pattern:  "users",
+			expected: []string{"users", "users_archive"},
+		},
+		{
+			name:     "anchored",
+			pattern:  "^orders$",
+			expected: []string{"orders"},
+		},
+		{
+			name:     "case insensitive",
+			pattern:  "(?i)^orders",
+			expected: []string{"orders", "Orders_2024"},
+		},
+		{
+			name:     "no matches",
+			pattern:  "^items",
+			expected: []string{},
+		},
+		{
+			name:    "invalid pattern",
+			pattern: "users(",
+			err:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := filterTables(tables, tc.pattern)
+			if tc.err {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+var _ api_service.Connector_ListTablesServer = (*listTablesStreamStub)(nil)
+
+type listTablesStreamStub struct {
+	api_service.Connector_ListTablesServer
+	responses []*api_service_protos.TListTablesResponse
+	err       error
+}
+
+func (s *listTablesStreamStub) Send(response *api_service_protos.TListTablesResponse) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.responses = append(s.responses, response)
+
+	return nil
+}
+
+func makeTableNames(n int) []string {
+	tables := make([]string, n)
+
+	for i := range tables {
+		tables[i] = fmt.Sprintf("table_%d", i)
+	}
+
+	return tables
+}
+
+func TestSendListTablesResponses(t *testing.T) {
+	testCases := []struct {
+		name       string
+		tables     int
+		batchSizes []int
+	}{
+		{
+			// the client must receive the response even if there are no tables
+			name:       "no tables",
+			tables:     0,
+			batchSizes: []int{0},
+		},
+		{
+			name:       "single batch",
+			tables:     10,
+			batchSizes: []int{10},
+		},
+		{
+			name:       "full batch",
+			tables:     listTablesBatchSize,
+			batchSizes: []int{listTablesBatchSize},
+		},
+		{
+			name:       "several batches",
+			tables:     2*listTablesBatchSize + 1,
+			batchSizes: []int{listTablesBatchSize, listTablesBatchSize, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			tables := makeTableNames(tc.tables)
+			stream := &listTablesStreamStub{}
+
+			require.NoError(t, sendListTablesResponses(stream, tables))
+			require.Len(t, stream.responses, len(tc.batchSizes))
+
+			var actual []string
+
+			for i, response := range stream.responses {
+				require.True(t, common.IsSuccess(response.Error))
+				require.Len(t, response.Tables, tc.batchSizes[i])
+
+				actual = append(actual, response.Tables...)
+			}
+
+			// the tables are sent in the original order
+			require.Equal(t, len(tables), len(actual))
+
+			for i := range tables {
+				require.Equal(t, tables[i], actual[i])
+			}
+		})
+	}
+
+	t.Run("send error", func(t *testing.T) {
+		stream := &listTablesStreamStub{err: errors.New("stream closed")}
+
+		require.ErrorIs(t, sendListTablesResponses(stream, makeTableNames(3)), stream.err)
+	})
+}
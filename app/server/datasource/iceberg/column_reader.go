@@ -0,0 +1,135 @@
+package iceberg
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// columnReader moves the values of a single column from the Arrow arrays decoded from Parquet
+// to the acceptor of the row transformer.
+type columnReader struct {
+	acceptor any
+	appender func(acceptor any, builder array.Builder) error
+	// set takes the value from the row of the array; nil array means the column is missing in the data file
+	set func(arr arrow.Array, row int) error
+}
+
+// newColumnReader chooses the conversion depending on the requested YDB type and the Arrow type of the column.
+// The acceptors are always nullable, because the column may be missing in the older data files of the table.
+//
+//nolint:gocyclo
+func newColumnReader(ydbType *Ydb.Type, arrowType arrow.DataType, cc conversion.Collection) (*columnReader, error) {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	switch ydbType.GetTypeId() {
+	case Ydb.Type_BOOL:
+		return makeColumnReader[bool, uint8, *array.Uint8Builder](cc.Bool(), getValue[bool]), nil
+	case Ydb.Type_INT32:
+		return makeColumnReader[int32, int32, *array.Int32Builder](cc.Int32(), getValue[int32]), nil
+	case Ydb.Type_INT64:
+		return makeColumnReader[int64, int64, *array.Int64Builder](cc.Int64(), getValue[int64]), nil
+	case Ydb.Type_FLOAT:
+		return makeColumnReader[float32, float32, *array.Float32Builder](cc.Float32(), getValue[float32]), nil
+	case Ydb.Type_DOUBLE:
+		return makeColumnReader[float64, float64, *array.Float64Builder](cc.Float64(), getValue[float64]), nil
+	case Ydb.Type_STRING:
+		return makeColumnReader[[]byte, []byte, *array.BinaryBuilder](cc.Bytes(), getBytes), nil
+	case Ydb.Type_DATE:
+		return makeColumnReader[time.Time, uint16, *array.Uint16Builder](cc.Date(), getTime), nil
+	case Ydb.Type_TIMESTAMP:
+		return makeColumnReader[time.Time, uint64, *array.Uint64Builder](cc.Timestamp(), getTime), nil
+	case Ydb.Type_UTF8:
+		// Dates and timestamps are represented with strings in the STRING date time format
+		switch arrowType.ID() {
+		case arrow.DATE32:
+			return makeColumnReader[time.Time, string, *array.StringBuilder](cc.DateToString(), getTime), nil
+		case arrow.TIMESTAMP:
+			return makeColumnReader[time.Time, string, *array.StringBuilder](cc.TimestampToString(true), getTime), nil
+		default:
+			return makeColumnReader[string, string, *array.StringBuilder](cc.String(), getValue[string]), nil
+		}
+	default:
+		return nil, fmt.Errorf("read column of type %v: %w", ydbType, common.ErrDataTypeNotSupported)
+	}
+}
+
+func makeColumnReader[IN common.ValueType, OUT common.ValueType, AB common.ArrowBuilder[OUT]](
+	converter conversion.ValuePtrConverter[IN, OUT],
+	get func(arr arrow.Array, row int) (IN, error),
+) *columnReader {
+	acceptor := new(*IN)
+
+	return &columnReader{
+		acceptor: acceptor,
+		appender: utils.MakeAppenderNullable[IN, OUT, AB](converter),
+		set: func(arr arrow.Array, row int) error {
+			if arr == nil || arr.IsNull(row) {
+				*acceptor = nil
+
+				return nil
+			}
+
+			value, err := get(arr, row)
+			if err != nil {
+				return err
+			}
+
+			*acceptor = &value
+
+			return nil
+		},
+	}
+}
+
+type valueArray[T any] interface {
+	Value(i int) T
+}
+
+func getValue[T any](arr arrow.Array, row int) (T, error) {
+	typed, ok := arr.(valueArray[T])
+	if !ok {
+		var zero T
+
+		return zero, fmt.Errorf("unexpected array type %T for value type %T", arr, zero)
+	}
+
+	return typed.Value(row), nil
+}
+
+// getBytes copies the value, because the array memory is released once the batch is processed
+func getBytes(arr arrow.Array, row int) ([]byte, error) {
+	value, err := getValue[[]byte](arr, row)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.Clone(value), nil
+}
+
+func getTime(arr arrow.Array, row int) (time.Time, error) {
+	switch typed := arr.(type) {
+	case *array.Date32:
+		return typed.Value(row).ToTime(), nil
+	case *array.Timestamp:
+		timestampType, ok := typed.DataType().(*arrow.TimestampType)
+		if !ok {
+			return time.Time{}, fmt.Errorf("unexpected data type %T", typed.DataType())
+		}
+
+		return typed.Value(row).ToTime(timestampType.Unit).UTC(), nil
+	default:
+		return time.Time{}, fmt.Errorf("unexpected array type %T for time value", arr)
+	}
+}
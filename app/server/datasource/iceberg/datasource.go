@@ -0,0 +1,602 @@
+package iceberg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/parquet/file"
+	"github.com/apache/arrow/go/v13/parquet/pqarrow"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+const (
+	fileFormatParquet = "PARQUET"
+
+	// parquetFieldIDKey is the metadata key of the field ID set by the Parquet to Arrow schema conversion
+	parquetFieldIDKey = "PARQUET:field_id"
+)
+
+var _ datasource.DataSource[any] = (*dataSource)(nil)
+
+// dataSource reads Iceberg tables stored in the warehouse of the Hadoop catalog.
+// The namespace of the table is taken from the database name of the data source instance.
+type dataSource struct {
+	retrierSet  *retry.RetrierSet
+	cc          conversion.Collection
+	cfg         *config.TIcebergConfig
+	queryLogger common.QueryLogger
+}
+
+func NewDataSource(
+	retrierSet *retry.RetrierSet,
+	cfg *config.TIcebergConfig,
+	cc conversion.Collection,
+	queryLogger common.QueryLogger,
+) datasource.DataSource[any] {
+	return &dataSource{
+		retrierSet:  retrierSet,
+		cc:          cc,
+		cfg:         cfg,
+		queryLogger: queryLogger,
+	}
+}
+
+func (ds *dataSource) DescribeTable(
+	ctx context.Context,
+	logger *zap.Logger,
+	request *api_service_protos.TDescribeTableRequest,
+) (*api_service_protos.TDescribeTableResponse, error) {
+	metadata, err := ds.loadTable(ctx, logger, request.DataSourceInstance, request.Table)
+	if err != nil {
+		return nil, fmt.Errorf("load table: %w", err)
+	}
+
+	sch, err := metadata.currentSchema()
+	if err != nil {
+		return nil, fmt.Errorf("get current schema: %w", err)
+	}
+
+	columns := make([]*Ydb.Column, 0, len(sch.Fields))
+
+	for _, f := range sch.Fields {
+		column, err := makeColumn(f, request.TypeMappingSettings)
+		if err != nil {
+			if errors.Is(err, common.ErrDataTypeNotSupported) {
+				logger.Warn("skipping column of unsupported type", zap.String("column", f.Name), zap.ByteString("type", f.Type))
+
+				continue
+			}
+
+			return nil, fmt.Errorf("make column: %w", err)
+		}
+
+		columns = append(columns, column)
+	}
+
+	// The predicate is only used to prune the data files, so the rows must be filtered on the YDB side anyway
+	return &api_service_protos.TDescribeTableResponse{
+		Schema:               &api_service_protos.TSchema{Columns: columns},
+		PushdownCapabilities: &api_service_protos.TPushdownCapabilities{},
+	}, nil
+}
+
+func (ds *dataSource) ListTables(
+	ctx context.Context,
+	logger *zap.Logger,
+	request *api_service_protos.TListTablesRequest,
+) (*api_service_protos.TListTablesResponse, error) {
+	catalog, err := makeCatalog(request.DataSourceInstance, ds.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("make catalog: %w", err)
+	}
+
+	var tables []string
+
+	err = ds.retrierSet.Query.Run(ctx, logger,
+		func() error {
+			var err error
+			tables, err = catalog.listTables(ctx, request.DataSourceInstance.Database)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+
+	return &api_service_protos.TListTablesResponse{Tables: tables}, nil
+}
+
+// ListSplits emits a split per data file of the current snapshot.
+// The manifests and the data files that cannot contain the rows satisfying the predicate are skipped.
+func (ds *dataSource) ListSplits(
+	ctx context.Context,
+	logger *zap.Logger,
+	_ *api_service_protos.TListSplitsRequest,
+	slct *api_service_protos.TSelect,
+	resultChan chan<- *datasource.ListSplitResult,
+) error {
+	dataFiles, err := ds.listDataFiles(ctx, logger, slct)
+	if err != nil {
+		return fmt.Errorf("list data files: %w", err)
+	}
+
+	// Data files are read as is, so the rows are sorted on the YDB side
+	slct = common.SelectWithoutOrderBy(slct)
+
+	for _, df := range dataFiles {
+		description := &TSplitDescription{
+			Payload: &TSplitDescription_DataFile{
+				DataFile: &TSplitDescription_TDataFile{
+					FilePath:        df.filePath,
+					FileFormat:      df.fileFormat,
+					RecordCount:     df.recordCount,
+					FileSizeInBytes: df.fileSizeInBytes,
+				},
+			},
+		}
+
+		select {
+		case resultChan <- &datasource.ListSplitResult{Slct: slct, Description: description}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (ds *dataSource) listDataFiles(
+	ctx context.Context,
+	logger *zap.Logger,
+	slct *api_service_protos.TSelect,
+) ([]*dataFile, error) {
+	catalog, err := makeCatalog(slct.DataSourceInstance, ds.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("make catalog: %w", err)
+	}
+
+	metadata, err := ds.loadTable(ctx, logger, slct.DataSourceInstance, slct.From.Table)
+	if err != nil {
+		return nil, fmt.Errorf("load table: %w", err)
+	}
+
+	snap, err := metadata.currentSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("get current snapshot: %w", err)
+	}
+
+	// The table has no data yet
+	if snap == nil {
+		return nil, nil
+	}
+
+	sch, err := metadata.currentSchema()
+	if err != nil {
+		return nil, fmt.Errorf("get current schema: %w", err)
+	}
+
+	manifests, err := readManifestList(ctx, catalog.storage, snap)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest list: %w", err)
+	}
+
+	var (
+		p               = newPruner(sch, slct.Where)
+		dataFiles       []*dataFile
+		prunedManifests int
+		prunedDataFiles int
+	)
+
+	for _, mf := range manifests {
+		if mf.specID != manifestSpecIDUnknown && !p.manifestMayMatch(metadata.partitionSpec(mf.specID), mf) {
+			prunedManifests++
+
+			continue
+		}
+
+		entries, err := readManifest(ctx, catalog.storage, mf)
+		if err != nil {
+			return nil, fmt.Errorf("read manifest '%s': %w", mf.path, err)
+		}
+
+		for _, df := range entries {
+			if mf.content != manifestContentData || df.content != dataFileContentData {
+				return nil, fmt.Errorf("table contains delete files: %w", common.ErrMethodNotSupported)
+			}
+
+			if df.fileFormat != fileFormatParquet {
+				return nil, fmt.Errorf("data file format '%s': %w", df.fileFormat, common.ErrMethodNotSupported)
+			}
+
+			if !p.dataFileMayMatch(metadata.partitionSpec(df.specID), df) {
+				prunedDataFiles++
+
+				continue
+			}
+
+			dataFiles = append(dataFiles, df)
+		}
+	}
+
+	logger.Info(
+		"listed data files",
+		zap.Int("manifests", len(manifests)),
+		zap.Int("pruned_manifests", prunedManifests),
+		zap.Int("data_files", len(dataFiles)),
+		zap.Int("pruned_data_files", prunedDataFiles),
+	)
+
+	return dataFiles, nil
+}
+
+func (ds *dataSource) ReadSplit(
+	ctx context.Context,
+	logger *zap.Logger,
+	_ string,
+	_ *api_service_protos.TReadSplitsRequest,
+	split *api_service_protos.TSplit,
+	sinkFactory paging.SinkFactory[any],
+) error {
+	if common.SelectWhatHasComputedColumns(split.Select.What) {
+		return fmt.Errorf("computed columns are not supported by Iceberg: %w", common.ErrUnimplementedExpression)
+	}
+
+	if split.Select.From.Table == "" {
+		return common.ErrEmptyTableName
+	}
+
+	dataFiles, err := ds.getSplitDataFiles(ctx, logger, split)
+	if err != nil {
+		return fmt.Errorf("get split data files: %w", err)
+	}
+
+	catalog, err := makeCatalog(split.Select.DataSourceInstance, ds.cfg)
+	if err != nil {
+		return fmt.Errorf("make catalog: %w", err)
+	}
+
+	// Columns are identified by field IDs in the data files, since the names may change with schema evolution
+	metadata, err := ds.loadTable(ctx, logger, split.Select.DataSourceInstance, split.Select.From.Table)
+	if err != nil {
+		return fmt.Errorf("load table: %w", err)
+	}
+
+	sch, err := metadata.currentSchema()
+	if err != nil {
+		return fmt.Errorf("get current schema: %w", err)
+	}
+
+	sinks, err := sinkFactory.MakeSinks([]*paging.SinkParams{{Logger: logger}})
+	if err != nil {
+		return fmt.Errorf("make sinks: %w", err)
+	}
+
+	sink := sinks[0]
+
+	for _, df := range dataFiles {
+		ds.queryLogger.Dump(df.FilePath, split.Select.What.String())
+
+		if err := ds.readDataFile(ctx, logger, catalog.storage, df, sch, split.Select.What, sink); err != nil {
+			return fmt.Errorf("read data file '%s': %w", df.FilePath, err)
+		}
+	}
+
+	sink.Finish()
+
+	return nil
+}
+
+// getSplitDataFiles returns the data file of the split; the split without description
+// (e. g. made by the client itself) means reading all the data files of the table.
+func (ds *dataSource) getSplitDataFiles(
+	ctx context.Context,
+	logger *zap.Logger,
+	split *api_service_protos.TSplit,
+) ([]*TSplitDescription_TDataFile, error) {
+	if len(split.GetDescription()) == 0 {
+		dataFiles, err := ds.listDataFiles(ctx, logger, split.Select)
+		if err != nil {
+			return nil, fmt.Errorf("list data files: %w", err)
+		}
+
+		result := make([]*TSplitDescription_TDataFile, 0, len(dataFiles))
+		for _, df := range dataFiles {
+			result = append(result, &TSplitDescription_TDataFile{
+				FilePath:        df.filePath,
+				FileFormat:      df.fileFormat,
+				RecordCount:     df.recordCount,
+				FileSizeInBytes: df.fileSizeInBytes,
+			})
+		}
+
+		return result, nil
+	}
+
+	var splitDescription TSplitDescription
+
+	if err := protojson.Unmarshal(split.GetDescription(), &splitDescription); err != nil {
+		return nil, fmt.Errorf("unmarshal split description: %w", err)
+	}
+
+	dataFile := splitDescription.GetDataFile()
+	if dataFile == nil {
+		return nil, fmt.Errorf("unknown split description type: %T", splitDescription.GetPayload())
+	}
+
+	return []*TSplitDescription_TDataFile{dataFile}, nil
+}
+
+func (ds *dataSource) readDataFile(
+	ctx context.Context,
+	logger *zap.Logger,
+	st storage,
+	df *TSplitDescription_TDataFile,
+	sch *schema,
+	what *api_service_protos.TSelect_TWhat,
+	sink paging.Sink[any],
+) error {
+	reader, err := st.openFile(ctx, df.FilePath, df.FileSizeInBytes)
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+
+	defer common.LogCloserError(logger, reader, "close data file")
+
+	parquetReader, err := file.NewParquetReader(reader)
+	if err != nil {
+		return fmt.Errorf("new Parquet reader: %w", err)
+	}
+
+	fileReader, err := pqarrow.NewFileReader(
+		parquetReader,
+		pqarrow.ArrowReadProperties{BatchSize: int64(ds.cfg.BatchSize)},
+		memory.DefaultAllocator,
+	)
+	if err != nil {
+		return fmt.Errorf("new Arrow file reader: %w", err)
+	}
+
+	columnReaders, recordIndices, colIndices, err := ds.makeColumnReaders(fileReader, sch, what)
+	if err != nil {
+		return fmt.Errorf("make column readers: %w", err)
+	}
+
+	acceptors := make([]any, 0, len(columnReaders))
+	appenders := make([]func(acceptor any, builder array.Builder) error, 0, len(columnReaders))
+
+	for _, cr := range columnReaders {
+		acceptors = append(acceptors, cr.acceptor)
+		appenders = append(appenders, cr.appender)
+	}
+
+	transformer := paging.NewRowTransformer(acceptors, appenders, nil)
+
+	// None of the requested columns are present in the file (or no columns are requested at all),
+	// so only the number of rows matters.
+	if len(colIndices) == 0 {
+		for i := int64(0); i < parquetReader.NumRows(); i++ {
+			for _, cr := range columnReaders {
+				if err := cr.set(nil, 0); err != nil {
+					return fmt.Errorf("set value: %w", err)
+				}
+			}
+
+			if err := sink.AddRow(transformer); err != nil {
+				return fmt.Errorf("add row to sink: %w", err)
+			}
+		}
+
+		return nil
+	}
+
+	recordReader, err := fileReader.GetRecordReader(ctx, colIndices, nil)
+	if err != nil {
+		return fmt.Errorf("get record reader: %w", err)
+	}
+
+	defer recordReader.Release()
+
+	for recordReader.Next() {
+		if err := writeRecord(recordReader.Record(), columnReaders, recordIndices, transformer, sink); err != nil {
+			return fmt.Errorf("write record: %w", err)
+		}
+	}
+
+	if err := recordReader.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("read record: %w", err)
+	}
+
+	return nil
+}
+
+// makeColumnReaders matches the requested columns with the top-level columns of the data file
+// by the Iceberg field IDs kept in the Parquet schema: the columns may be renamed after the file has been written.
+// The files written without field IDs (e. g. imported from Hive tables) are matched by the column names.
+// For each column reader it returns the index of the column within the record (-1 if the column is missing),
+// and the leaf column indices to read from the file.
+//
+//nolint:gocyclo
+func (ds *dataSource) makeColumnReaders(
+	fileReader *pqarrow.FileReader,
+	sch *schema,
+	what *api_service_protos.TSelect_TWhat,
+) ([]*columnReader, []int, []int, error) {
+	var (
+		fieldsByID   = make(map[int]pqarrow.SchemaField, len(fileReader.Manifest.Fields))
+		fieldsByName = make(map[string]pqarrow.SchemaField, len(fileReader.Manifest.Fields))
+	)
+
+	for _, f := range fileReader.Manifest.Fields {
+		if !f.IsLeaf() {
+			continue
+		}
+
+		fieldsByName[f.Field.Name] = f
+
+		if id, ok := parquetFieldID(f.Field); ok {
+			fieldsByID[id] = f
+		}
+	}
+
+	schemaFieldIDs := make(map[string]int, len(sch.Fields))
+	for _, f := range sch.Fields {
+		schemaFieldIDs[f.Name] = f.ID
+	}
+
+	var (
+		columnReaders = make([]*columnReader, 0, len(what.GetItems()))
+		recordIndices = make([]int, 0, len(what.GetItems()))
+		colIndices    []int
+		positions     = make(map[int]int)
+	)
+
+	for _, item := range what.GetItems() {
+		column := item.GetColumn()
+
+		var arrowType arrow.DataType = arrow.Null
+
+		recordIndex := -1
+
+		var (
+			f     pqarrow.SchemaField
+			found bool
+		)
+
+		if len(fieldsByID) > 0 {
+			id, ok := schemaFieldIDs[column.GetName()]
+			if !ok {
+				return nil, nil, nil, fmt.Errorf("column '%s' is missing in the table schema: %w", column.GetName(), common.ErrInvalidRequest)
+			}
+
+			f, found = fieldsByID[id]
+		} else {
+			f, found = fieldsByName[column.GetName()]
+		}
+
+		if found {
+			arrowType = f.Field.Type
+
+			position, ok := positions[f.ColIndex]
+			if !ok {
+				position = len(colIndices)
+				positions[f.ColIndex] = position
+				colIndices = append(colIndices, f.ColIndex)
+			}
+
+			recordIndex = position
+		}
+
+		cr, err := newColumnReader(column.GetType(), arrowType, ds.cc)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("new column reader for '%s': %w", column.GetName(), err)
+		}
+
+		columnReaders = append(columnReaders, cr)
+		recordIndices = append(recordIndices, recordIndex)
+	}
+
+	return columnReaders, recordIndices, colIndices, nil
+}
+
+func writeRecord(
+	record arrow.Record,
+	columnReaders []*columnReader,
+	recordIndices []int,
+	transformer paging.RowTransformer[any],
+	sink paging.Sink[any],
+) error {
+	for row := 0; row < int(record.NumRows()); row++ {
+		for i, cr := range columnReaders {
+			var arr arrow.Array
+
+			if recordIndices[i] >= 0 {
+				arr = record.Column(recordIndices[i])
+			}
+
+			if err := cr.set(arr, row); err != nil {
+				return fmt.Errorf("set value of column #%d: %w", i, err)
+			}
+		}
+
+		if err := sink.AddRow(transformer); err != nil {
+			return fmt.Errorf("add row to sink: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (ds *dataSource) loadTable(
+	ctx context.Context,
+	logger *zap.Logger,
+	dsi *api_common.TGenericDataSourceInstance,
+	table string,
+) (*tableMetadata, error) {
+	if table == "" {
+		return nil, common.ErrEmptyTableName
+	}
+
+	catalog, err := makeCatalog(dsi, ds.cfg)
+	if err != nil {
+		return nil, fmt.Errorf("make catalog: %w", err)
+	}
+
+	var metadata *tableMetadata
+
+	err = ds.retrierSet.Query.Run(ctx, logger,
+		func() error {
+			var err error
+			metadata, err = catalog.loadTable(ctx, dsi.Database, table)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+func makeCatalog(dsi *api_common.TGenericDataSourceInstance, cfg *config.TIcebergConfig) (*hadoopCatalog, error) {
+	st, warehouse, err := makeStorage(dsi, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("make storage: %w", err)
+	}
+
+	return &hadoopCatalog{storage: st, warehouse: warehouse}, nil
+}
+
+// parquetFieldID extracts the field ID from the metadata of the Arrow field decoded from Parquet schema
+func parquetFieldID(f *arrow.Field) (int, bool) {
+	value, ok := f.Metadata.GetValue(parquetFieldIDKey)
+	if !ok {
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(value)
+	if err != nil || id < 0 {
+		return 0, false
+	}
+
+	return id, true
+}
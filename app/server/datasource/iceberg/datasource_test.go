@@ -0,0 +1,613 @@
+package iceberg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/apache/arrow/go/v13/parquet"
+	"github.com/apache/arrow/go/v13/parquet/pqarrow"
+	"github.com/linkedin/goavro/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
+	"github.com/ydb-platform/fq-connector-go/common"
+	tests_utils "github.com/ydb-platform/fq-connector-go/tests/utils"
+)
+
+// The subsets of the Avro schemas from https://iceberg.apache.org/spec/#manifests
+const (
+	testManifestListSchema = `{"type": "record", "name": "manifest_file", "fields": [
+		{"name": "manifest_path", "type": "string"},
+		{"name": "manifest_length", "type": "long"},
+		{"name": "partition_spec_id", "type": "int"},
+		{"name": "content", "type": "int"},
+		{"name": "partitions", "type": ["null", {"type": "array", "items": {"type": "record", "name": "r508", "fields": [
+			{"name": "contains_null", "type": "boolean"},
+			{"name": "lower_bound", "type": ["null", "bytes"]},
+			{"name": "upper_bound", "type": ["null", "bytes"]}
+		]}}]}
+	]}`
+
+	testManifestSchema = `{"type": "record", "name": "manifest_entry", "fields": [
+		{"name": "status", "type": "int"},
+		{"name": "data_file", "type": {"type": "record", "name": "r2", "fields": [
+			{"name": "content", "type": "int"},
+			{"name": "file_path", "type": "string"},
+			{"name": "file_format", "type": "string"},
+			{"name": "partition", "type": {"type": "record", "name": "r102", "fields": [
+				{"name": "day", "type": ["null", {"type": "int", "logicalType": "date"}]}
+			]}},
+			{"name": "record_count", "type": "long"},
+			{"name": "file_size_in_bytes", "type": "long"},
+			{"name": "null_value_counts", "type": ["null", {"type": "array", "items": {"type": "record", "name": "k121_v122", "fields": [
+				{"name": "key", "type": "int"}, {"name": "value", "type": "long"}
+			]}}]},
+			{"name": "lower_bounds", "type": ["null", {"type": "array", "items": {"type": "record", "name": "k126_v127", "fields": [
+				{"name": "key", "type": "int"}, {"name": "value", "type": "bytes"}
+			]}}]},
+			{"name": "upper_bounds", "type": ["null", {"type": "array", "items": {"type": "record", "name": "k129_v130", "fields": [
+				{"name": "key", "type": "int"}, {"name": "value", "type": "bytes"}
+			]}}]}
+		]}}
+	]}`
+
+	testTableSchema = `{"type": "struct", "schema-id": 0, "fields": [
+		{"id": 1, "name": "id", "required": true, "type": "long"},
+		{"id": 2, "name": "name", "required": false, "type": "string"},
+		{"id": 3, "name": "day", "required": false, "type": "date"},
+		{"id": 4, "name": "tags", "required": false, "type": {"type": "list", "element-id": 5, "element": "string", "element-required": false}}
+	]}`
+)
+
+type testRow struct {
+	id   int64
+	name *string
+	day  int32
+}
+
+// testWarehouse writes the table 'db.events' partitioned by day: every partition is kept
+// in a separate data file listed in a separate manifest.
+type testWarehouse struct {
+	// root is the local directory of the warehouse
+	root string
+	// location is the warehouse location written to the metadata files
+	location string
+}
+
+func (w *testWarehouse) path(location string) string {
+	return filepath.Join(w.root, filepath.FromSlash(strings.TrimPrefix(location, w.location)))
+}
+
+func (w *testWarehouse) writeFile(t *testing.T, location string, data []byte) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(w.path(location)), 0o755))
+	require.NoError(t, os.WriteFile(w.path(location), data, 0o600))
+}
+
+func (w *testWarehouse) writeTable(t *testing.T, partitions map[int32][]testRow) {
+	tableLocation := joinLocation(w.location, "db", "events")
+
+	var manifests []map[string]any
+
+	for day := int32(19723); day <= 19724; day++ {
+		rows := partitions[day]
+
+		dataFileLocation := joinLocation(tableLocation, "data", fmt.Sprintf("day=%d", day), "00000.parquet")
+		size := w.writeDataFile(t, dataFileLocation, rows)
+
+		manifestLocation := joinLocation(tableLocation, "metadata", fmt.Sprintf("manifest-%d.avro", day))
+		w.writeAvroFile(t, manifestLocation, testManifestSchema, w.makeManifestEntry(dataFileLocation, size, day, rows))
+
+		manifests = append(manifests, map[string]any{
+			"manifest_path":     manifestLocation,
+			"manifest_length":   int64(0),
+			"partition_spec_id": int32(0),
+			"content":           int32(0),
+			"partitions": goavro.Union("array", []any{
+				map[string]any{
+					"contains_null": false,
+					"lower_bound":   goavro.Union("bytes", encodeInt32Bound(day)),
+					"upper_bound":   goavro.Union("bytes", encodeInt32Bound(day)),
+				},
+			}),
+		})
+	}
+
+	manifestListLocation := joinLocation(tableLocation, "metadata", "snap-1.avro")
+	w.writeAvroFile(t, manifestListLocation, testManifestListSchema, manifests...)
+
+	metadata := fmt.Sprintf(`{
+		"format-version": 2,
+		"location": %q,
+		"current-schema-id": 0,
+		"schemas": [%s],
+		"partition-specs": [{"spec-id": 0, "fields": [{"name": "day", "transform": "identity", "source-id": 3, "field-id": 1000}]}],
+		"current-snapshot-id": 1,
+		"snapshots": [{"snapshot-id": 1, "manifest-list": %q}]
+	}`, tableLocation, testTableSchema, manifestListLocation)
+
+	w.writeFile(t, joinLocation(tableLocation, "metadata", "v1.metadata.json"), []byte(metadata))
+	w.writeFile(t, joinLocation(tableLocation, "metadata", "version-hint.text"), []byte("1"))
+}
+
+func (w *testWarehouse) makeManifestEntry(location string, size int64, day int32, rows []testRow) map[string]any {
+	var nullNames int64
+
+	for _, row := range rows {
+		if row.name == nil {
+			nullNames++
+		}
+	}
+
+	return map[string]any{
+		"status": int32(1),
+		"data_file": map[string]any{
+			"content":            int32(0),
+			"file_path":          location,
+			"file_format":        fileFormatParquet,
+			"partition":          map[string]any{"day": goavro.Union("int.date", time.Unix(int64(day)*24*3600, 0).UTC())},
+			"record_count":       int64(len(rows)),
+			"file_size_in_bytes": size,
+			"null_value_counts": goavro.Union("array", []any{
+				map[string]any{"key": int32(1), "value": int64(0)},
+				map[string]any{"key": int32(2), "value": nullNames},
+			}),
+			"lower_bounds": goavro.Union("array", []any{
+				map[string]any{"key": int32(1), "value": encodeInt64Bound(rows[0].id)},
+			}),
+			"upper_bounds": goavro.Union("array", []any{
+				map[string]any{"key": int32(1), "value": encodeInt64Bound(rows[len(rows)-1].id)},
+			}),
+		},
+	}
+}
+
+func (w *testWarehouse) writeDataFile(t *testing.T, location string, rows []testRow) int64 {
+	fieldID := func(id string) arrow.Metadata {
+		return arrow.NewMetadata([]string{parquetFieldIDKey}, []string{id})
+	}
+
+	// The column 'name' has been renamed after the file was written: it must be resolved by the field ID
+	arrowSchema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64, Metadata: fieldID("1")},
+		{Name: "title", Type: arrow.BinaryTypes.String, Nullable: true, Metadata: fieldID("2")},
+		{Name: "day", Type: arrow.FixedWidthTypes.Date32, Nullable: true, Metadata: fieldID("3")},
+	}, nil)
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, arrowSchema)
+	defer builder.Release()
+
+	for _, row := range rows {
+		builder.Field(0).(*array.Int64Builder).Append(row.id)
+
+		if row.name != nil {
+			builder.Field(1).(*array.StringBuilder).Append(*row.name)
+		} else {
+			builder.Field(1).AppendNull()
+		}
+
+		builder.Field(2).(*array.Date32Builder).Append(arrow.Date32(row.day))
+	}
+
+	record := builder.NewRecord()
+	defer record.Release()
+
+	table := array.NewTableFromRecords(arrowSchema, []arrow.Record{record})
+	defer table.Release()
+
+	var buf bytes.Buffer
+
+	require.NoError(t, pqarrow.WriteTable(table, &buf, 1024, parquet.NewWriterProperties(), pqarrow.DefaultWriterProps()))
+
+	w.writeFile(t, location, buf.Bytes())
+
+	return int64(buf.Len())
+}
+
+func (w *testWarehouse) writeAvroFile(t *testing.T, location, schema string, records ...map[string]any) {
+	var buf bytes.Buffer
+
+	writer, err := goavro.NewOCFWriter(goavro.OCFConfig{W: &buf, Schema: schema})
+	require.NoError(t, err)
+
+	data := make([]any, 0, len(records))
+	for _, record := range records {
+		data = append(data, record)
+	}
+
+	require.NoError(t, writer.Append(data))
+
+	w.writeFile(t, location, buf.Bytes())
+}
+
+// newS3StandIn imitates the subset of the S3 API used by the data source: the objects
+// of the single bucket are served from the local directory.
+func newS3StandIn(t *testing.T, bucket, root string) string {
+	type commonPrefix struct {
+		Prefix string `xml:"Prefix"`
+	}
+
+	type listBucketResult struct {
+		XMLName        xml.Name       `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+		Name           string         `xml:"Name"`
+		Prefix         string         `xml:"Prefix"`
+		KeyCount       int            `xml:"KeyCount"`
+		IsTruncated    bool           `xml:"IsTruncated"`
+		CommonPrefixes []commonPrefix `xml:"CommonPrefixes"`
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		key, found := strings.CutPrefix(r.URL.Path, "/"+bucket)
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchBucket</Code></Error>`))
+
+			return
+		}
+
+		key = strings.TrimPrefix(key, "/")
+
+		if key == "" && r.URL.Query().Get("list-type") == "2" {
+			prefix := r.URL.Query().Get("prefix")
+			result := listBucketResult{Name: bucket, Prefix: prefix}
+
+			entries, _ := os.ReadDir(filepath.Join(root, filepath.FromSlash(prefix)))
+			for _, entry := range entries {
+				if entry.IsDir() {
+					result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{Prefix: prefix + entry.Name() + "/"})
+				}
+			}
+
+			result.KeyCount = len(result.CommonPrefixes)
+
+			w.Header().Set("Content-Type", "application/xml")
+			_ = xml.NewEncoder(w).Encode(result)
+
+			return
+		}
+
+		f, err := os.Open(filepath.Join(root, filepath.FromSlash(key)))
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code></Error>`))
+
+			return
+		}
+
+		defer f.Close()
+
+		// Range and HEAD requests are handled here
+		http.ServeContent(w, r, key, time.Time{}, f)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func makeTestDataSourceInstance(warehouse, endpoint string) *api_common.TGenericDataSourceInstance {
+	return &api_common.TGenericDataSourceInstance{
+		Kind:     api_common.EGenericDataSourceKind_ICEBERG,
+		Database: "db",
+		Credentials: &api_common.TGenericCredentials{
+			Payload: &api_common.TGenericCredentials_Basic{
+				Basic: &api_common.TGenericCredentials_TBasic{Username: "access_key", Password: "secret_key"},
+			},
+		},
+		Options: &api_common.TGenericDataSourceInstance_IcebergOptions{
+			IcebergOptions: &api_common.TIcebergDataSourceOptions{
+				Catalog: &api_common.TIcebergCatalog{
+					Payload: &api_common.TIcebergCatalog_Hadoop{Hadoop: &api_common.TIcebergCatalog_THadoop{}},
+				},
+				Warehouse: &api_common.TIcebergWarehouse{
+					Payload: &api_common.TIcebergWarehouse_S3{
+						S3: &api_common.TIcebergWarehouse_TS3{Uri: warehouse, Endpoint: endpoint},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDataSource(t *testing.T) {
+	name := "first"
+
+	partitions := map[int32][]testRow{
+		19723: {{id: 1, name: &name, day: 19723}, {id: 2, day: 19723}},
+		19724: {{id: 3, day: 19724}},
+	}
+
+	t.Run("local", func(t *testing.T) {
+		root := t.TempDir()
+		w := &testWarehouse{root: root, location: "file://" + filepath.ToSlash(root)}
+		w.writeTable(t, partitions)
+
+		cfg := &config.TIcebergConfig{BatchSize: 2, AllowedLocalWarehouses: []string{filepath.Dir(root)}}
+
+		testDataSource(t, cfg, makeTestDataSourceInstance(w.location, ""))
+	})
+
+	t.Run("s3", func(t *testing.T) {
+		bucketRoot := t.TempDir()
+		w := &testWarehouse{root: filepath.Join(bucketRoot, "iceberg"), location: "s3a://warehouse/iceberg"}
+		w.writeTable(t, partitions)
+
+		endpoint := newS3StandIn(t, "warehouse", bucketRoot)
+
+		testDataSource(t, &config.TIcebergConfig{BatchSize: 2}, makeTestDataSourceInstance(w.location, endpoint))
+	})
+}
+
+//nolint:funlen
+func testDataSource(t *testing.T, cfg *config.TIcebergConfig, dsi *api_common.TGenericDataSourceInstance) {
+	logger := common.NewTestLogger(t)
+	ctx := context.Background()
+
+	ds := &dataSource{
+		retrierSet:  retry.NewRetrierSetNoop(),
+		cc:          conversion.NewCollection(&config.TConversionConfig{}),
+		cfg:         cfg,
+		queryLogger: common.QueryLogger{Logger: logger},
+	}
+
+	t.Run("describe_table", func(t *testing.T) {
+		response, err := ds.DescribeTable(ctx, logger, &api_service_protos.TDescribeTableRequest{
+			DataSourceInstance:  dsi,
+			Table:               "events",
+			TypeMappingSettings: &api_service_protos.TTypeMappingSettings{DateTimeFormat: api_service_protos.EDateTimeFormat_YQL_FORMAT},
+		})
+		require.NoError(t, err)
+
+		// The list column is not supported yet
+		expected := []*Ydb.Column{
+			{Name: "id", Type: common.MakePrimitiveType(Ydb.Type_INT64)},
+			{Name: "name", Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8))},
+			{Name: "day", Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_DATE))},
+		}
+
+		columns := response.Schema.Columns
+		require.Len(t, columns, len(expected))
+
+		for i, column := range columns {
+			require.Equal(t, expected[i].Name, column.Name)
+			require.True(t, common.TypesEqual(expected[i].Type, column.Type), column.Name)
+		}
+
+		_, err = ds.DescribeTable(ctx, logger, &api_service_protos.TDescribeTableRequest{DataSourceInstance: dsi, Table: "missing"})
+		require.ErrorIs(t, err, common.ErrTableDoesNotExist)
+	})
+
+	t.Run("list_tables", func(t *testing.T) {
+		response, err := ds.ListTables(ctx, logger, &api_service_protos.TListTablesRequest{DataSourceInstance: dsi})
+		require.NoError(t, err)
+		require.Equal(t, []string{"events"}, response.Tables)
+	})
+
+	makeSelect := func(where *api_service_protos.TSelect_TWhere) *api_service_protos.TSelect {
+		return &api_service_protos.TSelect{
+			DataSourceInstance: dsi,
+			From:               &api_service_protos.TSelect_TFrom{Table: "events"},
+			Where:              where,
+			What: &api_service_protos.TSelect_TWhat{
+				Items: []*api_service_protos.TSelect_TWhat_TItem{
+					{Payload: &api_service_protos.TSelect_TWhat_TItem_Column{Column: &Ydb.Column{
+						Name: "day", Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_DATE)),
+					}}},
+					{Payload: &api_service_protos.TSelect_TWhat_TItem_Column{Column: &Ydb.Column{
+						Name: "id", Type: common.MakePrimitiveType(Ydb.Type_INT64),
+					}}},
+					{Payload: &api_service_protos.TSelect_TWhat_TItem_Column{Column: &Ydb.Column{
+						Name: "name", Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+					}}},
+				},
+			},
+		}
+	}
+
+	listSplits := func(t *testing.T, slct *api_service_protos.TSelect) []*TSplitDescription_TDataFile {
+		resultChan := make(chan *datasource.ListSplitResult, 10)
+
+		require.NoError(t, ds.ListSplits(ctx, logger, &api_service_protos.TListSplitsRequest{}, slct, resultChan))
+		close(resultChan)
+
+		var dataFiles []*TSplitDescription_TDataFile
+
+		for result := range resultChan {
+			description, ok := result.Description.(*TSplitDescription)
+			require.True(t, ok)
+			dataFiles = append(dataFiles, description.GetDataFile())
+		}
+
+		return dataFiles
+	}
+
+	t.Run("list_splits", func(t *testing.T) {
+		dataFiles := listSplits(t, makeSelect(nil))
+		require.Len(t, dataFiles, 2)
+		require.EqualValues(t, 2, dataFiles[0].RecordCount)
+		require.EqualValues(t, 1, dataFiles[1].RecordCount)
+
+		// Pruned by the manifest partition summary
+		dataFiles = listSplits(t, makeSelect(makeWhere(&api_service_protos.TPredicate{
+			Payload: tests_utils.MakePredicateComparisonColumn("day", api_service_protos.TPredicate_TComparison_EQ, makeDateValue(19724)),
+		})))
+		require.Len(t, dataFiles, 1)
+		require.Contains(t, dataFiles[0].FilePath, "day=19724")
+
+		// Pruned by the column bounds of the data file
+		dataFiles = listSplits(t, makeSelect(makeWhere(&api_service_protos.TPredicate{
+			Payload: tests_utils.MakePredicateComparisonColumn(
+				"id", api_service_protos.TPredicate_TComparison_LE,
+				common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT64), int64(2)),
+			),
+		})))
+		require.Len(t, dataFiles, 1)
+		require.Contains(t, dataFiles[0].FilePath, "day=19723")
+	})
+
+	t.Run("read_split", func(t *testing.T) {
+		slct := makeSelect(nil)
+		dataFiles := listSplits(t, slct)
+		require.Len(t, dataFiles, 2)
+
+		description, err := protojson.Marshal(&TSplitDescription{
+			Payload: &TSplitDescription_DataFile{DataFile: dataFiles[0]},
+		})
+		require.NoError(t, err)
+
+		var rows [][]any
+
+		sink := &paging.SinkMock{}
+		sink.On("AddRow", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			transformer := args.Get(0).(paging.RowTransformer[any])
+
+			var row []any
+
+			for _, acceptor := range transformer.GetAcceptors() {
+				switch a := acceptor.(type) {
+				case **time.Time:
+					row = append(row, derefOrNil(*a))
+				case **int64:
+					row = append(row, derefOrNil(*a))
+				case **string:
+					row = append(row, derefOrNil(*a))
+				default:
+					t.Fatalf("unexpected acceptor type %T", acceptor)
+				}
+			}
+
+			rows = append(rows, row)
+		})
+		sink.On("Finish").Return()
+
+		sinkFactory := &paging.SinkFactoryMock{}
+		sinkFactory.On("MakeSinks", mock.Anything).Return([]paging.Sink[any]{sink}, nil)
+
+		err = ds.ReadSplit(
+			ctx,
+			logger,
+			"",
+			&api_service_protos.TReadSplitsRequest{},
+			&api_service_protos.TSplit{Select: slct, Payload: &api_service_protos.TSplit_Description{Description: description}},
+			sinkFactory,
+		)
+		require.NoError(t, err)
+
+		day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, [][]any{{day, int64(1), "first"}, {day, int64(2), nil}}, rows)
+		sink.AssertExpectations(t)
+	})
+}
+
+func derefOrNil[T any](value *T) any {
+	if value == nil {
+		return nil
+	}
+
+	return *value
+}
+
+func TestLocalWarehouseAccess(t *testing.T) {
+	ctx := context.Background()
+	logger := common.NewTestLogger(t)
+
+	root := t.TempDir()
+	w := &testWarehouse{root: filepath.Join(root, "warehouse"), location: filepath.ToSlash(filepath.Join(root, "warehouse"))}
+	w.writeTable(t, map[int32][]testRow{19723: {{id: 1, day: 19723}}, 19724: {{id: 2, day: 19724}}})
+	require.NoError(t, os.WriteFile(filepath.Join(root, "secret"), []byte("secret"), 0o600))
+
+	dsi := makeTestDataSourceInstance(w.location, "")
+
+	t.Run("forbidden_by_default", func(t *testing.T) {
+		_, _, err := makeStorage(dsi, &config.TIcebergConfig{})
+		require.ErrorIs(t, err, common.ErrInvalidRequest)
+	})
+
+	t.Run("outside_of_allowed_directory", func(t *testing.T) {
+		_, _, err := makeStorage(dsi, &config.TIcebergConfig{AllowedLocalWarehouses: []string{filepath.Join(root, "other")}})
+		require.ErrorIs(t, err, common.ErrInvalidRequest)
+
+		escaping := makeTestDataSourceInstance(w.location+"/../..", "")
+		_, _, err = makeStorage(escaping, &config.TIcebergConfig{AllowedLocalWarehouses: []string{root}})
+		require.ErrorIs(t, err, common.ErrInvalidRequest)
+	})
+
+	st, warehouse, err := makeStorage(dsi, &config.TIcebergConfig{AllowedLocalWarehouses: []string{root}})
+	require.NoError(t, err)
+
+	t.Run("files_outside_of_warehouse", func(t *testing.T) {
+		_, err := st.readFile(ctx, joinLocation(warehouse, "..", "secret"))
+		require.ErrorIs(t, err, common.ErrInvalidRequest)
+
+		_, err = st.openFile(ctx, filepath.ToSlash(filepath.Join(root, "secret")), 0)
+		require.ErrorIs(t, err, common.ErrInvalidRequest)
+	})
+
+	t.Run("names_with_path_components", func(t *testing.T) {
+		ds := &dataSource{
+			retrierSet:  retry.NewRetrierSetNoop(),
+			cfg:         &config.TIcebergConfig{BatchSize: 2, AllowedLocalWarehouses: []string{root}},
+			queryLogger: common.QueryLogger{Logger: logger},
+		}
+
+		for _, table := range []string{"..", "../secret", "/etc/passwd", "a\\b"} {
+			_, err := ds.DescribeTable(ctx, logger, &api_service_protos.TDescribeTableRequest{DataSourceInstance: dsi, Table: table})
+			require.ErrorIs(t, err, common.ErrInvalidRequest, table)
+		}
+
+		for _, namespace := range []string{"..", "db..x", "db./"} {
+			other := makeTestDataSourceInstance(w.location, "")
+			other.Database = namespace
+
+			_, err := ds.ListTables(ctx, logger, &api_service_protos.TListTablesRequest{DataSourceInstance: other})
+			require.ErrorIs(t, err, common.ErrInvalidRequest, namespace)
+		}
+
+		response, err := ds.DescribeTable(ctx, logger, &api_service_protos.TDescribeTableRequest{
+			DataSourceInstance:  dsi,
+			Table:               "events",
+			TypeMappingSettings: &api_service_protos.TTypeMappingSettings{DateTimeFormat: api_service_protos.EDateTimeFormat_YQL_FORMAT},
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, response.Schema.Columns)
+	})
+}
+
+func TestTableMetadataDecoding(t *testing.T) {
+	var metadata tableMetadata
+
+	require.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(`{"current-schema-id": 0, "schemas": [%s]}`, testTableSchema)), &metadata))
+
+	sch, err := metadata.currentSchema()
+	require.NoError(t, err)
+	require.Len(t, sch.Fields, 4)
+	require.Equal(t, typeLong, sch.Fields[0].primitiveType())
+	require.Empty(t, sch.Fields[3].primitiveType())
+
+	snap, err := metadata.currentSnapshot()
+	require.NoError(t, err)
+	require.Nil(t, snap)
+}
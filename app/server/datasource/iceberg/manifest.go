@@ -0,0 +1,256 @@
+package iceberg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/linkedin/goavro/v2"
+)
+
+const (
+	// https://iceberg.apache.org/spec/#manifests
+	manifestContentData    = 0
+	manifestEntryDeleted   = 2
+	dataFileContentData    = 0
+	manifestSpecIDUnknown  = -1
+	manifestSpecIDMetadata = "partition-spec-id"
+)
+
+// manifestFile is an entry of the manifest list
+type manifestFile struct {
+	path    string
+	specID  int
+	content int
+	// partitions contains the summaries for each partition field of the spec
+	partitions []*fieldSummary
+}
+
+type fieldSummary struct {
+	containsNull bool
+	lowerBound   []byte
+	upperBound   []byte
+}
+
+// dataFile is a live entry of a manifest
+type dataFile struct {
+	content         int
+	filePath        string
+	fileFormat      string
+	recordCount     int64
+	fileSizeInBytes int64
+	specID          int
+	partition       map[string]any
+	valueCounts     map[int]int64
+	nullValueCounts map[int]int64
+	lowerBounds     map[int][]byte
+	upperBounds     map[int][]byte
+}
+
+// readManifestList returns the manifests of the snapshot
+func readManifestList(ctx context.Context, st storage, snap *snapshot) ([]*manifestFile, error) {
+	// Format version 1 allows to list manifests right in the snapshot
+	if snap.ManifestList == "" {
+		manifests := make([]*manifestFile, 0, len(snap.Manifests))
+
+		for _, path := range snap.Manifests {
+			manifests = append(manifests, &manifestFile{path: path, specID: manifestSpecIDUnknown})
+		}
+
+		return manifests, nil
+	}
+
+	records, _, err := readAvroFile(ctx, st, snap.ManifestList)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest list: %w", err)
+	}
+
+	manifests := make([]*manifestFile, 0, len(records))
+
+	for _, record := range records {
+		mf := &manifestFile{
+			path:    asString(record["manifest_path"]),
+			specID:  int(asInt64(record["partition_spec_id"])),
+			content: int(asInt64(unwrapOptional(record["content"]))),
+		}
+
+		summaries, _ := unwrapOptional(record["partitions"]).([]any)
+
+		for _, item := range summaries {
+			summary, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("unexpected field summary type %T", item)
+			}
+
+			mf.partitions = append(mf.partitions, &fieldSummary{
+				containsNull: asBool(summary["contains_null"]),
+				lowerBound:   asBytes(unwrapOptional(summary["lower_bound"])),
+				upperBound:   asBytes(unwrapOptional(summary["upper_bound"])),
+			})
+		}
+
+		manifests = append(manifests, mf)
+	}
+
+	return manifests, nil
+}
+
+// readManifest returns the live (not deleted) entries of the manifest
+func readManifest(ctx context.Context, st storage, mf *manifestFile) ([]*dataFile, error) {
+	records, metadata, err := readAvroFile(ctx, st, mf.path)
+	if err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+
+	specID := mf.specID
+	if specID == manifestSpecIDUnknown {
+		specID, err = strconv.Atoi(string(metadata[manifestSpecIDMetadata]))
+		if err != nil {
+			return nil, fmt.Errorf("parse partition spec id: %w", err)
+		}
+	}
+
+	var dataFiles []*dataFile
+
+	for _, record := range records {
+		if asInt64(record["status"]) == manifestEntryDeleted {
+			continue
+		}
+
+		df, ok := record["data_file"].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected data file type %T", record["data_file"])
+		}
+
+		partition, _ := df["partition"].(map[string]any)
+
+		dataFiles = append(dataFiles, &dataFile{
+			content:         int(asInt64(unwrapOptional(df["content"]))),
+			filePath:        asString(df["file_path"]),
+			fileFormat:      asString(df["file_format"]),
+			recordCount:     asInt64(df["record_count"]),
+			fileSizeInBytes: asInt64(df["file_size_in_bytes"]),
+			specID:          specID,
+			partition:       partition,
+			valueCounts:     asIntToLongMap(unwrapOptional(df["value_counts"])),
+			nullValueCounts: asIntToLongMap(unwrapOptional(df["null_value_counts"])),
+			lowerBounds:     asIntToBytesMap(unwrapOptional(df["lower_bounds"])),
+			upperBounds:     asIntToBytesMap(unwrapOptional(df["upper_bounds"])),
+		})
+	}
+
+	return dataFiles, nil
+}
+
+func readAvroFile(ctx context.Context, st storage, location string) ([]map[string]any, map[string][]byte, error) {
+	data, err := st.readFile(ctx, location)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read file: %w", err)
+	}
+
+	reader, err := goavro.NewOCFReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("new OCF reader: %w", err)
+	}
+
+	var records []map[string]any
+
+	for reader.Scan() {
+		datum, err := reader.Read()
+		if err != nil {
+			return nil, nil, fmt.Errorf("read datum: %w", err)
+		}
+
+		record, ok := datum.(map[string]any)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected datum type %T", datum)
+		}
+
+		records = append(records, record)
+	}
+
+	if err := reader.Err(); err != nil {
+		return nil, nil, fmt.Errorf("scan: %w", err)
+	}
+
+	return records, reader.MetaData(), nil
+}
+
+// unwrapOptional extracts the value from the Avro union with null, which is decoded as a single-key map
+func unwrapOptional(value any) any {
+	if m, ok := value.(map[string]any); ok && len(m) == 1 {
+		for _, v := range m {
+			return v
+		}
+	}
+
+	return value
+}
+
+func asString(value any) string {
+	s, _ := value.(string)
+
+	return s
+}
+
+func asBool(value any) bool {
+	b, _ := value.(bool)
+
+	return b
+}
+
+func asBytes(value any) []byte {
+	b, _ := value.([]byte)
+
+	return b
+}
+
+func asInt64(value any) int64 {
+	switch v := value.(type) {
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	default:
+		return 0
+	}
+}
+
+// asIntToLongMap decodes the map<int, long> which is represented as the array of key-value records in Avro
+func asIntToLongMap(value any) map[int]int64 {
+	items, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+
+	result := make(map[int]int64, len(items))
+
+	for _, item := range items {
+		if kv, ok := item.(map[string]any); ok {
+			result[int(asInt64(kv["key"]))] = asInt64(kv["value"])
+		}
+	}
+
+	return result
+}
+
+// asIntToBytesMap decodes the map<int, binary> which is represented as the array of key-value records in Avro
+func asIntToBytesMap(value any) map[int][]byte {
+	items, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+
+	result := make(map[int][]byte, len(items))
+
+	for _, item := range items {
+		if kv, ok := item.(map[string]any); ok {
+			result[int(asInt64(kv["key"]))] = asBytes(kv["value"])
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,233 @@
+package iceberg
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// tableMetadata is the subset of the Iceberg table metadata required for reading:
+// https://iceberg.apache.org/spec/#table-metadata-fields
+type tableMetadata struct {
+	FormatVersion     int              `json:"format-version"`
+	Location          string           `json:"location"`
+	CurrentSchemaID   *int             `json:"current-schema-id"`
+	Schemas           []*schema        `json:"schemas"`
+	Schema            *schema          `json:"schema"` // format version 1 only
+	PartitionSpecs    []*partitionSpec `json:"partition-specs"`
+	CurrentSnapshotID *int64           `json:"current-snapshot-id"`
+	Snapshots         []*snapshot      `json:"snapshots"`
+}
+
+type schema struct {
+	SchemaID int      `json:"schema-id"`
+	Fields   []*field `json:"fields"`
+}
+
+type field struct {
+	ID       int             `json:"id"`
+	Name     string          `json:"name"`
+	Required bool            `json:"required"`
+	Type     json.RawMessage `json:"type"`
+}
+
+// primitiveType returns the name of the primitive type; nested types (struct, list, map) return an empty string
+func (f *field) primitiveType() string {
+	var typeName string
+
+	if err := json.Unmarshal(f.Type, &typeName); err != nil {
+		return ""
+	}
+
+	return typeName
+}
+
+type partitionSpec struct {
+	SpecID int                   `json:"spec-id"`
+	Fields []*partitionSpecField `json:"fields"`
+}
+
+type partitionSpecField struct {
+	Name      string `json:"name"`
+	Transform string `json:"transform"`
+	SourceID  int    `json:"source-id"`
+}
+
+type snapshot struct {
+	SnapshotID   int64    `json:"snapshot-id"`
+	ManifestList string   `json:"manifest-list"`
+	Manifests    []string `json:"manifests"` // format version 1 only
+}
+
+func (m *tableMetadata) currentSchema() (*schema, error) {
+	if m.CurrentSchemaID == nil {
+		if m.Schema == nil {
+			return nil, fmt.Errorf("table schema is missing")
+		}
+
+		return m.Schema, nil
+	}
+
+	for _, s := range m.Schemas {
+		if s.SchemaID == *m.CurrentSchemaID {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("schema %d is missing", *m.CurrentSchemaID)
+}
+
+// currentSnapshot returns nil if the table has no data yet
+func (m *tableMetadata) currentSnapshot() (*snapshot, error) {
+	if m.CurrentSnapshotID == nil || *m.CurrentSnapshotID == -1 {
+		return nil, nil
+	}
+
+	for _, s := range m.Snapshots {
+		if s.SnapshotID == *m.CurrentSnapshotID {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("snapshot %d is missing", *m.CurrentSnapshotID)
+}
+
+func (m *tableMetadata) partitionSpec(specID int) *partitionSpec {
+	for _, spec := range m.PartitionSpecs {
+		if spec.SpecID == specID {
+			return spec
+		}
+	}
+
+	return nil
+}
+
+// hadoopCatalog locates tables in the warehouse following the layout of the Hadoop catalog:
+// <warehouse>/<namespace>/<table>/metadata/v<N>.metadata.json, where N is stored in version-hint.text
+type hadoopCatalog struct {
+	storage   storage
+	warehouse string
+}
+
+func (c *hadoopCatalog) namespaceLocation(namespace string) string {
+	if namespace == "" {
+		return c.warehouse
+	}
+
+	return joinLocation(c.warehouse, strings.Split(namespace, ".")...)
+}
+
+func (c *hadoopCatalog) tableLocation(namespace, table string) string {
+	return joinLocation(c.namespaceLocation(namespace), table)
+}
+
+// validateNames checks that the namespace and the table name cannot address the files outside the warehouse
+func validateNames(namespace string, names ...string) error {
+	if namespace != "" {
+		names = append(strings.Split(namespace, "."), names...)
+	}
+
+	for _, name := range names {
+		if name == "" || name == "." || name == ".." || strings.ContainsAny(name, "/\\") {
+			return fmt.Errorf("invalid name '%s' in '%s': %w", name, namespace, common.ErrInvalidRequest)
+		}
+	}
+
+	return nil
+}
+
+func (c *hadoopCatalog) loadTable(ctx context.Context, namespace, table string) (*tableMetadata, error) {
+	if err := validateNames(namespace, table); err != nil {
+		return nil, fmt.Errorf("validate names: %w", err)
+	}
+
+	metadataLocation := joinLocation(c.tableLocation(namespace, table), "metadata")
+
+	versionHint, err := c.storage.readFile(ctx, joinLocation(metadataLocation, "version-hint.text"))
+	if err != nil {
+		if errors.Is(err, errFileNotFound) {
+			return nil, fmt.Errorf("%w: %s", common.ErrTableDoesNotExist, err.Error())
+		}
+
+		return nil, fmt.Errorf("read version hint: %w", err)
+	}
+
+	version, err := strconv.Atoi(strings.TrimSpace(string(versionHint)))
+	if err != nil {
+		return nil, fmt.Errorf("parse version hint '%s': %w", versionHint, err)
+	}
+
+	data, err := c.readMetadataFile(ctx, metadataLocation, version)
+	if err != nil {
+		return nil, fmt.Errorf("read metadata file: %w", err)
+	}
+
+	var metadata tableMetadata
+
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("unmarshal table metadata: %w", err)
+	}
+
+	return &metadata, nil
+}
+
+// readMetadataFile reads either plain or compressed metadata file
+func (c *hadoopCatalog) readMetadataFile(ctx context.Context, metadataLocation string, version int) ([]byte, error) {
+	data, err := c.storage.readFile(ctx, joinLocation(metadataLocation, fmt.Sprintf("v%d.metadata.json", version)))
+	if err == nil {
+		return data, nil
+	}
+
+	if !errors.Is(err, errFileNotFound) {
+		return nil, err
+	}
+
+	data, err = c.storage.readFile(ctx, joinLocation(metadataLocation, fmt.Sprintf("v%d.gz.metadata.json", version)))
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("new gzip reader: %w", err)
+	}
+
+	return io.ReadAll(reader)
+}
+
+// listTables returns the names of the namespace subdirectories containing the table metadata
+func (c *hadoopCatalog) listTables(ctx context.Context, namespace string) ([]string, error) {
+	if err := validateNames(namespace); err != nil {
+		return nil, fmt.Errorf("validate names: %w", err)
+	}
+
+	names, err := c.storage.listDirectories(ctx, c.namespaceLocation(namespace))
+	if err != nil {
+		return nil, fmt.Errorf("list directories: %w", err)
+	}
+
+	var tables []string
+
+	for _, name := range names {
+		_, err := c.storage.readFile(ctx, joinLocation(c.tableLocation(namespace, name), "metadata", "version-hint.text"))
+		if err != nil {
+			if errors.Is(err, errFileNotFound) {
+				continue
+			}
+
+			return nil, fmt.Errorf("read version hint: %w", err)
+		}
+
+		tables = append(tables, name)
+	}
+
+	return tables, nil
+}
@@ -0,0 +1,291 @@
+package iceberg
+
+import (
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+// columnStats describes the values of a column within a manifest or a data file.
+// Unknown properties are kept zero, so that nothing is pruned on their basis.
+type columnStats struct {
+	// typeName is the Iceberg type of the column
+	typeName string
+	lower    any
+	upper    any
+	noNulls  bool
+	allNull  bool
+}
+
+// pruner checks whether the rows satisfying the predicate may be found in a file with the given statistics.
+// The check is conservative: any unsupported predicate or missing statistics keep the file.
+type pruner struct {
+	predicate *api_service_protos.TPredicate
+	fields    map[string]*field
+}
+
+func newPruner(sch *schema, where *api_service_protos.TSelect_TWhere) *pruner {
+	if where.GetFilterTyped() == nil {
+		return nil
+	}
+
+	fields := make(map[string]*field, len(sch.Fields))
+	for _, f := range sch.Fields {
+		fields[f.Name] = f
+	}
+
+	return &pruner{predicate: where.FilterTyped, fields: fields}
+}
+
+// manifestMayMatch uses the partition field summaries of the manifest list entry
+func (p *pruner) manifestMayMatch(spec *partitionSpec, mf *manifestFile) bool {
+	if p == nil || spec == nil || len(mf.partitions) != len(spec.Fields) {
+		return true
+	}
+
+	stats := make(map[int]*columnStats)
+
+	for i, specField := range spec.Fields {
+		sourceField := p.fieldByID(specField.SourceID)
+		if specField.Transform != "identity" || sourceField == nil {
+			continue
+		}
+
+		summary := mf.partitions[i]
+		cs := &columnStats{typeName: sourceField.primitiveType(), noNulls: !summary.containsNull}
+
+		if summary.lowerBound != nil && summary.upperBound != nil {
+			cs.lower, _ = decodeBound(sourceField.primitiveType(), summary.lowerBound)
+			cs.upper, _ = decodeBound(sourceField.primitiveType(), summary.upperBound)
+		} else if summary.containsNull {
+			cs.allNull = true
+		}
+
+		stats[sourceField.ID] = cs
+	}
+
+	return p.mayMatch(p.predicate, stats)
+}
+
+// dataFileMayMatch uses the column statistics and the identity partition values of the data file
+func (p *pruner) dataFileMayMatch(spec *partitionSpec, df *dataFile) bool {
+	if p == nil {
+		return true
+	}
+
+	stats := make(map[int]*columnStats)
+
+	for _, f := range p.fields {
+		cs := &columnStats{typeName: f.primitiveType()}
+
+		if nullCount, ok := df.nullValueCounts[f.ID]; ok {
+			cs.noNulls = nullCount == 0
+
+			if valueCount, ok := df.valueCounts[f.ID]; ok {
+				cs.allNull = nullCount == valueCount
+			}
+		}
+
+		if lower, ok := df.lowerBounds[f.ID]; ok {
+			cs.lower, _ = decodeBound(f.primitiveType(), lower)
+		}
+
+		if upper, ok := df.upperBounds[f.ID]; ok {
+			cs.upper, _ = decodeBound(f.primitiveType(), upper)
+		}
+
+		stats[f.ID] = cs
+	}
+
+	if spec != nil {
+		for _, specField := range spec.Fields {
+			sourceField := p.fieldByID(specField.SourceID)
+			if specField.Transform != "identity" || sourceField == nil {
+				continue
+			}
+
+			value, present := df.partition[specField.Name]
+			if !present {
+				continue
+			}
+
+			value = unwrapOptional(value)
+			if value == nil {
+				stats[sourceField.ID] = &columnStats{typeName: sourceField.primitiveType(), allNull: true}
+
+				continue
+			}
+
+			if normalized, ok := normalizePartitionValue(sourceField.primitiveType(), value); ok {
+				stats[sourceField.ID] = &columnStats{
+					typeName: sourceField.primitiveType(),
+					lower:    normalized,
+					upper:    normalized,
+					noNulls:  true,
+				}
+			}
+		}
+	}
+
+	return p.mayMatch(p.predicate, stats)
+}
+
+func (p *pruner) fieldByID(id int) *field {
+	for _, f := range p.fields {
+		if f.ID == id {
+			return f
+		}
+	}
+
+	return nil
+}
+
+func (p *pruner) columnStats(expression *api_service_protos.TExpression, stats map[int]*columnStats) *columnStats {
+	f, ok := p.fields[expression.GetColumn()]
+	if !ok {
+		return nil
+	}
+
+	return stats[f.ID]
+}
+
+func (p *pruner) mayMatch(predicate *api_service_protos.TPredicate, stats map[int]*columnStats) bool {
+	switch pr := predicate.GetPayload().(type) {
+	case *api_service_protos.TPredicate_Conjunction:
+		for _, operand := range pr.Conjunction.Operands {
+			if !p.mayMatch(operand, stats) {
+				return false
+			}
+		}
+
+		return true
+	case *api_service_protos.TPredicate_Disjunction:
+		for _, operand := range pr.Disjunction.Operands {
+			if p.mayMatch(operand, stats) {
+				return true
+			}
+		}
+
+		return false
+	case *api_service_protos.TPredicate_IsNull:
+		cs := p.columnStats(pr.IsNull.Value, stats)
+
+		return cs == nil || !cs.noNulls
+	case *api_service_protos.TPredicate_IsNotNull:
+		cs := p.columnStats(pr.IsNotNull.Value, stats)
+
+		return cs == nil || !cs.allNull
+	case *api_service_protos.TPredicate_Comparison:
+		return p.comparisonMayMatch(pr.Comparison, stats)
+	case *api_service_protos.TPredicate_Between:
+		cs := p.columnStats(pr.Between.Value, stats)
+
+		return rangeMayMatch(cs, api_service_protos.TPredicate_TComparison_GE, pr.Between.Least) &&
+			rangeMayMatch(cs, api_service_protos.TPredicate_TComparison_LE, pr.Between.Greatest)
+	case *api_service_protos.TPredicate_In:
+		cs := p.columnStats(pr.In.Value, stats)
+
+		for _, item := range pr.In.Set {
+			if rangeMayMatch(cs, api_service_protos.TPredicate_TComparison_EQ, item) {
+				return true
+			}
+		}
+
+		return len(pr.In.Set) == 0
+	default:
+		return true
+	}
+}
+
+func (p *pruner) comparisonMayMatch(comparison *api_service_protos.TPredicate_TComparison, stats map[int]*columnStats) bool {
+	column, literal, operation := comparison.LeftValue, comparison.RightValue, comparison.Operation
+
+	// Bring the comparison to the form `column <op> literal`
+	if column.GetColumn() == "" {
+		column, literal = literal, column
+
+		switch operation {
+		case api_service_protos.TPredicate_TComparison_L:
+			operation = api_service_protos.TPredicate_TComparison_G
+		case api_service_protos.TPredicate_TComparison_LE:
+			operation = api_service_protos.TPredicate_TComparison_GE
+		case api_service_protos.TPredicate_TComparison_G:
+			operation = api_service_protos.TPredicate_TComparison_L
+		case api_service_protos.TPredicate_TComparison_GE:
+			operation = api_service_protos.TPredicate_TComparison_LE
+		}
+	}
+
+	return rangeMayMatch(p.columnStats(column, stats), operation, literal)
+}
+
+// rangeMayMatch checks whether the range of the column values may contain the values satisfying `column <op> literal`
+func rangeMayMatch(
+	cs *columnStats,
+	operation api_service_protos.TPredicate_TComparison_EOperation,
+	literal *api_service_protos.TExpression,
+) bool {
+	if cs == nil || literal.GetTypedValue() == nil {
+		return true
+	}
+
+	switch operation {
+	case api_service_protos.TPredicate_TComparison_EQ,
+		api_service_protos.TPredicate_TComparison_L,
+		api_service_protos.TPredicate_TComparison_LE,
+		api_service_protos.TPredicate_TComparison_G,
+		api_service_protos.TPredicate_TComparison_GE:
+	default:
+		return true
+	}
+
+	// Comparison with NULL is never true
+	if cs.allNull {
+		return false
+	}
+
+	value, ok := normalizeLiteral(cs.typeName, literal.GetTypedValue())
+	if !ok {
+		return true
+	}
+
+	if cs.lower != nil && !lowerBoundMayMatch(cs.lower, operation, value) {
+		return false
+	}
+
+	if cs.upper != nil && !upperBoundMayMatch(cs.upper, operation, value) {
+		return false
+	}
+
+	return true
+}
+
+func lowerBoundMayMatch(lower any, operation api_service_protos.TPredicate_TComparison_EOperation, value any) bool {
+	cmp, ok := compareValues(lower, value)
+	if !ok {
+		return true
+	}
+
+	switch operation {
+	case api_service_protos.TPredicate_TComparison_EQ, api_service_protos.TPredicate_TComparison_LE:
+		return cmp <= 0
+	case api_service_protos.TPredicate_TComparison_L:
+		return cmp < 0
+	default:
+		return true
+	}
+}
+
+func upperBoundMayMatch(upper any, operation api_service_protos.TPredicate_TComparison_EOperation, value any) bool {
+	cmp, ok := compareValues(upper, value)
+	if !ok {
+		return true
+	}
+
+	switch operation {
+	case api_service_protos.TPredicate_TComparison_EQ, api_service_protos.TPredicate_TComparison_GE:
+		return cmp >= 0
+	case api_service_protos.TPredicate_TComparison_G:
+		return cmp > 0
+	default:
+		return true
+	}
+}
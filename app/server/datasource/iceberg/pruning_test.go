@@ -0,0 +1,240 @@
+package iceberg
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+	tests_utils "github.com/ydb-platform/fq-connector-go/tests/utils"
+)
+
+func encodeInt32Bound(v int32) []byte {
+	return binary.LittleEndian.AppendUint32(nil, uint32(v))
+}
+
+func encodeInt64Bound(v int64) []byte {
+	return binary.LittleEndian.AppendUint64(nil, uint64(v))
+}
+
+func makeDateValue(days uint32) *Ydb.TypedValue {
+	return &Ydb.TypedValue{
+		Type:  common.MakePrimitiveType(Ydb.Type_DATE),
+		Value: &Ydb.Value{Value: &Ydb.Value_Uint32Value{Uint32Value: days}},
+	}
+}
+
+func makeWhere(predicate *api_service_protos.TPredicate) *api_service_protos.TSelect_TWhere {
+	return &api_service_protos.TSelect_TWhere{FilterTyped: predicate}
+}
+
+func makePruningSchema() *schema {
+	makeField := func(id int, name, typeName string) *field {
+		typ, _ := json.Marshal(typeName)
+
+		return &field{ID: id, Name: name, Type: typ}
+	}
+
+	return &schema{
+		Fields: []*field{
+			makeField(1, "id", typeLong),
+			makeField(2, "name", typeString),
+			makeField(3, "day", typeDate),
+			makeField(4, "score", typeDouble),
+		},
+	}
+}
+
+//nolint:funlen
+func TestDataFileMayMatch(t *testing.T) {
+	type testCase struct {
+		testName  string
+		predicate *api_service_protos.TPredicate
+		expected  bool
+	}
+
+	int64Type := common.MakePrimitiveType(Ydb.Type_INT64)
+	int32Type := common.MakePrimitiveType(Ydb.Type_INT32)
+	utf8Type := common.MakePrimitiveType(Ydb.Type_UTF8)
+
+	spec := &partitionSpec{
+		Fields: []*partitionSpecField{{Name: "day", Transform: "identity", SourceID: 3}},
+	}
+
+	// id is in [10, 20], name is always NULL, day is 19724, score has no statistics
+	df := &dataFile{
+		partition:       map[string]any{"day": map[string]any{"int.date": int32(19724)}},
+		valueCounts:     map[int]int64{1: 5, 2: 5},
+		nullValueCounts: map[int]int64{1: 0, 2: 5},
+		lowerBounds:     map[int][]byte{1: encodeInt64Bound(10), 3: encodeInt32Bound(19724)},
+		upperBounds:     map[int][]byte{1: encodeInt64Bound(20), 3: encodeInt32Bound(19724)},
+	}
+
+	tcs := []testCase{
+		{
+			testName: "equal_inside",
+			predicate: &api_service_protos.TPredicate{
+				Payload: tests_utils.MakePredicateComparisonColumn(
+					"id", api_service_protos.TPredicate_TComparison_EQ, common.MakeTypedValue(int64Type, int64(15))),
+			},
+			expected: true,
+		},
+		{
+			testName: "equal_outside",
+			predicate: &api_service_protos.TPredicate{
+				Payload: tests_utils.MakePredicateComparisonColumn(
+					"id", api_service_protos.TPredicate_TComparison_EQ, common.MakeTypedValue(int64Type, int64(21))),
+			},
+			expected: false,
+		},
+		{
+			testName: "less_than_lower_bound",
+			predicate: &api_service_protos.TPredicate{
+				Payload: tests_utils.MakePredicateComparisonColumn(
+					"id", api_service_protos.TPredicate_TComparison_L, common.MakeTypedValue(int32Type, int32(10))),
+			},
+			expected: false,
+		},
+		{
+			testName: "greater_or_equal_upper_bound",
+			predicate: &api_service_protos.TPredicate{
+				Payload: tests_utils.MakePredicateComparisonColumn(
+					"id", api_service_protos.TPredicate_TComparison_GE, common.MakeTypedValue(int64Type, int64(20))),
+			},
+			expected: true,
+		},
+		{
+			testName: "between_outside",
+			predicate: &api_service_protos.TPredicate{
+				Payload: tests_utils.MakePredicateBetweenColumn(
+					"id", common.MakeTypedValue(int64Type, int64(21)), common.MakeTypedValue(int64Type, int64(30))),
+			},
+			expected: false,
+		},
+		{
+			testName: "in_outside",
+			predicate: &api_service_protos.TPredicate{
+				Payload: tests_utils.MakePredicateInColumn("id", []*Ydb.TypedValue{
+					common.MakeTypedValue(int64Type, int64(1)),
+					common.MakeTypedValue(int64Type, int64(25)),
+				}),
+			},
+			expected: false,
+		},
+		{
+			testName:  "is_null_without_nulls",
+			predicate: &api_service_protos.TPredicate{Payload: tests_utils.MakePredicateIsNullColumn("id")},
+			expected:  false,
+		},
+		{
+			testName:  "is_not_null_all_null",
+			predicate: &api_service_protos.TPredicate{Payload: tests_utils.MakePredicateIsNotNullColumn("name")},
+			expected:  false,
+		},
+		{
+			testName: "comparison_with_all_null",
+			predicate: &api_service_protos.TPredicate{
+				Payload: tests_utils.MakePredicateComparisonColumn(
+					"name", api_service_protos.TPredicate_TComparison_EQ, common.MakeTypedValue(utf8Type, "a")),
+			},
+			expected: false,
+		},
+		{
+			testName: "partition_value",
+			predicate: &api_service_protos.TPredicate{
+				Payload: tests_utils.MakePredicateComparisonColumn(
+					"day", api_service_protos.TPredicate_TComparison_G, makeDateValue(19724)),
+			},
+			expected: false,
+		},
+		{
+			testName: "incompatible_literal_type",
+			predicate: &api_service_protos.TPredicate{
+				Payload: tests_utils.MakePredicateComparisonColumn(
+					"day", api_service_protos.TPredicate_TComparison_G, common.MakeTypedValue(int64Type, int64(20000))),
+			},
+			expected: true,
+		},
+		{
+			testName: "no_statistics",
+			predicate: &api_service_protos.TPredicate{
+				Payload: tests_utils.MakePredicateComparisonColumn(
+					"score", api_service_protos.TPredicate_TComparison_L, common.MakeTypedValue(int64Type, int64(0))),
+			},
+			expected: true,
+		},
+		{
+			testName: "disjunction",
+			predicate: &api_service_protos.TPredicate{
+				Payload: &api_service_protos.TPredicate_Disjunction{
+					Disjunction: &api_service_protos.TPredicate_TDisjunction{
+						Operands: []*api_service_protos.TPredicate{
+							{Payload: tests_utils.MakePredicateIsNullColumn("id")},
+							{
+								Payload: tests_utils.MakePredicateComparisonColumn(
+									"id", api_service_protos.TPredicate_TComparison_LE, common.MakeTypedValue(int64Type, int64(10))),
+							},
+						},
+					},
+				},
+			},
+			expected: true,
+		},
+		{
+			testName: "unsupported_predicate",
+			predicate: &api_service_protos.TPredicate{
+				Payload: &api_service_protos.TPredicate_Negation{
+					Negation: &api_service_protos.TPredicate_TNegation{
+						Operand: &api_service_protos.TPredicate{Payload: tests_utils.MakePredicateIsNullColumn("name")},
+					},
+				},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			p := newPruner(makePruningSchema(), makeWhere(tc.predicate))
+			require.Equal(t, tc.expected, p.dataFileMayMatch(spec, df))
+		})
+	}
+}
+
+func TestManifestMayMatch(t *testing.T) {
+	spec := &partitionSpec{
+		Fields: []*partitionSpecField{{Name: "day", Transform: "identity", SourceID: 3}},
+	}
+
+	mf := &manifestFile{
+		partitions: []*fieldSummary{
+			{lowerBound: encodeInt32Bound(19720), upperBound: encodeInt32Bound(19724)},
+		},
+	}
+
+	makePruner := func(operation api_service_protos.TPredicate_TComparison_EOperation, days uint32) *pruner {
+		return newPruner(makePruningSchema(), makeWhere(&api_service_protos.TPredicate{
+			Payload: tests_utils.MakePredicateComparisonColumn("day", operation, makeDateValue(days)),
+		}))
+	}
+
+	require.True(t, makePruner(api_service_protos.TPredicate_TComparison_EQ, 19722).manifestMayMatch(spec, mf))
+	require.False(t, makePruner(api_service_protos.TPredicate_TComparison_EQ, 19725).manifestMayMatch(spec, mf))
+	require.False(t, makePruner(api_service_protos.TPredicate_TComparison_L, 19720).manifestMayMatch(spec, mf))
+
+	// Partition transforms other than identity are not used for pruning
+	bucketSpec := &partitionSpec{
+		Fields: []*partitionSpecField{{Name: "day_bucket", Transform: "bucket[16]", SourceID: 3}},
+	}
+	require.True(t, makePruner(api_service_protos.TPredicate_TComparison_EQ, 19725).manifestMayMatch(bucketSpec, mf))
+
+	// No filter means no pruning
+	require.True(t, newPruner(makePruningSchema(), nil).manifestMayMatch(spec, mf))
+}
@@ -0,0 +1,387 @@
+package iceberg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3_types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var errFileNotFound = errors.New("file not found")
+
+// fileReader provides random access to the data file contents required by the Parquet decoder
+type fileReader interface {
+	io.ReaderAt
+	io.Seeker
+	io.Reader
+	io.Closer
+}
+
+// storage is an abstraction over the file system keeping the warehouse
+type storage interface {
+	// readFile returns the whole contents of a (small) file
+	readFile(ctx context.Context, location string) ([]byte, error)
+	// openFile opens the file for the random access
+	openFile(ctx context.Context, location string, size int64) (fileReader, error)
+	// listDirectories returns the names of the direct subdirectories of the location
+	listDirectories(ctx context.Context, location string) ([]string, error)
+}
+
+// makeStorage chooses the storage implementation depending on the scheme of the warehouse URI:
+// 'file' scheme (or no scheme at all) means the local file system, any other scheme is treated as S3.
+// Local warehouses are available only inside the directories allowed by the server configuration,
+// otherwise any client could read arbitrary files of the connector host.
+func makeStorage(dsi *api_common.TGenericDataSourceInstance, cfg *config.TIcebergConfig) (storage, string, error) {
+	options := dsi.GetIcebergOptions()
+
+	if options.GetCatalog().GetHadoop() == nil {
+		return nil, "", fmt.Errorf("only Hadoop catalog is supported: %w", common.ErrInvalidRequest)
+	}
+
+	s3Options := options.GetWarehouse().GetS3()
+	if s3Options.GetUri() == "" {
+		return nil, "", fmt.Errorf("warehouse location is empty: %w", common.ErrInvalidRequest)
+	}
+
+	warehouse := strings.TrimSuffix(s3Options.Uri, "/")
+
+	if isLocalLocation(warehouse) {
+		root := filepath.Clean(localPath(warehouse))
+
+		for _, allowed := range cfg.GetAllowedLocalWarehouses() {
+			if isPathInside(filepath.Clean(allowed), root) {
+				return localStorage{root: root}, warehouse, nil
+			}
+		}
+
+		return nil, "", fmt.Errorf("local warehouse '%s' is not allowed by the server configuration: %w", warehouse, common.ErrInvalidRequest)
+	}
+
+	if s3Options.Endpoint == "" {
+		return nil, "", fmt.Errorf("warehouse endpoint is empty: %w", common.ErrInvalidRequest)
+	}
+
+	var credentials aws.CredentialsProvider = aws.AnonymousCredentials{}
+
+	if basic := dsi.GetCredentials().GetBasic(); basic.GetUsername() != "" {
+		credentials = aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: basic.Username, SecretAccessKey: basic.Password}, nil
+		})
+	}
+
+	region := s3Options.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	client := s3.New(s3.Options{
+		BaseEndpoint: aws.String(s3Options.Endpoint),
+		Region:       region,
+		Credentials:  credentials,
+		UsePathStyle: true,
+	})
+
+	return &s3Storage{client: client}, warehouse, nil
+}
+
+func joinLocation(location string, elems ...string) string {
+	return strings.Join(append([]string{strings.TrimSuffix(location, "/")}, elems...), "/")
+}
+
+func isLocalLocation(location string) bool {
+	return strings.HasPrefix(location, "/") || strings.HasPrefix(location, "file:")
+}
+
+// isPathInside checks if the path is the directory itself or is located inside it; both must be cleaned
+func isPathInside(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// localStorage reads the files from the warehouse directory only:
+// the locations of metadata and data files are taken from the files written by the clients.
+type localStorage struct {
+	root string
+}
+
+func (s localStorage) resolve(location string) (string, error) {
+	path := filepath.Clean(localPath(location))
+	if !isPathInside(s.root, path) {
+		return "", fmt.Errorf("location '%s' is outside of the warehouse: %w", location, common.ErrInvalidRequest)
+	}
+
+	return path, nil
+}
+
+func (s localStorage) readFile(_ context.Context, location string) ([]byte, error) {
+	path, err := s.resolve(location)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", errFileNotFound, location)
+		}
+
+		return nil, fmt.Errorf("read file: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s localStorage) openFile(_ context.Context, location string, _ int64) (fileReader, error) {
+	path, err := s.resolve(location)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", errFileNotFound, location)
+		}
+
+		return nil, fmt.Errorf("open file: %w", err)
+	}
+
+	return f, nil
+}
+
+func (s localStorage) listDirectories(_ context.Context, location string) ([]string, error) {
+	path, err := s.resolve(location)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", errFileNotFound, location)
+		}
+
+		return nil, fmt.Errorf("read dir: %w", err)
+	}
+
+	var names []string
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+
+	return names, nil
+}
+
+// localPath converts the location like 'file:/tmp/warehouse' or 'file:///tmp/warehouse' into the path
+func localPath(location string) string {
+	if !strings.HasPrefix(location, "file:") {
+		return filepath.FromSlash(location)
+	}
+
+	u, err := url.Parse(location)
+	if err != nil || u.Path == "" {
+		return filepath.FromSlash(strings.TrimPrefix(location, "file:"))
+	}
+
+	return filepath.FromSlash(u.Path)
+}
+
+type s3Storage struct {
+	client *s3.Client
+}
+
+func (s *s3Storage) readFile(ctx context.Context, location string) ([]byte, error) {
+	bucket, key, err := parseS3Location(location)
+	if err != nil {
+		return nil, fmt.Errorf("parse S3 location: %w", err)
+	}
+
+	output, err := s.client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	if err != nil {
+		return nil, wrapS3Error(err, location)
+	}
+
+	defer output.Body.Close()
+
+	data, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read object body: %w", err)
+	}
+
+	return data, nil
+}
+
+func (s *s3Storage) openFile(ctx context.Context, location string, size int64) (fileReader, error) {
+	bucket, key, err := parseS3Location(location)
+	if err != nil {
+		return nil, fmt.Errorf("parse S3 location: %w", err)
+	}
+
+	if size <= 0 {
+		output, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+		if err != nil {
+			return nil, wrapS3Error(err, location)
+		}
+
+		size = aws.ToInt64(output.ContentLength)
+	}
+
+	return &s3FileReader{ctx: ctx, client: s.client, bucket: bucket, key: key, size: size}, nil
+}
+
+func (s *s3Storage) listDirectories(ctx context.Context, location string) ([]string, error) {
+	bucket, prefix, err := parseS3Location(location)
+	if err != nil {
+		return nil, fmt.Errorf("parse S3 location: %w", err)
+	}
+
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	var names []string
+
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket:    aws.String(bucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	})
+
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, wrapS3Error(err, location)
+		}
+
+		for _, commonPrefix := range page.CommonPrefixes {
+			names = append(names, strings.TrimSuffix(strings.TrimPrefix(aws.ToString(commonPrefix.Prefix), prefix), "/"))
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// parseS3Location splits the location like 's3a://bucket/path/to/file' into the bucket and the key
+func parseS3Location(location string) (string, string, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return "", "", fmt.Errorf("parse URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "s3", "s3a", "s3n":
+	default:
+		return "", "", fmt.Errorf("unsupported scheme '%s' of location '%s'", u.Scheme, location)
+	}
+
+	if u.Host == "" {
+		return "", "", fmt.Errorf("bucket is missing in location '%s'", location)
+	}
+
+	return u.Host, strings.Trim(u.Path, "/"), nil
+}
+
+func wrapS3Error(err error, location string) error {
+	var (
+		noSuchKey *s3_types.NoSuchKey
+		notFound  *s3_types.NotFound
+	)
+
+	if errors.As(err, &noSuchKey) || errors.As(err, &notFound) {
+		return fmt.Errorf("%w: %s", errFileNotFound, location)
+	}
+
+	return fmt.Errorf("S3 request for '%s': %w", location, err)
+}
+
+// s3FileReader reads the object with HTTP range requests, so that only the requested
+// parts of the data file (footer and column chunks) are downloaded.
+type s3FileReader struct {
+	ctx    context.Context
+	client *s3.Client
+	bucket string
+	key    string
+	size   int64
+	offset int64
+}
+
+func (r *s3FileReader) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+
+	end := min(off+int64(len(p)), r.size) - 1
+
+	output, err := r.client.GetObject(r.ctx, &s3.GetObjectInput{
+		Bucket: aws.String(r.bucket),
+		Key:    aws.String(r.key),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", off, end)),
+	})
+	if err != nil {
+		return 0, wrapS3Error(err, r.key)
+	}
+
+	defer output.Body.Close()
+
+	n, err := io.ReadFull(output.Body, p[:end-off+1])
+	if err != nil {
+		return n, fmt.Errorf("read object range: %w", err)
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+func (r *s3FileReader) Read(p []byte) (int, error) {
+	n, err := r.ReadAt(p, r.offset)
+	r.offset += int64(n)
+
+	return n, err
+}
+
+func (r *s3FileReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	default:
+		return 0, fmt.Errorf("invalid whence: %d", whence)
+	}
+
+	if offset < 0 {
+		return 0, fmt.Errorf("negative offset: %d", offset)
+	}
+
+	r.offset = offset
+
+	return offset, nil
+}
+
+func (*s3FileReader) Close() error { return nil }
@@ -0,0 +1,268 @@
+package iceberg
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// https://iceberg.apache.org/spec/#primitive-types
+const (
+	typeBoolean     = "boolean"
+	typeInt         = "int"
+	typeLong        = "long"
+	typeFloat       = "float"
+	typeDouble      = "double"
+	typeDate        = "date"
+	typeTimestamp   = "timestamp"
+	typeTimestampTZ = "timestamptz"
+	typeString      = "string"
+	typeBinary      = "binary"
+)
+
+// makeColumn maps the Iceberg field to the YDB column.
+// Returns ErrDataTypeNotSupported for the types that cannot be read yet.
+func makeColumn(f *field, rules *api_service_protos.TTypeMappingSettings) (*Ydb.Column, error) {
+	typeName := f.primitiveType()
+
+	var (
+		ydbType *Ydb.Type
+		err     error
+	)
+
+	switch {
+	case typeName == typeBoolean:
+		ydbType = common.MakePrimitiveType(Ydb.Type_BOOL)
+	case typeName == typeInt:
+		ydbType = common.MakePrimitiveType(Ydb.Type_INT32)
+	case typeName == typeLong:
+		ydbType = common.MakePrimitiveType(Ydb.Type_INT64)
+	case typeName == typeFloat:
+		ydbType = common.MakePrimitiveType(Ydb.Type_FLOAT)
+	case typeName == typeDouble:
+		ydbType = common.MakePrimitiveType(Ydb.Type_DOUBLE)
+	case typeName == typeString:
+		ydbType = common.MakePrimitiveType(Ydb.Type_UTF8)
+	case typeName == typeBinary, strings.HasPrefix(typeName, "fixed["):
+		ydbType = common.MakePrimitiveType(Ydb.Type_STRING)
+	case typeName == typeDate:
+		ydbType, err = common.MakeYdbDateTimeType(Ydb.Type_DATE, rules.GetDateTimeFormat())
+	case typeName == typeTimestamp, typeName == typeTimestampTZ:
+		ydbType, err = common.MakeYdbDateTimeType(Ydb.Type_TIMESTAMP, rules.GetDateTimeFormat())
+	default:
+		return nil, fmt.Errorf("convert type '%s': %w", f.Type, common.ErrDataTypeNotSupported)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("make YDB date time type: %w", err)
+	}
+
+	if !f.Required {
+		ydbType = common.MakeOptionalType(ydbType)
+	}
+
+	return &Ydb.Column{Name: f.Name, Type: ydbType}, nil
+}
+
+// decodeBound decodes the single-value binary serialization used for the column bounds:
+// https://iceberg.apache.org/spec/#binary-single-value-serialization
+// The values are normalized to int64, float64, string or bool, so that they can be compared with the literals.
+func decodeBound(typeName string, data []byte) (any, bool) {
+	switch typeName {
+	case typeBoolean:
+		if len(data) != 1 {
+			return nil, false
+		}
+
+		return data[0] != 0, true
+	case typeInt, typeDate:
+		if len(data) != 4 {
+			return nil, false
+		}
+
+		return int64(int32(binary.LittleEndian.Uint32(data))), true
+	case typeLong, typeTimestamp, typeTimestampTZ:
+		if len(data) != 8 {
+			return nil, false
+		}
+
+		return int64(binary.LittleEndian.Uint64(data)), true
+	case typeFloat:
+		if len(data) != 4 {
+			return nil, false
+		}
+
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(data))), true
+	case typeDouble:
+		if len(data) != 8 {
+			return nil, false
+		}
+
+		return math.Float64frombits(binary.LittleEndian.Uint64(data)), true
+	case typeString:
+		return string(data), true
+	default:
+		return nil, false
+	}
+}
+
+// normalizePartitionValue converts the value decoded from Avro to the representation used by decodeBound
+func normalizePartitionValue(typeName string, value any) (any, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, typeName == typeBoolean
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		return v, typeName == typeString
+	case time.Time:
+		switch typeName {
+		case typeDate:
+			return v.Unix() / int64(24*time.Hour/time.Second), true
+		case typeTimestamp, typeTimestampTZ:
+			return v.UnixMicro(), true
+		}
+	}
+
+	return nil, false
+}
+
+// literalMatchesType checks that the literal can be compared with the column bounds of the Iceberg type
+func literalMatchesType(typeName string, typeID Ydb.Type_PrimitiveTypeId) bool {
+	switch typeName {
+	case typeBoolean:
+		return typeID == Ydb.Type_BOOL
+	case typeInt, typeLong, typeFloat, typeDouble:
+		switch typeID {
+		case Ydb.Type_INT8, Ydb.Type_INT16, Ydb.Type_INT32, Ydb.Type_INT64,
+			Ydb.Type_UINT8, Ydb.Type_UINT16, Ydb.Type_UINT32, Ydb.Type_UINT64,
+			Ydb.Type_FLOAT, Ydb.Type_DOUBLE:
+			return true
+		default:
+			return false
+		}
+	case typeDate:
+		return typeID == Ydb.Type_DATE
+	case typeTimestamp, typeTimestampTZ:
+		return typeID == Ydb.Type_TIMESTAMP
+	case typeString:
+		return typeID == Ydb.Type_UTF8 || typeID == Ydb.Type_STRING
+	default:
+		return false
+	}
+}
+
+// normalizeLiteral converts the typed value of the predicate to the representation used by decodeBound
+//
+//nolint:gocyclo
+func normalizeLiteral(typeName string, typedValue *Ydb.TypedValue) (any, bool) {
+	ydbType := typedValue.GetType()
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	if !literalMatchesType(typeName, ydbType.GetTypeId()) {
+		return nil, false
+	}
+
+	value := typedValue.GetValue()
+	if _, isNull := value.GetValue().(*Ydb.Value_NullFlagValue); isNull {
+		return nil, false
+	}
+
+	switch ydbType.GetTypeId() {
+	case Ydb.Type_BOOL:
+		return value.GetBoolValue(), true
+	case Ydb.Type_INT8, Ydb.Type_INT16, Ydb.Type_INT32:
+		return int64(value.GetInt32Value()), true
+	case Ydb.Type_INT64:
+		return value.GetInt64Value(), true
+	case Ydb.Type_UINT8, Ydb.Type_UINT16, Ydb.Type_UINT32:
+		return int64(value.GetUint32Value()), true
+	case Ydb.Type_UINT64:
+		if value.GetUint64Value() > math.MaxInt64 {
+			return nil, false
+		}
+
+		return int64(value.GetUint64Value()), true
+	case Ydb.Type_FLOAT:
+		return float64(value.GetFloatValue()), true
+	case Ydb.Type_DOUBLE:
+		return value.GetDoubleValue(), true
+	case Ydb.Type_UTF8:
+		return value.GetTextValue(), true
+	case Ydb.Type_STRING:
+		return string(value.GetBytesValue()), true
+	case Ydb.Type_DATE:
+		return int64(value.GetUint32Value()), true
+	case Ydb.Type_TIMESTAMP:
+		return int64(value.GetUint64Value()), true
+	default:
+		return nil, false
+	}
+}
+
+// compareValues returns the result of comparison and false if the values are not comparable
+func compareValues(lhs, rhs any) (int, bool) {
+	switch l := lhs.(type) {
+	case int64:
+		switch r := rhs.(type) {
+		case int64:
+			return compareOrdered(l, r), true
+		case float64:
+			return compareOrdered(float64(l), r), true
+		}
+	case float64:
+		switch r := rhs.(type) {
+		case int64:
+			return compareOrdered(l, float64(r)), true
+		case float64:
+			if math.IsNaN(l) || math.IsNaN(r) {
+				return 0, false
+			}
+
+			return compareOrdered(l, r), true
+		}
+	case string:
+		if r, ok := rhs.(string); ok {
+			return strings.Compare(l, r), true
+		}
+	case bool:
+		if r, ok := rhs.(bool); ok {
+			switch {
+			case l == r:
+				return 0, true
+			case !l:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func compareOrdered[T int64 | float64](lhs, rhs T) int {
+	switch {
+	case lhs < rhs:
+		return -1
+	case lhs > rhs:
+		return 1
+	default:
+		return 0
+	}
+}
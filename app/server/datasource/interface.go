@@ -38,6 +38,14 @@ type DataSource[T paging.Acceptor] interface {
 		request *api_service_protos.TDescribeTableRequest,
 	) (*api_service_protos.TDescribeTableResponse, error)
 
+	// ListTables returns the names of tables (or similar entities in non-relational data sources)
+	// available within a particular database in a cluster of a certain type.
+	ListTables(
+		ctx context.Context,
+		logger *zap.Logger,
+		request *api_service_protos.TListTablesRequest,
+	) (*api_service_protos.TListTablesResponse, error)
+
 	// ListSplits analyzes the external table and returns the stream of its splits.
 	ListSplits(
 		ctx context.Context,
@@ -58,6 +66,12 @@ type DataSource[T paging.Acceptor] interface {
 	) error
 }
 
+// PredicateCheckerMaker is implemented by the data sources able to push down the WHERE clause (or some of its parts).
+// The conjuncts that are not pushed down are evaluated by the connector itself.
+type PredicateCheckerMaker interface {
+	MakePredicateChecker(logger *zap.Logger, dataSourceKind api_common.EGenericDataSourceKind) PredicateChecker
+}
+
 type TypeMapper interface {
 	SQLTypeToYDBColumn(columnName, typeName string, rules *api_service_protos.TTypeMappingSettings) (*Ydb.Column, error)
 }
@@ -25,6 +25,14 @@ func (*DataSourceMock[T]) DescribeTable(
 	panic("not implemented") // TODO: Implement
 }
 
+func (*DataSourceMock[T]) ListTables(
+	_ context.Context,
+	_ *zap.Logger,
+	_ *api_service_protos.TListTablesRequest,
+) (*api_service_protos.TListTablesResponse, error) {
+	panic("not implemented") // TODO: Implement
+}
+
 func (*DataSourceMock[T]) ListSplits(
 	_ context.Context,
 	_ *zap.Logger,
@@ -20,6 +20,7 @@ import (
 )
 
 var _ datasource.DataSource[any] = (*dataSource)(nil)
+var _ datasource.PredicateCheckerMaker = (*dataSource)(nil)
 
 type dataSource struct {
 	retrierSet  *retry.RetrierSet
@@ -110,15 +111,92 @@ func (ds *dataSource) DescribeTable(
 		return nil, fmt.Errorf("bsonToYqlColumn: %w", err)
 	}
 
-	return &api_service_protos.TDescribeTableResponse{Schema: &api_service_protos.TSchema{Columns: columns}}, nil
+	return &api_service_protos.TDescribeTableResponse{
+		Schema:               &api_service_protos.TSchema{Columns: columns},
+		PushdownCapabilities: makePushdownCapabilities(logger),
+	}, nil
+}
+
+func (*dataSource) MakePredicateChecker(logger *zap.Logger, _ api_common.EGenericDataSourceKind) datasource.PredicateChecker {
+	return makePredicateChecker(logger)
+}
+
+func makePredicateChecker(logger *zap.Logger) datasource.PredicateChecker {
+	return func(predicate *api_service_protos.TPredicate) error {
+		_, err := makePredicateFilter(logger, predicate, false)
+
+		return err
+	}
+}
+
+func makePushdownCapabilities(logger *zap.Logger) *api_service_protos.TPushdownCapabilities {
+	checkPredicate := makePredicateChecker(logger)
+
+	checkOrderBy := func(orderBy *api_service_protos.TSelect_TOrderBy) error {
+		_, err := makeSort(orderBy)
+
+		return err
+	}
+
+	return datasource.ProbePushdownCapabilities(checkPredicate, checkOrderBy, nil, true)
+}
+
+func (ds *dataSource) ListTables(
+	ctx context.Context,
+	logger *zap.Logger,
+	request *api_service_protos.TListTablesRequest,
+) (*api_service_protos.TListTablesResponse, error) {
+	dsi := request.DataSourceInstance
+
+	if dsi.Protocol != api_common.EGenericProtocol_NATIVE {
+		return nil, fmt.Errorf("cannot run MongoDb connection with protocol '%v'", dsi.Protocol)
+	}
+
+	if dsi.GetMongodbOptions() == nil {
+		return nil, fmt.Errorf("TMongoDbDataSourceOptions not provided")
+	}
+
+	var conn *mongo.Client
+
+	err := ds.retrierSet.MakeConnection.Run(ctx, logger,
+		func() error {
+			var err error
+			conn, err = ds.makeConnection(ctx, logger, dsi)
+
+			return err
+		},
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("make connection: %w", err)
+	}
+
+	defer func() {
+		if err = conn.Disconnect(ctx); err != nil {
+			logger.Error(fmt.Sprintf("disconnect: %v", err))
+		}
+	}()
+
+	collections, err := conn.Database(dsi.Database).ListCollectionNames(ctx, bson.D{})
+	if err != nil {
+		return nil, fmt.Errorf("list collection names: %w", err)
+	}
+
+	return &api_service_protos.TListTablesResponse{Tables: collections}, nil
 }
 
 func (*dataSource) ListSplits(
 	ctx context.Context,
-	_ *zap.Logger,
+	logger *zap.Logger,
 	_ *api_service_protos.TListSplitsRequest,
 	slct *api_service_protos.TSelect,
 	resultChan chan<- *datasource.ListSplitResult) error {
+	if _, err := makeSort(slct.GetOrderBy()); err != nil {
+		logger.Warn("ORDER BY pushdown is not possible, rows will be sorted on the YDB side", zap.Error(err))
+
+		slct = common.SelectWithoutOrderBy(slct)
+	}
+
 	// By default we deny table splitting
 	select {
 	case resultChan <- &datasource.ListSplitResult{Slct: slct, Description: nil}:
@@ -136,6 +214,10 @@ func (ds *dataSource) ReadSplit(
 	request *api_service_protos.TReadSplitsRequest,
 	split *api_service_protos.TSplit,
 	sinkFactory paging.SinkFactory[any]) error {
+	if common.SelectWhatHasComputedColumns(split.Select.What) {
+		return fmt.Errorf("computed columns are not supported by MongoDB: %w", common.ErrUnimplementedExpression)
+	}
+
 	dsi := split.Select.DataSourceInstance
 
 	if dsi.Protocol != api_common.EGenericProtocol_NATIVE {
@@ -240,6 +322,22 @@ func (ds *dataSource) doReadSplitSingleConn(
 		return fmt.Errorf("failed to make filter: %w", err)
 	}
 
+	if position := sink.ResumePosition(); position != nil {
+		var ok bool
+
+		filter, ok, err = applyResumePosition(filter, opts, position)
+		if err != nil {
+			return fmt.Errorf("apply resume position: %w", err)
+		}
+
+		// All the documents have been delivered before the interruption of the previous request
+		if !ok || position.Finished {
+			sink.Finish()
+
+			return nil
+		}
+	}
+
 	ds.queryLogger.Dump("Query filter", zap.Any("filter", filter))
 
 	var cursor *mongo.Cursor
@@ -293,6 +391,14 @@ func (ds *dataSource) doReadSplitSingleConn(
 		if err = sink.AddRow(reader.transformer); err != nil {
 			return fmt.Errorf("add row to sink: %w", err)
 		}
+
+		// The reading of the sorted documents can't be resumed after the last '_id',
+		// the documents delivered before the interruption are skipped by the sink instead
+		if split.Select.GetOrderBy() == nil {
+			if resumeCursor := makeResumeCursor(cursor.Current); resumeCursor != nil {
+				sink.Checkpoint(resumeCursor)
+			}
+		}
 	}
 
 	if err = cursor.Err(); err != nil {
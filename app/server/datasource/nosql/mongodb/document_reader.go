@@ -243,6 +243,9 @@ func (r *documentReader) accept(doc bson.M) error {
 				return fmt.Errorf("unuspported type %T: %w", value, common.ErrDataTypeNotSupported)
 			}
 
+		case *nestedValue:
+			// Documents and arrays are appended by the nested value appender which checks types on its own
+			a.value = doc[f.Name]
 		case **any:
 			// We use any to handle both ObjectID and Binary BSON types when converting them to YQL String.
 			value, ok := doc[f.Name]
@@ -275,7 +278,7 @@ func makeDocumentReader(
 	ydbTypes []*Ydb.Type,
 	cc conversion.Collection,
 ) (*documentReader, error) {
-	transformer, err := makeTransformer(ydbTypes, cc)
+	transformer, err := makeTransformer(ydbTypes, cc, unexpectedDisplayMode)
 	if err != nil {
 		return nil, err
 	}
@@ -291,13 +294,24 @@ func makeDocumentReader(
 
 type appenderFunc = func(acceptor any, builder array.Builder) error
 
-func makeTransformer(ydbTypes []*Ydb.Type, cc conversion.Collection) (paging.RowTransformer[any], error) {
+func makeTransformer(
+	ydbTypes []*Ydb.Type,
+	cc conversion.Collection,
+	unexpectedDisplayMode unexpectedTypeDisplayMode,
+) (paging.RowTransformer[any], error) {
 	acceptors := make([]any, 0, len(ydbTypes))
 	appenders := make([]appenderFunc, 0, len(ydbTypes))
 
 	var err error
 
 	for _, ydbType := range ydbTypes {
+		if isNestedType(ydbType) {
+			acceptors = append(acceptors, new(nestedValue))
+			appenders = append(appenders, makeNestedValueAppender(ydbType, unexpectedDisplayMode))
+
+			continue
+		}
+
 		acceptors, appenders, err = addAcceptorAppender(ydbType, cc, acceptors, appenders)
 
 		if err != nil {
@@ -479,3 +493,193 @@ func yqlStringAppender(acceptor any, builder array.Builder, ccBytes conversion.V
 		return fmt.Errorf("unsupported type mapped to YQL STRING: %v", value)
 	}
 }
+
+// nestedValue is the acceptor for the embedded documents and arrays
+type nestedValue struct {
+	value any
+}
+
+func isNestedType(ydbType *Ydb.Type) bool {
+	if optType := ydbType.GetOptionalType(); optType != nil {
+		ydbType = optType.Item
+	}
+
+	return ydbType.GetStructType() != nil || ydbType.GetListType() != nil
+}
+
+func makeNestedValueAppender(ydbType *Ydb.Type, unexpectedDisplayMode unexpectedTypeDisplayMode) appenderFunc {
+	return func(acceptor any, builder array.Builder) error {
+		//nolint:forcetypeassert
+		value := acceptor.(*nestedValue)
+
+		err := appendNestedValue(ydbType, value.value, builder, unexpectedDisplayMode)
+
+		// the acceptor is reused for the next document where the field may be missing
+		value.value = nil
+
+		return err
+	}
+}
+
+// appendNestedValue appends the value decoded from BSON to the Arrow builder made for the given YDB type.
+// Just like for the top level fields, the values of unexpected types are appended as NULL.
+//
+//nolint:gocyclo
+func appendNestedValue(
+	ydbType *Ydb.Type,
+	value any,
+	builder array.Builder,
+	unexpectedDisplayMode unexpectedTypeDisplayMode,
+) error {
+	if optType := ydbType.GetOptionalType(); optType != nil {
+		ydbType = optType.Item
+	}
+
+	if value == nil {
+		builder.AppendNull()
+
+		return nil
+	}
+
+	switch t := ydbType.Type.(type) {
+	case *Ydb.Type_StructType:
+		doc, ok := documentToMap(value)
+		if !ok {
+			builder.AppendNull()
+
+			return nil
+		}
+
+		//nolint:forcetypeassert
+		structBuilder := builder.(*array.StructBuilder)
+		structBuilder.Append(true)
+
+		for i, member := range t.StructType.Members {
+			err := appendNestedValue(member.Type, doc[member.Name], structBuilder.FieldBuilder(i), unexpectedDisplayMode)
+			if err != nil {
+				return fmt.Errorf("append member %s: %w", member.Name, err)
+			}
+		}
+	case *Ydb.Type_ListType:
+		items, ok := value.(primitive.A)
+		if !ok {
+			builder.AppendNull()
+
+			return nil
+		}
+
+		//nolint:forcetypeassert
+		listBuilder := builder.(*array.ListBuilder)
+		listBuilder.Append(true)
+
+		for i, item := range items {
+			err := appendNestedValue(t.ListType.Item, item, listBuilder.ValueBuilder(), unexpectedDisplayMode)
+			if err != nil {
+				return fmt.Errorf("append item #%d: %w", i, err)
+			}
+		}
+	case *Ydb.Type_TaggedType:
+		if t.TaggedType.Tag != objectIdTag {
+			return fmt.Errorf("unknown Tagged tag: %s", t.TaggedType.Tag)
+		}
+
+		return appendNestedPrimitiveValue(Ydb.Type_STRING, value, builder, unexpectedDisplayMode)
+	case *Ydb.Type_TypeId:
+		return appendNestedPrimitiveValue(t.TypeId, value, builder, unexpectedDisplayMode)
+	default:
+		return fmt.Errorf("unsupported: %v", ydbType.String())
+	}
+
+	return nil
+}
+
+//nolint:gocyclo
+func appendNestedPrimitiveValue(
+	typeID Ydb.Type_PrimitiveTypeId,
+	value any,
+	builder array.Builder,
+	unexpectedDisplayMode unexpectedTypeDisplayMode,
+) error {
+	ok := true
+
+	switch typeID {
+	case Ydb.Type_BOOL:
+		var v bool
+		if v, ok = value.(bool); ok {
+			var out uint8
+			if v {
+				out = 1
+			}
+
+			//nolint:forcetypeassert
+			builder.(*array.Uint8Builder).Append(out)
+		}
+	case Ydb.Type_INT32:
+		var v int32
+		if v, ok = value.(int32); ok {
+			//nolint:forcetypeassert
+			builder.(*array.Int32Builder).Append(v)
+		}
+	case Ydb.Type_INT64:
+		var v int64
+		if v, ok = value.(int64); ok {
+			//nolint:forcetypeassert
+			builder.(*array.Int64Builder).Append(v)
+		}
+	case Ydb.Type_DOUBLE:
+		var v float64
+		if v, ok = value.(float64); ok {
+			//nolint:forcetypeassert
+			builder.(*array.Float64Builder).Append(v)
+		}
+	case Ydb.Type_UTF8:
+		str, err := bsonToString(value)
+		if err != nil {
+			if !errors.Is(err, common.ErrDataTypeNotSupported) {
+				return err
+			}
+
+			ok = unexpectedDisplayMode != api_common.TMongoDbDataSourceOptions_UNEXPECTED_AS_NULL
+		}
+
+		if ok {
+			//nolint:forcetypeassert
+			builder.(*array.StringBuilder).Append(str)
+		}
+	case Ydb.Type_STRING:
+		switch v := value.(type) {
+		case primitive.Binary:
+			//nolint:forcetypeassert
+			builder.(*array.BinaryBuilder).Append(v.Data)
+		case primitive.ObjectID:
+			text, err := v.MarshalText()
+			if err != nil {
+				return fmt.Errorf("marshal text from data in ObjectId: %w", err)
+			}
+
+			//nolint:forcetypeassert
+			builder.(*array.BinaryBuilder).Append(text)
+		default:
+			ok = false
+		}
+	default:
+		return fmt.Errorf("unsupported primitive type %v: %w", typeID, common.ErrDataTypeNotSupported)
+	}
+
+	if !ok {
+		builder.AppendNull()
+	}
+
+	return nil
+}
+
+func documentToMap(value any) (bson.M, bool) {
+	switch doc := value.(type) {
+	case primitive.M:
+		return doc, true
+	case primitive.D:
+		return doc.Map(), true
+	default:
+		return nil, false
+	}
+}
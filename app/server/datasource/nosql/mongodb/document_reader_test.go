@@ -0,0 +1,48 @@
+package mongodb
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestAppendNestedValue(t *testing.T) {
+	optional := common.MakeOptionalType
+
+	ydbType := optional(common.MakeStructType([]*Ydb.StructMember{
+		{Name: "id", Type: optional(common.MakePrimitiveType(Ydb.Type_INT32))},
+		{Name: "tags", Type: optional(common.MakeListType(optional(common.MakePrimitiveType(Ydb.Type_UTF8))))},
+		{Name: "data", Type: optional(common.MakePrimitiveType(Ydb.Type_STRING))},
+	}))
+
+	builders, err := common.YdbTypesToArrowBuilders([]*Ydb.Type{ydbType}, memory.NewGoAllocator())
+	require.NoError(t, err)
+
+	values := []any{
+		bson.M{"id": int32(1), "tags": bson.A{"a", nil, int32(2)}, "data": primitive.Binary{Data: []byte{0xab}}},
+		// unexpected types are appended as NULL
+		bson.D{{Key: "id", Value: "1"}, {Key: "tags", Value: "a"}},
+		nil,
+		int32(1),
+	}
+
+	for _, value := range values {
+		err := appendNestedValue(ydbType, value, builders[0], api_common.TMongoDbDataSourceOptions_UNEXPECTED_AS_STRING)
+		require.NoError(t, err)
+	}
+
+	structs := builders[0].NewArray().(*array.Struct)
+	require.Equal(t, `{[1 (null) (null) (null)] [["a" (null) "2"] (null) (null) (null)] ["\xab" (null) (null) (null)]}`, structs.String())
+	require.True(t, structs.IsValid(1))
+	require.True(t, structs.IsNull(2))
+	require.True(t, structs.IsNull(3))
+}
@@ -3,8 +3,10 @@ package mongodb
 import (
 	"encoding/hex"
 	"fmt"
+	"strings"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
@@ -12,6 +14,7 @@ import (
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
@@ -34,6 +37,13 @@ func makeFilter(
 
 	opts.SetProjection(projection)
 
+	sort, err := makeSort(split.Select.GetOrderBy())
+	if err != nil {
+		return nil, nil, fmt.Errorf("make sort: %w", err)
+	}
+
+	opts.SetSort(sort)
+
 	limit := split.Select.Limit
 	if limit != nil {
 		opts.SetSkip(int64(limit.Offset))
@@ -70,6 +80,92 @@ func makeFilter(
 	return filter, opts, nil
 }
 
+// makeSort renders the requested ordering. Documents are read in '_id' order by default,
+// so that the reading can be resumed after the last delivered document.
+// '_id' also finishes the list of the requested sort keys to make the order stable.
+// Missing fields and nulls precede the other values in ascending order, as they do in YQL.
+func makeSort(orderBy *api_service_protos.TSelect_TOrderBy) (bson.D, error) {
+	sort := make(bson.D, 0, len(orderBy.GetKeys())+1)
+	sortedByID := false
+
+	for _, key := range orderBy.GetKeys() {
+		path, err := formatFieldPath(key.Column)
+		if err != nil {
+			return nil, fmt.Errorf("format field path: %w", err)
+		}
+
+		switch key.Order {
+		case api_service_protos.TSelect_TOrderBy_ASC:
+			sort = append(sort, bson.E{Key: path, Value: 1})
+		case api_service_protos.TSelect_TOrderBy_DESC:
+			sort = append(sort, bson.E{Key: path, Value: -1})
+		default:
+			return nil, fmt.Errorf("unknown sort order '%v' for column '%s': %w", key.Order, key.Column, common.ErrInvalidRequest)
+		}
+
+		sortedByID = sortedByID || path == "_id"
+	}
+
+	if !sortedByID {
+		sort = append(sort, bson.E{Key: "_id", Value: 1})
+	}
+
+	return sort, nil
+}
+
+// makeResumeCursor encodes '_id' of the document, so that the reading can be resumed right after it.
+// Returns nil if the document has no '_id'.
+func makeResumeCursor(doc bson.Raw) *paging.TCursor {
+	id, err := doc.LookupErr("_id")
+	if err != nil {
+		return nil
+	}
+
+	resumeID := make([]byte, 0, len(id.Value)+1)
+	resumeID = append(resumeID, byte(id.Type))
+	resumeID = append(resumeID, id.Value...)
+
+	return &paging.TCursor{Payload: &paging.TCursor_ResumeId{ResumeId: resumeID}}
+}
+
+// applyResumePosition restricts the query to the documents following the last delivered one.
+// The returned flag is false if there is nothing left to read.
+func applyResumePosition(
+	filter bson.D,
+	opts *options.FindOptions,
+	position *paging.TContinuationToken_TPosition,
+) (bson.D, bool, error) {
+	resumeID := position.GetCursor().GetResumeId()
+	if len(resumeID) == 0 {
+		// The rows delivered so far will be skipped by the sink
+		return filter, true, nil
+	}
+
+	id := bson.RawValue{Type: bsontype.Type(resumeID[0]), Value: resumeID[1:]}
+	if err := id.Validate(); err != nil {
+		return nil, false, fmt.Errorf("validate resume id: %v: %w", err, common.ErrInvalidRequest)
+	}
+
+	// OFFSET has been already applied to the delivered documents, LIMIT is reduced by their number
+	if opts.Limit != nil {
+		remaining := *opts.Limit - int64(position.GetRowsDelivered())
+		if remaining <= 0 {
+			return nil, false, nil
+		}
+
+		opts.SetSkip(0)
+		opts.SetLimit(remaining)
+	}
+
+	idFilter := bson.D{{Key: "_id", Value: bson.D{{Key: "$gt", Value: id}}}}
+
+	if len(filter) == 0 {
+		return idFilter, true, nil
+	}
+
+	return bson.D{{Key: "$and", Value: bson.A{filter, idFilter}}}, true, nil
+}
+
 //nolint:funlen,gocyclo
 func makePredicateFilter(
 	logger *zap.Logger,
@@ -209,7 +305,12 @@ func getIsNotNullFilter(
 ) (bson.D, error) {
 	switch e := expression.Payload.(type) {
 	case *api_service_protos.TExpression_Column:
-		return bson.D{{Key: e.Column, Value: bson.D{{Key: "$ne", Value: nil}}}}, nil
+		fieldPath, err := formatFieldPath(e.Column)
+		if err != nil {
+			return nil, err
+		}
+
+		return bson.D{{Key: fieldPath, Value: bson.D{{Key: "$ne", Value: nil}}}}, nil
 	default:
 		return nil, fmt.Errorf("unsupported expression in IsNotNull filter: %w", common.ErrUnimplementedExpression)
 	}
@@ -220,10 +321,15 @@ func getIsNullFilter(
 ) (bson.D, error) {
 	switch e := expression.Payload.(type) {
 	case *api_service_protos.TExpression_Column:
+		fieldPath, err := formatFieldPath(e.Column)
+		if err != nil {
+			return nil, err
+		}
+
 		return bson.D{
 			{Key: "$or", Value: bson.A{
-				bson.D{{Key: e.Column, Value: bson.D{{Key: "$exists", Value: false}}}},
-				bson.D{{Key: e.Column, Value: bson.D{{Key: "$eq", Value: nil}}}},
+				bson.D{{Key: fieldPath, Value: bson.D{{Key: "$exists", Value: false}}}},
+				bson.D{{Key: fieldPath, Value: bson.D{{Key: "$eq", Value: nil}}}},
 			}},
 		}, nil
 	default:
@@ -296,7 +402,12 @@ func getStringComparisonFilter(
 
 	switch e := comparison.LeftValue.Payload.(type) {
 	case *api_service_protos.TExpression_Column:
-		fieldName = e.Column
+		fieldPath, err := formatFieldPath(e.Column)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldName = fieldPath
 	default:
 		return nil, fmt.Errorf("unsupported expression for left value in string comparison filter: %w", common.ErrUnimplementedExpression)
 	}
@@ -330,7 +441,12 @@ func getInSetFilter(
 	var fieldName string
 	switch e := in.Value.Payload.(type) {
 	case *api_service_protos.TExpression_Column:
-		fieldName = e.Column
+		fieldPath, err := formatFieldPath(e.Column)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldName = fieldPath
 	default:
 		return nil, fmt.Errorf("unsupported expression in In filter: %w", common.ErrUnimplementedExpression)
 	}
@@ -361,7 +477,12 @@ func getBetweenFilter(
 
 	switch e := between.Value.Payload.(type) {
 	case *api_service_protos.TExpression_Column:
-		fieldName = e.Column
+		fieldPath, err := formatFieldPath(e.Column)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldName = fieldPath
 	default:
 		return nil, fmt.Errorf("unsupported expression in Between filter: %w", common.ErrUnimplementedExpression)
 	}
@@ -398,7 +519,12 @@ func getRegexFilter(
 
 	switch e := regex.Value.Payload.(type) {
 	case *api_service_protos.TExpression_Column:
-		fieldName = e.Column
+		fieldPath, err := formatFieldPath(e.Column)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldName = fieldPath
 	default:
 		return nil, fmt.Errorf("unsupported expression in Regexp filter: %w", common.ErrUnimplementedExpression)
 	}
@@ -414,7 +540,12 @@ func getRegexFilter(
 func formatExpression(expression *api_service_protos.TExpression) (any, error) {
 	switch e := expression.Payload.(type) {
 	case *api_service_protos.TExpression_Column:
-		return fmt.Sprintf("$%s", e.Column), nil
+		fieldPath, err := formatFieldPath(e.Column)
+		if err != nil {
+			return nil, err
+		}
+
+		return fmt.Sprintf("$%s", fieldPath), nil
 	case *api_service_protos.TExpression_TypedValue:
 		return formatTypedValue(e.TypedValue)
 	case *api_service_protos.TExpression_Null:
@@ -426,6 +557,18 @@ func formatExpression(expression *api_service_protos.TExpression) (any, error) {
 	}
 }
 
+// formatFieldPath checks the column name which may also refer to a member of the embedded document
+// with a dotted path like `struct.member`: MongoDB resolves such paths on its own both in queries and in `$expr`.
+func formatFieldPath(column string) (string, error) {
+	for _, segment := range strings.Split(column, ".") {
+		if segment == "" || strings.HasPrefix(segment, "$") {
+			return "", fmt.Errorf("invalid field path '%s': %w", column, common.ErrUnimplementedExpression)
+		}
+	}
+
+	return column, nil
+}
+
 func formatTypedValue(expr *Ydb.TypedValue) (any, error) {
 	v := expr.GetValue()
 	ydbType := expr.GetType()
@@ -2,15 +2,19 @@ package mongodb
 
 import (
 	"encoding/hex"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/common"
 	tests_utils "github.com/ydb-platform/fq-connector-go/tests/utils"
 )
@@ -104,6 +108,16 @@ func TestYqlStringFilter(t *testing.T) {
 				}}},
 			},
 		}},
+		{
+			Payload: tests_utils.MakePredicateInColumn(
+				"struct.foo",
+				[]*Ydb.TypedValue{
+					common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT32), int32(42)),
+				},
+			),
+		}: {{Key: "struct.foo",
+			Value: bson.D{{Key: "$in", Value: []any{int32(42)}}},
+		}},
 		{
 			// SELECT * FROM object_ids WHERE COALESCE("$b", hexEncodedValid, hexEncodedValid) = a;
 			Payload: &api_service_protos.TPredicate_Comparison{
@@ -155,3 +169,127 @@ func TestYqlStringFilter(t *testing.T) {
 		}
 	}
 }
+
+func TestYqlFilterFieldPath(t *testing.T) {
+	logger := common.NewDefaultLogger()
+
+	for _, column := range []string{"struct.", ".foo", "struct.$foo"} {
+		_, err := makePredicateFilter(
+			logger,
+			&api_service_protos.TPredicate{
+				Payload: tests_utils.MakePredicateIsNullColumn(column),
+			},
+			false,
+		)
+
+		require.ErrorIs(t, err, common.ErrUnimplementedExpression, column)
+	}
+}
+
+func TestApplyResumePosition(t *testing.T) {
+	objectID, _ := primitive.ObjectIDFromHex("171e75500ecde1c75c59139e")
+
+	doc, err := bson.Marshal(bson.D{{Key: "_id", Value: objectID}, {Key: "a", Value: int32(1)}})
+	require.NoError(t, err)
+
+	position := &paging.TContinuationToken_TPosition{Cursor: makeResumeCursor(doc), RowsDelivered: 3}
+
+	t.Run("without filter", func(t *testing.T) {
+		filter, ok, err := applyResumePosition(bson.D{}, options.Find(), position)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		expected, err := bson.Marshal(bson.D{{Key: "_id", Value: bson.D{{Key: "$gt", Value: objectID}}}})
+		require.NoError(t, err)
+
+		actual, err := bson.Marshal(filter)
+		require.NoError(t, err)
+		require.Equal(t, bson.Raw(expected), bson.Raw(actual))
+	})
+
+	t.Run("with filter and limit", func(t *testing.T) {
+		opts := options.Find().SetSkip(10).SetLimit(5)
+
+		filter, ok, err := applyResumePosition(bson.D{{Key: "a", Value: int32(1)}}, opts, position)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Len(t, filter, 1)
+		require.Equal(t, "$and", filter[0].Key)
+		require.EqualValues(t, 0, *opts.Skip)
+		require.EqualValues(t, 2, *opts.Limit)
+	})
+
+	t.Run("limit exhausted", func(t *testing.T) {
+		_, ok, err := applyResumePosition(bson.D{}, options.Find().SetLimit(3), position)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("no resume id", func(t *testing.T) {
+		filter := bson.D{{Key: "a", Value: int32(1)}}
+		opts := options.Find().SetSkip(10)
+
+		actual, ok, err := applyResumePosition(filter, opts, &paging.TContinuationToken_TPosition{RowsAfterCursor: 3})
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, filter, actual)
+		require.EqualValues(t, 10, *opts.Skip)
+	})
+
+	t.Run("malformed resume id", func(t *testing.T) {
+		malformed := &paging.TContinuationToken_TPosition{
+			Cursor: &paging.TCursor{Payload: &paging.TCursor_ResumeId{ResumeId: []byte{byte(bson.TypeObjectID), 1, 2}}},
+		}
+
+		_, _, err := applyResumePosition(bson.D{}, options.Find(), malformed)
+		require.True(t, errors.Is(err, common.ErrInvalidRequest))
+	})
+}
+
+func TestMakeSort(t *testing.T) {
+	sort, err := makeSort(nil)
+	require.NoError(t, err)
+	require.Equal(t, bson.D{{Key: "_id", Value: 1}}, sort)
+
+	sort, err = makeSort(&api_service_protos.TSelect_TOrderBy{
+		Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{
+			{Column: "struct.foo", Order: api_service_protos.TSelect_TOrderBy_DESC},
+			{Column: "a", Order: api_service_protos.TSelect_TOrderBy_ASC},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, bson.D{{Key: "struct.foo", Value: -1}, {Key: "a", Value: 1}, {Key: "_id", Value: 1}}, sort)
+
+	sort, err = makeSort(&api_service_protos.TSelect_TOrderBy{
+		Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{
+			{Column: "_id", Order: api_service_protos.TSelect_TOrderBy_DESC},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, bson.D{{Key: "_id", Value: -1}}, sort)
+
+	_, err = makeSort(&api_service_protos.TSelect_TOrderBy{
+		Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{
+			{Column: "$a", Order: api_service_protos.TSelect_TOrderBy_ASC},
+		},
+	})
+	require.ErrorIs(t, err, common.ErrUnimplementedExpression)
+}
+
+func TestMakePushdownCapabilities(t *testing.T) {
+	capabilities := makePushdownCapabilities(common.NewTestLogger(t))
+
+	require.Contains(t, capabilities.ComparisonOperations, api_service_protos.TPredicate_TComparison_STARTS_WITH)
+	require.Contains(t, capabilities.ValueTypes, Ydb.Type_INT64)
+	require.NotContains(t, capabilities.ValueTypes, Ydb.Type_TIMESTAMP)
+	require.Empty(t, capabilities.ArithmeticalOperations)
+	require.True(t, capabilities.In)
+	require.True(t, capabilities.Between)
+	require.True(t, capabilities.Regexp)
+	require.False(t, capabilities.Cast)
+	require.True(t, capabilities.Limit)
+	require.True(t, capabilities.OrderBy)
+	require.False(t, capabilities.ComputedColumns)
+	require.Empty(t, capabilities.DateTimeFunctions)
+	require.Empty(t, capabilities.FullTextMatchModes)
+}
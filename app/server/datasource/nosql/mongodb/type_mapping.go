@@ -3,9 +3,11 @@ package mongodb
 import (
 	"errors"
 	"fmt"
+	"sort"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
@@ -14,9 +16,6 @@ import (
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
-var errEmptyArray = errors.New("can't determine field type for items in an empty array")
-var errNull = errors.New("can't determine field type for null")
-
 const objectIdTag string = "ObjectId"
 
 var objectIdTaggedType *Ydb.Type = common.MakeTaggedType(objectIdTag, common.MakePrimitiveType(Ydb.Type_STRING))
@@ -38,7 +37,10 @@ func typeMapObjectId(objectIdType objectIdType) (*Ydb.Type, error) {
 	}
 }
 
-func typeMap(logger *zap.Logger, v bson.RawValue, objectIdType objectIdType) (*Ydb.Type, error) {
+// typeMap deduces the type of the value; nil type stands for the value which type can't be determined yet,
+// that is null or the items of an empty array.
+// Nested documents and arrays are mapped to Struct and List; their members and items become Optional in finalizeType.
+func typeMap(logger *zap.Logger, v bson.RawValue, omitUnsupported bool, objectIdType objectIdType) (*Ydb.Type, error) {
 	switch v.Type {
 	case bson.TypeInt32:
 		return common.MakePrimitiveType(Ydb.Type_INT32), nil
@@ -54,8 +56,12 @@ func typeMap(logger *zap.Logger, v bson.RawValue, objectIdType objectIdType) (*Y
 		return common.MakePrimitiveType(Ydb.Type_STRING), nil
 	case bson.TypeObjectID:
 		return typeMapObjectId(objectIdType)
+	case bson.TypeEmbeddedDocument:
+		return typeMapDocument(logger, v.Document(), omitUnsupported, objectIdType)
+	case bson.TypeArray:
+		return typeMapArray(logger, v.Array(), omitUnsupported, objectIdType)
 	case bson.TypeNull:
-		return nil, errNull
+		return nil, nil
 	default:
 		logger.Debug(fmt.Sprintf("typeMap: skipping unsupported type %v", v.Type.String()))
 	}
@@ -63,11 +69,157 @@ func typeMap(logger *zap.Logger, v bson.RawValue, objectIdType objectIdType) (*Y
 	return nil, common.ErrDataTypeNotSupported
 }
 
+func typeMapDocument(logger *zap.Logger, doc bson.Raw, omitUnsupported bool, objectIdType objectIdType) (*Ydb.Type, error) {
+	elements, err := doc.Elements()
+	if err != nil {
+		return nil, fmt.Errorf("document elements: %w", err)
+	}
+
+	members := make([]*Ydb.StructMember, 0, len(elements))
+
+	for _, elem := range elements {
+		key, err := elem.KeyErr()
+		if err != nil {
+			return nil, fmt.Errorf("elem.KeyErr: %w", err)
+		}
+
+		t, err := typeMap(logger, elem.Value(), omitUnsupported, objectIdType)
+		if err != nil {
+			if !errors.Is(err, common.ErrDataTypeNotSupported) {
+				return nil, fmt.Errorf("member %s: %w", key, err)
+			}
+
+			if omitUnsupported {
+				continue
+			}
+
+			t = makeSerializedType()
+		}
+
+		members = append(members, &Ydb.StructMember{Name: key, Type: t})
+	}
+
+	return mergeStructTypes(nil, members), nil
+}
+
+func typeMapArray(logger *zap.Logger, arr bson.Raw, omitUnsupported bool, objectIdType objectIdType) (*Ydb.Type, error) {
+	values, err := arr.Values()
+	if err != nil {
+		return nil, fmt.Errorf("array values: %w", err)
+	}
+
+	var itemType *Ydb.Type
+
+	for i, value := range values {
+		t, err := typeMap(logger, value, omitUnsupported, objectIdType)
+		if err != nil {
+			// the array is kept serialized (or omitted) entirely if any of its items is not supported
+			return nil, fmt.Errorf("item #%d: %w", i, err)
+		}
+
+		itemType = mergeTypes(itemType, t)
+	}
+
+	return makeListType(itemType), nil
+}
+
+// makeSerializedType makes the type of the fields which values can't be represented with a single YDB type,
+// such values are passed as strings
+func makeSerializedType() *Ydb.Type {
+	return common.MakePrimitiveType(Ydb.Type_UTF8)
+}
+
+// makeListType makes a list type even for the unknown item type (when nothing but empty arrays are met)
+func makeListType(itemType *Ydb.Type) *Ydb.Type {
+	return &Ydb.Type{Type: &Ydb.Type_ListType{ListType: &Ydb.ListType{Item: itemType}}}
+}
+
+// mergeTypes merges the types deduced from different samples of the same field.
+// Lists and structs are merged item-wise and member-wise, other conflicting types make the field serialized.
+func mergeTypes(prev, curr *Ydb.Type) *Ydb.Type {
+	switch {
+	case prev == nil:
+		return curr
+	case curr == nil:
+		return prev
+	}
+
+	prevList, currList := prev.GetListType(), curr.GetListType()
+	if prevList != nil && currList != nil {
+		return makeListType(mergeTypes(prevList.Item, currList.Item))
+	}
+
+	prevStruct, currStruct := prev.GetStructType(), curr.GetStructType()
+	if prevStruct != nil && currStruct != nil {
+		return mergeStructTypes(prevStruct.Members, currStruct.Members)
+	}
+
+	if common.TypesEqual(prev, curr) {
+		return prev
+	}
+
+	return makeSerializedType()
+}
+
+// mergeStructTypes makes the struct having the members of both structs sorted by their names
+func mergeStructTypes(prev, curr []*Ydb.StructMember) *Ydb.Type {
+	merged := make(map[string]*Ydb.Type, len(prev)+len(curr))
+
+	for _, member := range append(append([]*Ydb.StructMember{}, prev...), curr...) {
+		merged[member.Name] = mergeTypes(merged[member.Name], member.Type)
+	}
+
+	names := make([]string, 0, len(merged))
+	for name := range merged {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	members := make([]*Ydb.StructMember, 0, len(names))
+	for _, name := range names {
+		members = append(members, &Ydb.StructMember{Name: name, Type: merged[name]})
+	}
+
+	return common.MakeStructType(members)
+}
+
+// finalizeType turns the deduced type into the column type: the types that are still unknown
+// (fields that were always null or empty arrays) become serialized,
+// and every struct member and list item becomes Optional because it can be missing or null in other documents.
+func finalizeType(t *Ydb.Type) *Ydb.Type {
+	if t == nil {
+		return makeSerializedType()
+	}
+
+	if listType := t.GetListType(); listType != nil {
+		if listType.Item == nil {
+			return makeSerializedType()
+		}
+
+		return common.MakeListType(common.MakeOptionalType(finalizeType(listType.Item)))
+	}
+
+	if structType := t.GetStructType(); structType != nil {
+		members := make([]*Ydb.StructMember, 0, len(structType.Members))
+
+		for _, member := range structType.Members {
+			members = append(members, &Ydb.StructMember{
+				Name: member.Name,
+				Type: common.MakeOptionalType(finalizeType(member.Type)),
+			})
+		}
+
+		return common.MakeStructType(members)
+	}
+
+	return t
+}
+
 func bsonToYqlColumn(
 	logger *zap.Logger,
 	elem bson.RawElement,
 	deducedTypes map[string]*Ydb.Type,
-	ambiguousFields, ambiguousArrayFields map[string]struct{},
 	omitUnsupported bool,
 	objectIdType objectIdType,
 ) error {
@@ -76,54 +228,30 @@ func bsonToYqlColumn(
 		return fmt.Errorf("elem.KeyErr: %w", err)
 	}
 
-	prevType, prevTypeExists := deducedTypes[key]
+	prevType := deducedTypes[key]
 
-	t, err := typeMap(logger, elem.Value(), objectIdType)
+	t, err := typeMap(logger, elem.Value(), omitUnsupported, objectIdType)
 	if err != nil {
-		if errors.Is(err, errNull) {
-			ambiguousFields[key] = struct{}{}
-
-			return nil
-		} else if errors.Is(err, errEmptyArray) {
-			ambiguousArrayFields[key] = struct{}{}
-
-			if prevTypeExists && prevType.GetListType() == nil {
-				deducedTypes[key] = common.MakePrimitiveType(Ydb.Type_UTF8)
-
-				logger.Debug(fmt.Sprintf("bsonToYqlColumn: keeping serialized %v. prev: %v curr: []", key, prevType.String()))
-			}
-
-			return nil
-		} else if errors.Is(err, common.ErrDataTypeNotSupported) {
-			logger.Debug(fmt.Sprintf("bsonToYqlColumn: data not supported: %v", key))
+		if !errors.Is(err, common.ErrDataTypeNotSupported) {
+			return err
+		}
 
-			if !omitUnsupported {
-				deducedTypes[key] = common.MakePrimitiveType(Ydb.Type_UTF8)
-			}
+		logger.Debug(fmt.Sprintf("bsonToYqlColumn: data not supported: %v", key))
 
+		if omitUnsupported {
 			return nil
 		}
 
-		return err
+		t = makeSerializedType()
 	}
 
-	tString := t.String()
-	_, prevIsArray := ambiguousArrayFields[key]
-
-	// Leaving fields that have inconsistent types serialized
-	// Extra check for arrays because we might have encountered an empty one:
-	// we know it is an array, but prevType is not determined yet
-	if (prevTypeExists && !common.TypesEqual(prevType, t)) || (prevIsArray && t.GetListType() == nil) {
-		deducedTypes[key] = common.MakePrimitiveType(Ydb.Type_UTF8)
+	deducedTypes[key] = mergeTypes(prevType, t)
 
-		logger.Debug(fmt.Sprintf("bsonToYqlColumn: keeping serialized %v. prev: %v curr: %v", key, prevType.String(), tString))
-
-		return nil
+	if prevType != nil && t != nil && !proto.Equal(prevType, t) {
+		logger.Debug(fmt.Sprintf("bsonToYqlColumn: merging %v. prev: %v curr: %v", key, prevType.String(), t.String()))
 	}
 
-	deducedTypes[key] = t
-
-	logger.Debug(fmt.Sprintf("bsonToYqlColumn: column %v of type %v", key, tString))
+	logger.Debug(fmt.Sprintf("bsonToYqlColumn: column %v of type %v", key, deducedTypes[key].String()))
 
 	return nil
 }
@@ -134,8 +262,6 @@ func bsonToYql(logger *zap.Logger, docs []bson.Raw, omitUnsupported bool, object
 	}
 
 	deducedTypes := make(map[string]*Ydb.Type)
-	ambiguousFields := make(map[string]struct{})
-	ambiguousArrayFields := make(map[string]struct{})
 
 	for _, doc := range docs {
 		elements, err := doc.Elements()
@@ -148,8 +274,6 @@ func bsonToYql(logger *zap.Logger, docs []bson.Raw, omitUnsupported bool, object
 				logger,
 				elem,
 				deducedTypes,
-				ambiguousFields,
-				ambiguousArrayFields,
 				omitUnsupported,
 				objectIdType,
 			)
@@ -160,20 +284,10 @@ func bsonToYql(logger *zap.Logger, docs []bson.Raw, omitUnsupported bool, object
 		}
 	}
 
-	for field := range ambiguousArrayFields {
-		ambiguousFields[field] = struct{}{}
-	}
-
-	for field := range ambiguousFields {
-		if _, ok := deducedTypes[field]; !ok {
-			deducedTypes[field] = common.MakePrimitiveType(Ydb.Type_UTF8)
-		}
-	}
-
 	columns := make([]*Ydb.Column, 0, len(deducedTypes))
 
 	for columnName, deducedType := range deducedTypes {
-		columns = append(columns, &Ydb.Column{Name: columnName, Type: common.MakeOptionalType(deducedType)})
+		columns = append(columns, &Ydb.Column{Name: columnName, Type: common.MakeOptionalType(finalizeType(deducedType))})
 	}
 
 	return columns, nil
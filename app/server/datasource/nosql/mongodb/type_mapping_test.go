@@ -0,0 +1,79 @@
+package mongodb
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestBsonToYqlNested(t *testing.T) {
+	logger := common.NewTestLogger(t)
+
+	docs := []bson.D{
+		{
+			{Key: "_id", Value: int32(0)},
+			{Key: "struct", Value: bson.D{{Key: "foo", Value: int32(42)}, {Key: "nulls", Value: nil}}},
+			{Key: "arr", Value: bson.A{}},
+			{Key: "nested", Value: bson.A{bson.D{{Key: "one", Value: int32(1)}}, bson.D{{Key: "two", Value: "2"}}}},
+			{Key: "conflict", Value: bson.D{{Key: "a", Value: int32(1)}}},
+			{Key: "empty", Value: bson.A{}},
+		},
+		{
+			{Key: "_id", Value: int32(1)},
+			{Key: "struct", Value: bson.D{{Key: "bar", Value: bson.D{{Key: "baz", Value: ":)"}}}}},
+			{Key: "arr", Value: bson.A{int32(8), nil}},
+			{Key: "nested", Value: bson.A{bson.D{{Key: "one", Value: "1"}}}},
+			{Key: "conflict", Value: bson.A{int32(1)}},
+		},
+	}
+
+	raws := make([]bson.Raw, 0, len(docs))
+
+	for _, doc := range docs {
+		raw, err := bson.Marshal(doc)
+		require.NoError(t, err)
+
+		raws = append(raws, raw)
+	}
+
+	columns, err := bsonToYql(logger, raws, false, config.TMongoDbConfig_OBJECT_ID_AS_STRING)
+	require.NoError(t, err)
+
+	sort.Slice(columns, func(i, j int) bool { return columns[i].Name < columns[j].Name })
+
+	optional := common.MakeOptionalType
+	utf8 := common.MakePrimitiveType(Ydb.Type_UTF8)
+
+	expected := []*Ydb.Column{
+		{Name: "_id", Type: optional(common.MakePrimitiveType(Ydb.Type_INT32))},
+		{Name: "arr", Type: optional(common.MakeListType(optional(common.MakePrimitiveType(Ydb.Type_INT32))))},
+		{Name: "conflict", Type: optional(utf8)},
+		{Name: "empty", Type: optional(utf8)},
+		{Name: "nested", Type: optional(common.MakeListType(optional(common.MakeStructType([]*Ydb.StructMember{
+			// "one" is met both as a number and as a string, so it is kept serialized
+			{Name: "one", Type: optional(utf8)},
+			{Name: "two", Type: optional(utf8)},
+		}))))},
+		{Name: "struct", Type: optional(common.MakeStructType([]*Ydb.StructMember{
+			{Name: "bar", Type: optional(common.MakeStructType([]*Ydb.StructMember{
+				{Name: "baz", Type: optional(utf8)},
+			}))},
+			{Name: "foo", Type: optional(common.MakePrimitiveType(Ydb.Type_INT32))},
+			{Name: "nulls", Type: optional(utf8)},
+		}))},
+	}
+
+	require.Len(t, columns, len(expected))
+
+	for i := range expected {
+		require.True(t, proto.Equal(expected[i], columns[i]), "expected: %v\nactual: %v", expected[i], columns[i])
+	}
+}
@@ -1,6 +1,7 @@
 package opensearch
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"encoding/json"
@@ -8,6 +9,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/opensearch-project/opensearch-go/v4"
@@ -25,6 +27,7 @@ import (
 )
 
 var _ datasource.DataSource[any] = (*dataSource)(nil)
+var _ datasource.PredicateCheckerMaker = (*dataSource)(nil)
 
 type dataSource struct {
 	retrierSet   *retry.RetrierSet
@@ -111,11 +114,96 @@ func (ds *dataSource) DescribeTable(
 		return nil, fmt.Errorf("parse mapping: %w", err)
 	}
 
+	if ds.cfg.GetExposeScoreColumn() {
+		columns = append(columns, makeScoreColumn())
+	}
+
 	return &api_service_protos.TDescribeTableResponse{
-		Schema: &api_service_protos.TSchema{Columns: columns},
+		Schema:               &api_service_protos.TSchema{Columns: columns},
+		PushdownCapabilities: makePushdownCapabilities(logger),
 	}, nil
 }
 
+func (*dataSource) MakePredicateChecker(logger *zap.Logger, _ api_common.EGenericDataSourceKind) datasource.PredicateChecker {
+	return makePredicateChecker(logger)
+}
+
+func makePredicateChecker(logger *zap.Logger) datasource.PredicateChecker {
+	qb := newQueryBuilder(logger)
+
+	return func(predicate *api_service_protos.TPredicate) error {
+		_, err := qb.makePredicateFilter(predicate, false)
+
+		return err
+	}
+}
+
+func makePushdownCapabilities(logger *zap.Logger) *api_service_protos.TPushdownCapabilities {
+	checkPredicate := makePredicateChecker(logger)
+
+	checkOrderBy := func(orderBy *api_service_protos.TSelect_TOrderBy) error {
+		_, err := makeSort(orderBy, indexOrder)
+
+		return err
+	}
+
+	return datasource.ProbePushdownCapabilities(checkPredicate, checkOrderBy, nil, true)
+}
+
+func (ds *dataSource) ListTables(
+	ctx context.Context,
+	logger *zap.Logger,
+	request *api_service_protos.TListTablesRequest,
+) (*api_service_protos.TListTablesResponse, error) {
+	dsi := request.DataSourceInstance
+
+	if dsi.Protocol != api_common.EGenericProtocol_HTTP {
+		return nil, fmt.Errorf("cannot run OpenSearch connection with protocol '%v'", dsi.Protocol)
+	}
+
+	var client *opensearchapi.Client
+
+	err := ds.retrierSet.MakeConnection.Run(ctx, logger,
+		func() error {
+			var err error
+			client, err = ds.makeConnection(ctx, logger, dsi)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("make connection: %w", err)
+	}
+
+	res, err := client.Cat.Indices(
+		ctx,
+		&opensearchapi.CatIndicesReq{Params: opensearchapi.CatIndicesParams{Sort: []string{"index"}}},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cat indices: %w", err)
+	}
+
+	defer closeResponseBody(logger, res.Inspect().Response.Body)
+
+	err = checkStatusCode(res.Inspect().Response.StatusCode)
+	if err != nil {
+		return nil, fmt.Errorf("check status code: %w", err)
+	}
+
+	tables := make([]string, 0, len(res.Indices))
+
+	for _, index := range res.Indices {
+		// skip system and hidden indices
+		if strings.HasPrefix(index.Index, ".") {
+			continue
+		}
+
+		tables = append(tables, index.Index)
+	}
+
+	return &api_service_protos.TListTablesResponse{Tables: tables}, nil
+}
+
 func (*dataSource) ListSplits(
 	ctx context.Context,
 	_ *zap.Logger,
@@ -140,6 +228,10 @@ func (ds *dataSource) ReadSplit(
 	split *api_service_protos.TSplit,
 	sinkFactory paging.SinkFactory[any],
 ) error {
+	if common.SelectWhatHasComputedColumns(split.Select.What) {
+		return fmt.Errorf("computed columns are not supported by OpenSearch: %w", common.ErrUnimplementedExpression)
+	}
+
 	dsi := split.Select.DataSourceInstance
 
 	if dsi.Protocol != api_common.EGenericProtocol_HTTP {
@@ -173,7 +265,19 @@ func (ds *dataSource) ReadSplit(
 
 	sink := sinks[0]
 
-	if err := ds.doReadSplitSingleConn(ctx, logger, request, split, sink, client); err != nil {
+	if sink.ResumePosition().GetFinished() {
+		sink.Finish()
+
+		return nil
+	}
+
+	// Scroll context cannot outlive the interrupted request, so the split that may be resumed
+	// is read from the point in time page by page instead, which costs some extra requests.
+	if sink.CheckpointsRequired() {
+		if err := ds.doReadSplitPointInTime(ctx, logger, request, split, sink, client); err != nil {
+			return fmt.Errorf("read split point in time: %w", err)
+		}
+	} else if err := ds.doReadSplitSingleConn(ctx, logger, request, split, sink, client); err != nil {
 		return fmt.Errorf("read split single conn: %w", err)
 	}
 
@@ -249,6 +353,267 @@ func (ds *dataSource) doReadSplitSingleConn(
 	return nil
 }
 
+// doReadSplitPointInTime reads the documents following the cursor saved before the interruption
+// of the previous request. Every page is requested with `search_after` set to the sort values
+// of the last document of the previous page, and these values are saved as the cursor of the sink.
+func (ds *dataSource) doReadSplitPointInTime(
+	ctx context.Context,
+	logger *zap.Logger,
+	request *api_service_protos.TReadSplitsRequest,
+	split *api_service_protos.TSplit,
+	sink paging.Sink[any],
+	client *opensearchapi.Client,
+) error {
+	keepAlive := common.MustDurationFromString(ds.cfg.PointInTimeKeepAlive)
+	position := sink.ResumePosition()
+
+	cursor, err := parseSearchAfterCursor(position.GetCursor())
+	if err != nil {
+		return fmt.Errorf("parse search after cursor: %w", err)
+	}
+
+	offset, limit, ok := makePointInTimeLimit(split.Select.GetLimit(), position, cursor)
+	if !ok {
+		// LIMIT has been reached before the interruption of the previous request
+		return nil
+	}
+
+	if cursor == nil {
+		pitID, err := ds.createPointInTime(ctx, logger, client, split.Select.From.Table, keepAlive)
+		if err != nil {
+			return fmt.Errorf("create point in time: %w", err)
+		}
+
+		// The documents delivered before the first checkpoint are skipped by the sink during the resumption
+		cursor = &searchAfterCursor{PitID: pitID}
+		if err := checkpointSearchAfter(sink, cursor); err != nil {
+			return fmt.Errorf("checkpoint search after: %w", err)
+		}
+	}
+
+	if err := ds.readPointInTimePages(ctx, logger, request, split, sink, client, cursor, offset, limit); err != nil {
+		return fmt.Errorf("read point in time pages: %w", err)
+	}
+
+	// The point in time is deleted only once the split has been read entirely,
+	// otherwise it's kept until it expires, so that the reading could be resumed
+	if err := deletePointInTime(ctx, client, cursor.PitID); err != nil {
+		logger.Warn("failed to delete point in time, it will expire by itself", zap.Error(err))
+	}
+
+	return nil
+}
+
+// readPointInTimePages reads the pages of the point in time following the cursor
+// and moves the cursor to the last document of every page.
+// At most limit documents are delivered after skipping offset ones, zero limit means there is no limit.
+func (ds *dataSource) readPointInTimePages(
+	ctx context.Context,
+	logger *zap.Logger,
+	request *api_service_protos.TReadSplitsRequest,
+	split *api_service_protos.TSplit,
+	sink paging.Sink[any],
+	client *opensearchapi.Client,
+	cursor *searchAfterCursor,
+	offset, limit uint64,
+) error {
+	keepAlive := common.MustDurationFromString(ds.cfg.PointInTimeKeepAlive)
+
+	reader, err := prepareDocumentReader(split, ds.cc)
+	if err != nil {
+		return fmt.Errorf("make document reader: %w", err)
+	}
+
+	for {
+		batchSize := ds.cfg.BatchSize
+		if limit != 0 {
+			batchSize = min(batchSize, offset+limit)
+		}
+
+		body, err := ds.queryBuilder.buildPointInTimeSearchQuery(split, request.GetFiltering(), batchSize, cursor, keepAlive)
+		if err != nil {
+			return fmt.Errorf("build query: %w", err)
+		}
+
+		resp, err := ds.searchPointInTime(ctx, logger, client, body)
+		if err != nil {
+			return fmt.Errorf("search point in time: %w", err)
+		}
+
+		hits := resp.Hits.Hits
+		if len(hits) == 0 {
+			return nil
+		}
+
+		// OFFSET is applied on the connector side, since `from` is not allowed with `search_after`
+		skipped := min(offset, uint64(len(hits)))
+		offset -= skipped
+
+		delivered, err := processPointInTimeHits(logger, hits[skipped:], limit, reader, sink)
+		if err != nil {
+			return fmt.Errorf("process hits: %w", err)
+		}
+
+		if limit != 0 {
+			limit -= delivered
+
+			if limit == 0 {
+				return nil
+			}
+		}
+
+		// The point in time id may change from one request to another
+		if resp.PitID != "" {
+			cursor.PitID = resp.PitID
+		}
+
+		cursor.Sort = hits[len(hits)-1].Sort
+
+		// The documents skipped due to OFFSET must be skipped again during the resumption,
+		// so the cursor must not point to them
+		if offset == 0 {
+			if err := checkpointSearchAfter(sink, cursor); err != nil {
+				return fmt.Errorf("checkpoint search after: %w", err)
+			}
+		}
+
+		if uint64(len(hits)) < batchSize {
+			return nil
+		}
+	}
+}
+
+// processPointInTimeHits adds at most limit documents to the sink (zero limit means no limit)
+// and returns the number of the added documents
+func processPointInTimeHits(
+	logger *zap.Logger,
+	hits []pointInTimeSearchHit,
+	limit uint64,
+	reader *documentReader,
+	sink paging.Sink[any],
+) (uint64, error) {
+	var delivered uint64
+
+	for _, hit := range hits {
+		if limit != 0 && delivered == limit {
+			break
+		}
+
+		if err := reader.accept(logger, hit.SearchHit); err != nil {
+			return 0, fmt.Errorf("accept document: %w", err)
+		}
+
+		if err := sink.AddRow(reader.transformer); err != nil {
+			return 0, fmt.Errorf("add row to sink: %w", err)
+		}
+
+		delivered++
+	}
+
+	return delivered, nil
+}
+
+func checkpointSearchAfter(sink paging.Sink[any], cursor *searchAfterCursor) error {
+	pagingCursor, err := cursor.toPagingCursor()
+	if err != nil {
+		return fmt.Errorf("make paging cursor: %w", err)
+	}
+
+	sink.Checkpoint(pagingCursor)
+
+	return nil
+}
+
+// pointInTimeSearchResp is a part of the search response that is required to read the point in time
+type pointInTimeSearchResp struct {
+	PitID string `json:"pit_id"`
+	Hits  struct {
+		Hits []pointInTimeSearchHit `json:"hits"`
+	} `json:"hits"`
+}
+
+// pointInTimeSearchHit keeps the sort values as is, since 64-bit integers lose precision being decoded as float64
+type pointInTimeSearchHit struct {
+	opensearchapi.SearchHit
+	Sort []json.RawMessage `json:"sort"`
+}
+
+func (ds *dataSource) createPointInTime(
+	ctx context.Context,
+	logger *zap.Logger,
+	client *opensearchapi.Client,
+	index string,
+	keepAlive time.Duration,
+) (string, error) {
+	var resp *opensearchapi.PointInTimeCreateResp
+
+	err := ds.retrierSet.Query.Run(ctx, logger, func() error {
+		var err error
+		resp, err = client.PointInTime.Create(ctx, opensearchapi.PointInTimeCreateReq{
+			Indices: []string{index},
+			Params: opensearchapi.PointInTimeCreateParams{
+				KeepAlive: keepAlive,
+			},
+		})
+
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	closeResponseBody(logger, resp.Inspect().Response.Body)
+
+	return resp.PitID, nil
+}
+
+// searchPointInTime sends the search request to the point in time. Unlike the scroll,
+// the point in time keeps no position inside it, so the retries are safe.
+// If the point in time has expired, the request fails.
+func (ds *dataSource) searchPointInTime(
+	ctx context.Context,
+	logger *zap.Logger,
+	client *opensearchapi.Client,
+	body []byte,
+) (*pointInTimeSearchResp, error) {
+	var resp *pointInTimeSearchResp
+
+	err := ds.retrierSet.Query.Run(ctx, logger, func() error {
+		resp = &pointInTimeSearchResp{}
+
+		// The point in time search request must not refer to the index
+		httpResp, err := client.Client.Do(ctx, opensearchapi.SearchReq{Body: bytes.NewReader(body)}, resp)
+		if err != nil {
+			return fmt.Errorf("search: %w", err)
+		}
+
+		defer closeResponseBody(logger, httpResp.Body)
+
+		if httpResp.IsError() {
+			return fmt.Errorf("search: %w", opensearch.ParseError(httpResp))
+		}
+
+		return nil
+	})
+
+	return resp, err
+}
+
+// Delete the point in time when the reading is finished, since it keeps the index segments from merging
+func deletePointInTime(
+	ctx context.Context,
+	client *opensearchapi.Client,
+	pitID string,
+) error {
+	if _, err := client.PointInTime.Delete(ctx, opensearchapi.PointInTimeDeleteReq{
+		PitID: []string{pitID},
+	}); err != nil {
+		return fmt.Errorf("delete point in time: %w", err)
+	}
+
+	return nil
+}
+
 func (ds *dataSource) initialSearch(
 	ctx context.Context,
 	logger *zap.Logger,
@@ -74,6 +74,7 @@ func (r *documentReader) accept(
 	}
 
 	doc["_id"] = hit.ID
+	doc[scoreColumnName] = float64(hit.Score)
 
 	acceptors := r.transformer.GetAcceptors()
 
@@ -13,9 +13,17 @@ import (
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
+const (
+	// indexOrder is the cheapest order for scrolling
+	indexOrder = "_doc"
+	// shardDocOrder is unique within the point in time, so it's suitable for `search_after`
+	shardDocOrder = "_shard_doc"
+)
+
 type queryBuilder struct {
 	logger *zap.Logger
 }
@@ -36,6 +44,8 @@ func newQueryBuilder(logger *zap.Logger) *queryBuilder {
 //   - Invalid fields will be silently ignored by OpenSearch
 //   - Predicate pushdown: filter documents at source
 //   - Pagination: control batch size via scroll API
+//   - Relevance score: the virtual `_score` column is not a part of the document,
+//     it makes OpenSearch compute the scores even if the documents are sorted by other fields
 func (qb *queryBuilder) buildSearchQuery(
 	split *api_service_protos.TSplit,
 	filtering api_service_protos.TReadSplitsRequest_EFiltering,
@@ -46,29 +56,103 @@ func (qb *queryBuilder) buildSearchQuery(
 		Scroll: scrollTimeout,
 	}
 
+	query, err := qb.makeQuery(split, filtering, batchSize, indexOrder)
+	if err != nil {
+		return nil, nil, fmt.Errorf("make query: %w", err)
+	}
+
+	limit := split.Select.GetLimit()
+	if limit != nil {
+		from := int(limit.Offset)
+		size := int(limit.Limit)
+
+		params.From = &from
+		params.Size = &size
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, nil, fmt.Errorf("encode query: %w", err)
+	}
+
+	return &buf, params, nil
+}
+
+// buildPointInTimeSearchQuery constructs the query reading the next page of the point in time
+// right after the cursor; the rest of the features are the same as the ones of buildSearchQuery.
+// The documents are finally ordered by `_shard_doc`, so the sort values of the last delivered document
+// are enough to resume the reading. Point in time search accepts neither the index nor `from`,
+// so OFFSET and LIMIT are applied by the caller.
+// The query is returned as is, so that it could be sent again on retries.
+func (qb *queryBuilder) buildPointInTimeSearchQuery(
+	split *api_service_protos.TSplit,
+	filtering api_service_protos.TReadSplitsRequest_EFiltering,
+	batchSize uint64,
+	cursor *searchAfterCursor,
+	keepAlive time.Duration,
+) ([]byte, error) {
+	query, err := qb.makeQuery(split, filtering, batchSize, shardDocOrder)
+	if err != nil {
+		return nil, fmt.Errorf("make query: %w", err)
+	}
+
+	query["pit"] = map[string]any{
+		"id":         cursor.PitID,
+		"keep_alive": fmt.Sprintf("%dms", keepAlive.Milliseconds()),
+	}
+
+	if len(cursor.Sort) > 0 {
+		query["search_after"] = cursor.Sort
+	}
+
+	data, err := json.Marshal(query)
+	if err != nil {
+		return nil, fmt.Errorf("marshal query: %w", err)
+	}
+
+	return data, nil
+}
+
+// makeQuery renders the body of the search request
+func (qb *queryBuilder) makeQuery(
+	split *api_service_protos.TSplit,
+	filtering api_service_protos.TReadSplitsRequest_EFiltering,
+	batchSize uint64,
+	tiebreaker string,
+) (map[string]any, error) {
 	what := split.Select.GetWhat()
 	if what == nil {
-		return nil, nil, fmt.Errorf("not specified columns to query in Select.What")
+		return nil, fmt.Errorf("not specified columns to query in Select.What")
 	}
 
 	// TODO (Test for top to bottom struct projection)
-	var projection []string
+	var (
+		projection  []string
+		trackScores bool
+	)
+
 	for _, item := range what.GetItems() {
+		if item.GetColumn().Name == scoreColumnName {
+			trackScores = true
+			continue
+		}
+
 		projection = append(projection, item.GetColumn().Name)
 	}
 
+	sort, err := makeSort(split.Select.GetOrderBy(), tiebreaker)
+	if err != nil {
+		return nil, fmt.Errorf("make sort: %w", err)
+	}
+
 	query := map[string]any{
 		"size":    batchSize,
 		"_source": projection,
+		"sort":    sort,
 	}
 
-	limit := split.Select.GetLimit()
-	if limit != nil {
-		from := int(limit.Offset)
-		size := int(limit.Limit)
-
-		params.From = &from
-		params.Size = &size
+	if trackScores {
+		query["track_scores"] = true
 	}
 
 	where := split.Select.GetWhere()
@@ -82,15 +166,15 @@ func (qb *queryBuilder) buildSearchQuery(
 		if err != nil {
 			switch filtering {
 			case api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY:
-				return nil, nil, fmt.Errorf("make predicate filter: %w", err)
+				return nil, fmt.Errorf("make predicate filter: %w", err)
 			case api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL:
 				if common.OptionalFilteringAllowedErrors.Match(err) {
 					qb.logger.Warn("considering pushdown error as acceptable", zap.Error(err))
 				} else {
-					return nil, nil, fmt.Errorf("encountered an error making a filter: %w", err)
+					return nil, fmt.Errorf("encountered an error making a filter: %w", err)
 				}
 			default:
-				return nil, nil, fmt.Errorf("unknown filtering mode: %d", filtering)
+				return nil, fmt.Errorf("unknown filtering mode: %d", filtering)
 			}
 		} else {
 			query["query"] = filter
@@ -101,12 +185,113 @@ func (qb *queryBuilder) buildSearchQuery(
 		}
 	}
 
-	var buf bytes.Buffer
-	if err := json.NewEncoder(&buf).Encode(query); err != nil {
-		return nil, nil, fmt.Errorf("encode query: %w", err)
+	return query, nil
+}
+
+// makeSort renders the requested ordering followed by the tiebreaker, e.g. the index order.
+// Missing values precede the other ones in ascending order, as NULL values do in YQL.
+func makeSort(orderBy *api_service_protos.TSelect_TOrderBy, tiebreaker string) ([]any, error) {
+	sort := make([]any, 0, len(orderBy.GetKeys())+1)
+
+	for _, key := range orderBy.GetKeys() {
+		var order, missing string
+
+		switch key.Order {
+		case api_service_protos.TSelect_TOrderBy_ASC:
+			order, missing = "asc", "_first"
+		case api_service_protos.TSelect_TOrderBy_DESC:
+			order, missing = "desc", "_last"
+		default:
+			return nil, fmt.Errorf("unknown sort order '%v' for column '%s': %w", key.Order, key.Column, common.ErrInvalidRequest)
+		}
+
+		// every document has a score, and the score is not a field, so it accepts only the order
+		if key.Column == scoreColumnName {
+			sort = append(sort, map[string]any{
+				scoreColumnName: map[string]any{
+					"order": order,
+				},
+			})
+
+			continue
+		}
+
+		sort = append(sort, map[string]any{
+			key.Column: map[string]any{
+				"order":   order,
+				"missing": missing,
+			},
+		})
 	}
 
-	return &buf, params, nil
+	sort = append(sort, tiebreaker)
+
+	return sort, nil
+}
+
+// searchAfterCursor is the position within the point in time of the index
+type searchAfterCursor struct {
+	// Point in time the split is read from
+	PitID string `json:"pit_id"`
+	// Sort values of the last delivered document, empty at the beginning of the point in time.
+	// They are kept as is, since 64-bit integers lose precision being decoded as float64.
+	Sort []json.RawMessage `json:"sort,omitempty"`
+}
+
+func (c *searchAfterCursor) toPagingCursor() (*paging.TCursor, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, fmt.Errorf("marshal search after cursor: %w", err)
+	}
+
+	return &paging.TCursor{Payload: &paging.TCursor_SearchAfter{SearchAfter: data}}, nil
+}
+
+// parseSearchAfterCursor decodes the cursor saved before the interruption of the previous request.
+// Returns nil if the split is read from the beginning.
+func parseSearchAfterCursor(cursor *paging.TCursor) (*searchAfterCursor, error) {
+	data := cursor.GetSearchAfter()
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var result searchAfterCursor
+
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("unmarshal search after cursor: %v: %w", err, common.ErrInvalidRequest)
+	}
+
+	if result.PitID == "" {
+		return nil, fmt.Errorf("empty point in time id in search after cursor: %w", common.ErrInvalidRequest)
+	}
+
+	return &result, nil
+}
+
+// makePointInTimeLimit returns the numbers of documents to skip and to read after the cursor;
+// zero limit means there is no limit. OFFSET is applied only when the reading starts
+// from the beginning of the point in time, and LIMIT is reduced by the number of documents
+// delivered up to the cursor. The returned flag is false if there is nothing left to read.
+func makePointInTimeLimit(
+	limit *api_service_protos.TSelect_TLimit,
+	position *paging.TContinuationToken_TPosition,
+	cursor *searchAfterCursor,
+) (uint64, uint64, bool) {
+	if limit.GetLimit() == 0 {
+		return 0, 0, true
+	}
+
+	var offset uint64
+	if cursor == nil || len(cursor.Sort) == 0 {
+		offset = limit.GetOffset()
+	}
+
+	deliveredBeforeCursor := position.GetRowsDelivered() - position.GetRowsAfterCursor()
+	if deliveredBeforeCursor >= limit.GetLimit() {
+		return 0, 0, false
+	}
+
+	return offset, limit.GetLimit() - deliveredBeforeCursor, true
 }
 
 //nolint:funlen,gocyclo
@@ -184,6 +369,13 @@ func (qb *queryBuilder) makePredicateFilter(
 			return nil, fmt.Errorf("make regex filter: %w", err)
 		}
 
+		return filter, nil
+	case *api_service_protos.TPredicate_FullTextMatch:
+		filter, err := qb.makeFullTextMatchFilter(p.FullTextMatch)
+		if err != nil {
+			return nil, fmt.Errorf("make full text match filter: %w", err)
+		}
+
 		return filter, nil
 	default:
 		return nil, fmt.Errorf("%w: %T", common.ErrUnimplementedPredicateType, p)
@@ -474,9 +666,74 @@ func (qb *queryBuilder) makeRegexFilter(regex *api_service_protos.TPredicate_TRe
 	}, nil
 }
 
+// makeFullTextMatchFilter renders relevance search queries.
+// Unlike the term level queries, they analyze the query text with the analyzer of the searched field,
+// and the documents matching the query better get higher `_score` values.
+// https://docs.opensearch.org/docs/latest/query-dsl/full-text/index/
+func (*queryBuilder) makeFullTextMatchFilter(match *api_service_protos.TPredicate_TFullTextMatch) (map[string]any, error) {
+	if match.Query == "" {
+		return nil, fmt.Errorf("empty full text query: %w", common.ErrInvalidRequest)
+	}
+
+	for _, column := range match.Columns {
+		if column == scoreColumnName {
+			return nil, fmt.Errorf("cannot search in column '%s': %w", column, common.ErrUnsupportedExpression)
+		}
+	}
+
+	switch match.Mode {
+	case api_service_protos.TPredicate_TFullTextMatch_MATCH, api_service_protos.TPredicate_TFullTextMatch_MATCH_PHRASE:
+		queryType, multiMatchType := "match", "best_fields"
+		if match.Mode == api_service_protos.TPredicate_TFullTextMatch_MATCH_PHRASE {
+			queryType, multiMatchType = "match_phrase", "phrase"
+		}
+
+		switch len(match.Columns) {
+		case 0:
+			return nil, fmt.Errorf("no columns to search in for mode %v: %w", match.Mode, common.ErrInvalidRequest)
+		case 1:
+			return map[string]any{
+				queryType: map[string]any{
+					match.Columns[0]: map[string]any{
+						"query": match.Query,
+					},
+				},
+			}, nil
+		default:
+			return map[string]any{
+				"multi_match": map[string]any{
+					"query":  match.Query,
+					"fields": match.Columns,
+					"type":   multiMatchType,
+				},
+			}, nil
+		}
+	case api_service_protos.TPredicate_TFullTextMatch_QUERY_STRING:
+		queryString := map[string]any{
+			"query": match.Query,
+		}
+
+		// otherwise the fields from the index settings are searched
+		if len(match.Columns) > 0 {
+			queryString["fields"] = match.Columns
+		}
+
+		return map[string]any{
+			"query_string": queryString,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: full text match mode %v", common.ErrUnimplementedOperation, match.Mode)
+	}
+}
+
 func (*queryBuilder) getFieldName(expr *api_service_protos.TExpression) (string, error) {
 	switch e := expr.Payload.(type) {
 	case *api_service_protos.TExpression_Column:
+		// relevance score is computed by the query itself, so it cannot be filtered at the data source
+		if e.Column == scoreColumnName {
+			return "", fmt.Errorf("%w: column '%s'", common.ErrUnsupportedExpression, e.Column)
+		}
+
 		return e.Column, nil
 	default:
 		return "", fmt.Errorf("%w: expected column name", common.ErrUnimplementedExpression)
@@ -0,0 +1,351 @@
+package opensearch
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestBuildSearchQueryTrackScores(t *testing.T) {
+	makeWhat := func(names ...string) *api_service_protos.TSelect_TWhat {
+		what := &api_service_protos.TSelect_TWhat{}
+
+		for _, name := range names {
+			what.Items = append(what.Items, &api_service_protos.TSelect_TWhat_TItem{
+				Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+					Column: &Ydb.Column{Name: name, Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8))},
+				},
+			})
+		}
+
+		return what
+	}
+
+	testCases := []struct {
+		name        string
+		what        *api_service_protos.TSelect_TWhat
+		orderBy     *api_service_protos.TSelect_TOrderBy
+		source      []any
+		trackScores bool
+	}{
+		{
+			name:   "without score",
+			what:   makeWhat("_id", "a"),
+			source: []any{"_id", "a"},
+		},
+		{
+			// the score is not a field of the document, so it's not requested from the source
+			name:        "with score",
+			what:        makeWhat("_id", scoreColumnName, "a"),
+			source:      []any{"_id", "a"},
+			trackScores: true,
+		},
+		{
+			// the documents are sorted by the score, but the score itself is not read
+			name: "ordered by score",
+			what: makeWhat("_id"),
+			orderBy: &api_service_protos.TSelect_TOrderBy{
+				Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{
+					{Column: scoreColumnName, Order: api_service_protos.TSelect_TOrderBy_DESC},
+				},
+			},
+			source: []any{"_id"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			split := &api_service_protos.TSplit{
+				Select: &api_service_protos.TSelect{
+					What:    tc.what,
+					From:    &api_service_protos.TSelect_TFrom{Table: "index"},
+					OrderBy: tc.orderBy,
+				},
+			}
+
+			body, _, err := newQueryBuilder(common.NewTestLogger(t)).buildSearchQuery(
+				split, api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL, 100, time.Minute)
+			require.NoError(t, err)
+
+			var query map[string]any
+			require.NoError(t, json.NewDecoder(body).Decode(&query))
+
+			require.Equal(t, tc.source, query["_source"])
+
+			trackScores, ok := query["track_scores"]
+			require.Equal(t, tc.trackScores, ok)
+
+			if tc.trackScores {
+				require.Equal(t, true, trackScores)
+			}
+		})
+	}
+}
+
+func TestMakeSort(t *testing.T) {
+	testCases := []struct {
+		name       string
+		orderBy    *api_service_protos.TSelect_TOrderBy
+		tiebreaker string
+		expected   []any
+		err        error
+	}{
+		{
+			name:       "index order",
+			orderBy:    nil,
+			tiebreaker: indexOrder,
+			expected:   []any{"_doc"},
+		},
+		{
+			name: "fields",
+			orderBy: &api_service_protos.TSelect_TOrderBy{
+				Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{
+					{Column: "a", Order: api_service_protos.TSelect_TOrderBy_ASC},
+					{Column: "b", Order: api_service_protos.TSelect_TOrderBy_DESC},
+				},
+			},
+			tiebreaker: indexOrder,
+			expected: []any{
+				map[string]any{"a": map[string]any{"order": "asc", "missing": "_first"}},
+				map[string]any{"b": map[string]any{"order": "desc", "missing": "_last"}},
+				"_doc",
+			},
+		},
+		{
+			// the documents of the point in time are totally ordered, so it can be read with `search_after`
+			name: "point in time",
+			orderBy: &api_service_protos.TSelect_TOrderBy{
+				Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{
+					{Column: "a", Order: api_service_protos.TSelect_TOrderBy_ASC},
+				},
+			},
+			tiebreaker: shardDocOrder,
+			expected: []any{
+				map[string]any{"a": map[string]any{"order": "asc", "missing": "_first"}},
+				"_shard_doc",
+			},
+		},
+		{
+			// every document has a score, so the missing values are not specified
+			name: "score",
+			orderBy: &api_service_protos.TSelect_TOrderBy{
+				Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{
+					{Column: scoreColumnName, Order: api_service_protos.TSelect_TOrderBy_DESC},
+					{Column: "a", Order: api_service_protos.TSelect_TOrderBy_ASC},
+				},
+			},
+			tiebreaker: indexOrder,
+			expected: []any{
+				map[string]any{scoreColumnName: map[string]any{"order": "desc"}},
+				map[string]any{"a": map[string]any{"order": "asc", "missing": "_first"}},
+				"_doc",
+			},
+		},
+		{
+			name: "unknown order",
+			orderBy: &api_service_protos.TSelect_TOrderBy{
+				Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{
+					{Column: "a", Order: api_service_protos.TSelect_TOrderBy_SORT_ORDER_UNSPECIFIED},
+				},
+			},
+			tiebreaker: indexOrder,
+			err:        common.ErrInvalidRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := makeSort(tc.orderBy, tc.tiebreaker)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestBuildPointInTimeSearchQuery(t *testing.T) {
+	split := &api_service_protos.TSplit{
+		Select: &api_service_protos.TSelect{
+			What: &api_service_protos.TSelect_TWhat{
+				Items: []*api_service_protos.TSelect_TWhat_TItem{
+					{
+						Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+							Column: &Ydb.Column{Name: "a", Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT64))},
+						},
+					},
+				},
+			},
+			From: &api_service_protos.TSelect_TFrom{Table: "index"},
+			// LIMIT and OFFSET are applied by the caller
+			Limit: &api_service_protos.TSelect_TLimit{Limit: 10, Offset: 5},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		cursor      *searchAfterCursor
+		searchAfter any
+	}{
+		{
+			name:   "beginning of point in time",
+			cursor: &searchAfterCursor{PitID: "pit"},
+		},
+		{
+			name:        "after document",
+			cursor:      &searchAfterCursor{PitID: "pit", Sort: []json.RawMessage{json.RawMessage(`9223372036854775807`)}},
+			searchAfter: []any{json.Number("9223372036854775807")},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			body, err := newQueryBuilder(common.NewTestLogger(t)).buildPointInTimeSearchQuery(
+				split, api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL, 100, tc.cursor, 5*time.Minute)
+			require.NoError(t, err)
+
+			decoder := json.NewDecoder(bytes.NewReader(body))
+			decoder.UseNumber()
+
+			var query map[string]any
+			require.NoError(t, decoder.Decode(&query))
+
+			require.Equal(t, map[string]any{"id": "pit", "keep_alive": "300000ms"}, query["pit"])
+			require.Equal(t, json.Number("100"), query["size"])
+			require.Equal(t, []any{"_shard_doc"}, query["sort"])
+			require.NotContains(t, query, "from")
+
+			searchAfter, ok := query["search_after"]
+			require.Equal(t, tc.searchAfter != nil, ok)
+			require.Equal(t, tc.searchAfter, searchAfter)
+		})
+	}
+}
+
+func TestSearchAfterCursor(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		// the sort values of the long fields must not lose precision
+		expected := &searchAfterCursor{
+			PitID: "pit",
+			Sort:  []json.RawMessage{json.RawMessage(`"abc"`), json.RawMessage(`9223372036854775807`)},
+		}
+
+		cursor, err := expected.toPagingCursor()
+		require.NoError(t, err)
+
+		actual, err := parseSearchAfterCursor(cursor)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	})
+
+	t.Run("beginning of split", func(t *testing.T) {
+		actual, err := parseSearchAfterCursor(nil)
+		require.NoError(t, err)
+		require.Nil(t, actual)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, data := range []string{`{"pit_id":`, `{"sort":[1]}`} {
+			cursor := &paging.TCursor{Payload: &paging.TCursor_SearchAfter{SearchAfter: []byte(data)}}
+
+			_, err := parseSearchAfterCursor(cursor)
+			require.ErrorIs(t, err, common.ErrInvalidRequest)
+		}
+	})
+}
+
+func TestMakePointInTimeLimit(t *testing.T) {
+	started := &searchAfterCursor{PitID: "pit"}
+	advanced := &searchAfterCursor{PitID: "pit", Sort: []json.RawMessage{json.RawMessage(`1`)}}
+
+	testCases := []struct {
+		name     string
+		limit    *api_service_protos.TSelect_TLimit
+		position *paging.TContinuationToken_TPosition
+		cursor   *searchAfterCursor
+		offset   uint64
+		expected uint64
+		ok       bool
+	}{
+		{
+			name: "no limit",
+			ok:   true,
+		},
+		{
+			name:     "beginning of split",
+			limit:    &api_service_protos.TSelect_TLimit{Limit: 10, Offset: 5},
+			offset:   5,
+			expected: 10,
+			ok:       true,
+		},
+		{
+			// the rows delivered before the first checkpoint are skipped by the sink
+			name:     "beginning of point in time",
+			limit:    &api_service_protos.TSelect_TLimit{Limit: 10, Offset: 5},
+			position: &paging.TContinuationToken_TPosition{RowsDelivered: 3, RowsAfterCursor: 3},
+			cursor:   started,
+			offset:   5,
+			expected: 10,
+			ok:       true,
+		},
+		{
+			// OFFSET has been applied to the documents preceding the cursor
+			name:     "after document",
+			limit:    &api_service_protos.TSelect_TLimit{Limit: 10, Offset: 5},
+			position: &paging.TContinuationToken_TPosition{RowsDelivered: 6, RowsAfterCursor: 2},
+			cursor:   advanced,
+			expected: 6,
+			ok:       true,
+		},
+		{
+			name:     "limit reached",
+			limit:    &api_service_protos.TSelect_TLimit{Limit: 10},
+			position: &paging.TContinuationToken_TPosition{RowsDelivered: 10},
+			cursor:   advanced,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			offset, limit, ok := makePointInTimeLimit(tc.limit, tc.position, tc.cursor)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.offset, offset)
+			require.Equal(t, tc.expected, limit)
+		})
+	}
+}
+
+func TestMakePushdownCapabilities(t *testing.T) {
+	capabilities := makePushdownCapabilities(common.NewTestLogger(t))
+
+	require.True(t, capabilities.OrderBy)
+	require.ElementsMatch(t,
+		[]api_service_protos.TPredicate_TFullTextMatch_EMode{
+			api_service_protos.TPredicate_TFullTextMatch_MATCH,
+			api_service_protos.TPredicate_TFullTextMatch_MATCH_PHRASE,
+			api_service_protos.TPredicate_TFullTextMatch_QUERY_STRING,
+		},
+		capabilities.FullTextMatchModes,
+	)
+}
@@ -11,6 +11,16 @@ import (
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
+// scoreColumnName is the name of the virtual column containing the relevance score of the document
+const scoreColumnName = "_score"
+
+func makeScoreColumn() *Ydb.Column {
+	return &Ydb.Column{
+		Name: scoreColumnName,
+		Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_FLOAT)),
+	}
+}
+
 func parseMapping(
 	logger *zap.Logger,
 	mappings map[string]any,
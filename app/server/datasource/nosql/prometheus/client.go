@@ -0,0 +1,202 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// client is a minimal client of the Prometheus HTTP API:
+// https://prometheus.io/docs/prometheus/latest/querying/api/
+type client struct {
+	httpClient  *http.Client
+	baseURL     string
+	credentials *api_common.TGenericCredentials
+	logger      *zap.Logger
+}
+
+// apiResponse is the envelope of every Prometheus HTTP API response
+type apiResponse struct {
+	Status    string          `json:"status"`
+	Data      json.RawMessage `json:"data"`
+	ErrorType string          `json:"errorType"`
+	Error     string          `json:"error"`
+}
+
+type queryData struct {
+	ResultType model.ValueType `json:"resultType"`
+	Result     json.RawMessage `json:"result"`
+}
+
+// metricNames returns the names of all the metrics stored in Prometheus
+func (c *client) metricNames(ctx context.Context) ([]string, error) {
+	var names []string
+
+	if err := c.do(ctx, http.MethodGet, "/api/v1/label/"+model.MetricNameLabel+"/values", nil, &names); err != nil {
+		return nil, fmt.Errorf("get label values: %w", err)
+	}
+
+	return names, nil
+}
+
+// labelNames returns the names of labels of the series matching the selector within the time range
+func (c *client) labelNames(ctx context.Context, selector string, fromMs, toMs int64) ([]string, error) {
+	params := url.Values{
+		"match[]": []string{selector},
+		"start":   []string{formatTimestamp(fromMs)},
+		"end":     []string{formatTimestamp(toMs)},
+	}
+
+	var names []string
+
+	if err := c.do(ctx, http.MethodPost, "/api/v1/labels", params, &names); err != nil {
+		return nil, fmt.Errorf("get label names: %w", err)
+	}
+
+	return names, nil
+}
+
+// readSamples returns the samples of the series matching the selector
+// with the timestamps belonging to the range (fromMs, toMs].
+func (c *client) readSamples(ctx context.Context, selector string, fromMs, toMs int64) (model.Matrix, error) {
+	params := url.Values{
+		"query": []string{fmt.Sprintf("%s[%dms]", selector, toMs-fromMs)},
+		"time":  []string{formatTimestamp(toMs)},
+	}
+
+	var data queryData
+
+	if err := c.do(ctx, http.MethodPost, "/api/v1/query", params, &data); err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	if data.ResultType != model.ValMatrix {
+		return nil, fmt.Errorf("unexpected result type '%s'", data.ResultType)
+	}
+
+	var matrix model.Matrix
+
+	if err := json.Unmarshal(data.Result, &matrix); err != nil {
+		return nil, fmt.Errorf("unmarshal matrix: %w", err)
+	}
+
+	// Range selectors are closed on the left side in Prometheus 2.x and open in 3.x,
+	// so the samples are filtered here to make the neighbouring splits disjoint.
+	for _, stream := range matrix {
+		values := stream.Values[:0]
+
+		for _, pair := range stream.Values {
+			if ts := int64(pair.Timestamp); ts > fromMs && ts <= toMs {
+				values = append(values, pair)
+			}
+		}
+
+		stream.Values = values
+	}
+
+	return matrix, nil
+}
+
+func (c *client) do(ctx context.Context, method, path string, params url.Values, dst any) error {
+	var (
+		body    io.Reader
+		address = c.baseURL + path
+	)
+
+	if method == http.MethodGet && len(params) > 0 {
+		address += "?" + params.Encode()
+	} else if method == http.MethodPost {
+		body = strings.NewReader(params.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, address, body)
+	if err != nil {
+		return fmt.Errorf("new request: %w", err)
+	}
+
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	c.setCredentials(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+
+	defer common.LogCloserError(c.logger, resp.Body, "close response body")
+
+	var envelope apiResponse
+
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+
+		return fmt.Errorf("decode response body: %w", err)
+	}
+
+	if envelope.Status != "success" {
+		return fmt.Errorf("%s: %s", envelope.ErrorType, envelope.Error)
+	}
+
+	if err := json.Unmarshal(envelope.Data, dst); err != nil {
+		return fmt.Errorf("unmarshal response data: %w", err)
+	}
+
+	return nil
+}
+
+func (c *client) setCredentials(req *http.Request) {
+	if basic := c.credentials.GetBasic(); basic != nil && basic.Username != "" {
+		req.SetBasicAuth(basic.Username, basic.Password)
+	}
+
+	if token := c.credentials.GetToken(); token != nil && token.Value != "" {
+		req.Header.Set("Authorization", "Bearer "+token.Value)
+	}
+}
+
+func makeClient(
+	logger *zap.Logger,
+	dsi *api_common.TGenericDataSourceInstance,
+	requestTimeout time.Duration,
+) (*client, error) {
+	if dsi.Protocol != api_common.EGenericProtocol_HTTP {
+		return nil, fmt.Errorf("cannot run Prometheus connection with protocol '%v'", dsi.Protocol)
+	}
+
+	scheme := "http"
+	if dsi.UseTls {
+		scheme = "https"
+	}
+
+	baseURL := fmt.Sprintf("%s://%s:%d", scheme, dsi.Endpoint.Host, dsi.Endpoint.Port)
+
+	logger.Debug("creating client", zap.String("address", baseURL))
+
+	return &client{
+		httpClient:  &http.Client{Timeout: requestTimeout},
+		baseURL:     baseURL,
+		credentials: dsi.Credentials,
+		logger:      logger,
+	}, nil
+}
+
+// formatTimestamp renders milliseconds since epoch as the fractional seconds accepted by Prometheus
+func formatTimestamp(ms int64) string {
+	return strconv.FormatFloat(float64(ms)/1000, 'f', 3, 64)
+}
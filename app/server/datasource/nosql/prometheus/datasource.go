@@ -0,0 +1,417 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/prometheus/common/model"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+const (
+	timestampColumnName = "timestamp"
+	valueColumnName     = "value"
+)
+
+var _ datasource.DataSource[any] = (*dataSource)(nil)
+var _ datasource.PredicateCheckerMaker = (*dataSource)(nil)
+
+// dataSource represents every metric as a table: each sample of a series is a row
+// containing the sample timestamp, the sample value and the labels of the series.
+type dataSource struct {
+	retrierSet   *retry.RetrierSet
+	cc           conversion.Collection
+	cfg          *config.TPrometheusConfig
+	queryLogger  common.QueryLogger
+	queryBuilder *queryBuilder
+}
+
+func NewDataSource(
+	retrierSet *retry.RetrierSet,
+	cfg *config.TPrometheusConfig,
+	logger *zap.Logger,
+	cc conversion.Collection,
+	queryLogger common.QueryLogger,
+) datasource.DataSource[any] {
+	return &dataSource{
+		retrierSet:   retrierSet,
+		cc:           cc,
+		cfg:          cfg,
+		queryLogger:  queryLogger,
+		queryBuilder: newQueryBuilder(logger),
+	}
+}
+
+func (ds *dataSource) DescribeTable(
+	ctx context.Context,
+	logger *zap.Logger,
+	request *api_service_protos.TDescribeTableRequest,
+) (*api_service_protos.TDescribeTableResponse, error) {
+	cl, err := makeClient(logger, request.DataSourceInstance, common.MustDurationFromString(ds.cfg.RequestTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("make client: %w", err)
+	}
+
+	toMs := time.Now().UnixMilli()
+	fromMs := toMs - common.MustDurationFromString(ds.cfg.MaxQueryRange).Milliseconds()
+
+	var labels []string
+
+	err = ds.retrierSet.Query.Run(ctx, logger,
+		func() error {
+			var err error
+			labels, err = cl.labelNames(ctx, makeSelector(request.Table, nil), fromMs, toMs)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get label names: %w", err)
+	}
+
+	// Series of existing metrics always have the label containing the metric name
+	if len(labels) == 0 {
+		return nil, common.ErrTableDoesNotExist
+	}
+
+	columns := []*Ydb.Column{
+		{Name: timestampColumnName, Type: common.MakePrimitiveType(Ydb.Type_TIMESTAMP)},
+		{Name: valueColumnName, Type: common.MakePrimitiveType(Ydb.Type_DOUBLE)},
+	}
+
+	for _, label := range labels {
+		switch label {
+		case model.MetricNameLabel:
+			continue
+		case timestampColumnName, valueColumnName:
+			logger.Warn("label name collides with the column name, skipping it", zap.String("label", label))
+
+			continue
+		}
+
+		columns = append(columns, &Ydb.Column{
+			Name: label,
+			Type: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+		})
+	}
+
+	return &api_service_protos.TDescribeTableResponse{
+		Schema:               &api_service_protos.TSchema{Columns: columns},
+		PushdownCapabilities: makePushdownCapabilities(logger),
+	}, nil
+}
+
+// makePushdownCapabilities describes the label matchers; the timestamp column
+// is additionally restricted with the comparisons and BETWEEN, turned into the time range.
+func (*dataSource) MakePredicateChecker(logger *zap.Logger, _ api_common.EGenericDataSourceKind) datasource.PredicateChecker {
+	return makePredicateChecker(logger)
+}
+
+func makePredicateChecker(logger *zap.Logger) datasource.PredicateChecker {
+	qb := newQueryBuilder(logger)
+
+	return func(predicate *api_service_protos.TPredicate) error {
+		return qb.applyPredicate(&query{}, predicate)
+	}
+}
+
+func makePushdownCapabilities(logger *zap.Logger) *api_service_protos.TPushdownCapabilities {
+	checkPredicate := makePredicateChecker(logger)
+
+	// the samples are neither sorted, nor limited
+	return datasource.ProbePushdownCapabilities(checkPredicate, nil, nil, false)
+}
+
+func (ds *dataSource) ListTables(
+	ctx context.Context,
+	logger *zap.Logger,
+	request *api_service_protos.TListTablesRequest,
+) (*api_service_protos.TListTablesResponse, error) {
+	cl, err := makeClient(logger, request.DataSourceInstance, common.MustDurationFromString(ds.cfg.RequestTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("make client: %w", err)
+	}
+
+	var tables []string
+
+	err = ds.retrierSet.Query.Run(ctx, logger,
+		func() error {
+			var err error
+			tables, err = cl.metricNames(ctx)
+
+			return err
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get metric names: %w", err)
+	}
+
+	return &api_service_protos.TListTablesResponse{Tables: tables}, nil
+}
+
+// ListSplits cuts the requested time range into the intervals of the configured duration
+func (ds *dataSource) ListSplits(
+	ctx context.Context,
+	logger *zap.Logger,
+	request *api_service_protos.TListSplitsRequest,
+	slct *api_service_protos.TSelect,
+	resultChan chan<- *datasource.ListSplitResult,
+) error {
+	timeRange, err := ds.makeTimeRange(slct.Where)
+	if err != nil {
+		return fmt.Errorf("make time range: %w", err)
+	}
+
+	timeRanges := makeTimeRanges(
+		timeRange,
+		common.MustDurationFromString(ds.cfg.SplitDuration).Milliseconds(),
+		uint64(request.GetMaxSplitCount()),
+	)
+
+	logger.Info(
+		"determined time ranges",
+		zap.Int64("from_ms", timeRange.FromMs),
+		zap.Int64("to_ms", timeRange.ToMs),
+		zap.Int("splits", len(timeRanges)),
+	)
+
+	// Prometheus returns the samples grouped by time series, so the rows are sorted on the YDB side
+	slct = common.SelectWithoutOrderBy(slct)
+
+	for _, tr := range timeRanges {
+		description := &TSplitDescription{
+			Payload: &TSplitDescription_TimeRange{
+				TimeRange: tr,
+			},
+		}
+
+		select {
+		case resultChan <- &datasource.ListSplitResult{Slct: slct, Description: description}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (ds *dataSource) ReadSplit(
+	ctx context.Context,
+	logger *zap.Logger,
+	_ string,
+	request *api_service_protos.TReadSplitsRequest,
+	split *api_service_protos.TSplit,
+	sinkFactory paging.SinkFactory[any],
+) error {
+	if common.SelectWhatHasComputedColumns(split.Select.What) {
+		return fmt.Errorf("computed columns are not supported by Prometheus: %w", common.ErrUnimplementedExpression)
+	}
+
+	if split.Select.From.Table == "" {
+		return common.ErrEmptyTableName
+	}
+
+	cl, err := makeClient(logger, split.Select.DataSourceInstance, common.MustDurationFromString(ds.cfg.RequestTimeout))
+	if err != nil {
+		return fmt.Errorf("make client: %w", err)
+	}
+
+	q, err := ds.queryBuilder.buildQuery(split.Select.Where, request.GetFiltering())
+	if err != nil {
+		return fmt.Errorf("build query: %w", err)
+	}
+
+	timeRange, err := ds.getSplitTimeRange(split)
+	if err != nil {
+		return fmt.Errorf("get split time range: %w", err)
+	}
+
+	sinks, err := sinkFactory.MakeSinks([]*paging.SinkParams{{Logger: logger}})
+	if err != nil {
+		return fmt.Errorf("make sinks: %w", err)
+	}
+
+	sink := sinks[0]
+
+	// The range is empty, there is nothing to read
+	if timeRange.ToMs <= timeRange.FromMs {
+		sink.Finish()
+
+		return nil
+	}
+
+	selector := makeSelector(split.Select.From.Table, q.matchers)
+
+	ds.queryLogger.Dump(selector, timeRange.FromMs, timeRange.ToMs)
+
+	var matrix model.Matrix
+
+	err = ds.retrierSet.Query.Run(ctx, logger,
+		func() error {
+			var err error
+			matrix, err = cl.readSamples(ctx, selector, timeRange.FromMs, timeRange.ToMs)
+
+			return err
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("read samples: %w", err)
+	}
+
+	if err := ds.writeSamples(split.Select.What, matrix, sink); err != nil {
+		return fmt.Errorf("write samples: %w", err)
+	}
+
+	sink.Finish()
+
+	return nil
+}
+
+func (ds *dataSource) writeSamples(
+	what *api_service_protos.TSelect_TWhat,
+	matrix model.Matrix,
+	sink paging.Sink[any],
+) error {
+	acceptors, appenders := ds.makeAcceptorsAndAppenders(what)
+	transformer := paging.NewRowTransformer(acceptors, appenders, nil)
+
+	for _, stream := range matrix {
+		for _, pair := range stream.Values {
+			for i, item := range what.GetItems() {
+				switch columnName := item.GetColumn().GetName(); columnName {
+				case timestampColumnName:
+					*acceptors[i].(*time.Time) = pair.Timestamp.Time().UTC()
+				case valueColumnName:
+					*acceptors[i].(*float64) = float64(pair.Value)
+				default:
+					labelValue, ok := stream.Metric[model.LabelName(columnName)]
+					if !ok {
+						*acceptors[i].(**string) = nil
+
+						continue
+					}
+
+					value := string(labelValue)
+					*acceptors[i].(**string) = &value
+				}
+			}
+
+			if err := sink.AddRow(transformer); err != nil {
+				return fmt.Errorf("add row to sink: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (ds *dataSource) makeAcceptorsAndAppenders(
+	what *api_service_protos.TSelect_TWhat,
+) ([]any, []func(acceptor any, builder array.Builder) error) {
+	acceptors := make([]any, 0, len(what.GetItems()))
+	appenders := make([]func(acceptor any, builder array.Builder) error, 0, len(what.GetItems()))
+
+	for _, item := range what.GetItems() {
+		switch item.GetColumn().GetName() {
+		case timestampColumnName:
+			acceptors = append(acceptors, new(time.Time))
+			appenders = append(appenders, utils.MakeAppender[time.Time, uint64, *array.Uint64Builder](ds.cc.Timestamp()))
+		case valueColumnName:
+			acceptors = append(acceptors, new(float64))
+			appenders = append(appenders, utils.MakeAppender[float64, float64, *array.Float64Builder](ds.cc.Float64()))
+		default:
+			acceptors = append(acceptors, new(*string))
+			appenders = append(appenders, utils.MakeAppenderNullable[string, string, *array.StringBuilder](ds.cc.String()))
+		}
+	}
+
+	return acceptors, appenders
+}
+
+// makeTimeRange determines the time range of the query: if the predicate has no bounds
+// for the timestamp column, the latest samples within the configured range are read.
+func (ds *dataSource) makeTimeRange(where *api_service_protos.TSelect_TWhere) (*TSplitDescription_TTimeRange, error) {
+	// Optional filtering is used here, because the filtering mode is known only when the split is read
+	q, err := ds.queryBuilder.buildQuery(where, api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL)
+	if err != nil {
+		return nil, fmt.Errorf("build query: %w", err)
+	}
+
+	timeRange := &TSplitDescription_TTimeRange{ToMs: time.Now().UnixMilli()}
+
+	if q.toMs != nil {
+		timeRange.ToMs = *q.toMs
+	}
+
+	timeRange.FromMs = timeRange.ToMs - common.MustDurationFromString(ds.cfg.MaxQueryRange).Milliseconds()
+
+	if q.fromMs != nil {
+		timeRange.FromMs = *q.fromMs
+	}
+
+	return timeRange, nil
+}
+
+func (ds *dataSource) getSplitTimeRange(split *api_service_protos.TSplit) (*TSplitDescription_TTimeRange, error) {
+	if len(split.GetDescription()) == 0 {
+		return ds.makeTimeRange(split.Select.Where)
+	}
+
+	var splitDescription TSplitDescription
+
+	if err := protojson.Unmarshal(split.GetDescription(), &splitDescription); err != nil {
+		return nil, fmt.Errorf("unmarshal split description: %w", err)
+	}
+
+	timeRange := splitDescription.GetTimeRange()
+	if timeRange == nil {
+		return nil, fmt.Errorf("unknown split description type: %T", splitDescription.GetPayload())
+	}
+
+	return timeRange, nil
+}
+
+// makeTimeRanges cuts the time range into the intervals of splitDurationMs,
+// increasing the interval duration if the number of splits exceeds maxSplitCount.
+func makeTimeRanges(
+	timeRange *TSplitDescription_TTimeRange,
+	splitDurationMs int64,
+	maxSplitCount uint64,
+) []*TSplitDescription_TTimeRange {
+	total := timeRange.ToMs - timeRange.FromMs
+	if total <= 0 || splitDurationMs <= 0 {
+		return []*TSplitDescription_TTimeRange{timeRange}
+	}
+
+	splitCount := (total + splitDurationMs - 1) / splitDurationMs
+	if maxSplitCount > 0 && uint64(splitCount) > maxSplitCount {
+		splitCount = int64(maxSplitCount)
+		splitDurationMs = (total + splitCount - 1) / splitCount
+	}
+
+	timeRanges := make([]*TSplitDescription_TTimeRange, 0, splitCount)
+
+	for fromMs := timeRange.FromMs; fromMs < timeRange.ToMs; fromMs += splitDurationMs {
+		timeRanges = append(timeRanges, &TSplitDescription_TTimeRange{
+			FromMs: fromMs,
+			ToMs:   min(fromMs+splitDurationMs, timeRange.ToMs),
+		})
+	}
+
+	return timeRanges
+}
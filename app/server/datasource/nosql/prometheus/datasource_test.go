@@ -0,0 +1,224 @@
+package prometheus
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// newPrometheusStandIn imitates the subset of the Prometheus HTTP API used by the data source
+func newPrometheusStandIn(t *testing.T) *api_common.TGenericDataSourceInstance {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/v1/label/__name__/values", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"success","data":["node_load1","up"]}`))
+	})
+
+	mux.HandleFunc("/api/v1/labels", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		if r.Form.Get("match[]") != `{__name__="up"}` {
+			_, _ = w.Write([]byte(`{"status":"success","data":[]}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"status":"success","data":["__name__","instance","job"]}`))
+	})
+
+	mux.HandleFunc("/api/v1/query", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		if r.Form.Get("query") != `{__name__="up",job="node"}[3000ms]` || r.Form.Get("time") != "4.000" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"unexpected query"}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[
+			{"metric":{"__name__":"up","job":"node","instance":"a:9100"},"values":[[1,"0"],[2,"1"],[4,"1"]]},
+			{"metric":{"__name__":"up","job":"node"},"values":[[3.5,"0.5"]]}
+		]}}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	host, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+
+	portNumber, err := strconv.ParseUint(port, 10, 32)
+	require.NoError(t, err)
+
+	dsi := &api_common.TGenericDataSourceInstance{
+		Kind:     api_common.EGenericDataSourceKind_PROMETHEUS,
+		Endpoint: &api_common.TGenericEndpoint{Host: host, Port: uint32(portNumber)},
+		Protocol: api_common.EGenericProtocol_HTTP,
+	}
+
+	return dsi
+}
+
+func TestClient(t *testing.T) {
+	logger := common.NewTestLogger(t)
+	dsi := newPrometheusStandIn(t)
+
+	cl, err := makeClient(logger, dsi, common.MustDurationFromString("5s"))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	t.Run("metric_names", func(t *testing.T) {
+		names, err := cl.metricNames(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"node_load1", "up"}, names)
+	})
+
+	t.Run("label_names", func(t *testing.T) {
+		names, err := cl.labelNames(ctx, `{__name__="up"}`, 0, 1000)
+		require.NoError(t, err)
+		require.Equal(t, []string{"__name__", "instance", "job"}, names)
+	})
+
+	t.Run("read_samples", func(t *testing.T) {
+		matrix, err := cl.readSamples(ctx, `{__name__="up",job="node"}`, 1000, 4000)
+		require.NoError(t, err)
+		require.Len(t, matrix, 2)
+
+		// The sample with the timestamp equal to the lower bound belongs to the previous range
+		require.Len(t, matrix[0].Values, 2)
+		require.EqualValues(t, 2000, matrix[0].Values[0].Timestamp)
+		require.EqualValues(t, 4000, matrix[0].Values[1].Timestamp)
+		require.Equal(t, "a:9100", string(matrix[0].Metric["instance"]))
+
+		require.Len(t, matrix[1].Values, 1)
+		require.EqualValues(t, 0.5, matrix[1].Values[0].Value)
+	})
+
+	t.Run("api_error", func(t *testing.T) {
+		_, err := cl.readSamples(ctx, `{__name__="up"}`, 1000, 4000)
+		require.ErrorContains(t, err, "bad_data: unexpected query")
+	})
+}
+
+func TestDescribeTable(t *testing.T) {
+	logger := common.NewTestLogger(t)
+	dsi := newPrometheusStandIn(t)
+
+	ds := &dataSource{
+		retrierSet: retry.NewRetrierSetNoop(),
+		cfg: &config.TPrometheusConfig{
+			RequestTimeout: "5s",
+			MaxQueryRange:  "1h",
+			SplitDuration:  "1m",
+		},
+		queryBuilder: newQueryBuilder(logger),
+	}
+
+	response, err := ds.DescribeTable(
+		context.Background(),
+		logger,
+		&api_service_protos.TDescribeTableRequest{DataSourceInstance: dsi, Table: "up"},
+	)
+	require.NoError(t, err)
+
+	columns := response.Schema.Columns
+	require.Len(t, columns, 4)
+
+	expected := []struct {
+		name string
+		typ  *Ydb.Type
+	}{
+		{timestampColumnName, common.MakePrimitiveType(Ydb.Type_TIMESTAMP)},
+		{valueColumnName, common.MakePrimitiveType(Ydb.Type_DOUBLE)},
+		{"instance", common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8))},
+		{"job", common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8))},
+	}
+
+	for i, column := range columns {
+		require.Equal(t, expected[i].name, column.Name)
+		require.True(t, common.TypesEqual(expected[i].typ, column.Type))
+	}
+
+	_, err = ds.DescribeTable(
+		context.Background(),
+		logger,
+		&api_service_protos.TDescribeTableRequest{DataSourceInstance: dsi, Table: "missing"},
+	)
+	require.ErrorIs(t, err, common.ErrTableDoesNotExist)
+}
+
+func TestMakeTimeRanges(t *testing.T) {
+	type testCase struct {
+		testName      string
+		fromMs        int64
+		toMs          int64
+		splitDuration int64
+		maxSplitCount uint64
+		expected      [][2]int64
+	}
+
+	tcs := []testCase{
+		{
+			testName:      "even",
+			fromMs:        0,
+			toMs:          3000,
+			splitDuration: 1000,
+			expected:      [][2]int64{{0, 1000}, {1000, 2000}, {2000, 3000}},
+		},
+		{
+			testName:      "remainder",
+			fromMs:        0,
+			toMs:          2500,
+			splitDuration: 1000,
+			expected:      [][2]int64{{0, 1000}, {1000, 2000}, {2000, 2500}},
+		},
+		{
+			testName:      "max_split_count",
+			fromMs:        0,
+			toMs:          10000,
+			splitDuration: 1000,
+			maxSplitCount: 3,
+			expected:      [][2]int64{{0, 3334}, {3334, 6668}, {6668, 10000}},
+		},
+		{
+			testName:      "empty",
+			fromMs:        5000,
+			toMs:          5000,
+			splitDuration: 1000,
+			expected:      [][2]int64{{5000, 5000}},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			timeRanges := makeTimeRanges(
+				&TSplitDescription_TTimeRange{FromMs: tc.fromMs, ToMs: tc.toMs},
+				tc.splitDuration,
+				tc.maxSplitCount,
+			)
+
+			actual := make([][2]int64, 0, len(timeRanges))
+			for _, tr := range timeRanges {
+				actual = append(actual, [2]int64{tr.FromMs, tr.ToMs})
+			}
+
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package prometheus
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var labelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// query contains the parts of the request that can be pushed down to Prometheus
+type query struct {
+	// matchers are the label matchers of the series selector, e. g. `job="node"`
+	matchers []string
+	// fromMs is the exclusive lower bound of the sample timestamps
+	fromMs *int64
+	// toMs is the inclusive upper bound of the sample timestamps
+	toMs *int64
+}
+
+func (q *query) restrictFrom(fromMs int64) {
+	if q.fromMs == nil || *q.fromMs < fromMs {
+		q.fromMs = &fromMs
+	}
+}
+
+func (q *query) restrictTo(toMs int64) {
+	if q.toMs == nil || *q.toMs > toMs {
+		q.toMs = &toMs
+	}
+}
+
+// makeSelector renders the series selector for the metric
+func makeSelector(metricName string, matchers []string) string {
+	items := append([]string{"__name__=" + strconv.Quote(metricName)}, matchers...)
+
+	return "{" + strings.Join(items, ",") + "}"
+}
+
+type queryBuilder struct {
+	logger *zap.Logger
+}
+
+func newQueryBuilder(logger *zap.Logger) *queryBuilder {
+	return &queryBuilder{logger: logger}
+}
+
+// buildQuery extracts label matchers and time range from the top-level conjunction of the predicate.
+// With the optional filtering the unsupported conjuncts are skipped, since they will be evaluated by the engine.
+func (qb *queryBuilder) buildQuery(
+	where *api_service_protos.TSelect_TWhere,
+	filtering api_service_protos.TReadSplitsRequest_EFiltering,
+) (*query, error) {
+	q := &query{}
+
+	if where.GetFilterTyped() == nil {
+		return q, nil
+	}
+
+	predicates := []*api_service_protos.TPredicate{where.FilterTyped}
+	if conjunction := where.FilterTyped.GetConjunction(); conjunction != nil {
+		predicates = conjunction.Operands
+	}
+
+	for _, predicate := range predicates {
+		err := qb.applyPredicate(q, predicate)
+		if err == nil {
+			continue
+		}
+
+		switch filtering {
+		case api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY:
+			return nil, fmt.Errorf("apply predicate: %w", err)
+		case api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL:
+			if !common.OptionalFilteringAllowedErrors.Match(err) {
+				return nil, fmt.Errorf("encountered an error applying a predicate: %w", err)
+			}
+
+			qb.logger.Warn("considering pushdown error as acceptable", zap.Error(err))
+		default:
+			return nil, fmt.Errorf("unknown filtering mode: %d", filtering)
+		}
+	}
+
+	return q, nil
+}
+
+func (qb *queryBuilder) applyPredicate(q *query, predicate *api_service_protos.TPredicate) error {
+	switch p := predicate.Payload.(type) {
+	case *api_service_protos.TPredicate_Comparison:
+		return qb.applyComparison(q, p.Comparison)
+	case *api_service_protos.TPredicate_Between:
+		return qb.applyBetween(q, p.Between)
+	case *api_service_protos.TPredicate_Regexp:
+		return qb.applyRegexp(q, p.Regexp)
+	case *api_service_protos.TPredicate_IsNull:
+		// Prometheus treats the missing label as the label with empty value
+		return qb.applyLabelMatcher(q, p.IsNull.Value, `=""`)
+	case *api_service_protos.TPredicate_IsNotNull:
+		return qb.applyLabelMatcher(q, p.IsNotNull.Value, `!=""`)
+	default:
+		return fmt.Errorf("%w: %T", common.ErrUnimplementedPredicateType, p)
+	}
+}
+
+func (qb *queryBuilder) applyComparison(q *query, comparison *api_service_protos.TPredicate_TComparison) error {
+	columnName, err := getColumnName(comparison.LeftValue)
+	if err != nil {
+		return fmt.Errorf("get column name: %w", err)
+	}
+
+	if columnName == timestampColumnName {
+		return applyTimestampComparison(q, comparison.Operation, comparison.RightValue)
+	}
+
+	value, err := getStringValue(comparison.RightValue)
+	if err != nil {
+		return fmt.Errorf("get string value: %w", err)
+	}
+
+	var matcher string
+
+	switch comparison.Operation {
+	case api_service_protos.TPredicate_TComparison_EQ:
+		matcher = "=" + strconv.Quote(value)
+	case api_service_protos.TPredicate_TComparison_NE:
+		matcher = "!=" + strconv.Quote(value)
+	case api_service_protos.TPredicate_TComparison_STARTS_WITH:
+		matcher = "=~" + strconv.Quote(regexp.QuoteMeta(value)+".*")
+	case api_service_protos.TPredicate_TComparison_ENDS_WITH:
+		matcher = "=~" + strconv.Quote(".*"+regexp.QuoteMeta(value))
+	case api_service_protos.TPredicate_TComparison_CONTAINS:
+		matcher = "=~" + strconv.Quote(".*"+regexp.QuoteMeta(value)+".*")
+	default:
+		return fmt.Errorf("%w: %s", common.ErrUnimplementedOperation, comparison.Operation)
+	}
+
+	return qb.applyLabelMatcher(q, comparison.LeftValue, matcher)
+}
+
+func (*queryBuilder) applyBetween(q *query, between *api_service_protos.TPredicate_TBetween) error {
+	columnName, err := getColumnName(between.Value)
+	if err != nil {
+		return fmt.Errorf("get column name: %w", err)
+	}
+
+	if columnName != timestampColumnName {
+		return fmt.Errorf("%w: BETWEEN for column '%s'", common.ErrUnsupportedExpression, columnName)
+	}
+
+	if err := applyTimestampComparison(q, api_service_protos.TPredicate_TComparison_GE, between.Least); err != nil {
+		return fmt.Errorf("apply least: %w", err)
+	}
+
+	if err := applyTimestampComparison(q, api_service_protos.TPredicate_TComparison_LE, between.Greatest); err != nil {
+		return fmt.Errorf("apply greatest: %w", err)
+	}
+
+	return nil
+}
+
+func (qb *queryBuilder) applyRegexp(q *query, predicate *api_service_protos.TPredicate_TRegexp) error {
+	pattern, err := getStringValue(predicate.Pattern)
+	if err != nil {
+		return fmt.Errorf("get pattern: %w", err)
+	}
+
+	// Prometheus regular expressions are fully anchored, while REGEXP matches any substring
+	return qb.applyLabelMatcher(q, predicate.Value, "=~"+strconv.Quote(".*(?:"+pattern+").*"))
+}
+
+func (*queryBuilder) applyLabelMatcher(q *query, expression *api_service_protos.TExpression, matcher string) error {
+	columnName, err := getColumnName(expression)
+	if err != nil {
+		return fmt.Errorf("get column name: %w", err)
+	}
+
+	if columnName == timestampColumnName || columnName == valueColumnName || !labelNameRegex.MatchString(columnName) {
+		return fmt.Errorf("%w: label matcher for column '%s'", common.ErrUnsupportedExpression, columnName)
+	}
+
+	q.matchers = append(q.matchers, columnName+matcher)
+
+	return nil
+}
+
+// applyTimestampComparison converts the bound of the timestamp (in microseconds)
+// to the range of sample timestamps (in milliseconds).
+func applyTimestampComparison(
+	q *query,
+	operation api_service_protos.TPredicate_TComparison_EOperation,
+	expression *api_service_protos.TExpression,
+) error {
+	micros, err := getTimestampValue(expression)
+	if err != nil {
+		return fmt.Errorf("get timestamp value: %w", err)
+	}
+
+	floorMs := micros / 1000
+	ceilMs := floorMs
+
+	if micros%1000 != 0 {
+		ceilMs++
+	}
+
+	switch operation {
+	case api_service_protos.TPredicate_TComparison_GE:
+		q.restrictFrom(ceilMs - 1)
+	case api_service_protos.TPredicate_TComparison_G:
+		q.restrictFrom(floorMs)
+	case api_service_protos.TPredicate_TComparison_LE:
+		q.restrictTo(floorMs)
+	case api_service_protos.TPredicate_TComparison_L:
+		q.restrictTo(ceilMs - 1)
+	case api_service_protos.TPredicate_TComparison_EQ:
+		q.restrictFrom(ceilMs - 1)
+		q.restrictTo(floorMs)
+	default:
+		return fmt.Errorf("%w: %s for column '%s'", common.ErrUnimplementedOperation, operation, timestampColumnName)
+	}
+
+	return nil
+}
+
+func getColumnName(expression *api_service_protos.TExpression) (string, error) {
+	column, ok := expression.GetPayload().(*api_service_protos.TExpression_Column)
+	if !ok {
+		return "", fmt.Errorf("%w: %T", common.ErrUnsupportedExpression, expression.GetPayload())
+	}
+
+	return column.Column, nil
+}
+
+func getStringValue(expression *api_service_protos.TExpression) (string, error) {
+	typedValue := expression.GetTypedValue()
+	if typedValue == nil {
+		return "", fmt.Errorf("%w: %T", common.ErrUnsupportedExpression, expression.GetPayload())
+	}
+
+	switch v := typedValue.Value.GetValue().(type) {
+	case *Ydb.Value_TextValue:
+		return v.TextValue, nil
+	case *Ydb.Value_BytesValue:
+		return string(v.BytesValue), nil
+	default:
+		return "", fmt.Errorf("%w: %s", common.ErrUnimplementedTypedValue, typedValue.Type)
+	}
+}
+
+func getTimestampValue(expression *api_service_protos.TExpression) (int64, error) {
+	typedValue := expression.GetTypedValue()
+	if typedValue == nil {
+		return 0, fmt.Errorf("%w: %T", common.ErrUnsupportedExpression, expression.GetPayload())
+	}
+
+	ydbType := typedValue.Type
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	if ydbType.GetTypeId() != Ydb.Type_TIMESTAMP {
+		return 0, fmt.Errorf("%w: %s", common.ErrUnimplementedTypedValue, typedValue.Type)
+	}
+
+	return int64(typedValue.Value.GetUint64Value()), nil
+}
@@ -0,0 +1,158 @@
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+	tests_utils "github.com/ydb-platform/fq-connector-go/tests/utils"
+)
+
+func makeTimestampValue(micros uint64) *Ydb.TypedValue {
+	return &Ydb.TypedValue{
+		Type:  common.MakePrimitiveType(Ydb.Type_TIMESTAMP),
+		Value: &Ydb.Value{Value: &Ydb.Value_Uint64Value{Uint64Value: micros}},
+	}
+}
+
+func makeConjunction(predicates ...*api_service_protos.TPredicate) *api_service_protos.TSelect_TWhere {
+	return &api_service_protos.TSelect_TWhere{
+		FilterTyped: &api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_Conjunction{
+				Conjunction: &api_service_protos.TPredicate_TConjunction{Operands: predicates},
+			},
+		},
+	}
+}
+
+func TestBuildQuery(t *testing.T) {
+	type testCase struct {
+		testName  string
+		where     *api_service_protos.TSelect_TWhere
+		filtering api_service_protos.TReadSplitsRequest_EFiltering
+		matchers  []string
+		fromMs    *int64
+		toMs      *int64
+		err       error
+	}
+
+	ptr := func(v int64) *int64 { return &v }
+
+	utf8 := common.MakePrimitiveType(Ydb.Type_UTF8)
+
+	tcs := []testCase{
+		{
+			testName:  "empty",
+			where:     nil,
+			filtering: api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY,
+		},
+		{
+			testName: "labels",
+			where: makeConjunction(
+				&api_service_protos.TPredicate{
+					Payload: tests_utils.MakePredicateComparisonColumn(
+						"job", api_service_protos.TPredicate_TComparison_EQ, common.MakeTypedValue(utf8, "node"),
+					),
+				},
+				&api_service_protos.TPredicate{
+					Payload: tests_utils.MakePredicateComparisonColumn(
+						"instance", api_service_protos.TPredicate_TComparison_NE, common.MakeTypedValue(utf8, `a"b`),
+					),
+				},
+				&api_service_protos.TPredicate{Payload: tests_utils.MakePredicateRegexpColumn("env", "^prod")},
+				&api_service_protos.TPredicate{Payload: tests_utils.MakePredicateIsNullColumn("zone")},
+				&api_service_protos.TPredicate{Payload: tests_utils.MakePredicateIsNotNullColumn("host")},
+			),
+			filtering: api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY,
+			matchers:  []string{`job="node"`, `instance!="a\"b"`, `env=~".*(?:^prod).*"`, `zone=""`, `host!=""`},
+		},
+		{
+			testName: "time_range",
+			where: makeConjunction(
+				&api_service_protos.TPredicate{
+					Payload: tests_utils.MakePredicateComparisonColumn(
+						"timestamp", api_service_protos.TPredicate_TComparison_GE, makeTimestampValue(1_000_500),
+					),
+				},
+				&api_service_protos.TPredicate{
+					Payload: tests_utils.MakePredicateComparisonColumn(
+						"timestamp", api_service_protos.TPredicate_TComparison_L, makeTimestampValue(5_000_000),
+					),
+				},
+			),
+			filtering: api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY,
+			fromMs:    ptr(1000),
+			toMs:      ptr(4999),
+		},
+		{
+			testName: "time_range_between",
+			where: &api_service_protos.TSelect_TWhere{
+				FilterTyped: &api_service_protos.TPredicate{
+					Payload: tests_utils.MakePredicateBetweenColumn(
+						"timestamp", makeTimestampValue(2_000_000), makeTimestampValue(3_000_999),
+					),
+				},
+			},
+			filtering: api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY,
+			fromMs:    ptr(1999),
+			toMs:      ptr(3000),
+		},
+		{
+			testName: "unsupported_mandatory",
+			where: makeConjunction(
+				&api_service_protos.TPredicate{
+					Payload: tests_utils.MakePredicateComparisonColumn(
+						"value", api_service_protos.TPredicate_TComparison_EQ, common.MakeTypedValue(utf8, "1"),
+					),
+				},
+			),
+			filtering: api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY,
+			err:       common.ErrUnsupportedExpression,
+		},
+		{
+			testName: "unsupported_optional",
+			where: makeConjunction(
+				&api_service_protos.TPredicate{
+					Payload: tests_utils.MakePredicateComparisonColumn(
+						"value", api_service_protos.TPredicate_TComparison_EQ, common.MakeTypedValue(utf8, "1"),
+					),
+				},
+				&api_service_protos.TPredicate{
+					Payload: tests_utils.MakePredicateComparisonColumn(
+						"job", api_service_protos.TPredicate_TComparison_EQ, common.MakeTypedValue(utf8, "node"),
+					),
+				},
+			),
+			filtering: api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+			matchers:  []string{`job="node"`},
+		},
+	}
+
+	qb := newQueryBuilder(common.NewTestLogger(t))
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			q, err := qb.buildQuery(tc.where, tc.filtering)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.matchers, q.matchers)
+			require.Equal(t, tc.fromMs, q.fromMs)
+			require.Equal(t, tc.toMs, q.toMs)
+		})
+	}
+}
+
+func TestMakeSelector(t *testing.T) {
+	require.Equal(t, `{__name__="up"}`, makeSelector("up", nil))
+	require.Equal(t, `{__name__="up",job="node"}`, makeSelector("up", []string{`job="node"`}))
+}
@@ -15,4 +15,7 @@ const (
 	HashColumnName   = "hash_values"
 
 	scanBatchSize = 100000
+
+	// keyPrefixDelimiter separates namespaces within a key (like `user:42:profile`)
+	keyPrefixDelimiter = ":"
 )
@@ -105,8 +105,9 @@ func (*dataSource) ListSplits(
 	resultChan chan<- *datasource.ListSplitResult,
 ) error {
 	// By default, we deny table splitting.
+	// Redis can't sort the values, so the rows are sorted on the YDB side.
 	select {
-	case resultChan <- &datasource.ListSplitResult{Slct: slct, Description: nil}:
+	case resultChan <- &datasource.ListSplitResult{Slct: common.SelectWithoutOrderBy(slct), Description: nil}:
 	case <-ctx.Done():
 		return ctx.Err()
 	}
@@ -198,6 +199,14 @@ func (*dataSource) readKeys(
 
 	var cursor, unsupported uint64
 
+	// Continue SCAN from the cursor reached before the interruption of the previous request
+	if position := sink.ResumePosition(); position != nil {
+		cursor = position.GetCursor().GetScanCursor()
+	}
+
+	// The rows of the first batch are also delivered after the cursor, even if it is the initial one
+	sink.Checkpoint(&paging.TCursor{Payload: &paging.TCursor_ScanCursor{ScanCursor: cursor}})
+
 	for {
 		// 1) Scan a batch of keys
 		keys, nextCursor, err := client.Scan(ctx, cursor, pattern, scanBatchSize).Result()
@@ -231,6 +240,9 @@ func (*dataSource) readKeys(
 		if cursor == 0 {
 			break
 		}
+
+		// All the keys returned before the cursor have been added to the sink
+		sink.Checkpoint(&paging.TCursor{Payload: &paging.TCursor_ScanCursor{ScanCursor: cursor}})
 	}
 
 	if unsupported > 0 {
@@ -370,6 +382,10 @@ func (ds *dataSource) ReadSplit(
 	split *api_service_protos.TSplit,
 	sinkFactory paging.SinkFactory[any],
 ) error {
+	if common.SelectWhatHasComputedColumns(split.Select.What) {
+		return fmt.Errorf("computed columns are not supported by Redis: %w", common.ErrUnimplementedExpression)
+	}
+
 	dsi := split.Select.DataSourceInstance
 
 	if dsi.Protocol != api_common.EGenericProtocol_NATIVE {
@@ -404,6 +420,13 @@ func (ds *dataSource) ReadSplit(
 
 	sink := sinks[0]
 
+	// All the keys have been delivered before the interruption of the previous request
+	if sink.ResumePosition().GetFinished() {
+		sink.Finish()
+
+		return nil
+	}
+
 	transformer, err := newRedisRowTransformer(split.Select.What.GetItems())
 	if err != nil {
 		return fmt.Errorf("create transformer: %w", err)
@@ -464,7 +487,8 @@ func (ds *dataSource) DescribeTable(
 	// If no keys found, return an empty schema.
 	if len(allKeys) == 0 {
 		return &api_service_protos.TDescribeTableResponse{
-			Schema: &api_service_protos.TSchema{Columns: nil},
+			Schema:               &api_service_protos.TSchema{Columns: nil},
+			PushdownCapabilities: &api_service_protos.TPushdownCapabilities{},
 		}, nil
 	}
 
@@ -475,11 +499,98 @@ func (ds *dataSource) DescribeTable(
 
 	columns := buildSchema(*keysInfo)
 
+	// Nothing is pushed down: all the keys matching the pattern are read
 	return &api_service_protos.TDescribeTableResponse{
-		Schema: &api_service_protos.TSchema{Columns: columns},
+		Schema:               &api_service_protos.TSchema{Columns: columns},
+		PushdownCapabilities: &api_service_protos.TPushdownCapabilities{},
 	}, nil
 }
 
+// ListTables scans the keyspace and treats every distinct key namespace as a table:
+// keys like `user:1`, `user:2` are reported as a single `user:*` pattern,
+// while keys without a delimiter are reported as is.
+// The scan stops after `list_tables_max_scanned_keys` keys, so the list may be incomplete for huge keyspaces.
+func (ds *dataSource) ListTables(
+	ctx context.Context,
+	logger *zap.Logger,
+	request *api_service_protos.TListTablesRequest,
+) (*api_service_protos.TListTablesResponse, error) {
+	dsi := request.DataSourceInstance
+
+	if dsi.Protocol != api_common.EGenericProtocol_NATIVE {
+		return nil, fmt.Errorf("cannot run Redis connection with protocol '%v'", dsi.Protocol)
+	}
+
+	var client *redis.Client
+
+	err := ds.retrierSet.MakeConnection.Run(ctx, logger, func() error {
+		var err error
+		client, err = ds.makeConnection(ctx, logger, dsi)
+
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("make connection: %w", err)
+	}
+
+	defer func() {
+		common.LogCloserError(logger, client, "close connection")
+	}()
+
+	var (
+		tableSet    = make(map[string]struct{})
+		cursor      uint64
+		scannedKeys uint64
+		maxKeys     = ds.cfg.GetListTablesMaxScannedKeys()
+	)
+
+	for {
+		keys, nextCursor, err := client.Scan(ctx, cursor, "*", int64(min(scanBatchSize, maxKeys-scannedKeys))).Result()
+		if err != nil {
+			return nil, fmt.Errorf("scan keys: %w", err)
+		}
+
+		for _, key := range keys {
+			tableSet[keyToTableName(key)] = struct{}{}
+		}
+
+		scannedKeys += uint64(len(keys))
+
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+
+		if scannedKeys >= maxKeys {
+			logger.Warn(
+				"the limit of scanned keys is reached, the list of tables may be incomplete",
+				zap.Uint64("scanned_keys", scannedKeys),
+				zap.Uint64("list_tables_max_scanned_keys", maxKeys),
+			)
+
+			break
+		}
+	}
+
+	tables := make([]string, 0, len(tableSet))
+	for table := range tableSet {
+		tables = append(tables, table)
+	}
+
+	sort.Strings(tables)
+
+	return &api_service_protos.TListTablesResponse{Tables: tables}, nil
+}
+
+func keyToTableName(key string) string {
+	if idx := strings.Index(key, keyPrefixDelimiter); idx > 0 {
+		return key[:idx+len(keyPrefixDelimiter)] + "*"
+	}
+
+	return key
+}
+
 // accumulateKeys scans Redis keys matching the given pattern until at least 'count' keys are collected
 // or the scan is finished.
 func (*dataSource) accumulateKeys(ctx context.Context, client *redis.Client, pattern string, count int) ([]string, error) {
@@ -0,0 +1,30 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyToTableName(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{key: "user:1", expected: "user:*"},
+		{key: "user:1:profile", expected: "user:*"},
+		{key: "user:", expected: "user:*"},
+		{key: "user", expected: "user"},
+		// the key starting with the delimiter has no namespace
+		{key: ":1", expected: ":1"},
+		{key: "", expected: ""},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.key, func(t *testing.T) {
+			require.Equal(t, tc.expected, keyToTableName(tc.key))
+		})
+	}
+}
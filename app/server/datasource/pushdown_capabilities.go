@@ -0,0 +1,433 @@
+package datasource
+
+import (
+	"slices"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// PredicateChecker returns an error if the predicate cannot be pushed down entirely
+type PredicateChecker func(predicate *api_service_protos.TPredicate) error
+
+// OrderByChecker returns an error if the ordering cannot be pushed down
+type OrderByChecker func(orderBy *api_service_protos.TSelect_TOrderBy) error
+
+// WhatChecker returns an error if the list of the selected items cannot be pushed down
+type WhatChecker func(what *api_service_protos.TSelect_TWhat) error
+
+// probeColumn is the name of an ordinary column used in the sample predicates
+const probeColumn = "col"
+
+// probeValueTypes lists the types of the constant values that may be supported by the data sources
+var probeValueTypes = []Ydb.Type_PrimitiveTypeId{
+	Ydb.Type_BOOL,
+	Ydb.Type_INT8,
+	Ydb.Type_UINT8,
+	Ydb.Type_INT16,
+	Ydb.Type_UINT16,
+	Ydb.Type_INT32,
+	Ydb.Type_UINT32,
+	Ydb.Type_INT64,
+	Ydb.Type_UINT64,
+	Ydb.Type_FLOAT,
+	Ydb.Type_DOUBLE,
+	Ydb.Type_DATE,
+	Ydb.Type_DATETIME,
+	Ydb.Type_TIMESTAMP,
+	Ydb.Type_INTERVAL,
+	Ydb.Type_DATE32,
+	Ydb.Type_DATETIME64,
+	Ydb.Type_TIMESTAMP64,
+	Ydb.Type_INTERVAL64,
+	Ydb.Type_STRING,
+	Ydb.Type_UTF8,
+	Ydb.Type_JSON,
+	Ydb.Type_UUID,
+}
+
+// ProbePushdownCapabilities determines what the data source is able to push down
+// by trying to render sample predicates over an ordinary column.
+// Nil checkers stand for the data sources that push down neither predicates, nor ordering,
+// nor computed columns.
+func ProbePushdownCapabilities(
+	checkPredicate PredicateChecker,
+	checkOrderBy OrderByChecker,
+	checkWhat WhatChecker,
+	limit bool,
+) *api_service_protos.TPushdownCapabilities {
+	out := &api_service_protos.TPushdownCapabilities{Limit: limit}
+
+	if checkWhat != nil {
+		out.ComputedColumns = checkWhat(makeWhatWithComputedColumn()) == nil
+	}
+
+	if checkOrderBy != nil {
+		orderBy := &api_service_protos.TSelect_TOrderBy{
+			Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{
+				{Column: probeColumn, Order: api_service_protos.TSelect_TOrderBy_ASC},
+			},
+		}
+
+		out.OrderBy = checkOrderBy(orderBy) == nil
+	}
+
+	if checkPredicate == nil {
+		return out
+	}
+
+	p := &pushdownProber{check: checkPredicate}
+	p.probeComparisons(out)
+
+	out.Negation = p.anyComparison(func(c *api_service_protos.TPredicate) *api_service_protos.TPredicate {
+		return &api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_Negation{
+				Negation: &api_service_protos.TPredicate_TNegation{Operand: c},
+			},
+		}
+	})
+
+	out.Disjunction = p.anyComparison(func(c *api_service_protos.TPredicate) *api_service_protos.TPredicate {
+		return &api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_Disjunction{
+				Disjunction: &api_service_protos.TPredicate_TDisjunction{
+					Operands: []*api_service_protos.TPredicate{c, c},
+				},
+			},
+		}
+	})
+
+	out.Coalesce = p.anyComparison(func(c *api_service_protos.TPredicate) *api_service_protos.TPredicate {
+		return &api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_Coalesce{
+				Coalesce: &api_service_protos.TPredicate_TCoalesce{
+					Operands: []*api_service_protos.TPredicate{c, c},
+				},
+			},
+		}
+	})
+
+	out.If = p.anyComparison(func(c *api_service_protos.TPredicate) *api_service_protos.TPredicate {
+		return replaceColumn(c, &api_service_protos.TExpression{
+			Payload: &api_service_protos.TExpression_If{
+				If: &api_service_protos.TExpression_TIf{
+					Predicate:      c,
+					ThenExpression: makeColumnExpression(),
+					ElseExpression: makeColumnExpression(),
+				},
+			},
+		})
+	})
+
+	out.Cast = p.anyComparison(func(c *api_service_protos.TPredicate) *api_service_protos.TPredicate {
+		return replaceColumn(c, &api_service_protos.TExpression{
+			Payload: &api_service_protos.TExpression_Cast{
+				Cast: &api_service_protos.TExpression_TCast{
+					Value: makeColumnExpression(),
+					Type:  c.GetComparison().GetRightValue().GetTypedValue().GetType(),
+				},
+			},
+		})
+	})
+
+	for _, op := range sortedEnumValues(api_service_protos.TExpression_TArithmeticalExpression_EOperation_name) {
+		operation := api_service_protos.TExpression_TArithmeticalExpression_EOperation(op)
+
+		supported := p.anyComparison(func(c *api_service_protos.TPredicate) *api_service_protos.TPredicate {
+			return replaceColumn(c, &api_service_protos.TExpression{
+				Payload: &api_service_protos.TExpression_ArithmeticalExpression{
+					ArithmeticalExpression: &api_service_protos.TExpression_TArithmeticalExpression{
+						Operation:  operation,
+						LeftValue:  makeColumnExpression(),
+						RightValue: c.GetComparison().GetRightValue(),
+					},
+				},
+			})
+		})
+
+		if supported {
+			out.ArithmeticalOperations = append(out.ArithmeticalOperations, operation)
+		}
+	}
+
+	p.probeOtherPredicates(out)
+	p.probeDateTimeFunctions(out)
+	p.probeFullTextMatch(out)
+
+	return out
+}
+
+type pushdownProber struct {
+	check PredicateChecker
+	// comparisons that have been pushed down successfully
+	comparisons []*api_service_protos.TPredicate
+}
+
+func (p *pushdownProber) supports(predicate *api_service_protos.TPredicate) bool {
+	return p.check(predicate) == nil
+}
+
+// anyComparison reports whether the predicate made of any supported comparison can be pushed down
+func (p *pushdownProber) anyComparison(
+	makePredicate func(comparison *api_service_protos.TPredicate) *api_service_protos.TPredicate,
+) bool {
+	for _, comparison := range p.comparisons {
+		if p.supports(makePredicate(comparison)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *pushdownProber) probeComparisons(out *api_service_protos.TPushdownCapabilities) {
+	operations := sortedEnumValues(api_service_protos.TPredicate_TComparison_EOperation_name)
+	supportedOperations := make(map[int32]bool, len(operations))
+
+	probeValue := func(value *api_service_protos.TExpression) bool {
+		supportedType := false
+
+		for _, op := range operations {
+			operation := api_service_protos.TPredicate_TComparison_EOperation(op)
+			comparison := makeComparison(operation, makeColumnExpression(), value)
+
+			if p.supports(comparison) {
+				p.comparisons = append(p.comparisons, comparison)
+				supportedOperations[op] = true
+				supportedType = true
+			}
+		}
+
+		return supportedType
+	}
+
+	for _, typeID := range probeValueTypes {
+		if probeValue(makeSampleValue(typeID)) {
+			out.ValueTypes = append(out.ValueTypes, typeID)
+		}
+	}
+
+	// Decimal is not a primitive type, but some data sources are able to cast the columns to it only
+	probeValue(&api_service_protos.TExpression{
+		Payload: &api_service_protos.TExpression_TypedValue{
+			TypedValue: &Ydb.TypedValue{
+				Type:  common.MakeDecimalType(22, 9),
+				Value: &Ydb.Value{Value: &Ydb.Value_Low_128{Low_128: 1}},
+			},
+		},
+	})
+
+	for _, op := range operations {
+		if supportedOperations[op] {
+			out.ComparisonOperations = append(out.ComparisonOperations, api_service_protos.TPredicate_TComparison_EOperation(op))
+		}
+	}
+}
+
+func (p *pushdownProber) probeOtherPredicates(out *api_service_protos.TPushdownCapabilities) {
+	out.IsNull = p.supports(&api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_IsNull{
+			IsNull: &api_service_protos.TPredicate_TIsNull{Value: makeColumnExpression()},
+		},
+	}) && p.supports(&api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_IsNotNull{
+			IsNotNull: &api_service_protos.TPredicate_TIsNotNull{Value: makeColumnExpression()},
+		},
+	})
+
+	for _, typeID := range out.ValueTypes {
+		value := makeSampleValue(typeID)
+
+		out.In = out.In || p.supports(MakeInPredicate(typeID, 2))
+
+		out.Between = out.Between || p.supports(&api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_Between{
+				Between: &api_service_protos.TPredicate_TBetween{
+					Value:    makeColumnExpression(),
+					Least:    value,
+					Greatest: value,
+				},
+			},
+		})
+
+		if typeID == Ydb.Type_STRING || typeID == Ydb.Type_UTF8 {
+			out.Regexp = out.Regexp || p.supports(&api_service_protos.TPredicate{
+				Payload: &api_service_protos.TPredicate_Regexp{
+					Regexp: &api_service_protos.TPredicate_TRegexp{
+						Value:   makeColumnExpression(),
+						Pattern: value,
+					},
+				},
+			})
+		}
+	}
+}
+
+// probeDateTimeFunctions checks the functions applied to the column either in IS NOT NULL predicate,
+// or in any of the supported comparisons
+func (p *pushdownProber) probeDateTimeFunctions(out *api_service_protos.TPushdownCapabilities) {
+	for _, f := range sortedEnumValues(api_service_protos.TExpression_TDateTimeFunction_EFunction_name) {
+		function := api_service_protos.TExpression_TDateTimeFunction_EFunction(f)
+
+		expression := &api_service_protos.TExpression{
+			Payload: &api_service_protos.TExpression_DateTimeFunction{
+				DateTimeFunction: &api_service_protos.TExpression_TDateTimeFunction{
+					Function: function,
+					Value:    makeColumnExpression(),
+				},
+			},
+		}
+
+		supported := p.supports(&api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_IsNotNull{
+				IsNotNull: &api_service_protos.TPredicate_TIsNotNull{Value: expression},
+			},
+		}) || p.anyComparison(func(c *api_service_protos.TPredicate) *api_service_protos.TPredicate {
+			return replaceColumn(c, expression)
+		})
+
+		if supported {
+			out.DateTimeFunctions = append(out.DateTimeFunctions, function)
+		}
+	}
+}
+
+func (p *pushdownProber) probeFullTextMatch(out *api_service_protos.TPushdownCapabilities) {
+	for _, m := range sortedEnumValues(api_service_protos.TPredicate_TFullTextMatch_EMode_name) {
+		mode := api_service_protos.TPredicate_TFullTextMatch_EMode(m)
+
+		supported := p.supports(&api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_FullTextMatch{
+				FullTextMatch: &api_service_protos.TPredicate_TFullTextMatch{
+					Mode:    mode,
+					Columns: []string{probeColumn},
+					Query:   "a",
+				},
+			},
+		})
+
+		if supported {
+			out.FullTextMatchModes = append(out.FullTextMatchModes, mode)
+		}
+	}
+}
+
+// makeWhatWithComputedColumn makes the list of the selected items containing the simplest computed column
+func makeWhatWithComputedColumn() *api_service_protos.TSelect_TWhat {
+	return &api_service_protos.TSelect_TWhat{
+		Items: []*api_service_protos.TSelect_TWhat_TItem{
+			{
+				Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+					Column: &Ydb.Column{Name: probeColumn, Type: common.MakePrimitiveType(Ydb.Type_INT64)},
+				},
+			},
+			{
+				Payload: &api_service_protos.TSelect_TWhat_TItem_ComputedColumn{
+					ComputedColumn: &api_service_protos.TSelect_TWhat_TComputedColumn{
+						Name:       "expr",
+						Expression: makeColumnExpression(),
+					},
+				},
+			},
+		},
+	}
+}
+
+// MakeInPredicate makes the sample IN predicate over the column with the set of the given size
+func MakeInPredicate(typeID Ydb.Type_PrimitiveTypeId, size int) *api_service_protos.TPredicate {
+	set := make([]*api_service_protos.TExpression, size)
+	for i := range set {
+		set[i] = makeSampleValue(typeID)
+	}
+
+	return &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_In{
+			In: &api_service_protos.TPredicate_TIn{
+				Value: makeColumnExpression(),
+				Set:   set,
+			},
+		},
+	}
+}
+
+func makeColumnExpression() *api_service_protos.TExpression {
+	return &api_service_protos.TExpression{
+		Payload: &api_service_protos.TExpression_Column{Column: probeColumn},
+	}
+}
+
+func makeComparison(
+	operation api_service_protos.TPredicate_TComparison_EOperation,
+	left, right *api_service_protos.TExpression,
+) *api_service_protos.TPredicate {
+	return &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Comparison{
+			Comparison: &api_service_protos.TPredicate_TComparison{
+				Operation:  operation,
+				LeftValue:  left,
+				RightValue: right,
+			},
+		},
+	}
+}
+
+// replaceColumn makes the copy of the comparison with the column replaced by the given expression
+func replaceColumn(comparison *api_service_protos.TPredicate, left *api_service_protos.TExpression) *api_service_protos.TPredicate {
+	src := comparison.GetComparison()
+
+	return makeComparison(src.Operation, left, src.RightValue)
+}
+
+func makeSampleValue(typeID Ydb.Type_PrimitiveTypeId) *api_service_protos.TExpression {
+	value := &Ydb.Value{}
+
+	switch typeID {
+	case Ydb.Type_BOOL:
+		value.Value = &Ydb.Value_BoolValue{BoolValue: true}
+	case Ydb.Type_INT8, Ydb.Type_INT16, Ydb.Type_INT32, Ydb.Type_DATE32:
+		value.Value = &Ydb.Value_Int32Value{Int32Value: 1}
+	case Ydb.Type_UINT8, Ydb.Type_UINT16, Ydb.Type_UINT32, Ydb.Type_DATE, Ydb.Type_DATETIME:
+		value.Value = &Ydb.Value_Uint32Value{Uint32Value: 1}
+	case Ydb.Type_INT64, Ydb.Type_TIMESTAMP, Ydb.Type_INTERVAL,
+		Ydb.Type_DATETIME64, Ydb.Type_TIMESTAMP64, Ydb.Type_INTERVAL64:
+		value.Value = &Ydb.Value_Int64Value{Int64Value: 1}
+	case Ydb.Type_UINT64:
+		value.Value = &Ydb.Value_Uint64Value{Uint64Value: 1}
+	case Ydb.Type_FLOAT:
+		value.Value = &Ydb.Value_FloatValue{FloatValue: 1}
+	case Ydb.Type_DOUBLE:
+		value.Value = &Ydb.Value_DoubleValue{DoubleValue: 1}
+	case Ydb.Type_STRING:
+		value.Value = &Ydb.Value_BytesValue{BytesValue: []byte("a")}
+	case Ydb.Type_UUID:
+		value.Value = &Ydb.Value_Low_128{Low_128: 1}
+	default:
+		value.Value = &Ydb.Value_TextValue{TextValue: "a"}
+	}
+
+	return &api_service_protos.TExpression{
+		Payload: &api_service_protos.TExpression_TypedValue{
+			TypedValue: &Ydb.TypedValue{
+				Type:  &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: typeID}},
+				Value: value,
+			},
+		},
+	}
+}
+
+// sortedEnumValues returns the meaningful values of the enum in ascending order
+func sortedEnumValues(names map[int32]string) []int32 {
+	out := make([]int32, 0, len(names))
+
+	for value := range names {
+		if value != 0 {
+			out = append(out, value)
+		}
+	}
+
+	slices.Sort(out)
+
+	return out
+}
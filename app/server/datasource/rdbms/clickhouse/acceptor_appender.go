@@ -2,9 +2,12 @@ package clickhouse
 
 import (
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
@@ -64,6 +67,23 @@ func addAcceptorAppenderFromSQLTypeName(
 		// Looks like []byte would be a better option here, but clickhouse driver prefers string
 		acceptors = append(acceptors, new(string))
 		appenders = append(appenders, utils.MakeAppender[string, []byte, *array.BinaryBuilder](cc.StringToBytes()))
+	case tm.isEnum.MatchString(typeName):
+		acceptors = append(acceptors, new(string))
+		appenders = append(appenders, utils.MakeAppender[string, string, *array.StringBuilder](cc.String()))
+	case tm.isDecimal.MatchString(typeName):
+		appendValue, err := makeDecimalValueAppender(typeName, ydbType, tm)
+		if err != nil {
+			return nil, nil, fmt.Errorf("make decimal value appender: %w", err)
+		}
+
+		acceptors = append(acceptors, new(decimal.Decimal))
+		appenders = append(appenders, makeValueAppender(appendValue))
+	case typeName == typeUUID:
+		acceptors = append(acceptors, new(uuid.UUID))
+		appenders = append(appenders, makeValueAppender(appendUUIDToArrowBuilder))
+	case typeName == typeIPv4, typeName == typeIPv6:
+		acceptors = append(acceptors, new(net.IP))
+		appenders = append(appenders, makeValueAppender(appendIPToArrowBuilder))
 	case typeName == typeDate:
 		acceptors = append(acceptors, new(time.Time))
 
@@ -5,8 +5,11 @@ import (
 	"fmt"
 
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
@@ -26,29 +29,237 @@ func (c *connectionManager) Make(
 		return nil, fmt.Errorf("currently only basic auth is supported")
 	}
 
-	var (
-		conn rdbms_utils.Connection
-		err  error
-	)
+	shard, err := getSplitShard(params.Split)
+	if err != nil {
+		return nil, fmt.Errorf("get split shard: %w", err)
+	}
+
+	dsi, tableName := params.DataSourceInstance, params.TableName
+
+	if shard != nil {
+		dsi, tableName, err = c.routeToShard(params, shard)
+		if err != nil {
+			return nil, fmt.Errorf("route to shard: %w", err)
+		}
+	}
+
+	conn, err := c.makeConnection(params, dsi, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	return []rdbms_utils.Connection{conn}, nil
+}
 
-	switch params.DataSourceInstance.Protocol {
+func (c *connectionManager) makeConnection(
+	params *rdbms_utils.ConnectionParams,
+	dsi *api_common.TGenericDataSourceInstance,
+	tableName string,
+) (rdbms_utils.Connection, error) {
+	switch dsi.Protocol {
 	case api_common.EGenericProtocol_NATIVE:
-		conn, err = makeConnectionNative(
-			params.Ctx, params.Logger, c.cfg, params.DataSourceInstance, params.TableName, c.QueryLoggerFactory.Make(params.Logger))
+		conn, err := makeConnectionNative(
+			params.Ctx, params.Logger, c.cfg, dsi, tableName, c.QueryLoggerFactory.Make(params.Logger))
 		if err != nil {
 			return nil, fmt.Errorf("make connection native: %w", err)
 		}
+
+		return conn, nil
 	case api_common.EGenericProtocol_HTTP:
-		conn, err = makeConnectionHTTP(
-			params.Ctx, params.Logger, c.cfg, params.DataSourceInstance, params.TableName, c.QueryLoggerFactory.Make(params.Logger))
+		conn, err := makeConnectionHTTP(
+			params.Ctx, params.Logger, c.cfg, dsi, tableName, c.QueryLoggerFactory.Make(params.Logger))
 		if err != nil {
 			return nil, fmt.Errorf("make connection http: %w", err)
 		}
+
+		return conn, nil
 	default:
-		return nil, fmt.Errorf("can not run connection with protocol '%v'", params.DataSourceInstance.Protocol)
+		return nil, fmt.Errorf("can not run connection with protocol '%v'", dsi.Protocol)
 	}
+}
 
-	return []rdbms_utils.Connection{conn}, nil
+// getSplitShard returns the shard of a Distributed table the split belongs to, if any
+func getSplitShard(split *api_service_protos.TSplit) (*TSplitDescription_TShard, error) {
+	if len(split.GetDescription()) == 0 {
+		return nil, nil
+	}
+
+	var splitDescription TSplitDescription
+
+	if err := protojson.Unmarshal(split.GetDescription(), &splitDescription); err != nil {
+		return nil, fmt.Errorf("unmarshal split description: %w", err)
+	}
+
+	return splitDescription.GetShard(), nil
+}
+
+// routeToShard replaces the data source instance and the table name with the ones of the shard.
+// Split descriptions come from the client, so the shard is never trusted as is: the connector
+// asks the original instance for the definition of the Distributed table and for the replicas
+// of its cluster, and connects only to a replica known to the original instance.
+func (c *connectionManager) routeToShard(
+	params *rdbms_utils.ConnectionParams,
+	shard *TSplitDescription_TShard,
+) (*api_common.TGenericDataSourceInstance, string, error) {
+	conn, err := c.makeConnection(params, params.DataSourceInstance, params.TableName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer common.LogCloserError(params.Logger, conn, "close clickhouse connection")
+
+	engine, engineFull, err := getTableEngine(params.Ctx, params.Logger, conn)
+	if err != nil {
+		return nil, "", fmt.Errorf("get table engine: %w", err)
+	}
+
+	if engine != "Distributed" {
+		return nil, "", fmt.Errorf("table '%s' is not Distributed: engine '%s'", params.TableName, engine)
+	}
+
+	engineArgs, err := parseDistributedEngineArgs(engineFull)
+	if err != nil {
+		return nil, "", fmt.Errorf("parse distributed engine args: %w", err)
+	}
+
+	if err = checkShardTable(shard, engineArgs, params.DataSourceInstance.Database); err != nil {
+		return nil, "", fmt.Errorf("check shard table: %w", err)
+	}
+
+	host := shard.GetEndpoint().GetHost()
+
+	clusterPort, err := getReplicaPort(params.Ctx, params.Logger, conn, engineArgs.clusterName, host)
+	if err != nil {
+		return nil, "", fmt.Errorf("get replica port: %w", err)
+	}
+
+	port, err := mapReplicaPort(clusterPort, params.DataSourceInstance)
+	if err != nil {
+		return nil, "", fmt.Errorf("map replica port: %w", err)
+	}
+
+	dsi := proto.Clone(params.DataSourceInstance).(*api_common.TGenericDataSourceInstance)
+	dsi.Endpoint = &api_common.TGenericEndpoint{Host: host, Port: port}
+	dsi.Database = shard.DatabaseName
+
+	params.Logger.Debug(
+		"routing connection to shard",
+		zap.String("endpoint", common.EndpointToString(dsi.Endpoint)),
+		zap.String("database", dsi.Database),
+		zap.String("table", shard.TableName),
+	)
+
+	return dsi, shard.TableName, nil
+}
+
+// checkShardTable makes sure that the split refers to the local table underlying the Distributed table
+func checkShardTable(
+	shard *TSplitDescription_TShard,
+	engineArgs *distributedEngineArgs,
+	defaultDatabaseName string,
+) error {
+	databaseName := engineArgs.databaseName
+	if databaseName == "" {
+		databaseName = defaultDatabaseName
+	}
+
+	if shard.DatabaseName != databaseName || shard.TableName != engineArgs.tableName {
+		return fmt.Errorf(
+			"split refers to table '%s.%s', while Distributed table is based on '%s.%s'",
+			shard.DatabaseName, shard.TableName, databaseName, engineArgs.tableName,
+		)
+	}
+
+	return nil
+}
+
+// getReplicaPort returns the port of the host taken from the cluster configuration.
+// It fails if the host does not belong to the cluster.
+func getReplicaPort(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn rdbms_utils.Connection,
+	clusterName string,
+	host string,
+) (uint16, error) {
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(clusterName)
+	args.AddUntyped(host)
+
+	rows, err := conn.Query(&rdbms_utils.QueryParams{
+		Ctx:       ctx,
+		Logger:    logger,
+		QueryText: "SELECT port FROM system.clusters WHERE cluster = ? AND host_name = ? LIMIT 1",
+		QueryArgs: &args,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	defer func() { common.LogCloserError(logger, rows, "close rows") }()
+
+	var (
+		port  uint16
+		found bool
+	)
+
+	for rows.Next() {
+		if err = rows.Scan(&port); err != nil {
+			return 0, fmt.Errorf("rows scan: %w", err)
+		}
+
+		found = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("rows iteration: %w", err)
+	}
+
+	if !found {
+		return 0, fmt.Errorf("host '%s' is not a replica of cluster '%s'", host, clusterName)
+	}
+
+	return port, nil
+}
+
+// Default ports of ClickHouse interfaces
+const (
+	portNative       = 9000
+	portNativeSecure = 9440
+	portHTTP         = 8123
+	portHTTPS        = 8443
+)
+
+// mapReplicaPort converts the port from `system.clusters` (which is always the port of
+// the native interface, either plain or secure one) into the port of the interface
+// matching the protocol and the TLS settings of the data source instance.
+// Only the default ports can be mapped, non-default native ports are used as is.
+func mapReplicaPort(clusterPort uint16, dsi *api_common.TGenericDataSourceInstance) (uint32, error) {
+	if clusterPort != portNative && clusterPort != portNativeSecure {
+		if dsi.Protocol == api_common.EGenericProtocol_NATIVE {
+			return uint32(clusterPort), nil
+		}
+
+		return 0, fmt.Errorf("cannot map non-default native port %d to protocol '%v'", clusterPort, dsi.Protocol)
+	}
+
+	switch dsi.Protocol {
+	case api_common.EGenericProtocol_NATIVE:
+		if dsi.UseTls {
+			return portNativeSecure, nil
+		}
+
+		return portNative, nil
+	case api_common.EGenericProtocol_HTTP:
+		if dsi.UseTls {
+			return portHTTPS, nil
+		}
+
+		return portHTTP, nil
+	default:
+		return 0, fmt.Errorf("unexpected protocol '%v'", dsi.Protocol)
+	}
 }
 
 func (*connectionManager) Release(_ context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
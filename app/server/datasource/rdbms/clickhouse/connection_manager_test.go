@@ -0,0 +1,91 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+)
+
+func TestMapReplicaPort(t *testing.T) {
+	type testCase struct {
+		testName    string
+		clusterPort uint16
+		protocol    api_common.EGenericProtocol
+		useTLS      bool
+		expected    uint32
+		err         bool
+	}
+
+	tcs := []testCase{
+		{
+			testName:    "native",
+			clusterPort: portNative,
+			protocol:    api_common.EGenericProtocol_NATIVE,
+			expected:    portNative,
+		},
+		{
+			testName:    "native_tls",
+			clusterPort: portNative,
+			protocol:    api_common.EGenericProtocol_NATIVE,
+			useTLS:      true,
+			expected:    portNativeSecure,
+		},
+		{
+			testName:    "http",
+			clusterPort: portNativeSecure,
+			protocol:    api_common.EGenericProtocol_HTTP,
+			expected:    portHTTP,
+		},
+		{
+			testName:    "https",
+			clusterPort: portNative,
+			protocol:    api_common.EGenericProtocol_HTTP,
+			useTLS:      true,
+			expected:    portHTTPS,
+		},
+		{
+			testName:    "native_non_default",
+			clusterPort: 19000,
+			protocol:    api_common.EGenericProtocol_NATIVE,
+			expected:    19000,
+		},
+		{
+			testName:    "http_non_default",
+			clusterPort: 19000,
+			protocol:    api_common.EGenericProtocol_HTTP,
+			err:         true,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			dsi := &api_common.TGenericDataSourceInstance{Protocol: tc.protocol, UseTls: tc.useTLS}
+
+			actual, err := mapReplicaPort(tc.clusterPort, dsi)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestCheckShardTable(t *testing.T) {
+	engineArgs := &distributedEngineArgs{clusterName: "cluster", databaseName: "", tableName: "tab_local"}
+
+	shard := &TSplitDescription_TShard{DatabaseName: "db", TableName: "tab_local"}
+	require.NoError(t, checkShardTable(shard, engineArgs, "db"))
+
+	shard = &TSplitDescription_TShard{DatabaseName: "system", TableName: "tab_local"}
+	require.Error(t, checkShardTable(shard, engineArgs, "db"))
+
+	shard = &TSplitDescription_TShard{DatabaseName: "db", TableName: "users"}
+	require.Error(t, checkShardTable(shard, engineArgs, "db"))
+}
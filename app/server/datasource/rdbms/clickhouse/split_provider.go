@@ -0,0 +1,453 @@
+package clickhouse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var _ rdbms_utils.SplitProvider = (*SplitProvider)(nil)
+
+// SplitProvider cuts MergeTree family tables by partitions and Distributed tables by shards.
+// All other tables (and the tables that cannot be split for some reason) are read within a single split.
+type SplitProvider struct {
+	cfg *config.TClickHouseConfig_TSplitting
+}
+
+func (s SplitProvider) ListSplits(
+	params *rdbms_utils.ListSplitsParams,
+) error {
+	resultChan, slct, ctx, logger := params.ResultChan, params.Select, params.Ctx, params.Logger
+
+	if !s.cfg.GetSplitByPartitions() && !s.cfg.GetSplitByShards() {
+		return listSingleSplit(ctx, slct, resultChan)
+	}
+
+	var cs []rdbms_utils.Connection
+
+	err := params.MakeConnectionRetrier.Run(ctx, logger,
+		func() error {
+			var makeConnErr error
+
+			makeConnectionParams := &rdbms_utils.ConnectionParams{
+				Ctx:                ctx,
+				Logger:             logger,
+				DataSourceInstance: slct.GetDataSourceInstance(),
+				TableName:          slct.GetFrom().GetTable(),
+				QueryPhase:         rdbms_utils.QueryPhaseListSplits,
+			}
+
+			cs, makeConnErr = params.ConnectionManager.Make(makeConnectionParams)
+			if makeConnErr != nil {
+				return fmt.Errorf("make connection: %w", makeConnErr)
+			}
+
+			return nil
+		},
+	)
+
+	if err != nil {
+		return fmt.Errorf("retry: %w", err)
+	}
+
+	defer params.ConnectionManager.Release(ctx, logger, cs)
+
+	descriptions, err := s.makeSplitDescriptions(ctx, logger, cs[0], s.getMaxSplitCount(params.Request))
+	if err != nil {
+		return fmt.Errorf("make split descriptions: %w", err)
+	}
+
+	if len(descriptions) < 2 {
+		return listSingleSplit(ctx, slct, resultChan)
+	}
+
+	for _, description := range descriptions {
+		select {
+		case resultChan <- &datasource.ListSplitResult{Slct: slct, Description: description}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (s SplitProvider) MaySplitTables() bool {
+	return s.cfg.GetSplitByPartitions() || s.cfg.GetSplitByShards()
+}
+
+func (s SplitProvider) makeSplitDescriptions(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn rdbms_utils.Connection,
+	maxSplitCount uint64,
+) ([]*TSplitDescription, error) {
+	engine, engineFull, err := getTableEngine(ctx, logger, conn)
+	if err != nil {
+		return nil, fmt.Errorf("get table engine: %w", err)
+	}
+
+	logger.Info("table engine discovered", zap.String("engine", engine))
+
+	switch {
+	case engine == "Distributed" && s.cfg.GetSplitByShards():
+		descriptions, err := s.makeShardSplitDescriptions(ctx, logger, conn, engineFull)
+		if err != nil {
+			return nil, fmt.Errorf("make shard split descriptions: %w", err)
+		}
+
+		// Every shard must be read with a distinct connection, so it's impossible to merge shards
+		if maxSplitCount != 0 && uint64(len(descriptions)) > maxSplitCount {
+			logger.Warn(
+				"too many shards, fallback to default (single split per table)",
+				zap.Int("shards", len(descriptions)),
+				zap.Uint64("max_split_count", maxSplitCount),
+			)
+
+			return nil, nil
+		}
+
+		return descriptions, nil
+	case strings.HasSuffix(engine, "MergeTree") && s.cfg.GetSplitByPartitions():
+		partitionIDs, err := s.getPartitionIDs(ctx, logger, conn)
+		if err != nil {
+			return nil, fmt.Errorf("get partition ids: %w", err)
+		}
+
+		var descriptions []*TSplitDescription
+
+		for _, partitions := range makePartitionGroups(partitionIDs, maxSplitCount) {
+			descriptions = append(descriptions, &TSplitDescription{
+				Payload: &TSplitDescription_Partitions{Partitions: partitions},
+			})
+		}
+
+		return descriptions, nil
+	default:
+		return nil, nil
+	}
+}
+
+func getTableEngine(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn rdbms_utils.Connection,
+) (string, string, error) {
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(conn.TableName())
+
+	rows, err := conn.Query(&rdbms_utils.QueryParams{
+		Ctx:       ctx,
+		Logger:    logger,
+		QueryText: "SELECT engine, engine_full FROM system.tables WHERE database = currentDatabase() AND name = ?",
+		QueryArgs: &args,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("query: %w", err)
+	}
+
+	defer func() { common.LogCloserError(logger, rows, "close rows") }()
+
+	var engine, engineFull string
+
+	for rows.Next() {
+		if err = rows.Scan(&engine, &engineFull); err != nil {
+			return "", "", fmt.Errorf("rows scan: %w", err)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return "", "", fmt.Errorf("rows iteration: %w", err)
+	}
+
+	return engine, engineFull, nil
+}
+
+func (SplitProvider) getPartitionIDs(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn rdbms_utils.Connection,
+) ([]string, error) {
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(conn.TableName())
+
+	rows, err := conn.Query(&rdbms_utils.QueryParams{
+		Ctx:    ctx,
+		Logger: logger,
+		QueryText: "SELECT DISTINCT partition_id FROM system.parts " +
+			"WHERE database = currentDatabase() AND table = ? AND active ORDER BY partition_id",
+		QueryArgs: &args,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	defer func() { common.LogCloserError(logger, rows, "close rows") }()
+
+	var (
+		partitionID  string
+		partitionIDs []string
+	)
+
+	for rows.Next() {
+		if err = rows.Scan(&partitionID); err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+
+		partitionIDs = append(partitionIDs, partitionID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration: %w", err)
+	}
+
+	return partitionIDs, nil
+}
+
+func (SplitProvider) makeShardSplitDescriptions(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn rdbms_utils.Connection,
+	engineFull string,
+) ([]*TSplitDescription, error) {
+	engineArgs, err := parseDistributedEngineArgs(engineFull)
+	if err != nil {
+		return nil, fmt.Errorf("parse distributed engine args: %w", err)
+	}
+
+	dsi := conn.DataSourceInstance()
+
+	databaseName := engineArgs.databaseName
+	if databaseName == "" {
+		databaseName = dsi.Database
+	}
+
+	shards, err := getClusterShards(ctx, logger, conn, engineArgs.clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("get cluster shards: %w", err)
+	}
+
+	descriptions := make([]*TSplitDescription, 0, len(shards))
+
+	for _, shard := range shards {
+		// The connection manager repeats the mapping when it connects to the shard,
+		// the port in the description is informational.
+		port, err := mapReplicaPort(shard.port, dsi)
+		if err != nil {
+			logger.Warn("cannot determine shard port, fallback to default (single split per table)", zap.Error(err))
+
+			return nil, nil
+		}
+
+		endpoint := &api_common.TGenericEndpoint{Host: shard.host, Port: port}
+
+		descriptions = append(descriptions, &TSplitDescription{
+			Payload: &TSplitDescription_Shard{
+				Shard: &TSplitDescription_TShard{
+					Endpoint:     endpoint,
+					DatabaseName: databaseName,
+					TableName:    engineArgs.tableName,
+				},
+			},
+		})
+	}
+
+	return descriptions, nil
+}
+
+type clusterShard struct {
+	host string
+	port uint16
+}
+
+// getClusterShards returns the first replica of every shard of the cluster
+func getClusterShards(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn rdbms_utils.Connection,
+	clusterName string,
+) ([]clusterShard, error) {
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(clusterName)
+
+	rows, err := conn.Query(&rdbms_utils.QueryParams{
+		Ctx:       ctx,
+		Logger:    logger,
+		QueryText: "SELECT shard_num, host_name, port FROM system.clusters WHERE cluster = ? ORDER BY shard_num, replica_num",
+		QueryArgs: &args,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	defer func() { common.LogCloserError(logger, rows, "close rows") }()
+
+	var (
+		shardNum, lastShardNum uint32
+		shard                  clusterShard
+		shards                 []clusterShard
+	)
+
+	for rows.Next() {
+		if err = rows.Scan(&shardNum, &shard.host, &shard.port); err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+
+		// shard numbers start from 1
+		if shardNum != lastShardNum {
+			shards = append(shards, shard)
+			lastShardNum = shardNum
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration: %w", err)
+	}
+
+	return shards, nil
+}
+
+type distributedEngineArgs struct {
+	clusterName  string
+	databaseName string // empty if the database of the Distributed table should be used
+	tableName    string
+}
+
+// parseDistributedEngineArgs extracts the arguments from the engine definition like
+// `Distributed('cluster', 'database', 'table'[, sharding_key[, policy_name]])`.
+func parseDistributedEngineArgs(engineFull string) (*distributedEngineArgs, error) {
+	const prefix = "Distributed("
+
+	if !strings.HasPrefix(engineFull, prefix) {
+		return nil, fmt.Errorf("unexpected engine definition: '%s'", engineFull)
+	}
+
+	var (
+		args    []string
+		current strings.Builder
+		depth   int
+		quoted  bool
+	)
+
+	for _, r := range engineFull[len(prefix):] {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case quoted:
+			current.WriteRune(r)
+		case r == '(':
+			depth++
+
+			current.WriteRune(r)
+		case r == ')' && depth == 0, r == ',' && depth == 0:
+			args = append(args, strings.Trim(strings.TrimSpace(current.String()), "`\""))
+			current.Reset()
+
+			if r == ')' {
+				return makeDistributedEngineArgs(engineFull, args)
+			}
+		case r == ')':
+			depth--
+
+			current.WriteRune(r)
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	return nil, fmt.Errorf("unterminated engine definition: '%s'", engineFull)
+}
+
+func makeDistributedEngineArgs(engineFull string, args []string) (*distributedEngineArgs, error) {
+	if len(args) < 3 {
+		return nil, fmt.Errorf("not enough arguments in engine definition: '%s'", engineFull)
+	}
+
+	result := &distributedEngineArgs{
+		clusterName:  args[0],
+		databaseName: args[1],
+		tableName:    args[2],
+	}
+
+	// Database may be defined with an expression like `currentDatabase()`
+	if strings.Contains(result.databaseName, "(") {
+		result.databaseName = ""
+	}
+
+	return result, nil
+}
+
+func (s SplitProvider) getMaxSplitCount(request *api_service_protos.TListSplitsRequest) uint64 {
+	maxSplitCount := s.cfg.GetMaxSplitCount()
+	requested := uint64(request.GetMaxSplitCount())
+
+	if requested != 0 && (maxSplitCount == 0 || requested < maxSplitCount) {
+		maxSplitCount = requested
+	}
+
+	return maxSplitCount
+}
+
+// makePartitionGroups distributes partitions between the groups of approximately equal size.
+// The last group is defined as the complement to all the other groups,
+// so that the partitions created after the split listing are not lost.
+func makePartitionGroups(partitionIDs []string, maxSplitCount uint64) []*TSplitDescription_TPartitions {
+	groupCount := uint64(len(partitionIDs))
+	if maxSplitCount != 0 && groupCount > maxSplitCount {
+		groupCount = maxSplitCount
+	}
+
+	if groupCount < 2 {
+		return nil
+	}
+
+	step := (uint64(len(partitionIDs)) + groupCount - 1) / groupCount
+	groups := make([]*TSplitDescription_TPartitions, 0, groupCount)
+
+	var begin uint64
+
+	for ; begin+step < uint64(len(partitionIDs)); begin += step {
+		groups = append(groups, &TSplitDescription_TPartitions{
+			PartitionIds: partitionIDs[begin : begin+step],
+		})
+	}
+
+	groups = append(groups, &TSplitDescription_TPartitions{
+		PartitionIds: partitionIDs[:begin],
+		Exclude:      true,
+	})
+
+	return groups
+}
+
+func listSingleSplit(
+	ctx context.Context,
+	slct *api_service_protos.TSelect,
+	resultChan chan<- *datasource.ListSplitResult,
+) error {
+	select {
+	case resultChan <- &datasource.ListSplitResult{Slct: slct, Description: nil}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+func NewSplitProvider(cfg *config.TClickHouseConfig_TSplitting) SplitProvider {
+	return SplitProvider{
+		cfg: cfg,
+	}
+}
@@ -0,0 +1,107 @@
+package clickhouse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDistributedEngineArgs(t *testing.T) {
+	type testCase struct {
+		testName   string
+		engineFull string
+		expected   *distributedEngineArgs
+		err        bool
+	}
+
+	tcs := []testCase{
+		{
+			testName:   "minimal",
+			engineFull: "Distributed('cluster', 'db', 'tab_local')",
+			expected:   &distributedEngineArgs{clusterName: "cluster", databaseName: "db", tableName: "tab_local"},
+		},
+		{
+			testName:   "sharding_key_and_settings",
+			engineFull: "Distributed('cluster', 'db', 'tab_local', cityHash64(id, 'salt')) SETTINGS fsync_after_insert = 0",
+			expected:   &distributedEngineArgs{clusterName: "cluster", databaseName: "db", tableName: "tab_local"},
+		},
+		{
+			testName:   "current_database",
+			engineFull: "Distributed(cluster, currentDatabase(), tab_local, rand())",
+			expected:   &distributedEngineArgs{clusterName: "cluster", databaseName: "", tableName: "tab_local"},
+		},
+		{
+			testName:   "not_enough_arguments",
+			engineFull: "Distributed('cluster')",
+			err:        true,
+		},
+		{
+			testName:   "unterminated",
+			engineFull: "Distributed('cluster', 'db'",
+			err:        true,
+		},
+		{
+			testName:   "other_engine",
+			engineFull: "MergeTree ORDER BY id",
+			err:        true,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			actual, err := parseDistributedEngineArgs(tc.engineFull)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestMakePartitionGroups(t *testing.T) {
+	type testCase struct {
+		testName      string
+		partitionIDs  []string
+		maxSplitCount uint64
+		expected      [][]string
+	}
+
+	tcs := []testCase{
+		{
+			testName:     "single_partition",
+			partitionIDs: []string{"all"},
+			expected:     nil,
+		},
+		{
+			testName:     "partition_per_split",
+			partitionIDs: []string{"1", "2", "3"},
+			expected:     [][]string{{"1"}, {"2"}, {"1", "2"}},
+		},
+		{
+			testName:      "max_split_count",
+			partitionIDs:  []string{"1", "2", "3", "4", "5", "6"},
+			maxSplitCount: 3,
+			expected:      [][]string{{"1", "2"}, {"3", "4"}, {"1", "2", "3", "4"}},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			actual := makePartitionGroups(tc.partitionIDs, tc.maxSplitCount)
+			require.Len(t, actual, len(tc.expected))
+
+			for i, group := range actual {
+				require.Equal(t, tc.expected[i], group.PartitionIds)
+				// only the last group is defined as the complement
+				require.Equal(t, i == len(actual)-1, group.Exclude)
+			}
+		})
+	}
+}
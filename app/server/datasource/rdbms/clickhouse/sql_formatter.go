@@ -1,13 +1,17 @@
 package clickhouse
 
 import (
+	"fmt"
 	"strings"
 
+	"google.golang.org/protobuf/encoding/protojson"
+
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ rdbms_utils.SQLFormatter = (*sqlFormatter)(nil)
@@ -50,6 +54,8 @@ func (f *sqlFormatter) supportsType(typeID Ydb.Type_PrimitiveTypeId) bool {
 		return false
 	case Ydb.Type_TIMESTAMP:
 		return f.cfg.EnableTimestampPushdown
+	case Ydb.Type_DATE:
+		return f.cfg.EnableTimestampPushdown
 	default:
 		return false
 	}
@@ -76,6 +82,8 @@ func (f sqlFormatter) SupportsExpression(expression *api_service_protos.TExpress
 		return false
 	case *api_service_protos.TExpression_Null:
 		return true
+	case *api_service_protos.TExpression_DateTimeFunction:
+		return f.SupportsExpression(e.DateTimeFunction.Value)
 	default:
 		return false
 	}
@@ -97,10 +105,168 @@ func (f sqlFormatter) FormatWhat(what *api_service_protos.TSelect_TWhat, _ strin
 	return rdbms_utils.FormatWhatDefault(f, what), nil
 }
 
+// ClickHouse places NULL values after the others in ascending order
+func (f sqlFormatter) FormatOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return rdbms_utils.FormatOrderByDefault(f, orderBy, true)
+}
+
+func (f sqlFormatter) MaxInSetParameters() int {
+	return rdbms_utils.MaxInSetParametersFromConfig(f.cfg)
+}
+
+func (sqlFormatter) LargeInSetMode() rdbms_utils.LargeInSetMode {
+	return rdbms_utils.LargeInSetModeArray
+}
+
+// FormatInArray passes the large IN sets as a single parameter, which is bound by the driver as an array.
+// `has` function is used because the right side of IN operator must be a tuple.
+func (sqlFormatter) FormatInArray(value, arrayPlaceholder string) (string, error) {
+	return fmt.Sprintf("has(%s, %s)", arrayPlaceholder, value), nil
+}
+
+func (sqlFormatter) FormatDateTimeFunction(
+	function api_service_protos.TExpression_TDateTimeFunction_EFunction,
+	value string,
+) (string, error) {
+	switch function {
+	case api_service_protos.TExpression_TDateTimeFunction_TO_DATE:
+		return fmt.Sprintf("toDate(%s)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_YEAR:
+		return fmt.Sprintf("toYear(%s)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_MONTH:
+		return fmt.Sprintf("toMonth(%s)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_DAY_OF_MONTH:
+		return fmt.Sprintf("toDayOfMonth(%s)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_HOUR:
+		return fmt.Sprintf("toHour(%s)", value), nil
+	default:
+		return "", fmt.Errorf("function %v: %w", function, common.ErrUnimplementedOperation)
+	}
+}
+
+// FormatComputedColumn converts the expression to the inferred type, because ClickHouse
+// widens the results of arithmetical expressions (e.g. Int32 * Int32 is Int64).
+func (sqlFormatter) FormatComputedColumn(expression string, ydbType *Ydb.Type, name string) (string, error) {
+	typeName, err := castTypeName(ydbType)
+	if err != nil {
+		return "", fmt.Errorf("cast type name: %w", err)
+	}
+
+	return fmt.Sprintf("CAST(%s AS %s) AS %s", expression, typeName, name), nil
+}
+
+// castTypeName returns the name of ClickHouse type that is mapped back to the given YDB type
+func castTypeName(ydbType *Ydb.Type) (string, error) {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		typeName, err := castTypeName(optionalType.Item)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("Nullable(%s)", typeName), nil
+	}
+
+	if decimalType := ydbType.GetDecimalType(); decimalType != nil {
+		return fmt.Sprintf("Decimal(%d, %d)", decimalType.Precision, decimalType.Scale), nil
+	}
+
+	switch ydbType.GetTypeId() {
+	case Ydb.Type_BOOL:
+		return "Bool", nil
+	case Ydb.Type_INT8:
+		return "Int8", nil
+	case Ydb.Type_UINT8:
+		return "UInt8", nil
+	case Ydb.Type_INT16:
+		return "Int16", nil
+	case Ydb.Type_UINT16:
+		return "UInt16", nil
+	case Ydb.Type_INT32:
+		return "Int32", nil
+	case Ydb.Type_UINT32:
+		return "UInt32", nil
+	case Ydb.Type_INT64:
+		return "Int64", nil
+	case Ydb.Type_UINT64:
+		return "UInt64", nil
+	case Ydb.Type_FLOAT:
+		return "Float32", nil
+	case Ydb.Type_DOUBLE:
+		return "Float64", nil
+	case Ydb.Type_STRING:
+		return "String", nil
+	case Ydb.Type_DATE:
+		return "Date", nil
+	case Ydb.Type_DATETIME:
+		return "DateTime", nil
+	case Ydb.Type_TIMESTAMP:
+		return "DateTime64(6)", nil
+	default:
+		return "", fmt.Errorf("type %v: %w", ydbType, common.ErrUnsupportedExpression)
+	}
+}
+
 func (f sqlFormatter) FormatFrom(tableName string) string {
 	return f.SanitiseIdentifier(tableName)
 }
 
+// RenderSelectQueryText restricts the query with the subset of partitions
+// if the table has been split by the split provider.
+func (f sqlFormatter) RenderSelectQueryText(
+	parts *rdbms_utils.SelectQueryParts,
+	split *api_service_protos.TSplit,
+) (string, error) {
+	if len(split.GetDescription()) == 0 {
+		return f.SQLFormatterDefault.RenderSelectQueryText(parts, split)
+	}
+
+	var splitDescription TSplitDescription
+
+	if err := protojson.Unmarshal(split.GetDescription(), &splitDescription); err != nil {
+		return "", fmt.Errorf("unmarshal split description: %w", err)
+	}
+
+	// Shards are handled by the connection manager, the query itself stays the same
+	partitions := splitDescription.GetPartitions()
+	if partitions == nil {
+		return f.SQLFormatterDefault.RenderSelectQueryText(parts, split)
+	}
+
+	// An empty exclusion list means the whole table, so no condition is needed
+	if len(partitions.PartitionIds) == 0 {
+		if !partitions.Exclude {
+			return "", fmt.Errorf("empty list of partitions")
+		}
+
+		return f.SQLFormatterDefault.RenderSelectQueryText(parts, split)
+	}
+
+	partitionIDs := make([]string, 0, len(partitions.PartitionIds))
+	for _, partitionID := range partitions.PartitionIds {
+		partitionIDs = append(partitionIDs, quoteString(partitionID))
+	}
+
+	operator := "IN"
+	if partitions.Exclude {
+		operator = "NOT IN"
+	}
+
+	condition := fmt.Sprintf("_partition_id %s (%s)", operator, strings.Join(partitionIDs, ", "))
+
+	modifiedParts := *parts
+	if parts.WhereClause != "" {
+		modifiedParts.WhereClause = "(" + parts.WhereClause + ") AND " + condition
+	} else {
+		modifiedParts.WhereClause = condition
+	}
+
+	return f.SQLFormatterDefault.RenderSelectQueryText(&modifiedParts, split)
+}
+
+func quoteString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
 func NewSQLFormatter(cfg *config.TPushdownConfig) rdbms_utils.SQLFormatter {
 	return sqlFormatter{cfg: cfg}
 }
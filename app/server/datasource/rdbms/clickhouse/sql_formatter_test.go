@@ -6,11 +6,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	ydb "github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
@@ -456,6 +458,168 @@ func TestMakeSelectQuery(t *testing.T) {
 			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32)},
 			err:            nil,
 		},
+		{
+			testName: "limit",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_CLICKHOUSE,
+				},
+			},
+			outputQuery:    `SELECT "col0", "col1" FROM "tab" LIMIT 10`,
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "limit_offset",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 5,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_CLICKHOUSE,
+				},
+			},
+			outputQuery:    `SELECT "col0", "col1" FROM "tab" LIMIT 10 OFFSET 5`,
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "limit_with_filter",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_IsNull{
+							IsNull: &api_service_protos.TPredicate_TIsNull{
+								Value: rdbms_utils.NewColumnExpression("col1"),
+							},
+						},
+					},
+				},
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 5,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_CLICKHOUSE,
+				},
+			},
+			outputQuery:    `SELECT "col0", "col1" FROM "tab" WHERE ("col1" IS NULL) LIMIT 10 OFFSET 5`,
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "limit_with_unsupported_predicate",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_Between{
+							Between: &api_service_protos.TPredicate_TBetween{
+								Value:    rdbms_utils.NewColumnExpression("col2"),
+								Least:    rdbms_utils.NewColumnExpression("col1"),
+								Greatest: rdbms_utils.NewColumnExpression("col3"),
+							},
+						},
+					},
+				},
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 5,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_CLICKHOUSE,
+				},
+			},
+			outputQuery:    `SELECT "col0", "col1" FROM "tab"`,
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "date_time_function",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_Comparison{
+							Comparison: &api_service_protos.TPredicate_TComparison{
+								Operation: api_service_protos.TPredicate_TComparison_EQ,
+								LeftValue: &api_service_protos.TExpression{
+									Payload: &api_service_protos.TExpression_DateTimeFunction{
+										DateTimeFunction: &api_service_protos.TExpression_TDateTimeFunction{
+											Function: api_service_protos.TExpression_TDateTimeFunction_GET_YEAR,
+											Value:    rdbms_utils.NewColumnExpression("col2"),
+										},
+									},
+								},
+								RightValue: &api_service_protos.TExpression{
+									Payload: &api_service_protos.TExpression_TypedValue{
+										TypedValue: &ydb.TypedValue{
+											Type:  common.MakePrimitiveType(ydb.Type_UINT16),
+											Value: &ydb.Value{Value: &ydb.Value_Uint32Value{Uint32Value: 2024}},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_CLICKHOUSE,
+				},
+			},
+			outputQuery:    `SELECT "col0", "col1" FROM "tab" WHERE (toYear("col2") = ?)`,
+			outputArgs:     []any{uint32(2024)},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "order_by_limit",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What:    rdbms_utils.NewDefaultWhat(),
+				OrderBy: rdbms_utils.NewDefaultOrderBy(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_CLICKHOUSE,
+				},
+			},
+			outputQuery:    `SELECT "col0", "col1" FROM "tab" ORDER BY "col0" DESC NULLS LAST, "col1" ASC NULLS FIRST LIMIT 10`,
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
 	}
 
 	for _, tc := range tcs {
@@ -481,3 +645,233 @@ func TestMakeSelectQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeSelectQueryWithSplitDescription(t *testing.T) {
+	type testCase struct {
+		testName    string
+		where       *api_service_protos.TSelect_TWhere
+		description *TSplitDescription
+		outputQuery string
+	}
+
+	logger := common.NewTestLogger(t)
+	formatter := NewSQLFormatter(nil)
+
+	tcs := []testCase{
+		{
+			testName: "partitions",
+			description: &TSplitDescription{
+				Payload: &TSplitDescription_Partitions{
+					Partitions: &TSplitDescription_TPartitions{PartitionIds: []string{"202401", "202402"}},
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE _partition_id IN ('202401', '202402')`,
+		},
+		{
+			testName: "excluded_partitions_with_filter",
+			where: &api_service_protos.TSelect_TWhere{
+				FilterTyped: &api_service_protos.TPredicate{
+					Payload: &api_service_protos.TPredicate_IsNull{
+						IsNull: &api_service_protos.TPredicate_TIsNull{
+							Value: rdbms_utils.NewColumnExpression("col1"),
+						},
+					},
+				},
+			},
+			description: &TSplitDescription{
+				Payload: &TSplitDescription_Partitions{
+					Partitions: &TSplitDescription_TPartitions{PartitionIds: []string{"202401"}, Exclude: true},
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE (("col1" IS NULL)) AND _partition_id NOT IN ('202401')`,
+		},
+		{
+			testName: "shard",
+			description: &TSplitDescription{
+				Payload: &TSplitDescription_Shard{
+					Shard: &TSplitDescription_TShard{
+						Endpoint:     &api_common.TGenericEndpoint{Host: "shard1", Port: 9000},
+						DatabaseName: "db",
+						TableName:    "tab",
+					},
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM "tab"`,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			description, err := protojson.Marshal(tc.description)
+			require.NoError(t, err)
+
+			split := &api_service_protos.TSplit{
+				Select: &api_service_protos.TSelect{
+					From:  &api_service_protos.TSelect_TFrom{Table: "tab"},
+					What:  rdbms_utils.NewDefaultWhat(),
+					Where: tc.where,
+					DataSourceInstance: &api_common.TGenericDataSourceInstance{
+						Kind: api_common.EGenericDataSourceKind_CLICKHOUSE,
+					},
+				},
+				Payload: &api_service_protos.TSplit_Description{Description: description},
+			}
+
+			readSplitsQuery, err := rdbms_utils.MakeSelectQuery(
+				context.Background(),
+				logger, formatter,
+				split,
+				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+				"tab",
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputQuery, readSplitsQuery.QueryText)
+		})
+	}
+}
+
+func TestFormatComputedColumn(t *testing.T) {
+	type testCase struct {
+		testName string
+		ydbType  *ydb.Type
+		output   string
+		err      error
+	}
+
+	formatter := NewSQLFormatter(nil)
+
+	tcs := []testCase{
+		{
+			testName: "int32",
+			ydbType:  common.MakePrimitiveType(ydb.Type_INT32),
+			output:   `CAST(("col0" * "col1") AS Int32) AS "product"`,
+		},
+		{
+			testName: "optional_uint16",
+			ydbType:  common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_UINT16)),
+			output:   `CAST(("col0" * "col1") AS Nullable(UInt16)) AS "product"`,
+		},
+		{
+			testName: "decimal",
+			ydbType:  common.MakeDecimalType(10, 2),
+			output:   `CAST(("col0" * "col1") AS Decimal(10, 2)) AS "product"`,
+		},
+		{
+			testName: "optional_date",
+			ydbType:  common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_DATE)),
+			output:   `CAST(("col0" * "col1") AS Nullable(Date)) AS "product"`,
+		},
+		{
+			testName: "utf8",
+			ydbType:  common.MakePrimitiveType(ydb.Type_UTF8),
+			err:      common.ErrUnsupportedExpression,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			output, err := formatter.FormatComputedColumn(`("col0" * "col1")`, tc.ydbType, `"product"`)
+			if tc.err != nil {
+				require.True(t, errors.Is(err, tc.err), err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestMakeSelectQueryWithInSet(t *testing.T) {
+	type testCase struct {
+		testName    string
+		filter      *api_service_protos.TPredicate
+		outputQuery string
+		outputArgs  []any
+	}
+
+	logger := common.NewTestLogger(t)
+	formatter := NewSQLFormatter(&config.TPushdownConfig{MaxInSetParameters: 2})
+
+	tcs := []testCase{
+		{
+			testName: "separate_parameters",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE ("col0" IN (?, ?))`,
+			outputArgs:  []any{int32(1), int32(2)},
+		},
+		{
+			testName: "array",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+				rdbms_utils.NewInt32ValueExpression(3),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE has(?, "col0")`,
+			outputArgs:  []any{[]int32{1, 2, 3}},
+		},
+		{
+			// the large set cannot be made of the values of different types, so the predicate is not pushed down
+			testName: "array_of_different_types",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+				rdbms_utils.NewInt64ValueExpression(3),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab"`,
+			outputArgs:  []any{},
+		},
+		{
+			// the limit on the number of parameters applies to the whole query
+			testName: "parameters_per_query",
+			filter: rdbms_utils.NewConjunctionPredicate(
+				rdbms_utils.NewInPredicate("col0",
+					rdbms_utils.NewInt32ValueExpression(1),
+					rdbms_utils.NewInt32ValueExpression(2),
+				),
+				rdbms_utils.NewInPredicate("col0",
+					rdbms_utils.NewInt32ValueExpression(3),
+					rdbms_utils.NewInt32ValueExpression(4),
+				),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE (("col0" IN (?, ?)) AND has(?, "col0"))`,
+			outputArgs:  []any{int32(1), int32(2), []int32{3, 4}},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			split := &api_service_protos.TSplit{
+				Select: &api_service_protos.TSelect{
+					From:  &api_service_protos.TSelect_TFrom{Table: "tab"},
+					What:  rdbms_utils.NewDefaultWhat(),
+					Where: &api_service_protos.TSelect_TWhere{FilterTyped: tc.filter},
+					DataSourceInstance: &api_common.TGenericDataSourceInstance{
+						Kind: api_common.EGenericDataSourceKind_CLICKHOUSE,
+					},
+				},
+			}
+
+			readSplitsQuery, err := rdbms_utils.MakeSelectQuery(
+				context.Background(),
+				logger,
+				formatter,
+				split,
+				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+				"tab",
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputQuery, readSplitsQuery.QueryText)
+			require.Equal(t, tc.outputArgs, readSplitsQuery.QueryArgs.Values())
+		})
+	}
+}
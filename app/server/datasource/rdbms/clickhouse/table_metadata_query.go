@@ -15,3 +15,13 @@ func TableMetadataQuery(request *api_service_protos.TDescribeTableRequest) (stri
 
 	return query, &args
 }
+
+func TableListQuery(request *api_service_protos.TListTablesRequest) (string, *rdbms_utils.QueryArgs) {
+	query := "SELECT name FROM system.tables WHERE database = ? AND NOT is_temporary ORDER BY name"
+
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(request.DataSourceInstance.Database)
+
+	return query, &args
+}
@@ -2,10 +2,15 @@ package clickhouse
 
 import (
 	"fmt"
+	"net"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
@@ -13,24 +18,43 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ datasource.TypeMapper = typeMapper{}
 
 type typeMapper struct {
-	isFixedString *regexp.Regexp
-	isDateTime    *regexp.Regexp
-	isDateTime64  *regexp.Regexp
-	isNullable    *regexp.Regexp
-	isArray       *regexp.Regexp
+	isFixedString     *regexp.Regexp
+	isDateTime        *regexp.Regexp
+	isDateTime64      *regexp.Regexp
+	isNullable        *regexp.Regexp
+	isArray           *regexp.Regexp
+	isLowCardinality  *regexp.Regexp
+	isDecimal         *regexp.Regexp
+	isEnum            *regexp.Regexp
+	isMap             *regexp.Regexp
+	isTuple           *regexp.Regexp
+	isNamedTupleField *regexp.Regexp
 }
 
-//nolint:gocyclo
 func (tm typeMapper) SQLTypeToYDBColumn(
 	columnName, typeName string,
 	rules *api_service_protos.TTypeMappingSettings,
 ) (*Ydb.Column, error) {
+	ydbType, err := tm.sqlTypeToYDBType(typeName, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Ydb.Column{
+		Name: columnName,
+		Type: ydbType,
+	}, nil
+}
+
+//nolint:gocyclo
+func (tm typeMapper) sqlTypeToYDBType(typeName string, rules *api_service_protos.TTypeMappingSettings) (*Ydb.Type, error) {
 	var (
 		ydbType *Ydb.Type
 		err     error
@@ -43,39 +67,42 @@ func (tm typeMapper) SQLTypeToYDBColumn(
 	// 2. The column type is a date/time. CH value ranges for date/time are much wider than YQL value ranges,
 	// so every time we encounter a value that is out of YQL ranges, we have to return NULL.
 	nullable := false
-	arrayContainer := false
-	innerNullable := false
 
-	if matches := tm.isNullable.FindStringSubmatch(typeName); len(matches) > 0 {
-		nullable = true
+	// LowCardinality affects only the way the values are stored, so it's transparent for the type mapping
+	if matches := tm.isLowCardinality.FindStringSubmatch(typeName); len(matches) > 0 {
 		typeName = matches[1]
 	}
 
-	if matches := tm.isArray.FindStringSubmatch(typeName); len(matches) > 0 {
-		arrayContainer = true
+	if matches := tm.isNullable.FindStringSubmatch(typeName); len(matches) > 0 {
+		nullable = true
 		typeName = matches[1]
+	}
 
-		if matches := tm.isNullable.FindStringSubmatch(typeName); len(matches) > 0 {
-			innerNullable = true
-			typeName = matches[1]
-		}
+	// Arrays, maps and tuples are mapped to lists, dicts and tuples (or structs if the tuple elements are named);
+	// the same rules are applied to the types of their items, so Array(Nullable(T)) becomes List<Optional<T>>.
+	ydbType, err = tm.compositeSQLTypeToYDBType(typeName, rules)
+	if err != nil {
+		return nil, err
 	}
 
-	if arrayContainer {
+	if ydbType != nil {
 		if nullable {
-			return nil, fmt.Errorf("convert type '%s' (nullable array is not supported): %w",
-				typeName, common.ErrDataTypeNotSupported)
-		} else if innerNullable {
-			return nil, fmt.Errorf("convert type '%s' (array with nullable elements is not supported): %w",
-				typeName, common.ErrDataTypeNotSupported)
+			ydbType = common.MakeOptionalType(ydbType)
 		}
 
-		return nil, fmt.Errorf("convert type '%s' (array is not supported): %w",
-			typeName, common.ErrDataTypeNotSupported)
+		return ydbType, nil
 	}
 
 	// Reference table: https://github.com/ydb-platform/fq-connector-go/blob/main/docs/type_mapping_table.md
 	switch { // JSON needs custom parser, has composite type name structure. Possible to parse into Arrow struct
+	case tm.isDecimal.MatchString(typeName):
+		matches := tm.isDecimal.FindStringSubmatch(typeName)
+		ydbType, err = decimalToYDBType(matches[1], matches[2])
+	case typeName == typeUUID:
+		ydbType = common.MakePrimitiveType(Ydb.Type_UUID)
+	// Enum values (which may contain any type names) and IP addresses are passed in their textual representation
+	case tm.isEnum.MatchString(typeName), typeName == typeIPv4, typeName == typeIPv6:
+		ydbType = common.MakePrimitiveType(Ydb.Type_UTF8)
 	case typeName == "Bool":
 		ydbType = common.MakePrimitiveType(Ydb.Type_BOOL)
 	case typeName == "Int8":
@@ -127,51 +154,332 @@ func (tm typeMapper) SQLTypeToYDBColumn(
 		ydbType = common.MakeOptionalType(ydbType)
 	}
 
-	return &Ydb.Column{
-		Name: columnName,
-		Type: ydbType,
-	}, nil
+	return ydbType, nil
+}
+
+// compositeSQLTypeToYDBType returns nil if the type is not a composite one
+func (tm typeMapper) compositeSQLTypeToYDBType(typeName string, rules *api_service_protos.TTypeMappingSettings) (*Ydb.Type, error) {
+	if matches := tm.isArray.FindStringSubmatch(typeName); len(matches) > 0 {
+		itemType, err := tm.sqlTypeToYDBType(matches[1], rules)
+		if err != nil {
+			return nil, fmt.Errorf("convert array item type: %w", err)
+		}
+
+		return common.MakeListType(itemType), nil
+	}
+
+	if matches := tm.isMap.FindStringSubmatch(typeName); len(matches) > 0 {
+		args := splitTypeArguments(matches[1])
+		if len(args) != 2 {
+			return nil, fmt.Errorf("unexpected number of map type arguments in '%s'", typeName)
+		}
+
+		keyType, err := tm.sqlTypeToYDBType(args[0], rules)
+		if err != nil {
+			return nil, fmt.Errorf("convert map key type: %w", err)
+		}
+
+		valueType, err := tm.sqlTypeToYDBType(args[1], rules)
+		if err != nil {
+			return nil, fmt.Errorf("convert map value type: %w", err)
+		}
+
+		return common.MakeDictType(keyType, valueType), nil
+	}
+
+	if matches := tm.isTuple.FindStringSubmatch(typeName); len(matches) > 0 {
+		names, elementTypeNames := tm.parseTupleElements(matches[1])
+
+		elementTypes := make([]*Ydb.Type, 0, len(elementTypeNames))
+
+		for i, elementTypeName := range elementTypeNames {
+			elementType, err := tm.sqlTypeToYDBType(elementTypeName, rules)
+			if err != nil {
+				return nil, fmt.Errorf("convert tuple element #%d type: %w", i, err)
+			}
+
+			elementTypes = append(elementTypes, elementType)
+		}
+
+		if names == nil {
+			return common.MakeTupleType(elementTypes), nil
+		}
+
+		members := make([]*Ydb.StructMember, 0, len(elementTypes))
+		for i, elementType := range elementTypes {
+			members = append(members, &Ydb.StructMember{Name: names[i], Type: elementType})
+		}
+
+		return common.MakeStructType(members), nil
+	}
+
+	return nil, nil
+}
+
+// parseTupleElements splits tuple type arguments into element names and types;
+// names are returned only if all the elements are named, like in `Tuple(a Int32, b String)`.
+func (tm typeMapper) parseTupleElements(args string) ([]string, []string) {
+	typeNames := splitTypeArguments(args)
+	names := make([]string, 0, len(typeNames))
+
+	for _, typeName := range typeNames {
+		matches := tm.isNamedTupleField.FindStringSubmatch(typeName)
+		if len(matches) == 0 {
+			return nil, typeNames
+		}
+
+		names = append(names, matches[1])
+	}
+
+	for i, typeName := range typeNames {
+		typeNames[i] = tm.isNamedTupleField.FindStringSubmatch(typeName)[2]
+	}
+
+	return names, typeNames
+}
+
+// splitTypeArguments splits the comma-separated arguments of a parametric type
+// ignoring the commas within nested types and string literals
+func splitTypeArguments(args string) []string {
+	var (
+		result []string
+		depth  int
+		quoted bool
+		start  int
+	)
+
+	for i := 0; i < len(args); i++ {
+		switch c := args[i]; {
+		case c == '\\' && quoted:
+			i++
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			result = append(result, strings.TrimSpace(args[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(result, strings.TrimSpace(args[start:]))
+}
+
+// decimalToYDBType maps Decimal(p,s) to YDB Decimal if it fits its precision, otherwise to string
+func decimalToYDBType(precisionStr, scaleStr string) (*Ydb.Type, error) {
+	precision, err := strconv.ParseUint(precisionStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parse precision: %w", err)
+	}
+
+	scale, err := strconv.ParseUint(scaleStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parse scale: %w", err)
+	}
+
+	if precision > common.DecimalMaxPrecision {
+		return common.MakePrimitiveType(Ydb.Type_UTF8), nil
+	}
+
+	return common.MakeDecimalType(uint32(precision), uint32(scale)), nil
 }
 
 func transformerFromSQLTypes(typeNames []string, ydbTypes []*Ydb.Type, cc conversion.Collection) (paging.RowTransformer[any], error) {
 	acceptors := make([]any, 0, len(typeNames))
 	appenders := make([]func(acceptor any, builder array.Builder) error, 0, len(typeNames))
 
-	tm := typeMapper{
-		isFixedString: regexp.MustCompile(`FixedString\([0-9]+\)`),
-		isDateTime:    regexp.MustCompile(`DateTime(\('[\w,/]+'\))?`),
-		isDateTime64:  regexp.MustCompile(`DateTime64\(\d{1}(, '[\w,/]+')?\)`),
-		isNullable:    regexp.MustCompile(`Nullable\((.+)\)`),
-		isArray:       regexp.MustCompile(`Array\((.+)\)`),
+	tm := newTypeMapper()
+
+	for i, typeName := range typeNames {
+		acceptor, appender, err := makeAcceptorAppender(typeName, ydbTypes[i], cc, tm)
+		if err != nil {
+			return nil, fmt.Errorf("make acceptor and appender for type '%s': %w", typeName, err)
+		}
+
+		acceptors = append(acceptors, acceptor)
+		appenders = append(appenders, appender)
 	}
 
+	return paging.NewRowTransformer[any](acceptors, appenders, nil), nil
+}
+
+func makeAcceptorAppender(
+	typeName string,
+	ydbType *Ydb.Type,
+	cc conversion.Collection,
+	tm typeMapper,
+) (any, func(acceptor any, builder array.Builder) error, error) {
 	var (
-		nullable bool
-		err      error
+		acceptors []any
+		appenders []func(acceptor any, builder array.Builder) error
+		nullable  bool
+		err       error
 	)
 
-	for i, typeName := range typeNames {
-		nullable = false
+	// LowCardinality values are scanned just like the values of the underlying type
+	if matches := tm.isLowCardinality.FindStringSubmatch(typeName); len(matches) > 0 {
+		typeName = matches[1]
+	}
+
+	if matches := tm.isNullable.FindStringSubmatch(typeName); len(matches) > 0 {
+		typeName = matches[1]
+		nullable = true
+	}
+
+	if matches := tm.isArray.FindStringSubmatch(typeName); len(matches) > 0 {
+		return makeListAcceptorAppender(matches[1], ydbType, nullable, cc, tm)
+	}
+
+	// ClickHouse doesn't allow maps and tuples to be nullable, so there is no need to check it
+	if matches := tm.isMap.FindStringSubmatch(typeName); len(matches) > 0 {
+		return makeMapAcceptorAppender(matches[1], ydbType, cc, tm)
+	}
 
-		if matches := tm.isNullable.FindStringSubmatch(typeName); len(matches) > 0 {
-			typeName = matches[1]
-			nullable = true
+	if matches := tm.isTuple.FindStringSubmatch(typeName); len(matches) > 0 {
+		return makeTupleAcceptorAppender(matches[1], ydbType, cc, tm)
+	}
+
+	if nullable {
+		acceptors, appenders, err = addAcceptorAppenderFromSQLTypeNameNullable(typeName, ydbType, acceptors, appenders, cc, tm)
+		if err != nil {
+			return nil, nil, fmt.Errorf("nullable: %w", err)
+		}
+	} else {
+		acceptors, appenders, err = addAcceptorAppenderFromSQLTypeName(typeName, ydbType, acceptors, appenders, cc, tm)
+		if err != nil {
+			return nil, nil, fmt.Errorf("nonnullable: %w", err)
 		}
+	}
 
-		if nullable {
-			acceptors, appenders, err = addAcceptorAppenderFromSQLTypeNameNullable(typeName, ydbTypes[i], acceptors, appenders, cc, tm)
-			if err != nil {
-				return nil, fmt.Errorf("nullable: %w", err)
-			}
-		} else {
-			acceptors, appenders, err = addAcceptorAppenderFromSQLTypeName(typeName, ydbTypes[i], acceptors, appenders, cc, tm)
-			if err != nil {
-				return nil, fmt.Errorf("nonnullable: %w", err)
-			}
+	return acceptors[0], appenders[0], nil
+}
+
+func makeListAcceptorAppender(
+	itemTypeName string,
+	ydbType *Ydb.Type,
+	nullable bool,
+	cc conversion.Collection,
+	tm typeMapper,
+) (any, func(acceptor any, builder array.Builder) error, error) {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	listType := ydbType.GetListType()
+	if listType == nil {
+		return nil, nil, fmt.Errorf("unexpected ydb type %v for array: %w", ydbType, common.ErrDataTypeNotSupported)
+	}
+
+	itemAcceptor, itemAppender, err := makeAcceptorAppender(itemTypeName, listType.Item, cc, tm)
+	if err != nil {
+		return nil, nil, fmt.Errorf("array item: %w", err)
+	}
+
+	return utils.MakeListAcceptor(itemAcceptor, nullable), utils.MakeListAppender(itemAppender, nullable), nil
+}
+
+func makeMapAcceptorAppender(
+	argsTypeName string,
+	ydbType *Ydb.Type,
+	cc conversion.Collection,
+	tm typeMapper,
+) (any, func(acceptor any, builder array.Builder) error, error) {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	dictType := ydbType.GetDictType()
+	if dictType == nil {
+		return nil, nil, fmt.Errorf("unexpected ydb type %v for map: %w", ydbType, common.ErrDataTypeNotSupported)
+	}
+
+	args := splitTypeArguments(argsTypeName)
+	if len(args) != 2 {
+		return nil, nil, fmt.Errorf("unexpected number of map type arguments in '%s'", argsTypeName)
+	}
+
+	keyAcceptor, keyAppender, err := makeAcceptorAppender(args[0], dictType.Key, cc, tm)
+	if err != nil {
+		return nil, nil, fmt.Errorf("map key: %w", err)
+	}
+
+	valueAcceptor, valueAppender, err := makeAcceptorAppender(args[1], dictType.Payload, cc, tm)
+	if err != nil {
+		return nil, nil, fmt.Errorf("map value: %w", err)
+	}
+
+	return utils.MakeMapAcceptor(keyAcceptor, valueAcceptor), utils.MakeMapAppender(keyAppender, valueAppender), nil
+}
+
+// makeTupleAcceptorAppender makes the acceptor of the type that ClickHouse driver uses for tuples:
+// []any for the tuples with unnamed elements and map[string]any for the tuples with named elements.
+func makeTupleAcceptorAppender(
+	argsTypeName string,
+	ydbType *Ydb.Type,
+	cc conversion.Collection,
+	tm typeMapper,
+) (any, func(acceptor any, builder array.Builder) error, error) {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	names, elementTypeNames := tm.parseTupleElements(argsTypeName)
+
+	var elementTypes []*Ydb.Type
+
+	switch {
+	case names == nil && ydbType.GetTupleType() != nil:
+		elementTypes = ydbType.GetTupleType().Elements
+	case names != nil && ydbType.GetStructType() != nil:
+		for _, member := range ydbType.GetStructType().Members {
+			elementTypes = append(elementTypes, member.Type)
 		}
+	default:
+		return nil, nil, fmt.Errorf("unexpected ydb type %v for tuple: %w", ydbType, common.ErrDataTypeNotSupported)
 	}
 
-	return paging.NewRowTransformer[any](acceptors, appenders, nil), nil
+	if len(elementTypes) != len(elementTypeNames) {
+		return nil, nil, fmt.Errorf("expected %d tuple elements, got %d", len(elementTypes), len(elementTypeNames))
+	}
+
+	acceptors := make([]any, 0, len(elementTypes))
+	appenders := make([]func(acceptor any, builder array.Builder) error, 0, len(elementTypes))
+
+	for i, elementTypeName := range elementTypeNames {
+		acceptor, appender, err := makeAcceptorAppender(elementTypeName, elementTypes[i], cc, tm)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tuple element #%d: %w", i, err)
+		}
+
+		acceptors = append(acceptors, acceptor)
+		appenders = append(appenders, appender)
+	}
+
+	if names == nil {
+		appender := func(acceptor any, builder array.Builder) error {
+			//nolint:forcetypeassert
+			return utils.AppendItemsToStructBuilder(*acceptor.(*[]any), acceptors, appenders, builder.(*array.StructBuilder))
+		}
+
+		return new([]any), appender, nil
+	}
+
+	appender := func(acceptor any, builder array.Builder) error {
+		values := *acceptor.(*map[string]any)
+
+		items := make([]any, 0, len(names))
+		for _, name := range names {
+			items = append(items, values[name])
+		}
+
+		//nolint:forcetypeassert
+		return utils.AppendItemsToStructBuilder(items, acceptors, appenders, builder.(*array.StructBuilder))
+	}
+
+	return new(map[string]any), appender, nil
 }
 
 // If time value is under of type bounds ClickHouse behavior is undefined
@@ -232,12 +540,114 @@ func (c dateTime64ToStringConverter) Convert(in *time.Time) (string, error) {
 	return c.conv.Convert(saturateDateTime(in, minClickHouseDatetime64, maxClickHouseDatetime64))
 }
 
+// The values of some ClickHouse types are scanned into the types that are not supported by conversion.Collection,
+// so they are appended to the Arrow builders directly.
+
+func makeValueAppender[T any](
+	appendValue func(value *T, builder array.Builder) error,
+) func(acceptor any, builder array.Builder) error {
+	return func(acceptor any, builder array.Builder) error {
+		//nolint:forcetypeassert
+		return appendValue(acceptor.(*T), builder)
+	}
+}
+
+func makeValueAppenderNullable[T any](
+	appendValue func(value *T, builder array.Builder) error,
+) func(acceptor any, builder array.Builder) error {
+	return func(acceptor any, builder array.Builder) error {
+		//nolint:forcetypeassert
+		value := *acceptor.(**T)
+		if value == nil {
+			builder.AppendNull()
+
+			return nil
+		}
+
+		return appendValue(value, builder)
+	}
+}
+
+func makeDecimalValueAppender(
+	typeName string,
+	ydbType *Ydb.Type,
+	tm typeMapper,
+) (func(value *decimal.Decimal, builder array.Builder) error, error) {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	if decimalType := ydbType.GetDecimalType(); decimalType != nil {
+		return func(value *decimal.Decimal, builder array.Builder) error {
+			return appendDecimalToDecimalBuilder(value, builder, decimalType)
+		}, nil
+	}
+
+	if ydbType.GetTypeId() == Ydb.Type_UTF8 {
+		// the decimals that are too wide for YDB are passed in their textual representation
+		scale, err := strconv.ParseInt(tm.isDecimal.FindStringSubmatch(typeName)[2], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("parse scale: %w", err)
+		}
+
+		return func(value *decimal.Decimal, builder array.Builder) error {
+			//nolint:forcetypeassert
+			builder.(*array.StringBuilder).Append(value.StringFixed(int32(scale)))
+
+			return nil
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected ydb type %v for decimal: %w", ydbType, common.ErrDataTypeNotSupported)
+}
+
+func appendDecimalToDecimalBuilder(value *decimal.Decimal, builder array.Builder, decimalType *Ydb.DecimalType) error {
+	unscaled, err := common.RescaleDecimal(value.Coefficient(), value.Exponent(), decimalType.Scale)
+	if err != nil {
+		return fmt.Errorf("rescale decimal: %w", err)
+	}
+
+	data, err := common.DecimalToBytes(unscaled, decimalType.Precision)
+	if err != nil {
+		return fmt.Errorf("decimal to bytes: %w", err)
+	}
+
+	//nolint:forcetypeassert
+	builder.(*array.FixedSizeBinaryBuilder).Append(data)
+
+	return nil
+}
+
+func appendUUIDToArrowBuilder(value *uuid.UUID, builder array.Builder) error {
+	//nolint:forcetypeassert
+	builder.(*array.FixedSizeBinaryBuilder).Append(common.UUIDToYDBBytes(*value))
+
+	return nil
+}
+
+func appendIPToArrowBuilder(value *net.IP, builder array.Builder) error {
+	//nolint:forcetypeassert
+	builder.(*array.StringBuilder).Append(value.String())
+
+	return nil
+}
+
 func NewTypeMapper() datasource.TypeMapper {
+	return newTypeMapper()
+}
+
+func newTypeMapper() typeMapper {
 	return typeMapper{
-		isFixedString: regexp.MustCompile(`FixedString\([0-9]+\)`),
-		isDateTime:    regexp.MustCompile(`DateTime(\('[\w,/]+'\))?`),
-		isDateTime64:  regexp.MustCompile(`DateTime64\(\d{1}(, '[\w,/]+')?\)`),
-		isNullable:    regexp.MustCompile(`Nullable\((.+)\)`),
-		isArray:       regexp.MustCompile(`Array\((.+)\)`),
+		isFixedString:     regexp.MustCompile(`FixedString\([0-9]+\)`),
+		isDateTime:        regexp.MustCompile(`DateTime(\('[\w,/]+'\))?`),
+		isDateTime64:      regexp.MustCompile(`DateTime64\(\d{1}(, '[\w,/]+')?\)`),
+		isNullable:        regexp.MustCompile(`^Nullable\((.+)\)$`),
+		isArray:           regexp.MustCompile(`^Array\((.+)\)$`),
+		isLowCardinality:  regexp.MustCompile(`^LowCardinality\((.+)\)$`),
+		isDecimal:         regexp.MustCompile(`^Decimal\((\d+),\s*(\d+)\)$`),
+		isEnum:            regexp.MustCompile(`^Enum(8|16)\(.*\)$`),
+		isMap:             regexp.MustCompile(`^Map\((.+)\)$`),
+		isTuple:           regexp.MustCompile(`^Tuple\((.+)\)$`),
+		isNamedTupleField: regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*) (.+)$`),
 	}
 }
@@ -0,0 +1,220 @@
+package clickhouse
+
+import (
+	"encoding/json"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/ptr"
+)
+
+func TestSQLTypeToYDBColumn(t *testing.T) {
+	rules := &api_service_protos.TTypeMappingSettings{DateTimeFormat: api_service_protos.EDateTimeFormat_YQL_FORMAT}
+
+	testCases := []struct {
+		typeName string
+		expected *Ydb.Type
+	}{
+		{
+			typeName: "Array(Int32)",
+			expected: common.MakeListType(common.MakePrimitiveType(Ydb.Type_INT32)),
+		},
+		{
+			typeName: "Array(Nullable(String))",
+			expected: common.MakeListType(common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_STRING))),
+		},
+		{
+			typeName: "Nullable(Array(Float64))",
+			expected: common.MakeOptionalType(common.MakeListType(common.MakePrimitiveType(Ydb.Type_DOUBLE))),
+		},
+		{
+			// date/time values may be out of the YDB range, so the items are optional
+			typeName: "Array(DateTime)",
+			expected: common.MakeListType(common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_DATETIME))),
+		},
+		{
+			typeName: "Array(Array(UInt8))",
+			expected: common.MakeListType(common.MakeListType(common.MakePrimitiveType(Ydb.Type_UINT8))),
+		},
+		{
+			typeName: "LowCardinality(Nullable(String))",
+			expected: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_STRING)),
+		},
+		{
+			typeName: "Decimal(10, 2)",
+			expected: common.MakeDecimalType(10, 2),
+		},
+		{
+			// YDB Decimal keeps no more than 35 digits
+			typeName: "Decimal(38, 4)",
+			expected: common.MakePrimitiveType(Ydb.Type_UTF8),
+		},
+		{
+			typeName: "UUID",
+			expected: common.MakePrimitiveType(Ydb.Type_UUID),
+		},
+		{
+			typeName: "Enum8('a, b' = 1, 'c\\'' = 2)",
+			expected: common.MakePrimitiveType(Ydb.Type_UTF8),
+		},
+		{
+			typeName: "Nullable(IPv6)",
+			expected: common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+		},
+		{
+			typeName: "Map(LowCardinality(String), Array(Nullable(Int64)))",
+			expected: common.MakeDictType(
+				common.MakePrimitiveType(Ydb.Type_STRING),
+				common.MakeListType(common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT64))),
+			),
+		},
+		{
+			typeName: "Tuple(Int32, Nullable(String))",
+			expected: common.MakeTupleType([]*Ydb.Type{
+				common.MakePrimitiveType(Ydb.Type_INT32),
+				common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_STRING)),
+			}),
+		},
+		{
+			typeName: "Tuple(id UInt64, price Decimal(9, 2))",
+			expected: common.MakeStructType([]*Ydb.StructMember{
+				{Name: "id", Type: common.MakePrimitiveType(Ydb.Type_UINT64)},
+				{Name: "price", Type: common.MakeDecimalType(9, 2)},
+			}),
+		},
+	}
+
+	tm := NewTypeMapper()
+
+	for _, tc := range testCases {
+		column, err := tm.SQLTypeToYDBColumn("col", tc.typeName, rules)
+		require.NoError(t, err, tc.typeName)
+		require.True(t, common.TypesEqual(tc.expected, column.Type), tc.typeName)
+	}
+
+	_, err := tm.SQLTypeToYDBColumn("col", "Array(Int128)", rules)
+	require.ErrorIs(t, err, common.ErrDataTypeNotSupported)
+}
+
+func TestTransformerFromSQLTypes(t *testing.T) {
+	cc := conversion.NewCollection(&config.TConversionConfig{})
+	rules := &api_service_protos.TTypeMappingSettings{DateTimeFormat: api_service_protos.EDateTimeFormat_YQL_FORMAT}
+	typeNames := []string{"Array(Nullable(Int32))", "Array(DateTime)"}
+
+	var ydbTypes []*Ydb.Type
+
+	for _, typeName := range typeNames {
+		column, err := NewTypeMapper().SQLTypeToYDBColumn("col", typeName, rules)
+		require.NoError(t, err)
+
+		ydbTypes = append(ydbTypes, column.Type)
+	}
+
+	transformer, err := transformerFromSQLTypes(typeNames, ydbTypes, cc)
+	require.NoError(t, err)
+
+	builders, err := common.YdbTypesToArrowBuilders(ydbTypes, memory.NewGoAllocator())
+	require.NoError(t, err)
+
+	acceptors := transformer.GetAcceptors()
+	*acceptors[0].(*[]*int32) = []*int32{ptr.Int32(1), nil}
+	*acceptors[1].(*[]time.Time) = []time.Time{time.Date(1988, 11, 20, 12, 55, 28, 0, time.UTC)}
+
+	require.NoError(t, transformer.AppendToArrowBuilders(nil, builders))
+
+	ints := builders[0].NewArray().(*array.List)
+	defer ints.Release()
+
+	require.Equal(t, "[1,null]", ints.ValueStr(0))
+
+	datetimes := builders[1].NewArray().(*array.List)
+	defer datetimes.Release()
+
+	require.Equal(t, "[596033728]", datetimes.ValueStr(0))
+}
+
+func TestTransformerFromSQLTypesComposite(t *testing.T) {
+	cc := conversion.NewCollection(&config.TConversionConfig{})
+	rules := &api_service_protos.TTypeMappingSettings{DateTimeFormat: api_service_protos.EDateTimeFormat_YQL_FORMAT}
+	typeNames := []string{
+		"Decimal(10, 2)",
+		"Nullable(Decimal(40, 3))",
+		"UUID",
+		"LowCardinality(Enum8('a' = 1, 'b' = 2))",
+		"IPv4",
+		"Map(Int32, Nullable(UInt16))",
+		"Tuple(Int32, Nullable(String))",
+		"Tuple(id UInt64, code Int16)",
+	}
+
+	var ydbTypes []*Ydb.Type
+
+	for _, typeName := range typeNames {
+		column, err := NewTypeMapper().SQLTypeToYDBColumn("col", typeName, rules)
+		require.NoError(t, err, typeName)
+
+		ydbTypes = append(ydbTypes, column.Type)
+	}
+
+	transformer, err := transformerFromSQLTypes(typeNames, ydbTypes, cc)
+	require.NoError(t, err)
+
+	builders, err := common.YdbTypesToArrowBuilders(ydbTypes, memory.NewGoAllocator())
+	require.NoError(t, err)
+
+	id := uuid.MustParse("00112233-4455-6677-8899-aabbccddeeff")
+
+	acceptors := transformer.GetAcceptors()
+	*acceptors[0].(*decimal.Decimal) = decimal.RequireFromString("-12.3")
+	*acceptors[1].(**decimal.Decimal) = ptr.T(decimal.RequireFromString("1.5"))
+	*acceptors[2].(*uuid.UUID) = id
+	*acceptors[3].(*string) = "b"
+	*acceptors[4].(*net.IP) = net.IPv4(192, 168, 0, 1)
+	*acceptors[5].(*map[int32]*uint16) = map[int32]*uint16{20: nil, 10: ptr.T[uint16](1)}
+	*acceptors[6].(*[]any) = []any{int32(1), nil}
+	*acceptors[7].(*map[string]any) = map[string]any{"code": int16(-1), "id": uint64(2)}
+
+	require.NoError(t, transformer.AppendToArrowBuilders(nil, builders))
+
+	arrays := make([]arrow.Array, 0, len(builders))
+
+	for _, builder := range builders {
+		arr := builder.NewArray()
+		defer arr.Release()
+
+		arrays = append(arrays, arr)
+	}
+
+	expectedDecimal, err := common.DecimalToBytes(big.NewInt(-1230), 10)
+	require.NoError(t, err)
+	require.Equal(t, expectedDecimal, arrays[0].(*array.FixedSizeBinary).Value(0))
+	require.Equal(t, "1.500", arrays[1].(*array.String).Value(0))
+	require.Equal(t, common.UUIDToYDBBytes(id), arrays[2].(*array.FixedSizeBinary).Value(0))
+	require.Equal(t, "b", arrays[3].(*array.String).Value(0))
+	require.Equal(t, "192.168.0.1", arrays[4].(*array.String).Value(0))
+	require.Equal(t, `[{"key":10,"value":1},{"key":20,"value":null}]`, marshalValue(t, arrays[5]))
+	require.Equal(t, `{"field0":1,"field1":null}`, marshalValue(t, arrays[6]))
+	require.JSONEq(t, `{"id":2,"code":-1}`, marshalValue(t, arrays[7]))
+}
+
+func marshalValue(t *testing.T, arr arrow.Array) string {
+	data, err := json.Marshal(arr.GetOneForMarshal(0))
+	require.NoError(t, err)
+
+	return string(data)
+}
@@ -15,4 +15,7 @@ const (
 	typeString  = "String"
 	typeDate    = "Date"
 	typeDate32  = "Date32"
+	typeUUID    = "UUID"
+	typeIPv4    = "IPv4"
+	typeIPv6    = "IPv6"
 )
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
@@ -23,10 +27,13 @@ type Preset struct {
 	TypeMapper        datasource.TypeMapper
 	SchemaProvider    rdbms_utils.SchemaProvider
 	SplitProvider     rdbms_utils.SplitProvider
+	TableListProvider rdbms_utils.TableListProvider
+	KeyProvider       rdbms_utils.KeyProvider // optional, makes the partially read splits resumable
 	RetrierSet        *retry.RetrierSet
 }
 
 var _ datasource.DataSource[any] = (*dataSourceImpl)(nil)
+var _ datasource.PredicateCheckerMaker = (*dataSourceImpl)(nil)
 
 type dataSourceImpl struct {
 	typeMapper          datasource.TypeMapper
@@ -34,6 +41,8 @@ type dataSourceImpl struct {
 	connectionManager   rdbms_utils.ConnectionManager
 	schemaProvider      rdbms_utils.SchemaProvider
 	splitProvider       rdbms_utils.SplitProvider
+	tableListProvider   rdbms_utils.TableListProvider
+	keyProvider         rdbms_utils.KeyProvider
 	retrierSet          *retry.RetrierSet
 	converterCollection conversion.Collection
 	observationStorage  observation.Storage
@@ -82,7 +91,69 @@ func (ds *dataSourceImpl) DescribeTable(
 		return nil, fmt.Errorf("get schema: %w", err)
 	}
 
-	return &api_service_protos.TDescribeTableResponse{Schema: schema}, nil
+	// OFFSET is not pushed down to the parts of the table, so LIMIT is reported only if tables are never split
+	pushdownCapabilities := rdbms_utils.ProbePushdownCapabilities(
+		ds.sqlFormatter, request.DataSourceInstance.Kind, !ds.splitProvider.MaySplitTables())
+
+	return &api_service_protos.TDescribeTableResponse{
+		Schema:               schema,
+		PushdownCapabilities: pushdownCapabilities,
+	}, nil
+}
+
+func (ds *dataSourceImpl) MakePredicateChecker(
+	_ *zap.Logger,
+	dataSourceKind api_common.EGenericDataSourceKind,
+) datasource.PredicateChecker {
+	return rdbms_utils.NewPredicateChecker(ds.sqlFormatter, dataSourceKind)
+}
+
+func (ds *dataSourceImpl) ListTables(
+	ctx context.Context,
+	logger *zap.Logger,
+	request *api_service_protos.TListTablesRequest,
+) (*api_service_protos.TListTablesResponse, error) {
+	if ds.tableListProvider == nil {
+		return nil, fmt.Errorf("list tables: %w", common.ErrMethodNotSupported)
+	}
+
+	var cs []rdbms_utils.Connection
+
+	err := ds.retrierSet.MakeConnection.Run(ctx, logger,
+		func() error {
+			var makeConnErr error
+
+			params := &rdbms_utils.ConnectionParams{
+				Ctx:                ctx,
+				Logger:             logger,
+				DataSourceInstance: request.DataSourceInstance,
+				QueryPhase:         rdbms_utils.QueryPhaseListTables,
+			}
+
+			cs, makeConnErr = ds.connectionManager.Make(params)
+			if makeConnErr != nil {
+				return fmt.Errorf("make connection: %w", makeConnErr)
+			}
+
+			return nil
+		},
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("retry: %w", err)
+	}
+
+	defer ds.connectionManager.Release(ctx, logger, cs)
+
+	// We asked for a single connection
+	conn := cs[0]
+
+	tables, err := ds.tableListProvider.ListTables(ctx, logger, conn, request)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+
+	return &api_service_protos.TListTablesResponse{Tables: tables}, nil
 }
 
 func (ds *dataSourceImpl) ListSplits(
@@ -91,6 +162,15 @@ func (ds *dataSourceImpl) ListSplits(
 	request *api_service_protos.TListSplitsRequest,
 	slct *api_service_protos.TSelect,
 	resultChan chan<- *datasource.ListSplitResult) error {
+	// The splits are returned without ordering if the data source is not able to provide it
+	if orderBy := slct.GetOrderBy(); orderBy != nil {
+		if _, err := ds.sqlFormatter.FormatOrderBy(orderBy); err != nil {
+			logger.Warn("ORDER BY pushdown is not possible, rows will be sorted on the YDB side", zap.Error(err))
+
+			slct = common.SelectWithoutOrderBy(slct)
+		}
+	}
+
 	params := &rdbms_utils.ListSplitsParams{
 		Ctx:                   ctx,
 		Logger:                logger,
@@ -170,19 +250,39 @@ func (ds *dataSourceImpl) ReadSplit(
 		sink := sinks[i]
 
 		group.Go(func() error {
+			// Connection has delivered all its data before the interruption of the previous request
+			if sink.ResumePosition().GetFinished() {
+				sink.Finish()
+
+				return nil
+			}
+
+			keyCursor, err := ds.makeKeyCursor(ctx, logger, conn, split, sink)
+			if err != nil {
+				return fmt.Errorf("make key cursor: %w", err)
+			}
+
 			// generate SQL query
-			query, err := rdbms_utils.MakeSelectQuery(
+			query, err := rdbms_utils.MakeSelectQueryWithKeyCursor(
 				ctx,
 				logger,
 				ds.sqlFormatter,
 				split,
 				request.Filtering,
 				conn.TableName(),
+				keyCursor,
 			)
 			if err != nil {
 				return fmt.Errorf("make select query: %w", err)
 			}
 
+			// All the rows within the LIMIT have been delivered before the interruption
+			if query.LimitExhausted {
+				sink.Finish()
+
+				return nil
+			}
+
 			annotatedLogger, outgoingQueryID, err := ds.observationStorage.CreateOutgoingQuery(
 				ctx, logger, incomingQueryID, conn.DataSourceInstance(), query.QueryText, query.QueryArgs.Values())
 			if err != nil {
@@ -219,6 +319,66 @@ func (ds *dataSourceImpl) ReadSplit(
 	return nil
 }
 
+// makeKeyCursor returns the cursor allowing to resume the reading of the split after the interruption,
+// or nil if the continuation tokens are not issued or the table has no key suitable for the cursor.
+// In the latter case the sink is only able to resume the reading of the fully delivered split.
+func (ds *dataSourceImpl) makeKeyCursor(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn rdbms_utils.Connection,
+	split *api_service_protos.TSplit,
+	sink paging.Sink[any],
+) (*rdbms_utils.KeyCursor, error) {
+	resumePosition := sink.ResumePosition()
+	lastKey := resumePosition.GetCursor().GetKey().GetValues()
+
+	var columns []*Ydb.Column
+
+	// The rows are already sorted in the requested order, that doesn't have to be unique
+	if sink.CheckpointsRequired() && ds.keyProvider != nil && split.Select.GetOrderBy() == nil {
+		var err error
+
+		columns, err = ds.keyProvider.GetKey(ctx, logger, conn)
+		if err != nil {
+			return nil, fmt.Errorf("get key: %w", err)
+		}
+	}
+
+	for _, column := range columns {
+		// The key values must be passed to the data source to select the rows following the last key
+		if !ds.sqlFormatter.SupportsExpression(&api_service_protos.TExpression{
+			Payload: &api_service_protos.TExpression_TypedValue{TypedValue: &Ydb.TypedValue{Type: column.Type}},
+		}) {
+			columns = nil
+
+			break
+		}
+
+		// The key columns are added to the query, so their names must not be taken by the computed columns
+		for _, item := range split.Select.What.GetItems() {
+			if item.GetComputedColumn().GetName() == column.Name {
+				columns = nil
+
+				break
+			}
+		}
+	}
+
+	if len(columns) == 0 {
+		if len(lastKey) > 0 {
+			return nil, fmt.Errorf("the reading cannot be resumed from the key: %w", common.ErrInvalidRequest)
+		}
+
+		return nil, nil
+	}
+
+	return &rdbms_utils.KeyCursor{
+		Columns:       columns,
+		LastKey:       lastKey,
+		RowsDelivered: resumePosition.GetRowsDelivered(),
+	}, nil
+}
+
 func (ds *dataSourceImpl) doReadSplitSingleConn(
 	ctx context.Context,
 	logger *zap.Logger,
@@ -253,6 +413,12 @@ func (ds *dataSourceImpl) doReadSplitSingleConn(
 		return 0, fmt.Errorf("make transformer: %w", err)
 	}
 
+	// The key columns appended to the requested ones are not returned to the client
+	var sinkTransformer paging.RowTransformer[any] = transformer
+	if query.RequestedColumns < len(query.YdbColumns) {
+		sinkTransformer = &keyColumnsHidingTransformer{RowTransformer: transformer, requestedColumns: query.RequestedColumns}
+	}
+
 	rowsRead := int64(0)
 
 	for cont := true; cont; cont = rows.NextResultSet() {
@@ -263,9 +429,15 @@ func (ds *dataSourceImpl) doReadSplitSingleConn(
 				return 0, fmt.Errorf("rows scan: %w", err)
 			}
 
-			if err := sink.AddRow(transformer); err != nil {
+			if err := sink.AddRow(sinkTransformer); err != nil {
 				return 0, fmt.Errorf("add row to paging writer: %w", err)
 			}
+
+			if len(query.KeyColumnIndices) > 0 {
+				if err := checkpointKey(sink, query, transformer.GetAcceptors()); err != nil {
+					return 0, fmt.Errorf("checkpoint key: %w", err)
+				}
+			}
 		}
 	}
 
@@ -280,6 +452,44 @@ func (ds *dataSourceImpl) doReadSplitSingleConn(
 	return rowsRead, nil
 }
 
+// checkpointKey saves the key of the row that has just been added to the sink
+func checkpointKey(sink paging.Sink[any], query *rdbms_utils.SelectQuery, acceptors []any) error {
+	values := make([]*Ydb.TypedValue, len(query.KeyColumnIndices))
+
+	for i, columnIndex := range query.KeyColumnIndices {
+		value, err := rdbms_utils.MakeKeyValue(acceptors[columnIndex], query.YdbColumns[columnIndex].Type)
+		if err != nil {
+			return fmt.Errorf("make value of key column '%s': %w", query.YdbColumns[columnIndex].Name, err)
+		}
+
+		values[i] = value
+	}
+
+	sink.Checkpoint(&paging.TCursor{Payload: &paging.TCursor_Key{Key: &paging.TCursor_TKey{Values: values}}})
+
+	return nil
+}
+
+// keyColumnsHidingTransformer passes only the requested columns to the sink,
+// concealing the key columns appended to them
+type keyColumnsHidingTransformer struct {
+	paging.RowTransformer[any]
+	requestedColumns int
+}
+
+func (t *keyColumnsHidingTransformer) GetAcceptors() []any {
+	return t.RowTransformer.GetAcceptors()[:t.requestedColumns]
+}
+
+func (t *keyColumnsHidingTransformer) AppendToArrowBuilders(schema *arrow.Schema, builders []array.Builder) error {
+	acceptors := t.RowTransformer.GetAcceptors()
+
+	t.RowTransformer.SetAcceptors(acceptors[:t.requestedColumns])
+	defer t.RowTransformer.SetAcceptors(acceptors)
+
+	return t.RowTransformer.AppendToArrowBuilders(schema, builders)
+}
+
 func NewDataSource(
 	logger *zap.Logger,
 	preset *Preset,
@@ -293,6 +503,8 @@ func NewDataSource(
 		typeMapper:          preset.TypeMapper,
 		schemaProvider:      preset.SchemaProvider,
 		splitProvider:       preset.SplitProvider,
+		tableListProvider:   preset.TableListProvider,
+		keyProvider:         preset.KeyProvider,
 		retrierSet:          preset.RetrierSet,
 		converterCollection: converterCollection,
 		observationStorage:  observationStorage,
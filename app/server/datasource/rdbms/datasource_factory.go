@@ -21,6 +21,7 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/server/observation"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils/retry"
 	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
 )
 
 var _ datasource.Factory[any] = (*dataSourceFactory)(nil)
@@ -37,6 +38,7 @@ type dataSourceFactory struct {
 
 	observationStorage  observation.Storage
 	loggingResolver     logging.Resolver
+	connectionPool      *rdbms_utils.ConnectionPool
 	converterCollection conversion.Collection
 }
 
@@ -71,17 +73,31 @@ func (dsf *dataSourceFactory) Close() error {
 		return fmt.Errorf("close logging resolver: %w", err)
 	}
 
+	if dsf.connectionPool != nil {
+		if err := dsf.connectionPool.Close(); err != nil {
+			return fmt.Errorf("close connection pool: %w", err)
+		}
+	}
+
 	return nil
 }
 
 func NewDataSourceFactory(
+	logger *zap.Logger,
 	cfg *config.TDatasourcesConfig,
 	qlf common.QueryLoggerFactory,
 	converterCollection conversion.Collection,
 	observationStorage observation.Storage,
+	registry metrics.Registry,
 ) (datasource.Factory[any], error) {
+	var connectionPool *rdbms_utils.ConnectionPool
+	if cfg.GetConnectionPool().GetEnabled() {
+		connectionPool = rdbms_utils.NewConnectionPool(logger, cfg.ConnectionPool, registry)
+	}
+
 	connManagerBase := rdbms_utils.ConnectionManagerBase{
 		QueryLoggerFactory: qlf,
+		ConnectionPool:     connectionPool,
 	}
 
 	postgresqlTypeMapper := postgresql.NewTypeMapper()
@@ -107,7 +123,8 @@ func NewDataSourceFactory(
 			ConnectionManager: clickhouse.NewConnectionManager(cfg.Clickhouse, connManagerBase),
 			TypeMapper:        clickhouseTypeMapper,
 			SchemaProvider:    rdbms_utils.NewDefaultSchemaProvider(clickhouseTypeMapper, clickhouse.TableMetadataQuery),
-			SplitProvider:     rdbms_utils.NewDefaultSplitProvider(),
+			SplitProvider:     clickhouse.NewSplitProvider(cfg.Clickhouse.Splitting),
+			TableListProvider: rdbms_utils.NewDefaultTableListProvider(clickhouse.TableListQuery),
 			RetrierSet: &retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(cfg.Clickhouse.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
 				Query:          retry.NewRetrierFromConfig(cfg.Clickhouse.ExponentialBackoff, retry.ErrorCheckerNoop),
@@ -125,7 +142,18 @@ func NewDataSourceFactory(
 						request,
 						schemaGetters[api_common.EGenericDataSourceKind_POSTGRESQL](request.DataSourceInstance))
 				}),
-			SplitProvider: rdbms_utils.NewDefaultSplitProvider(),
+			SplitProvider: postgresql.NewSplitProvider(cfg.Postgresql.Splitting),
+			TableListProvider: rdbms_utils.NewDefaultTableListProvider(
+				func(request *api_service_protos.TListTablesRequest) (string, *rdbms_utils.QueryArgs) {
+					return postgresql.TableListQuery(
+						request,
+						schemaGetters[api_common.EGenericDataSourceKind_POSTGRESQL](request.DataSourceInstance))
+				}),
+			KeyProvider: rdbms_utils.NewDefaultKeyProvider(
+				postgresqlTypeMapper,
+				func(dsi *api_common.TGenericDataSourceInstance, tableName string) (string, *rdbms_utils.QueryArgs) {
+					return postgresql.TableKeyQuery(tableName, schemaGetters[api_common.EGenericDataSourceKind_POSTGRESQL](dsi))
+				}),
 			RetrierSet: &retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(cfg.Postgresql.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
 				Query:          retry.NewRetrierFromConfig(cfg.Postgresql.ExponentialBackoff, retry.ErrorCheckerNoop),
@@ -137,6 +165,7 @@ func NewDataSourceFactory(
 			TypeMapper:        ydbTypeMapper,
 			SchemaProvider:    ydb.NewSchemaProvider(ydbTypeMapper),
 			SplitProvider:     ydb.NewSplitProvider(cfg.Ydb.Splitting),
+			TableListProvider: ydb.NewTableListProvider(),
 			RetrierSet: &retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(cfg.Ydb.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
 				Query:          retry.NewRetrierFromConfig(cfg.Ydb.ExponentialBackoff, ydb.ErrorCheckerQuery),
@@ -148,6 +177,8 @@ func NewDataSourceFactory(
 			TypeMapper:        msSQLServerTypeMapper,
 			SchemaProvider:    rdbms_utils.NewDefaultSchemaProvider(msSQLServerTypeMapper, ms_sql_server.TableMetadataQuery),
 			SplitProvider:     rdbms_utils.NewDefaultSplitProvider(),
+			TableListProvider: rdbms_utils.NewDefaultTableListProvider(ms_sql_server.TableListQuery),
+			KeyProvider:       rdbms_utils.NewDefaultKeyProvider(msSQLServerTypeMapper, ms_sql_server.TableKeyQuery),
 			RetrierSet: &retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(cfg.MsSqlServer.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
 				Query:          retry.NewRetrierFromConfig(cfg.MsSqlServer.ExponentialBackoff, retry.ErrorCheckerNoop),
@@ -159,6 +190,8 @@ func NewDataSourceFactory(
 			TypeMapper:        mysqlTypeMapper,
 			SchemaProvider:    rdbms_utils.NewDefaultSchemaProvider(mysqlTypeMapper, mysql.TableMetadataQuery),
 			SplitProvider:     rdbms_utils.NewDefaultSplitProvider(),
+			TableListProvider: rdbms_utils.NewDefaultTableListProvider(mysql.TableListQuery),
+			KeyProvider:       rdbms_utils.NewDefaultKeyProvider(mysqlTypeMapper, mysql.TableKeyQuery),
 			RetrierSet: &retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(cfg.Mysql.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
 				Query:          retry.NewRetrierFromConfig(cfg.Mysql.ExponentialBackoff, retry.ErrorCheckerNoop),
@@ -176,24 +209,40 @@ func NewDataSourceFactory(
 						request,
 						schemaGetters[api_common.EGenericDataSourceKind_GREENPLUM](request.DataSourceInstance))
 				}),
+			// ctid ranges are useless for Greenplum: it has no TID Range Scan,
+			// and the same ctid values are found on every segment
 			SplitProvider: rdbms_utils.NewDefaultSplitProvider(),
+			TableListProvider: rdbms_utils.NewDefaultTableListProvider(
+				func(request *api_service_protos.TListTablesRequest) (string, *rdbms_utils.QueryArgs) {
+					return postgresql.TableListQuery(
+						request,
+						schemaGetters[api_common.EGenericDataSourceKind_GREENPLUM](request.DataSourceInstance))
+				}),
+			KeyProvider: rdbms_utils.NewDefaultKeyProvider(
+				postgresqlTypeMapper,
+				func(dsi *api_common.TGenericDataSourceInstance, tableName string) (string, *rdbms_utils.QueryArgs) {
+					return postgresql.TableKeyQuery(tableName, schemaGetters[api_common.EGenericDataSourceKind_GREENPLUM](dsi))
+				}),
 			RetrierSet: &retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(cfg.Greenplum.ExponentialBackoff, retry.ErrorCheckerMakeConnectionCommon),
 				Query:          retry.NewRetrierFromConfig(cfg.Greenplum.ExponentialBackoff, retry.ErrorCheckerNoop),
 			},
 		},
 		oracle: Preset{
-			SQLFormatter:      oracle.NewSQLFormatter(cfg.Oracle.Pushdown),
+			SQLFormatter:      oracle.NewSQLFormatter(cfg.Oracle.Pushdown, cfg.Oracle.UseRownumForLimit),
 			ConnectionManager: oracle.NewConnectionManager(cfg.Oracle, connManagerBase),
 			TypeMapper:        oracleTypeMapper,
 			SchemaProvider:    rdbms_utils.NewDefaultSchemaProvider(oracleTypeMapper, oracle.TableMetadataQuery),
 			SplitProvider:     rdbms_utils.NewDefaultSplitProvider(),
+			TableListProvider: rdbms_utils.NewDefaultTableListProvider(oracle.TableListQuery),
+			KeyProvider:       rdbms_utils.NewDefaultKeyProvider(oracleTypeMapper, oracle.TableKeyQuery),
 			RetrierSet: &retry.RetrierSet{
 				MakeConnection: retry.NewRetrierFromConfig(cfg.Oracle.ExponentialBackoff, oracle.ErrorCheckerMakeConnection),
 				Query:          retry.NewRetrierFromConfig(cfg.Oracle.ExponentialBackoff, retry.ErrorCheckerNoop),
 			},
 		},
 		converterCollection: converterCollection,
+		connectionPool:      connectionPool,
 	}
 
 	var err error
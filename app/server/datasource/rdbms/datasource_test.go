@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
@@ -112,6 +113,8 @@ func TestReadSplit(t *testing.T) {
 		rows.On("Close").Return(nil).Once()
 
 		sink := &paging.SinkMock{}
+		sink.On("ResumePosition").Return(nil).Twice()
+		sink.On("CheckpointsRequired").Return(false).Once()
 		sink.On("AddRow", transformer).Return(nil).Times(2)
 		sink.On("Finish").Return().Once()
 
@@ -184,6 +187,8 @@ func TestReadSplit(t *testing.T) {
 		rows.On("Close").Return(nil).Once()
 
 		sink := &paging.SinkMock{}
+		sink.On("ResumePosition").Return(nil).Twice()
+		sink.On("CheckpointsRequired").Return(false).Once()
 		sink.On("AddRow", transformer).Return(nil).Once()
 
 		sinkFactory := &paging.SinkFactoryMock{}
@@ -201,4 +206,136 @@ func TestReadSplit(t *testing.T) {
 
 		mock.AssertExpectationsForObjects(t, connectionManager, connection, rows, sink, sinkFactory)
 	})
+
+	t.Run("resume from key", func(t *testing.T) {
+		logger := common.NewTestLogger(t)
+		connectionManager := &rdbms_utils.ConnectionManagerMock{}
+		keyProvider := &rdbms_utils.KeyProviderMock{}
+
+		preset := &Preset{
+			ConnectionManager: connectionManager,
+			SQLFormatter:      postgresql.NewSQLFormatter(nil),
+			KeyProvider:       keyProvider,
+			RetrierSet:        retry.NewRetrierSetNoop(),
+		}
+
+		// the key column is not requested by the client
+		keySplit := proto.Clone(split).(*api_service_protos.TSplit)
+		keySplit.Select.What.Items = keySplit.Select.What.Items[1:]
+		keySplit.Select.Limit = &api_service_protos.TSelect_TLimit{Limit: 3}
+
+		connection := &rdbms_utils.ConnectionMock{}
+		connection.On("Logger").Return(logger)
+		connection.On("TableName").Return("example_1").Once()
+		connection.On("DataSourceInstance").Return(&api_common.TGenericDataSourceInstance{}).Once()
+
+		connectionManager.On("Make", keySplit.Select.DataSourceInstance).Return([]rdbms_utils.Connection{connection}, nil).Once()
+		connectionManager.On("Release", []rdbms_utils.Connection{connection}).Return().Once()
+
+		keyColumn := &Ydb.Column{Name: "col1", Type: common.MakePrimitiveType(Ydb.Type_INT32)}
+		keyProvider.On("GetKey", connection).Return([]*Ydb.Column{keyColumn}, nil).Once()
+
+		// the row with key 1 has been delivered before the interruption
+		rows := &rdbms_utils.RowsMock{
+			PredefinedData: [][]any{
+				{"b", int32(2)},
+				{"c", int32(3)},
+			},
+		}
+		connection.On(
+			"Query",
+			`SELECT "col2", "col1" FROM "example_1" WHERE ("col1" > $1) ORDER BY "col1" ASC NULLS FIRST LIMIT 2`,
+			int32(1),
+		).Return(rows, nil).Once()
+
+		value, key := new(string), new(int32)
+		transformer := &rdbms_utils.RowTransformerMock{Acceptors: []any{&value, &key}}
+
+		rows.On("MakeTransformer",
+			[]*Ydb.Column{
+				{
+					Name: "col2",
+					Type: common.MakePrimitiveType(Ydb.Type_UTF8),
+				},
+				keyColumn,
+			},
+		).Return(transformer, nil).Once()
+		rows.On("Next").Return(true).Times(2)
+		rows.On("Next").Return(false).Once()
+		rows.On("Scan", transformer.GetAcceptors()...).Return(nil).Times(2)
+		rows.On("Err").Return(nil).Once()
+		rows.On("NextResultSet").Return(false).Once()
+		rows.On("Close").Return(nil).Once()
+
+		makeCursor := func(key int32) *paging.TCursor {
+			return &paging.TCursor{
+				Payload: &paging.TCursor_Key{
+					Key: &paging.TCursor_TKey{Values: []*Ydb.TypedValue{common.MakeTypedValue(keyColumn.Type, key)}},
+				},
+			}
+		}
+
+		matchCursor := func(expected *paging.TCursor) any {
+			return mock.MatchedBy(func(actual *paging.TCursor) bool { return proto.Equal(expected, actual) })
+		}
+
+		sink := &paging.SinkMock{}
+		sink.On("ResumePosition").Return(&paging.TContinuationToken_TPosition{Cursor: makeCursor(1), RowsDelivered: 1}).Twice()
+		sink.On("CheckpointsRequired").Return(true).Once()
+		// the key column is concealed from the sink
+		sink.On("AddRow", mock.MatchedBy(func(rt paging.RowTransformer[any]) bool {
+			return len(rt.GetAcceptors()) == 1
+		})).Return(nil).Times(2)
+		sink.On("Checkpoint", matchCursor(makeCursor(2))).Return().Once()
+		sink.On("Checkpoint", matchCursor(makeCursor(3))).Return().Once()
+		sink.On("Finish").Return().Once()
+
+		sinkFactory := &paging.SinkFactoryMock{}
+		sinkFactory.On("MakeSinks", []*paging.SinkParams{{Logger: logger}}).Return([]paging.Sink[any]{sink}, nil).Once()
+
+		observationStorage, err := observation.NewStorage(logger, nil)
+		require.NoError(t, err)
+
+		dataSource := NewDataSource(logger, preset, converterCollection, observationStorage)
+
+		err = dataSource.ReadSplit(ctx, logger, "test-query-id", readSplitsRequest, keySplit, sinkFactory)
+		require.NoError(t, err)
+
+		mock.AssertExpectationsForObjects(t, connectionManager, connection, keyProvider, rows, sink, sinkFactory)
+	})
+
+	t.Run("resume finished connection", func(t *testing.T) {
+		logger := common.NewTestLogger(t)
+		connectionManager := &rdbms_utils.ConnectionManagerMock{}
+
+		preset := &Preset{
+			ConnectionManager: connectionManager,
+			SQLFormatter:      postgresql.NewSQLFormatter(nil),
+			RetrierSet:        retry.NewRetrierSetNoop(),
+		}
+
+		connection := &rdbms_utils.ConnectionMock{}
+		connection.On("Logger").Return(logger)
+
+		connectionManager.On("Make", split.Select.DataSourceInstance).Return([]rdbms_utils.Connection{connection}, nil).Once()
+		connectionManager.On("Release", []rdbms_utils.Connection{connection}).Return().Once()
+
+		// The data has been delivered entirely before the interruption, so no query is made
+		sink := &paging.SinkMock{}
+		sink.On("ResumePosition").Return(&paging.TContinuationToken_TPosition{RowsAfterCursor: 2, Finished: true}).Once()
+		sink.On("Finish").Return().Once()
+
+		sinkFactory := &paging.SinkFactoryMock{}
+		sinkFactory.On("MakeSinks", []*paging.SinkParams{{Logger: logger}}).Return([]paging.Sink[any]{sink}, nil).Once()
+
+		observationStorage, err := observation.NewStorage(logger, nil)
+		require.NoError(t, err)
+
+		dataSource := NewDataSource(logger, preset, converterCollection, observationStorage)
+
+		err = dataSource.ReadSplit(ctx, logger, "test-query-id", readSplitsRequest, split, sinkFactory)
+		require.NoError(t, err)
+
+		mock.AssertExpectationsForObjects(t, connectionManager, connection, sink, sinkFactory)
+	})
 }
@@ -161,6 +161,10 @@ func (s *splitProviderImpl) handleYDBSource(
 	return nil
 }
 
+func (s *splitProviderImpl) MaySplitTables() bool {
+	return s.ydbSplitProvider.MaySplitTables()
+}
+
 func NewSplitProvider(resolver Resolver, ydbSplitProvider ydb.SplitProvider) rdbms_utils.SplitProvider {
 	return &splitProviderImpl{
 		resolver:         resolver,
@@ -165,6 +165,16 @@ func (sqlFormatter) FormatWhat(src *api_service_protos.TSelect_TWhat, tableName
 	return buf.String(), nil
 }
 
+// FormatComputedColumn denies computed columns because the most of the columns are computed from the log records
+func (sqlFormatter) FormatComputedColumn(_ string, _ *Ydb.Type, _ string) (string, error) {
+	return "", common.ErrUnimplementedOperation
+}
+
+// FormatOrderBy denies sorting because the most of the columns are computed from the log records
+func (sqlFormatter) FormatOrderBy(_ *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return "", common.ErrUnimplementedOperation
+}
+
 func (s sqlFormatter) RenderSelectQueryText(
 	parts *rdbms_utils.SelectQueryParts,
 	split *api_service_protos.TSplit,
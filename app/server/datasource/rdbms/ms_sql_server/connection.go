@@ -1,8 +1,6 @@
 package ms_sql_server
 
 import (
-	"database/sql"
-
 	_ "github.com/denisenkom/go-mssqldb"
 	"go.uber.org/zap"
 
@@ -14,14 +12,15 @@ import (
 var _ rdbms_utils.Connection = (*Connection)(nil)
 
 type Connection struct {
-	db                 *sql.DB
+	db                 *rdbms_utils.SharedDB
 	queryLogger        common.QueryLogger
 	dataSourceInstance *api_common.TGenericDataSourceInstance
 	tableName          string
 }
 
-func (c *Connection) Close() error {
-	return c.db.Close()
+// Close does nothing: the shared database handle is released by the connection manager
+func (*Connection) Close() error {
+	return nil
 }
 
 func (c *Connection) DataSourceInstance() *api_common.TGenericDataSourceInstance {
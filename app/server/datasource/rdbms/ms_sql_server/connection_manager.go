@@ -47,28 +47,38 @@ func (c *connectionManager) Make(
 		connectString += "&encrypt=disable"
 	}
 
-	db, err := sql.Open("sqlserver", connectString)
-	if err != nil {
-		return nil, fmt.Errorf("sql open: %w", err)
-	}
-
 	pingCtx, pingCtxCancel := context.WithTimeout(ctx, common.MustDurationFromString(c.cfg.PingConnectionTimeout))
 	defer pingCtxCancel()
 
-	err = db.PingContext(pingCtx)
+	// database/sql keeps the pool of physical connections itself, so the handle is shared between requests
+	db, err := c.AcquireSharedDB(logger, dsi, func() (*sql.DB, error) {
+		db, err := sql.Open("sqlserver", connectString)
+		if err != nil {
+			return nil, fmt.Errorf("sql open: %w", err)
+		}
+
+		err = db.PingContext(pingCtx)
+		if err != nil {
+			common.LogCloserError(logger, db, "close connection")
+			return nil, fmt.Errorf("ping: %w", err)
+		}
+
+		return db, nil
+	})
 	if err != nil {
-		common.LogCloserError(logger, db, "close connection")
-		return nil, fmt.Errorf("ping: %w", err)
+		return nil, fmt.Errorf("acquire shared db: %w", err)
 	}
 
 	queryLogger := c.QueryLoggerFactory.Make(logger)
 
-	return []rdbms_utils.Connection{&Connection{db, queryLogger, params.DataSourceInstance, params.TableName}}, nil
+	return []rdbms_utils.Connection{
+		&Connection{db, queryLogger, params.DataSourceInstance, params.TableName},
+	}, nil
 }
 
-func (*connectionManager) Release(_ context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
+func (c *connectionManager) Release(_ context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
 	for _, conn := range cs {
-		common.LogCloserError(logger, conn, "close connection")
+		c.ReleaseSharedDB(logger, conn.(*Connection).db)
 	}
 }
 
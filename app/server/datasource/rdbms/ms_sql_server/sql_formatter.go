@@ -11,6 +11,7 @@ import (
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ rdbms_utils.SQLFormatter = (*sqlFormatter)(nil)
@@ -46,6 +47,10 @@ func (f *sqlFormatter) supportsType(typeID Ydb.Type_PrimitiveTypeId) bool {
 		return true
 	case Ydb.Type_TIMESTAMP:
 		return f.cfg.EnableTimestampPushdown
+	case Ydb.Type_DATE:
+		return f.cfg.EnableTimestampPushdown
+	case Ydb.Type_UUID:
+		return true
 	default:
 		return false
 	}
@@ -72,11 +77,41 @@ func (f sqlFormatter) SupportsExpression(expression *api_service_protos.TExpress
 		return false
 	case *api_service_protos.TExpression_Null:
 		return true
+	case *api_service_protos.TExpression_DateTimeFunction:
+		return f.SupportsExpression(e.DateTimeFunction.Value)
 	default:
 		return false
 	}
 }
 
+// TransformPredicateComparison rejects the ordering comparisons of UUIDs, because MS SQL Server
+// compares uniqueidentifier values starting from the last group, unlike YDB.
+// UUIDs are passed as strings and are implicitly converted to uniqueidentifier by MS SQL Server.
+func (sqlFormatter) TransformPredicateComparison(
+	src *api_service_protos.TPredicate_TComparison,
+) (*api_service_protos.TPredicate_TComparison, error) {
+	switch src.Operation {
+	case api_service_protos.TPredicate_TComparison_EQ, api_service_protos.TPredicate_TComparison_NE:
+		return src, nil
+	default:
+	}
+
+	if isUUIDValue(src.LeftValue) || isUUIDValue(src.RightValue) {
+		return nil, fmt.Errorf("ordering comparison of UUID values: %w", common.ErrUnimplementedOperation)
+	}
+
+	return src, nil
+}
+
+func isUUIDValue(expression *api_service_protos.TExpression) bool {
+	ydbType := expression.GetTypedValue().GetType()
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	return ydbType.GetTypeId() == Ydb.Type_UUID
+}
+
 func (sqlFormatter) GetPlaceholder(n int) string {
 	return fmt.Sprintf("@p%d", n+1)
 }
@@ -92,10 +127,166 @@ func (f sqlFormatter) FormatWhat(what *api_service_protos.TSelect_TWhat, _ strin
 	return rdbms_utils.FormatWhatDefault(f, what), nil
 }
 
+// MS SQL Server treats NULL values as the smallest ones, just like YQL does
+func (f sqlFormatter) FormatOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return rdbms_utils.FormatOrderByDefault(f, orderBy, false)
+}
+
+func (f sqlFormatter) MaxInSetParameters() int {
+	return rdbms_utils.MaxInSetParametersFromConfig(f.cfg)
+}
+
+func (sqlFormatter) LargeInSetMode() rdbms_utils.LargeInSetMode {
+	return rdbms_utils.LargeInSetModeValues
+}
+
+// FormatInValues embeds the large IN sets into the query as a table value constructor,
+// so they are not limited by the maximum number of parameters (2100).
+// Only integers are supported: the strings would get the default collation of the database
+// rather than the one of the column, and their comparison could fail with a collation conflict.
+func (sqlFormatter) FormatInValues(value string, set []any) (string, error) {
+	var sb strings.Builder
+
+	sb.WriteString("(")
+	sb.WriteString(value)
+	sb.WriteString(" IN (SELECT [v] FROM (VALUES ")
+
+	for i, element := range set {
+		literal, err := rdbms_utils.FormatIntegerLiteral(element)
+		if err != nil {
+			return "", fmt.Errorf("format set element #%d: %w", i, err)
+		}
+
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString("(")
+		sb.WriteString(literal)
+		sb.WriteString(")")
+	}
+
+	sb.WriteString(") AS [t]([v])))")
+
+	return sb.String(), nil
+}
+
+// FormatDateTimeFunction casts the results of DATEPART, which returns int, to the types mapped back
+// to the inferred ones: tinyint is unsigned in MS SQL Server, and Uint16 is read from int.
+func (sqlFormatter) FormatDateTimeFunction(
+	function api_service_protos.TExpression_TDateTimeFunction_EFunction,
+	value string,
+) (string, error) {
+	switch function {
+	case api_service_protos.TExpression_TDateTimeFunction_TO_DATE:
+		return fmt.Sprintf("CONVERT(date, %s)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_YEAR:
+		return fmt.Sprintf("CAST(DATEPART(year, %s) AS int)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_MONTH:
+		return fmt.Sprintf("CAST(DATEPART(month, %s) AS tinyint)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_DAY_OF_MONTH:
+		return fmt.Sprintf("CAST(DATEPART(day, %s) AS tinyint)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_HOUR:
+		return fmt.Sprintf("CAST(DATEPART(hour, %s) AS tinyint)", value), nil
+	default:
+		return "", fmt.Errorf("function %v: %w", function, common.ErrUnimplementedOperation)
+	}
+}
+
+// FormatComputedColumn converts the expression to the inferred type, because MS SQL Server
+// widens the results of arithmetical expressions and some functions (e.g. DATEPART returns int).
+// MS SQL Server has no unsigned integer types except tinyint, so Uint16 expressions are read from int,
+// and the expressions of wider unsigned types are not supported.
+func (sqlFormatter) FormatComputedColumn(expression string, ydbType *Ydb.Type, name string) (string, error) {
+	typeName, err := castTypeName(ydbType)
+	if err != nil {
+		return "", fmt.Errorf("cast type name: %w", err)
+	}
+
+	return fmt.Sprintf("CAST(%s AS %s) AS %s", expression, typeName, name), nil
+}
+
+// castTypeName returns the name of MS SQL Server type that is mapped back to the given YDB type.
+// All the values are nullable in MS SQL Server, so the optional types are unwrapped.
+func castTypeName(ydbType *Ydb.Type) (string, error) {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	if decimalType := ydbType.GetDecimalType(); decimalType != nil {
+		return fmt.Sprintf("decimal(%d, %d)", decimalType.Precision, decimalType.Scale), nil
+	}
+
+	switch ydbType.GetTypeId() {
+	case Ydb.Type_BOOL:
+		return "bit", nil
+	case Ydb.Type_UINT8:
+		return "tinyint", nil
+	case Ydb.Type_INT16:
+		return "smallint", nil
+	case Ydb.Type_INT32, Ydb.Type_UINT16:
+		return "int", nil
+	case Ydb.Type_INT64:
+		return "bigint", nil
+	case Ydb.Type_FLOAT:
+		return "real", nil
+	case Ydb.Type_DOUBLE:
+		return "float", nil
+	case Ydb.Type_STRING:
+		return "varbinary(max)", nil
+	case Ydb.Type_UTF8:
+		return "nvarchar(max)", nil
+	case Ydb.Type_DATE:
+		return "date", nil
+	case Ydb.Type_TIMESTAMP:
+		return "datetime2", nil
+	default:
+		return "", fmt.Errorf("type %v: %w", ydbType, common.ErrUnsupportedExpression)
+	}
+}
+
 func (f sqlFormatter) FormatFrom(tableName string) string {
 	return f.SanitiseIdentifier(tableName)
 }
 
+// RenderSelectQueryText uses `TOP` clause to limit the number of rows.
+// OFFSET can be used only within ORDER BY clause, so the artificial ordering is added in this case.
+func (sqlFormatter) RenderSelectQueryText(
+	parts *rdbms_utils.SelectQueryParts,
+	_ *api_service_protos.TSplit,
+) (string, error) {
+	var sb strings.Builder
+
+	sb.WriteString("SELECT ")
+
+	if parts.Limit != 0 && parts.Offset == 0 {
+		sb.WriteString(fmt.Sprintf("TOP %d ", parts.Limit))
+	}
+
+	sb.WriteString(parts.SelectClause)
+	sb.WriteString(" FROM ")
+	sb.WriteString(parts.FromClause)
+
+	if parts.WhereClause != "" {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(parts.WhereClause)
+	}
+
+	if parts.Limit != 0 && parts.Offset != 0 {
+		// OFFSET requires ORDER BY clause, so arbitrary order is requested if no ordering is specified
+		orderBy := parts.OrderByClause
+		if orderBy == "" {
+			orderBy = "(SELECT NULL)"
+		}
+
+		sb.WriteString(fmt.Sprintf(" ORDER BY %s OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", orderBy, parts.Offset, parts.Limit))
+	} else {
+		sb.WriteString(rdbms_utils.FormatOrderByClauseDefault(parts))
+	}
+
+	return sb.String(), nil
+}
+
 func NewSQLFormatter(cfg *config.TPushdownConfig) rdbms_utils.SQLFormatter {
 	return sqlFormatter{cfg: cfg}
 }
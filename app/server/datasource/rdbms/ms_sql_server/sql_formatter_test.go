@@ -0,0 +1,494 @@
+package ms_sql_server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ydb "github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestMakeSelectQuery(t *testing.T) {
+	type testCase struct {
+		testName    string
+		formatter   rdbms_utils.SQLFormatter
+		selectReq   *api_service_protos.TSelect
+		outputQuery string
+		outputArgs  []any
+		err         error
+	}
+
+	logger := common.NewTestLogger(t)
+	pushdownCfg := &config.TPushdownConfig{}
+
+	tcs := []testCase{
+		{
+			testName:  "no_limit",
+			formatter: NewSQLFormatter(pushdownCfg),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  0,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_MS_SQL_SERVER,
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM "tab"`,
+			err:         nil,
+		},
+		{
+			testName:  "limit",
+			formatter: NewSQLFormatter(pushdownCfg),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_MS_SQL_SERVER,
+				},
+			},
+			outputQuery: `SELECT TOP 10 "col0", "col1" FROM "tab"`,
+			err:         nil,
+		},
+		{
+			testName:  "limit_with_filter",
+			formatter: NewSQLFormatter(pushdownCfg),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_IsNull{
+							IsNull: &api_service_protos.TPredicate_TIsNull{
+								Value: rdbms_utils.NewColumnExpression("col1"),
+							},
+						},
+					},
+				},
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_MS_SQL_SERVER,
+				},
+			},
+			outputQuery: `SELECT TOP 10 "col0", "col1" FROM "tab" WHERE ("col1" IS NULL)`,
+			err:         nil,
+		},
+		{
+			testName:  "limit_offset",
+			formatter: NewSQLFormatter(pushdownCfg),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_IsNull{
+							IsNull: &api_service_protos.TPredicate_TIsNull{
+								Value: rdbms_utils.NewColumnExpression("col1"),
+							},
+						},
+					},
+				},
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 5,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_MS_SQL_SERVER,
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE ("col1" IS NULL) ORDER BY (SELECT NULL) OFFSET 5 ROWS FETCH NEXT 10 ROWS ONLY`,
+			err:         nil,
+		},
+		{
+			testName:  "uuid_filter",
+			formatter: NewSQLFormatter(pushdownCfg),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_Comparison{
+							Comparison: &api_service_protos.TPredicate_TComparison{
+								Operation:  api_service_protos.TPredicate_TComparison_EQ,
+								LeftValue:  rdbms_utils.NewColumnExpression("col2"),
+								RightValue: newUUIDValueExpression(),
+							},
+						},
+					},
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_MS_SQL_SERVER,
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE ("col2" = @p1)`,
+			outputArgs:  []any{"00112233-4455-6677-8899-aabbccddeeff"},
+			err:         nil,
+		},
+		{
+			testName:  "uuid_ordering_filter",
+			formatter: NewSQLFormatter(pushdownCfg),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_Comparison{
+							Comparison: &api_service_protos.TPredicate_TComparison{
+								Operation:  api_service_protos.TPredicate_TComparison_L,
+								LeftValue:  rdbms_utils.NewColumnExpression("col2"),
+								RightValue: newUUIDValueExpression(),
+							},
+						},
+					},
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_MS_SQL_SERVER,
+				},
+			},
+			// MS SQL Server orders UUIDs differently, so the filter is not pushed down
+			outputQuery: `SELECT "col0", "col1" FROM "tab"`,
+			err:         nil,
+		},
+		{
+			testName:  "order_by_top",
+			formatter: NewSQLFormatter(pushdownCfg),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What:    rdbms_utils.NewDefaultWhat(),
+				OrderBy: rdbms_utils.NewDefaultOrderBy(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_MS_SQL_SERVER,
+				},
+			},
+			outputQuery: `SELECT TOP 10 "col0", "col1" FROM "tab" ORDER BY "col0" DESC, "col1" ASC`,
+			err:         nil,
+		},
+		{
+			testName:  "order_by_offset",
+			formatter: NewSQLFormatter(pushdownCfg),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What:    rdbms_utils.NewDefaultWhat(),
+				OrderBy: rdbms_utils.NewDefaultOrderBy(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 5,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_MS_SQL_SERVER,
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM "tab" ORDER BY "col0" DESC, "col1" ASC OFFSET 5 ROWS FETCH NEXT 10 ROWS ONLY`,
+			err:         nil,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			readSplitsQuery, err := rdbms_utils.MakeSelectQuery(
+				context.Background(),
+				logger,
+				tc.formatter,
+				&api_service_protos.TSplit{Select: tc.selectReq},
+				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+				tc.selectReq.From.Table,
+			)
+
+			if tc.err != nil {
+				require.True(t, errors.Is(err, tc.err), err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.outputQuery, readSplitsQuery.QueryText)
+
+			if tc.outputArgs != nil {
+				require.Equal(t, tc.outputArgs, readSplitsQuery.QueryArgs.Values())
+			}
+
+			require.Equal(t, []*ydb.Type{
+				common.MakePrimitiveType(ydb.Type_INT32),
+				common.MakePrimitiveType(ydb.Type_STRING),
+			}, common.YDBColumnsToYDBTypes(readSplitsQuery.YdbColumns))
+		})
+	}
+}
+
+func newUUIDValueExpression() *api_service_protos.TExpression {
+	// the halves of 00112233-4455-6677-8899-aabbccddeeff in YDB byte order
+	return &api_service_protos.TExpression{
+		Payload: &api_service_protos.TExpression_TypedValue{
+			TypedValue: &ydb.TypedValue{
+				Type: common.MakePrimitiveType(ydb.Type_UUID),
+				Value: &ydb.Value{
+					Value:    &ydb.Value_Low_128{Low_128: 0x6677445500112233},
+					High_128: 0xffeeddccbbaa9988,
+				},
+			},
+		},
+	}
+}
+
+func newDoubleValueExpression(val float64) *api_service_protos.TExpression {
+	return &api_service_protos.TExpression{
+		Payload: &api_service_protos.TExpression_TypedValue{
+			TypedValue: common.MakeTypedValue(common.MakePrimitiveType(ydb.Type_DOUBLE), val),
+		},
+	}
+}
+
+func TestFormatComputedColumn(t *testing.T) {
+	type testCase struct {
+		testName string
+		ydbType  *ydb.Type
+		output   string
+		err      error
+	}
+
+	formatter := NewSQLFormatter(nil)
+
+	tcs := []testCase{
+		{
+			testName: "int32",
+			ydbType:  common.MakePrimitiveType(ydb.Type_INT32),
+			output:   `CAST(([col0] * [col1]) AS int) AS [product]`,
+		},
+		{
+			testName: "optional_double",
+			ydbType:  common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_DOUBLE)),
+			output:   `CAST(([col0] * [col1]) AS float) AS [product]`,
+		},
+		{
+			testName: "decimal",
+			ydbType:  common.MakeDecimalType(10, 2),
+			output:   `CAST(([col0] * [col1]) AS decimal(10, 2)) AS [product]`,
+		},
+		{
+			testName: "date",
+			ydbType:  common.MakePrimitiveType(ydb.Type_DATE),
+			output:   `CAST(([col0] * [col1]) AS date) AS [product]`,
+		},
+		{
+			testName: "uint8",
+			ydbType:  common.MakePrimitiveType(ydb.Type_UINT8),
+			output:   `CAST(([col0] * [col1]) AS tinyint) AS [product]`,
+		},
+		{
+			testName: "uint16",
+			ydbType:  common.MakePrimitiveType(ydb.Type_UINT16),
+			output:   `CAST(([col0] * [col1]) AS int) AS [product]`,
+		},
+		{
+			testName: "uint32",
+			ydbType:  common.MakePrimitiveType(ydb.Type_UINT32),
+			err:      common.ErrUnsupportedExpression,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			output, err := formatter.FormatComputedColumn(`([col0] * [col1])`, tc.ydbType, `[product]`)
+			if tc.err != nil {
+				require.True(t, errors.Is(err, tc.err), err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestFormatDateTimeFunction(t *testing.T) {
+	type testCase struct {
+		function api_service_protos.TExpression_TDateTimeFunction_EFunction
+		output   string
+	}
+
+	formatter := NewSQLFormatter(nil)
+
+	// the results are cast to the types mapped back to the ones inferred for the functions
+	tcs := []testCase{
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_TO_DATE,
+			output:   `CONVERT(date, [col0])`,
+		},
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_GET_YEAR,
+			output:   `CAST(DATEPART(year, [col0]) AS int)`,
+		},
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_GET_MONTH,
+			output:   `CAST(DATEPART(month, [col0]) AS tinyint)`,
+		},
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_GET_DAY_OF_MONTH,
+			output:   `CAST(DATEPART(day, [col0]) AS tinyint)`,
+		},
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_GET_HOUR,
+			output:   `CAST(DATEPART(hour, [col0]) AS tinyint)`,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.function.String(), func(t *testing.T) {
+			output, err := formatter.FormatDateTimeFunction(tc.function, `[col0]`)
+			require.NoError(t, err)
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestMakeSelectQueryWithInSet(t *testing.T) {
+	type testCase struct {
+		testName    string
+		filter      *api_service_protos.TPredicate
+		outputQuery string
+		outputArgs  []any
+	}
+
+	logger := common.NewTestLogger(t)
+	formatter := NewSQLFormatter(&config.TPushdownConfig{MaxInSetParameters: 2})
+
+	tcs := []testCase{
+		{
+			testName: "separate_parameters",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE ("col0" IN (@p1, @p2))`,
+			outputArgs:  []any{int32(1), int32(2)},
+		},
+		{
+			testName: "values",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+				rdbms_utils.NewInt32ValueExpression(3),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE ("col0" IN (SELECT [v] FROM (VALUES (1), (2), (3)) AS [t]([v])))`,
+			outputArgs:  []any{},
+		},
+		{
+			// the large set cannot be made of the values of different types, so the predicate is not pushed down
+			testName: "values_of_different_types",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+				rdbms_utils.NewInt64ValueExpression(3),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab"`,
+			outputArgs:  []any{},
+		},
+		{
+			testName: "double_parameters",
+			filter: rdbms_utils.NewInPredicate("col0",
+				newDoubleValueExpression(1.5),
+				newDoubleValueExpression(2.5),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE ("col0" IN (@p1, @p2))`,
+			outputArgs:  []any{1.5, 2.5},
+		},
+		{
+			// the table of values is made of integer literals only, so the large set of doubles is not pushed down
+			testName: "double_values",
+			filter: rdbms_utils.NewInPredicate("col0",
+				newDoubleValueExpression(1.5),
+				newDoubleValueExpression(2.5),
+				newDoubleValueExpression(3.5),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab"`,
+			outputArgs:  []any{},
+		},
+		{
+			// the limit on the number of parameters applies to the whole query
+			testName: "parameters_per_query",
+			filter: rdbms_utils.NewConjunctionPredicate(
+				rdbms_utils.NewInPredicate("col0",
+					rdbms_utils.NewInt32ValueExpression(1),
+					rdbms_utils.NewInt32ValueExpression(2),
+				),
+				rdbms_utils.NewInPredicate("col0",
+					rdbms_utils.NewInt32ValueExpression(3),
+					rdbms_utils.NewInt32ValueExpression(4),
+				),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE (("col0" IN (@p1, @p2)) AND ("col0" IN (SELECT [v] FROM (VALUES (3), (4)) AS [t]([v]))))`,
+			outputArgs:  []any{int32(1), int32(2)},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			split := &api_service_protos.TSplit{
+				Select: &api_service_protos.TSelect{
+					From:  &api_service_protos.TSelect_TFrom{Table: "tab"},
+					What:  rdbms_utils.NewDefaultWhat(),
+					Where: &api_service_protos.TSelect_TWhere{FilterTyped: tc.filter},
+					DataSourceInstance: &api_common.TGenericDataSourceInstance{
+						Kind: api_common.EGenericDataSourceKind_MS_SQL_SERVER,
+					},
+				},
+			}
+
+			readSplitsQuery, err := rdbms_utils.MakeSelectQuery(
+				context.Background(),
+				logger,
+				formatter,
+				split,
+				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+				"tab",
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputQuery, readSplitsQuery.QueryText)
+			require.Equal(t, tc.outputArgs, readSplitsQuery.QueryArgs.Values())
+		})
+	}
+}
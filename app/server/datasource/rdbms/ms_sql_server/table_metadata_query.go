@@ -3,13 +3,20 @@ package ms_sql_server
 import (
 	_ "github.com/denisenkom/go-mssqldb"
 
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 )
 
 func TableMetadataQuery(request *api_service_protos.TDescribeTableRequest) (string, *rdbms_utils.QueryArgs) {
 	// opts := request.GetDataSourceInstance().GetPgOptions().GetSchema()
-	query := "SELECT COLUMN_NAME, DATA_TYPE FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_NAME = @p1;"
+	// The precision and scale of decimal columns are required to choose the appropriate YDB type,
+	// so they are described like `decimal(p,s)`.
+	query := "SELECT COLUMN_NAME, " +
+		"CASE WHEN DATA_TYPE IN ('decimal', 'numeric') " +
+		"THEN CONCAT(DATA_TYPE, '(', NUMERIC_PRECISION, ',', NUMERIC_SCALE, ')') " +
+		"ELSE DATA_TYPE END " +
+		"FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_NAME = @p1;"
 
 	var args rdbms_utils.QueryArgs
 
@@ -17,3 +24,29 @@ func TableMetadataQuery(request *api_service_protos.TDescribeTableRequest) (stri
 
 	return query, &args
 }
+
+// TableKeyQuery returns the columns of the primary key of the table in the order of the key
+func TableKeyQuery(_ *api_common.TGenericDataSourceInstance, tableName string) (string, *rdbms_utils.QueryArgs) {
+	query := "SELECT c.COLUMN_NAME, c.DATA_TYPE " +
+		"FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS t " +
+		"JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE k ON " +
+		"k.CONSTRAINT_SCHEMA = t.CONSTRAINT_SCHEMA AND k.CONSTRAINT_NAME = t.CONSTRAINT_NAME " +
+		"JOIN INFORMATION_SCHEMA.COLUMNS c ON " +
+		"c.TABLE_SCHEMA = k.TABLE_SCHEMA AND c.TABLE_NAME = k.TABLE_NAME AND c.COLUMN_NAME = k.COLUMN_NAME " +
+		"WHERE t.CONSTRAINT_TYPE = 'PRIMARY KEY' AND t.TABLE_NAME = @p1 AND t.TABLE_SCHEMA = SCHEMA_NAME() " +
+		"ORDER BY k.ORDINAL_POSITION;"
+
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(tableName)
+
+	return query, &args
+}
+
+func TableListQuery(_ *api_service_protos.TListTablesRequest) (string, *rdbms_utils.QueryArgs) {
+	// Table names are not qualified with the schema in the queries, so they are resolved
+	// in the default schema of the user; the tables from the other schemas cannot be read.
+	query := "SELECT TABLE_NAME FROM INFORMATION_SCHEMA.TABLES WHERE TABLE_SCHEMA = SCHEMA_NAME() ORDER BY TABLE_NAME;"
+
+	return query, &rdbms_utils.QueryArgs{}
+}
@@ -2,9 +2,12 @@ package ms_sql_server
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/shopspring/decimal"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
@@ -18,10 +21,12 @@ import (
 
 var _ datasource.TypeMapper = typeMapper{}
 
-type typeMapper struct{}
+type typeMapper struct {
+	isDecimal *regexp.Regexp
+}
 
 //nolint:gocyclo
-func (typeMapper) SQLTypeToYDBColumn(columnName, typeName string, rules *api_service_protos.TTypeMappingSettings) (*Ydb.Column, error) {
+func (tm typeMapper) SQLTypeToYDBColumn(columnName, typeName string, rules *api_service_protos.TTypeMappingSettings) (*Ydb.Column, error) {
 	var (
 		ydbType *Ydb.Type
 		err     error
@@ -51,8 +56,18 @@ func (typeMapper) SQLTypeToYDBColumn(columnName, typeName string, rules *api_ser
 		ydbType = common.MakePrimitiveType(Ydb.Type_DOUBLE)
 	case "binary", "varbinary", "image":
 		ydbType = common.MakePrimitiveType(Ydb.Type_STRING)
-	case "char", "varchar", "text", "nchar", "nvarchar", "ntext":
+	case "char", "varchar", "text", "nchar", "nvarchar", "ntext", "xml":
 		ydbType = common.MakePrimitiveType(Ydb.Type_UTF8)
+	case "money":
+		// money keeps 8 bytes with the fixed scale of 4 digits
+		ydbType = common.MakeDecimalType(19, 4)
+	case "smallmoney":
+		ydbType = common.MakeDecimalType(10, 4)
+	case "uniqueidentifier":
+		ydbType = common.MakePrimitiveType(Ydb.Type_UUID)
+	case "time":
+		// YDB has no separate type representing time of the day, so it is mapped to the interval since midnight
+		ydbType = common.MakePrimitiveType(Ydb.Type_INTERVAL)
 	case "date":
 		ydbType, err = common.MakeYdbDateTimeType(Ydb.Type_DATE, rules.GetDateTimeFormat())
 
@@ -65,14 +80,20 @@ func (typeMapper) SQLTypeToYDBColumn(columnName, typeName string, rules *api_ser
 		if err != nil {
 			return nil, fmt.Errorf("make YDB date time type: %w", err)
 		}
-	case "datetime", "datetime2":
+	case "datetime", "datetime2", "datetimeoffset":
+		// datetimeoffset values are normalized to UTC
 		ydbType, err = common.MakeYdbDateTimeType(Ydb.Type_TIMESTAMP, rules.GetDateTimeFormat())
 
 		if err != nil {
 			return nil, fmt.Errorf("make YDB date time type: %w", err)
 		}
 	default:
-		return nil, fmt.Errorf("convert type '%s': %w", typeName, common.ErrDataTypeNotSupported)
+		// decimal and numeric types are described with their precision and scale
+		if !tm.isDecimal.MatchString(typeName) {
+			return nil, fmt.Errorf("convert type '%s': %w", typeName, common.ErrDataTypeNotSupported)
+		}
+
+		ydbType, err = tm.decimalToYDBType(typeName)
 	}
 
 	if err != nil {
@@ -87,9 +108,30 @@ func (typeMapper) SQLTypeToYDBColumn(columnName, typeName string, rules *api_ser
 	}, nil
 }
 
+// decimalToYDBType maps decimal(p,s) to YDB Decimal if it fits its precision, otherwise to string
+func (tm typeMapper) decimalToYDBType(typeName string) (*Ydb.Type, error) {
+	matches := tm.isDecimal.FindStringSubmatch(typeName)
+
+	precision, err := strconv.ParseUint(matches[tm.isDecimal.SubexpIndex("precision")], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parse precision: %w", err)
+	}
+
+	scale, err := strconv.ParseUint(matches[tm.isDecimal.SubexpIndex("scale")], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parse scale: %w", err)
+	}
+
+	// MS SQL Server decimal keeps up to 38 digits, the wider values are passed in their textual representation
+	if precision > common.DecimalMaxPrecision {
+		return common.MakePrimitiveType(Ydb.Type_UTF8), nil
+	}
+
+	return common.MakeDecimalType(uint32(precision), uint32(scale)), nil
+}
+
 //nolint:funlen,gocyclo
 func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion.Collection) (paging.RowTransformer[any], error) {
-	_ = ydbTypes
 	acceptors := make([]any, 0, len(types))
 	appenders := make([]func(acceptor any, builder array.Builder) error, 0, len(types))
 
@@ -99,6 +141,14 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 			acceptors = append(acceptors, new(*bool))
 			appenders = append(appenders, utils.MakeAppenderNullable[bool, uint8, *array.Uint8Builder](cc.Bool()))
 		case "TINYINT":
+			// the results of datetime functions are inferred as Uint8
+			if isUnsignedType(ydbTypes[i], Ydb.Type_UINT8) {
+				acceptors = append(acceptors, new(*uint8))
+				appenders = append(appenders, utils.MakeAppenderNullable[uint8, uint8, *array.Uint8Builder](cc.Uint8()))
+
+				break
+			}
+
 			acceptors = append(acceptors, new(*int8))
 			appenders = append(appenders, utils.MakeAppenderNullable[int8, int8, *array.Int8Builder](cc.Int8()))
 		case "SMALLINT":
@@ -106,6 +156,15 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 			appenders = append(appenders, utils.MakeAppenderNullable[int16, int16, *array.Int16Builder](cc.Int16()))
 		case "INT":
 			acceptors = append(acceptors, new(*int32))
+
+			// the results of datetime functions inferred as Uint16 are read from int
+			if isUnsignedType(ydbTypes[i], Ydb.Type_UINT16) {
+				appenders = append(appenders,
+					utils.MakeAppenderNullable[int32, uint16, *array.Uint16Builder](conversion.SignedToUnsigned[int32, uint16]()))
+
+				break
+			}
+
 			appenders = append(appenders, utils.MakeAppenderNullable[int32, int32, *array.Int32Builder](cc.Int32()))
 		case "BIGINT":
 			acceptors = append(acceptors, new(*int64))
@@ -128,9 +187,27 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 
 				return nil
 			})
-		case "CHAR", "VARCHAR", "TEXT", "NCHAR", "NVARCHAR", "NTEXT":
+		case "CHAR", "VARCHAR", "TEXT", "NCHAR", "NVARCHAR", "NTEXT", "XML":
 			acceptors = append(acceptors, new(*string))
 			appenders = append(appenders, utils.MakeAppenderNullable[string, string, *array.StringBuilder](cc.String()))
+		case "DECIMAL", "MONEY", "SMALLMONEY":
+			// the driver passes decimals in their textual representation
+			acceptors = append(acceptors, new(*string))
+
+			appender, err := makeDecimalAppender(ydbTypes[i], cc)
+			if err != nil {
+				return nil, fmt.Errorf("make decimal appender for ms sql server type %v: %w", types[i], err)
+			}
+
+			appenders = append(appenders, appender)
+		case "UNIQUEIDENTIFIER":
+			// the driver returns the raw bytes of uniqueidentifier, which are stored by MS SQL Server
+			// with the first three groups in little-endian order, exactly like YDB Uuid
+			acceptors = append(acceptors, new(*[]byte))
+			appenders = append(appenders, utils.MakeAppenderNullable[[]byte, []byte, *array.FixedSizeBinaryBuilder](cc.Bytes()))
+		case "TIME":
+			acceptors = append(acceptors, new(*time.Time))
+			appenders = append(appenders, utils.MakeAppenderNullable[time.Time, int64, *array.Int64Builder](timeToIntervalConverter{}))
 		case "DATE":
 			acceptors = append(acceptors, new(*time.Time))
 
@@ -167,7 +244,7 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 					"unexpected ydb type %v for ms sql server type %v: %w",
 					ydbTypes[i], types[i], common.ErrDataTypeNotSupported)
 			}
-		case "DATETIME", "DATETIME2":
+		case "DATETIME", "DATETIME2", "DATETIMEOFFSET":
 			acceptors = append(acceptors, new(*time.Time))
 
 			ydbTypeID, err := common.YdbTypeToYdbPrimitiveTypeID(ydbTypes[i])
@@ -194,4 +271,62 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 	return paging.NewRowTransformer[any](acceptors, appenders, nil), nil
 }
 
-func NewTypeMapper() datasource.TypeMapper { return typeMapper{} }
+// isUnsignedType checks if the (possibly optional) YDB type is the given unsigned type
+func isUnsignedType(ydbType *Ydb.Type, typeID Ydb.Type_PrimitiveTypeId) bool {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	return ydbType.GetTypeId() == typeID
+}
+
+func makeDecimalAppender(ydbType *Ydb.Type, cc conversion.Collection) (func(acceptor any, builder array.Builder) error, error) {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	if decimalType := ydbType.GetDecimalType(); decimalType != nil {
+		return utils.MakeAppenderNullable[string, []byte, *array.FixedSizeBinaryBuilder](decimalConverter{decimalType: decimalType}), nil
+	}
+
+	if ydbType.GetTypeId() == Ydb.Type_UTF8 {
+		return utils.MakeAppenderNullable[string, string, *array.StringBuilder](cc.String()), nil
+	}
+
+	return nil, fmt.Errorf("unexpected ydb type %v for decimal: %w", ydbType, common.ErrDataTypeNotSupported)
+}
+
+type decimalConverter struct {
+	decimalType *Ydb.DecimalType
+}
+
+func (c decimalConverter) Convert(in *string) ([]byte, error) {
+	value, err := decimal.NewFromString(*in)
+	if err != nil {
+		return nil, fmt.Errorf("parse decimal '%s': %w", *in, err)
+	}
+
+	unscaled, err := common.RescaleDecimal(value.Coefficient(), value.Exponent(), c.decimalType.Scale)
+	if err != nil {
+		return nil, fmt.Errorf("rescale decimal: %w", err)
+	}
+
+	return common.DecimalToBytes(unscaled, c.decimalType.Precision)
+}
+
+// timeToIntervalConverter converts the time of the day into YDB Interval since midnight
+type timeToIntervalConverter struct{}
+
+func (timeToIntervalConverter) Convert(in *time.Time) (int64, error) {
+	hour, minute, second := in.Clock()
+	duration := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+		time.Duration(second)*time.Second + time.Duration(in.Nanosecond())
+
+	return duration.Microseconds(), nil
+}
+
+func NewTypeMapper() datasource.TypeMapper {
+	return typeMapper{
+		isDecimal: regexp.MustCompile(`^(decimal|numeric)\((?P<precision>\d+),(?P<scale>\d+)\)$`),
+	}
+}
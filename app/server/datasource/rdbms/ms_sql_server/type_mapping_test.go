@@ -0,0 +1,69 @@
+package ms_sql_server
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/ptr"
+)
+
+func TestSQLTypeToYDBColumn(t *testing.T) {
+	rules := &api_service_protos.TTypeMappingSettings{DateTimeFormat: api_service_protos.EDateTimeFormat_YQL_FORMAT}
+
+	testCases := []struct {
+		typeName string
+		expected *Ydb.Type
+	}{
+		{typeName: "decimal(10,2)", expected: common.MakeDecimalType(10, 2)},
+		{typeName: "numeric(35,0)", expected: common.MakeDecimalType(35, 0)},
+		{typeName: "decimal(38,10)", expected: common.MakePrimitiveType(Ydb.Type_UTF8)},
+		{typeName: "money", expected: common.MakeDecimalType(19, 4)},
+		{typeName: "smallmoney", expected: common.MakeDecimalType(10, 4)},
+		{typeName: "uniqueidentifier", expected: common.MakePrimitiveType(Ydb.Type_UUID)},
+		{typeName: "datetimeoffset", expected: common.MakePrimitiveType(Ydb.Type_TIMESTAMP)},
+		{typeName: "time", expected: common.MakePrimitiveType(Ydb.Type_INTERVAL)},
+		{typeName: "xml", expected: common.MakePrimitiveType(Ydb.Type_UTF8)},
+	}
+
+	tm := NewTypeMapper()
+
+	for _, tc := range testCases {
+		column, err := tm.SQLTypeToYDBColumn("col", tc.typeName, rules)
+		require.NoError(t, err, tc.typeName)
+		require.Equal(t, common.MakeOptionalType(tc.expected), column.Type, tc.typeName)
+	}
+
+	_, err := tm.SQLTypeToYDBColumn("col", "geography", rules)
+	require.ErrorIs(t, err, common.ErrDataTypeNotSupported)
+}
+
+func TestDecimalConverter(t *testing.T) {
+	converter := decimalConverter{decimalType: &Ydb.DecimalType{Precision: 19, Scale: 4}}
+
+	actual, err := converter.Convert(ptr.String("-12.3000"))
+	require.NoError(t, err)
+
+	expected, err := common.DecimalToBytes(big.NewInt(-123000), 19)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	_, err = converter.Convert(ptr.String("12.34567"))
+	require.True(t, errors.Is(err, common.ErrValueOutOfTypeBounds))
+}
+
+func TestTimeToIntervalConverter(t *testing.T) {
+	// the driver returns time of the day as the first day of the first year
+	value := time.Date(1, 1, 1, 12, 34, 56, 1234567, time.UTC)
+
+	actual, err := timeToIntervalConverter{}.Convert(&value)
+	require.NoError(t, err)
+	require.Equal(t, (12*time.Hour + 34*time.Minute + 56*time.Second + 1234*time.Microsecond).Microseconds(), actual)
+}
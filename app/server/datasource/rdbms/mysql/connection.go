@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"context"
 	"fmt"
 	"sync/atomic"
 	"time"
@@ -15,10 +16,30 @@ import (
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
+var _ rdbms_utils.PooledConnection = (*pooledConnection)(nil)
+
+// pooledConnection is a physical connection to MySQL that can be shared between requests
+type pooledConnection struct {
+	*client.Conn
+	// The number of queries which results are still being streamed
+	pendingQueries atomic.Int32
+	// Set when a query has failed, so the state of the protocol is unknown
+	broken atomic.Bool
+}
+
+func (c *pooledConnection) Ping(_ context.Context) error {
+	return c.Conn.Ping()
+}
+
+func (c *pooledConnection) Reusable() bool {
+	return c.pendingQueries.Load() == 0 && !c.broken.Load()
+}
+
 var _ rdbms_utils.Connection = (*connection)(nil)
 
 type connection struct {
 	queryLogger        common.QueryLogger
+	pooled             *pooledConnection
 	conn               *client.Conn
 	cfg                *config.TMySQLConfig
 	dataSourceInstance *api_common.TGenericDataSourceInstance
@@ -62,14 +83,19 @@ func (c *connection) Query(params *rdbms_utils.QueryParams) (rdbms_utils.Rows, e
 
 	stmt, err := c.conn.Prepare(params.QueryText)
 	if err != nil {
+		c.pooled.broken.Store(true)
+
 		return r, fmt.Errorf("mysql: failed to prepare query: %w", err)
 	}
 
+	// The connection is busy until the whole result set is read from it
+	c.pooled.pendingQueries.Add(1)
+
 	go func() {
 		defer close(r.rowChan)
 		defer close(r.errChan)
 
-		r.errChan <- stmt.ExecuteSelectStreaming(
+		err := stmt.ExecuteSelectStreaming(
 			result,
 			// In per-row handler copy entire row. The driver re-uses memory allocated for single row,
 			// so we need either to lock the row until the reader is done its reading and processing
@@ -103,6 +129,18 @@ func (c *connection) Query(params *rdbms_utils.QueryParams) (rdbms_utils.Rows, e
 			nil,
 			transformArgs(params.QueryArgs)...,
 		)
+
+		if err == nil {
+			err = stmt.Close()
+		}
+
+		if err != nil {
+			c.pooled.broken.Store(true)
+		}
+
+		c.pooled.pendingQueries.Add(-1)
+
+		r.errChan <- err
 	}()
 
 	return r, nil
@@ -21,7 +21,6 @@ var _ rdbms_utils.ConnectionManager = (*connectionManager)(nil)
 type connectionManager struct {
 	rdbms_utils.ConnectionManagerBase
 	cfg *config.TMySQLConfig
-	// TODO: cache of connections, remove unused connections with TTL
 }
 
 func (c *connectionManager) Make(
@@ -63,31 +62,42 @@ func (c *connectionManager) Make(
 	openConnectionCtx, openConnectionCtxCancel := context.WithTimeout(ctx, common.MustDurationFromString(c.cfg.OpenConnectionTimeout))
 	defer openConnectionCtxCancel()
 
-	conn, err := client.ConnectWithDialer(
-		openConnectionCtx,
-		proto,
-		addr,
-		user,
-		password,
-		db,
-		dialer.DialContext,
-		optionFuncs...)
+	pooled, err := c.AcquireConnection(openConnectionCtx, logger, dsi, func() (rdbms_utils.PooledConnection, error) {
+		conn, err := client.ConnectWithDialer(
+			openConnectionCtx,
+			proto,
+			addr,
+			user,
+			password,
+			db,
+			dialer.DialContext,
+			optionFuncs...)
+		if err != nil {
+			return nil, fmt.Errorf("connect with dialer: %w", pingcap_errors.Cause(err))
+		}
+
+		// YQ-3608: force using UTC for date/time formats were possible
+		_, err = conn.Execute("SET time_zone = 'UTC'")
+		if err != nil {
+			common.LogCloserError(logger, conn, "close connection")
+
+			return nil, fmt.Errorf("set time zone: %w", err)
+		}
+
+		return &pooledConnection{Conn: conn}, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("connect with dialer: %w", pingcap_errors.Cause(err))
+		return nil, fmt.Errorf("acquire connection: %w", err)
 	}
 
-	// YQ-3608: force using UTC for date/time formats were possible
-	_, err = conn.Execute("SET time_zone = 'UTC'")
-	if err != nil {
-		return nil, fmt.Errorf("set time zone: %w", err)
-	}
+	conn := pooled.(*pooledConnection)
 
-	return []rdbms_utils.Connection{&connection{queryLogger, conn, c.cfg, dsi, params.TableName}}, nil
+	return []rdbms_utils.Connection{&connection{queryLogger, conn, conn.Conn, c.cfg, dsi, params.TableName}}, nil
 }
 
-func (*connectionManager) Release(_ context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
-	for _, cs := range cs {
-		common.LogCloserError(logger, cs, "close connection")
+func (c *connectionManager) Release(_ context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
+	for _, conn := range cs {
+		c.ReleaseConnection(logger, conn.(*connection).pooled)
 	}
 }
 
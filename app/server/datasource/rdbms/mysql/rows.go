@@ -99,7 +99,11 @@ func scanToDest(
 	var err error
 
 	switch valueType {
-	case mysql.MYSQL_TYPE_STRING, mysql.MYSQL_TYPE_VARCHAR, mysql.MYSQL_TYPE_VAR_STRING, mysql.MYSQL_TYPE_JSON:
+	case mysql.MYSQL_TYPE_STRING, mysql.MYSQL_TYPE_VARCHAR, mysql.MYSQL_TYPE_VAR_STRING, mysql.MYSQL_TYPE_JSON,
+		mysql.MYSQL_TYPE_ENUM, mysql.MYSQL_TYPE_SET:
+		err = scanStringValue[[]byte, string](dest, value, fieldValueType)
+	// DECIMAL and TIME values are passed as strings and are parsed by the transformer
+	case mysql.MYSQL_TYPE_NEWDECIMAL, mysql.MYSQL_TYPE_DECIMAL, mysql.MYSQL_TYPE_TIME:
 		err = scanStringValue[[]byte, string](dest, value, fieldValueType)
 	case mysql.MYSQL_TYPE_MEDIUM_BLOB, mysql.MYSQL_TYPE_LONG_BLOB, mysql.MYSQL_TYPE_BLOB, mysql.MYSQL_TYPE_TINY_BLOB:
 		// MySQL returns both TEXT and BLOB types as []byte, so we have to check destination beforehand
@@ -121,6 +125,11 @@ func scanToDest(
 		} else {
 			err = scanNumberValue[int64, int32](dest, value, fieldValueType)
 		}
+	case mysql.MYSQL_TYPE_BIT:
+		err = scanBitValue(dest, value, fieldValueType)
+	// YEAR is always unsigned
+	case mysql.MYSQL_TYPE_YEAR:
+		err = scanNumberValue[uint64, uint16](dest, value, fieldValueType)
 	case mysql.MYSQL_TYPE_SHORT:
 		if flag == mysql.UNSIGNED_FLAG {
 			err = scanNumberValue[uint64, uint16](dest, value, fieldValueType)
@@ -220,6 +229,26 @@ func scanBoolValue(dest, value any, fieldValueType mysql.FieldValueType) error {
 	return nil
 }
 
+func scanBitValue(dest, value any, fieldValueType mysql.FieldValueType) error {
+	out := dest.(**uint64)
+
+	if fieldValueType == mysql.FieldValueTypeNull {
+		*out = nil
+		return nil
+	}
+
+	// BIT(n) values are passed as big-endian byte strings
+	var result uint64
+
+	for _, b := range value.([]byte) {
+		result = result<<8 | uint64(b)
+	}
+
+	*out = &result
+
+	return nil
+}
+
 func scanDateValue(dest, value any, fieldValueType mysql.FieldValueType) error {
 	out := dest.(**time.Time)
 
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"strings"
 
+	"google.golang.org/protobuf/proto"
+
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ rdbms_utils.SQLFormatter = (*sqlFormatter)(nil)
@@ -53,6 +56,8 @@ func (f sqlFormatter) supportsConstantValueExpression(t *Ydb.Type) bool {
 	switch v := t.Type.(type) {
 	case *Ydb.Type_TypeId:
 		return f.supportsType(v.TypeId)
+	case *Ydb.Type_DecimalType:
+		return true
 	case *Ydb.Type_OptionalType:
 		return f.supportsConstantValueExpression(v.OptionalType.Item)
 	default:
@@ -70,11 +75,104 @@ func (f sqlFormatter) SupportsExpression(expression *api_service_protos.TExpress
 		return false
 	case *api_service_protos.TExpression_Null:
 		return true
+	case *api_service_protos.TExpression_Cast:
+		return unwrapDecimalType(e.Cast.Type) != nil && f.SupportsExpression(e.Cast.Value)
 	default:
 		return false
 	}
 }
 
+// TransformPredicateComparison casts decimal constants to DECIMAL explicitly,
+// because MySQL compares decimal values with strings as floating point numbers with a loss of precision.
+func (sqlFormatter) TransformPredicateComparison(
+	src *api_service_protos.TPredicate_TComparison,
+) (*api_service_protos.TPredicate_TComparison, error) {
+	dst := proto.Clone(src).(*api_service_protos.TPredicate_TComparison)
+
+	dst.LeftValue = castDecimalValue(dst.LeftValue)
+	dst.RightValue = castDecimalValue(dst.RightValue)
+
+	return dst, nil
+}
+
+func castDecimalValue(expression *api_service_protos.TExpression) *api_service_protos.TExpression {
+	typedValue := expression.GetTypedValue()
+	if typedValue == nil || unwrapDecimalType(typedValue.GetType()) == nil {
+		return expression
+	}
+
+	return &api_service_protos.TExpression{
+		Payload: &api_service_protos.TExpression_Cast{
+			Cast: &api_service_protos.TExpression_TCast{
+				Value: expression,
+				Type:  typedValue.GetType(),
+			},
+		},
+	}
+}
+
+func (sqlFormatter) FormatCast(value string, ydbType *Ydb.Type) (string, error) {
+	decimalType := unwrapDecimalType(ydbType)
+	if decimalType == nil {
+		return "", fmt.Errorf("cast to %v: %w", ydbType, common.ErrUnimplementedOperation)
+	}
+
+	return fmt.Sprintf("CAST(%s AS DECIMAL(%d,%d))", value, decimalType.Precision, decimalType.Scale), nil
+}
+
+// FormatComputedColumn converts the expression to the inferred type, because MySQL widens
+// the results of arithmetical expressions (e.g. the sum of INT values is BIGINT).
+// MySQL can cast only to a few types, so the expressions of the other types are not supported.
+func (sqlFormatter) FormatComputedColumn(expression string, ydbType *Ydb.Type, name string) (string, error) {
+	typeName, err := castTypeName(ydbType)
+	if err != nil {
+		return "", fmt.Errorf("cast type name: %w", err)
+	}
+
+	return fmt.Sprintf("CAST(%s AS %s) AS %s", expression, typeName, name), nil
+}
+
+// castTypeName returns the name of MySQL cast target type that is mapped back to the given YDB type.
+// All the values are nullable in MySQL, so the optional types are unwrapped.
+func castTypeName(ydbType *Ydb.Type) (string, error) {
+	if decimalType := unwrapDecimalType(ydbType); decimalType != nil {
+		return fmt.Sprintf("DECIMAL(%d,%d)", decimalType.Precision, decimalType.Scale), nil
+	}
+
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	switch ydbType.GetTypeId() {
+	case Ydb.Type_INT64:
+		return "SIGNED", nil
+	case Ydb.Type_UINT64:
+		return "UNSIGNED", nil
+	case Ydb.Type_FLOAT:
+		return "FLOAT", nil
+	case Ydb.Type_DOUBLE:
+		return "DOUBLE", nil
+	case Ydb.Type_STRING:
+		return "BINARY", nil
+	case Ydb.Type_UTF8:
+		return "CHAR", nil
+	case Ydb.Type_DATE:
+		return "DATE", nil
+	case Ydb.Type_TIMESTAMP:
+		return "DATETIME(6)", nil
+	default:
+		return "", fmt.Errorf("type %v: %w", ydbType, common.ErrUnsupportedExpression)
+	}
+}
+
+func unwrapDecimalType(ydbType *Ydb.Type) *Ydb.DecimalType {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	return ydbType.GetDecimalType()
+}
+
 func (sqlFormatter) GetPlaceholder(_ int) string {
 	return "?"
 }
@@ -87,6 +185,49 @@ func (f sqlFormatter) FormatWhat(what *api_service_protos.TSelect_TWhat, _ strin
 	return rdbms_utils.FormatWhatDefault(f, what), nil
 }
 
+// MySQL treats NULL values as the smallest ones, just like YQL does
+func (f sqlFormatter) FormatOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return rdbms_utils.FormatOrderByDefault(f, orderBy, false)
+}
+
+func (f sqlFormatter) MaxInSetParameters() int {
+	return rdbms_utils.MaxInSetParametersFromConfig(f.cfg)
+}
+
+func (sqlFormatter) LargeInSetMode() rdbms_utils.LargeInSetMode {
+	return rdbms_utils.LargeInSetModeValues
+}
+
+// FormatInValues embeds the large IN sets into the query as a table value constructor (MySQL 8.0.19+).
+// Only integers are supported: the strings would get the collation of the connection
+// rather than the one of the column, and their comparison could fail with a collation conflict.
+func (sqlFormatter) FormatInValues(value string, set []any) (string, error) {
+	var sb strings.Builder
+
+	sb.WriteString("(")
+	sb.WriteString(value)
+	sb.WriteString(" IN (SELECT column_0 FROM (VALUES ")
+
+	for i, element := range set {
+		literal, err := rdbms_utils.FormatIntegerLiteral(element)
+		if err != nil {
+			return "", fmt.Errorf("format set element #%d: %w", i, err)
+		}
+
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString("ROW(")
+		sb.WriteString(literal)
+		sb.WriteString(")")
+	}
+
+	sb.WriteString(") AS t))")
+
+	return sb.String(), nil
+}
+
 func (f sqlFormatter) FormatFrom(tableName string) string {
 	return f.SanitiseIdentifier(tableName)
 }
@@ -0,0 +1,226 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestSupportsCastExpression(t *testing.T) {
+	formatter := NewSQLFormatter(&config.TPushdownConfig{})
+
+	decimalType := &Ydb.Type{Type: &Ydb.Type_DecimalType{DecimalType: &Ydb.DecimalType{Precision: 10, Scale: 2}}}
+
+	makeCast := func(value *api_service_protos.TExpression, ydbType *Ydb.Type) *api_service_protos.TExpression {
+		return &api_service_protos.TExpression{
+			Payload: &api_service_protos.TExpression_Cast{
+				Cast: &api_service_protos.TExpression_TCast{Value: value, Type: ydbType},
+			},
+		}
+	}
+
+	testCases := []struct {
+		testName   string
+		expression *api_service_protos.TExpression
+		expected   bool
+	}{
+		{
+			testName:   "column_to_decimal",
+			expression: makeCast(rdbms_utils.NewColumnExpression("col"), decimalType),
+			expected:   true,
+		},
+		{
+			testName:   "supported_value_to_decimal",
+			expression: makeCast(rdbms_utils.NewInt32ValueExpression(1), decimalType),
+			expected:   true,
+		},
+		{
+			// text constants are not pushed down, so neither are the casts of them
+			testName:   "unsupported_value_to_decimal",
+			expression: makeCast(rdbms_utils.NewTextValueExpression("1.5"), decimalType),
+			expected:   false,
+		},
+		{
+			testName: "nested_unsupported_value_to_decimal",
+			expression: makeCast(
+				makeCast(rdbms_utils.NewTextValueExpression("1.5"), decimalType),
+				decimalType,
+			),
+			expected: false,
+		},
+		{
+			testName:   "column_to_integer",
+			expression: makeCast(rdbms_utils.NewColumnExpression("col"), common.MakePrimitiveType(Ydb.Type_INT64)),
+			expected:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			require.Equal(t, tc.expected, formatter.SupportsExpression(tc.expression))
+		})
+	}
+}
+
+func TestFormatComputedColumn(t *testing.T) {
+	type testCase struct {
+		testName string
+		ydbType  *Ydb.Type
+		output   string
+		err      error
+	}
+
+	formatter := NewSQLFormatter(&config.TPushdownConfig{})
+
+	tcs := []testCase{
+		{
+			testName: "int64",
+			ydbType:  common.MakePrimitiveType(Ydb.Type_INT64),
+			output:   "CAST((`col0` * `col1`) AS SIGNED) AS `product`",
+		},
+		{
+			testName: "optional_uint64",
+			ydbType:  common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UINT64)),
+			output:   "CAST((`col0` * `col1`) AS UNSIGNED) AS `product`",
+		},
+		{
+			testName: "decimal",
+			ydbType:  common.MakeDecimalType(10, 2),
+			output:   "CAST((`col0` * `col1`) AS DECIMAL(10,2)) AS `product`",
+		},
+		{
+			testName: "int32",
+			ydbType:  common.MakePrimitiveType(Ydb.Type_INT32),
+			err:      common.ErrUnsupportedExpression,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			output, err := formatter.FormatComputedColumn("(`col0` * `col1`)", tc.ydbType, "`product`")
+			if tc.err != nil {
+				require.True(t, errors.Is(err, tc.err), err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestMakeSelectQueryWithInSet(t *testing.T) {
+	type testCase struct {
+		testName    string
+		filter      *api_service_protos.TPredicate
+		outputQuery string
+		outputArgs  []any
+	}
+
+	logger := common.NewTestLogger(t)
+	formatter := NewSQLFormatter(&config.TPushdownConfig{MaxInSetParameters: 2})
+
+	tcs := []testCase{
+		{
+			testName: "separate_parameters",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+			),
+			outputQuery: "SELECT `col0`, `col1` FROM `tab` WHERE (`col0` IN (?, ?))",
+			outputArgs:  []any{int32(1), int32(2)},
+		},
+		{
+			testName: "values",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+				rdbms_utils.NewInt32ValueExpression(3),
+			),
+			outputQuery: "SELECT `col0`, `col1` FROM `tab` WHERE (`col0` IN (SELECT column_0 FROM (VALUES ROW(1), ROW(2), ROW(3)) AS t))",
+			outputArgs:  []any{},
+		},
+		{
+			// the large set cannot be made of the values of different types, so the predicate is not pushed down
+			testName: "values_of_different_types",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+				rdbms_utils.NewInt64ValueExpression(3),
+			),
+			outputQuery: "SELECT `col0`, `col1` FROM `tab`",
+			outputArgs:  []any{},
+		},
+		{
+			// the limit on the number of parameters applies to the whole query
+			testName: "parameters_per_query",
+			filter: rdbms_utils.NewConjunctionPredicate(
+				rdbms_utils.NewInPredicate("col0",
+					rdbms_utils.NewInt32ValueExpression(1),
+					rdbms_utils.NewInt32ValueExpression(2),
+				),
+				rdbms_utils.NewInPredicate("col0",
+					rdbms_utils.NewInt32ValueExpression(3),
+					rdbms_utils.NewInt32ValueExpression(4),
+				),
+			),
+			outputQuery: "SELECT `col0`, `col1` FROM `tab` WHERE ((`col0` IN (?, ?)) AND (`col0` IN (SELECT column_0 FROM (VALUES ROW(3), ROW(4)) AS t)))",
+			outputArgs:  []any{int32(1), int32(2)},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			split := &api_service_protos.TSplit{
+				Select: &api_service_protos.TSelect{
+					From:  &api_service_protos.TSelect_TFrom{Table: "tab"},
+					What:  rdbms_utils.NewDefaultWhat(),
+					Where: &api_service_protos.TSelect_TWhere{FilterTyped: tc.filter},
+					DataSourceInstance: &api_common.TGenericDataSourceInstance{
+						Kind: api_common.EGenericDataSourceKind_MYSQL,
+					},
+				},
+			}
+
+			readSplitsQuery, err := rdbms_utils.MakeSelectQuery(
+				context.Background(),
+				logger,
+				formatter,
+				split,
+				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+				"tab",
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputQuery, readSplitsQuery.QueryText)
+			require.Equal(t, tc.outputArgs, readSplitsQuery.QueryArgs.Values())
+		})
+	}
+}
+
+func TestPushdownCapabilities(t *testing.T) {
+	formatter := NewSQLFormatter(&config.TPushdownConfig{MaxInSetParameters: 2})
+
+	actual := rdbms_utils.ProbePushdownCapabilities(formatter, api_common.EGenericDataSourceKind_MYSQL, false)
+
+	// only the sets of integers can be embedded into the query as a table of values
+	require.True(t, actual.In)
+	require.Equal(t, uint64(2), actual.MaxInSetSize)
+	require.True(t, actual.ComputedColumns)
+	require.False(t, actual.Limit)
+}
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 )
@@ -19,3 +20,34 @@ func TableMetadataQuery(request *api_service_protos.TDescribeTableRequest) (stri
 
 	return query, &args
 }
+
+// TableKeyQuery returns the columns of the primary key of the table in the order of the key.
+// TEXT columns are mapped to binary strings, though they are compared according to the collation,
+// so the types of the columns with non-binary collations are replaced with the empty string,
+// which prevents the use of the key cursor.
+func TableKeyQuery(dsi *api_common.TGenericDataSourceInstance, tableName string) (string, *rdbms_utils.QueryArgs) {
+	query := `SELECT k.column_name,
+		IF(c.collation_name IS NULL OR c.collation_name LIKE '%\\_bin', c.column_type, '')
+		FROM information_schema.key_column_usage k
+		JOIN information_schema.columns c ON
+		c.table_schema = k.table_schema AND c.table_name = k.table_name AND c.column_name = k.column_name
+		WHERE k.constraint_name = 'PRIMARY' AND k.table_name = ? AND k.table_schema = ?
+		ORDER BY k.ordinal_position`
+
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(tableName)
+	args.AddUntyped(dsi.Database)
+
+	return query, &args
+}
+
+func TableListQuery(request *api_service_protos.TListTablesRequest) (string, *rdbms_utils.QueryArgs) {
+	query := "SELECT table_name FROM information_schema.tables WHERE table_schema = ? ORDER BY table_name"
+
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(request.GetDataSourceInstance().Database)
+
+	return query, &args
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/apache/arrow/go/v13/arrow/array"
 	"github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/shopspring/decimal"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
@@ -23,7 +24,9 @@ import (
 var _ datasource.TypeMapper = &typeMapper{}
 
 type typeMapper struct {
-	reType *regexp.Regexp
+	reType      *regexp.Regexp
+	reDecimal   *regexp.Regexp
+	reEnumOrSet *regexp.Regexp
 }
 
 //nolint:gocyclo
@@ -39,7 +42,12 @@ func (tm *typeMapper) SQLTypeToYDBColumn(
 
 	typeNameWithoutModifier := strings.Split(columnType, " ")[0]
 
-	if matches := tm.reType.FindStringSubmatch(columnType); len(matches) > 0 {
+	if matches := tm.reEnumOrSet.FindStringSubmatch(columnType); len(matches) > 0 {
+		// the lists of the allowed values may contain any characters, so they are not parsed
+		typeName = matches[tm.reEnumOrSet.SubexpIndex("type")]
+	} else if tm.reDecimal.MatchString(columnType) {
+		typeName = typeDecimal
+	} else if matches := tm.reType.FindStringSubmatch(columnType); len(matches) > 0 {
 		typeName = matches[tm.reType.SubexpIndex("type")]
 		typeSize, err = strconv.ParseUint(matches[tm.reType.SubexpIndex("size")], 10, 64)
 
@@ -79,6 +87,14 @@ func (tm *typeMapper) SQLTypeToYDBColumn(
 		ydbColumn.Type = common.MakePrimitiveType(Ydb.Type_FLOAT)
 	case typeDouble:
 		ydbColumn.Type = common.MakePrimitiveType(Ydb.Type_DOUBLE)
+	case typeDecimal:
+		ydbColumn.Type, err = tm.decimalToYDBType(columnType)
+		if err != nil {
+			return nil, fmt.Errorf("decimal to YDB type: %w", err)
+		}
+	case typeBit:
+		// BIT(n) keeps up to 64 bits
+		ydbColumn.Type = common.MakePrimitiveType(Ydb.Type_UINT64)
 	case typeTinyInt:
 		if typeSize == 1 {
 			ydbColumn.Type = common.MakePrimitiveType(Ydb.Type_BOOL)
@@ -105,13 +121,20 @@ func (tm *typeMapper) SQLTypeToYDBColumn(
 		ydbColumn.Type = common.MakePrimitiveType(Ydb.Type_STRING)
 	case typeText, typeLongText, typeTinyText, typeMediumText:
 		ydbColumn.Type = common.MakePrimitiveType(Ydb.Type_STRING)
+	case typeEnum, typeSet:
+		// SET values are passed as the comma-separated lists of its members
+		ydbColumn.Type = common.MakePrimitiveType(Ydb.Type_UTF8)
 	case typeDate:
 		ydbColumn.Type, err = common.MakeYdbDateTimeType(Ydb.Type_DATE, typeMapperSettings.GetDateTimeFormat())
 		if err != nil {
 			return nil, fmt.Errorf("make YDB date/time type: %w", err)
 		}
-	// case "time":
-	// TODO: YDB has no separate type representing time of the day
+	case typeTime:
+		// MySQL TIME may represent not only the time of the day, but also the elapsed time
+		// in range from '-838:59:59.000000' to '838:59:59.000000', so it is mapped to YDB Interval.
+		ydbColumn.Type = common.MakePrimitiveType(Ydb.Type_INTERVAL)
+	case typeYear:
+		ydbColumn.Type = common.MakePrimitiveType(Ydb.Type_UINT16)
 	case typeDatetime, typeTimestamp:
 		// In MySQL `Datetime` and `Timestamp` are quite similar.
 		// Both of them can store fractional seconds (up to 6 digits).
@@ -138,9 +161,32 @@ func (tm *typeMapper) SQLTypeToYDBColumn(
 	return &ydbColumn, nil
 }
 
+func (tm *typeMapper) decimalToYDBType(columnType string) (*Ydb.Type, error) {
+	matches := tm.reDecimal.FindStringSubmatch(columnType)
+
+	precision, err := strconv.ParseUint(matches[tm.reDecimal.SubexpIndex("precision")], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parse precision: %w", err)
+	}
+
+	scale, err := strconv.ParseUint(matches[tm.reDecimal.SubexpIndex("scale")], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parse scale: %w", err)
+	}
+
+	// MySQL DECIMAL keeps up to 65 digits, the wider values are passed in their textual representation
+	if precision > common.DecimalMaxPrecision {
+		return common.MakePrimitiveType(Ydb.Type_UTF8), nil
+	}
+
+	return common.MakeDecimalType(uint32(precision), uint32(scale)), nil
+}
+
 func NewTypeMapper() datasource.TypeMapper {
 	return &typeMapper{
-		regexp.MustCompile(`(?P<type>.*)(:?\((?P<size>\d+)\))`),
+		reType:      regexp.MustCompile(`(?P<type>.*)(:?\((?P<size>\d+)\))`),
+		reDecimal:   regexp.MustCompile(`^decimal\((?P<precision>\d+),(?P<scale>\d+)\)`),
+		reEnumOrSet: regexp.MustCompile(`^(?P<type>enum|set)\(`),
 	}
 }
 
@@ -169,6 +215,11 @@ func addAcceptorAppender(
 	acceptors *[]any,
 	appenders *[]func(acceptor any, builder array.Builder) error,
 ) error {
+	// YDB Decimal is not a primitive type, so it is handled separately
+	if mySQLType == mysql.MYSQL_TYPE_NEWDECIMAL || mySQLType == mysql.MYSQL_TYPE_DECIMAL {
+		return addDecimalAcceptorAppender(ydbType, cc, acceptors, appenders)
+	}
+
 	ydbTypeId, err := common.YdbTypeToYdbPrimitiveTypeID(ydbType)
 	if err != nil {
 		return fmt.Errorf("ydb type to ydb primitive type id: %w", err)
@@ -231,7 +282,14 @@ func addAcceptorAppender(
 	case mysql.MYSQL_TYPE_LONG_BLOB, mysql.MYSQL_TYPE_BLOB, mysql.MYSQL_TYPE_MEDIUM_BLOB, mysql.MYSQL_TYPE_TINY_BLOB:
 		*acceptors = append(*acceptors, new(*[]byte))
 		*appenders = append(*appenders, utils.MakeAppenderNullable[[]byte, []byte, *array.BinaryBuilder](cc.Bytes()))
-	case mysql.MYSQL_TYPE_VARCHAR, mysql.MYSQL_TYPE_STRING, mysql.MYSQL_TYPE_VAR_STRING:
+	case mysql.MYSQL_TYPE_BIT:
+		*acceptors = append(*acceptors, new(*uint64))
+		*appenders = append(*appenders, utils.MakeAppenderNullable[uint64, uint64, *array.Uint64Builder](cc.Uint64()))
+	case mysql.MYSQL_TYPE_YEAR:
+		*acceptors = append(*acceptors, new(*uint16))
+		*appenders = append(*appenders, utils.MakeAppenderNullable[uint16, uint16, *array.Uint16Builder](cc.Uint16()))
+	// ENUM and SET columns are usually reported as MYSQL_TYPE_STRING with ENUM_FLAG or SET_FLAG
+	case mysql.MYSQL_TYPE_VARCHAR, mysql.MYSQL_TYPE_STRING, mysql.MYSQL_TYPE_VAR_STRING, mysql.MYSQL_TYPE_ENUM, mysql.MYSQL_TYPE_SET:
 		*acceptors = append(*acceptors, new(*string))
 
 		switch ydbTypeId {
@@ -261,8 +319,9 @@ func addAcceptorAppender(
 		default:
 			return fmt.Errorf("type mismatch: mysql '%d' vs ydb '%s': %w", mySQLType, ydbTypeId.String(), common.ErrDataTypeNotSupported)
 		}
-	// case "time":
-	// TODO: YDB has no separate type representing time of the day
+	case mysql.MYSQL_TYPE_TIME, mysql.MYSQL_TYPE_TIME2:
+		*acceptors = append(*acceptors, new(*string))
+		*appenders = append(*appenders, utils.MakeAppenderNullable[string, int64, *array.Int64Builder](timeToIntervalConverter{}))
 	case mysql.MYSQL_TYPE_DATETIME, mysql.MYSQL_TYPE_DATETIME2, mysql.MYSQL_TYPE_TIMESTAMP, mysql.MYSQL_TYPE_TIMESTAMP2:
 		*acceptors = append(*acceptors, new(*time.Time))
 
@@ -295,3 +354,92 @@ func addAcceptorAppender(
 
 	return nil
 }
+
+func addDecimalAcceptorAppender(
+	ydbType *Ydb.Type,
+	cc conversion.Collection,
+	acceptors *[]any,
+	appenders *[]func(acceptor any, builder array.Builder) error,
+) error {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	// the driver passes decimals in their textual representation
+	*acceptors = append(*acceptors, new(*string))
+
+	if decimalType := ydbType.GetDecimalType(); decimalType != nil {
+		*appenders = append(*appenders,
+			utils.MakeAppenderNullable[string, []byte, *array.FixedSizeBinaryBuilder](decimalConverter{decimalType: decimalType}))
+
+		return nil
+	}
+
+	if ydbType.GetTypeId() == Ydb.Type_UTF8 {
+		*appenders = append(*appenders, utils.MakeAppenderNullable[string, string, *array.StringBuilder](cc.String()))
+
+		return nil
+	}
+
+	return fmt.Errorf("unexpected ydb type %v for decimal: %w", ydbType, common.ErrDataTypeNotSupported)
+}
+
+type decimalConverter struct {
+	decimalType *Ydb.DecimalType
+}
+
+func (c decimalConverter) Convert(in *string) ([]byte, error) {
+	value, err := decimal.NewFromString(*in)
+	if err != nil {
+		return nil, fmt.Errorf("parse decimal '%s': %w", *in, err)
+	}
+
+	unscaled, err := common.RescaleDecimal(value.Coefficient(), value.Exponent(), c.decimalType.Scale)
+	if err != nil {
+		return nil, fmt.Errorf("rescale decimal: %w", err)
+	}
+
+	return common.DecimalToBytes(unscaled, c.decimalType.Precision)
+}
+
+// timeToIntervalConverter converts TIME represented by the driver like `-838:59:59.000000` into YDB Interval
+type timeToIntervalConverter struct{}
+
+func (timeToIntervalConverter) Convert(in *string) (int64, error) {
+	// The driver puts zero byte instead of the sign of the positive values
+	// and represents zero value of the shortest encoding as a zero date.
+	value := strings.TrimLeft(*in, "\x00")
+	if value == "0000-00-00" {
+		return 0, nil
+	}
+
+	value, negative := strings.CutPrefix(value, "-")
+	whole, fraction, _ := strings.Cut(value, ".")
+
+	var hours, minutes, seconds int64
+
+	if _, err := fmt.Sscanf(whole, "%d:%d:%d", &hours, &minutes, &seconds); err != nil {
+		return 0, fmt.Errorf("parse time '%s': %w", *in, err)
+	}
+
+	var microseconds int64
+
+	if fraction != "" {
+		var err error
+
+		// the fraction has no more than 6 digits
+		microseconds, err = strconv.ParseInt((fraction + "00000")[:6], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parse fractional seconds '%s': %w", *in, err)
+		}
+	}
+
+	duration := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	result := duration.Microseconds() + microseconds
+
+	if negative {
+		result = -result
+	}
+
+	return result, nil
+}
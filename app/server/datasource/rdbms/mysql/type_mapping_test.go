@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-mysql-org/go-mysql/mysql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/ptr"
+)
+
+func TestSQLTypeToYDBColumn(t *testing.T) {
+	rules := &api_service_protos.TTypeMappingSettings{DateTimeFormat: api_service_protos.EDateTimeFormat_YQL_FORMAT}
+
+	testCases := []struct {
+		columnType string
+		expected   *Ydb.Type
+	}{
+		{columnType: "decimal(10,2)", expected: common.MakeDecimalType(10, 2)},
+		{columnType: "decimal(35,0) unsigned", expected: common.MakeDecimalType(35, 0)},
+		{columnType: "decimal(65,30)", expected: common.MakePrimitiveType(Ydb.Type_UTF8)},
+		{columnType: "bit(1)", expected: common.MakePrimitiveType(Ydb.Type_UINT64)},
+		{columnType: "bit(64)", expected: common.MakePrimitiveType(Ydb.Type_UINT64)},
+		{columnType: "enum('a b','c(1)')", expected: common.MakePrimitiveType(Ydb.Type_UTF8)},
+		{columnType: "set('x','y')", expected: common.MakePrimitiveType(Ydb.Type_UTF8)},
+		{columnType: "year", expected: common.MakePrimitiveType(Ydb.Type_UINT16)},
+		{columnType: "time", expected: common.MakePrimitiveType(Ydb.Type_INTERVAL)},
+		{columnType: "time(6)", expected: common.MakePrimitiveType(Ydb.Type_INTERVAL)},
+	}
+
+	tm := NewTypeMapper()
+
+	for _, tc := range testCases {
+		column, err := tm.SQLTypeToYDBColumn("col", tc.columnType, rules)
+		require.NoError(t, err, tc.columnType)
+		require.Equal(t, common.MakeOptionalType(tc.expected), column.Type, tc.columnType)
+	}
+
+	_, err := tm.SQLTypeToYDBColumn("col", "geometry", rules)
+	require.ErrorIs(t, err, common.ErrDataTypeNotSupported)
+}
+
+func TestDecimalConverter(t *testing.T) {
+	converter := decimalConverter{decimalType: &Ydb.DecimalType{Precision: 10, Scale: 2}}
+
+	actual, err := converter.Convert(ptr.String("-12.30"))
+	require.NoError(t, err)
+
+	expected, err := common.DecimalToBytes(big.NewInt(-1230), 10)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	_, err = converter.Convert(ptr.String("123456789.00"))
+	require.True(t, errors.Is(err, common.ErrValueOutOfTypeBounds))
+}
+
+func TestTimeToIntervalConverter(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{value: "\x0012:34:56", expected: 12*time.Hour + 34*time.Minute + 56*time.Second},
+		{value: "\x0000:00:01.500000", expected: 1500 * time.Millisecond},
+		{value: "-838:59:59.000000", expected: -(838*time.Hour + 59*time.Minute + 59*time.Second)},
+		{value: "0000-00-00", expected: 0},
+	}
+
+	for _, tc := range testCases {
+		actual, err := timeToIntervalConverter{}.Convert(ptr.String(tc.value))
+		require.NoError(t, err, tc.value)
+		require.Equal(t, tc.expected.Microseconds(), actual, tc.value)
+	}
+
+	_, err := timeToIntervalConverter{}.Convert(ptr.String("noon"))
+	require.Error(t, err)
+}
+
+func TestScanBitValue(t *testing.T) {
+	var dest *uint64
+
+	require.NoError(t, scanToDest(&dest, []byte{0x01, 0x02}, mysql.MYSQL_TYPE_BIT, mysql.UNSIGNED_FLAG, mysql.FieldValueTypeString))
+	require.Equal(t, uint64(0x0102), *dest)
+
+	require.NoError(t, scanToDest(&dest, nil, mysql.MYSQL_TYPE_BIT, mysql.UNSIGNED_FLAG, mysql.FieldValueTypeNull))
+	require.Nil(t, dest)
+}
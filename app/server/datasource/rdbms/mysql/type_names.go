@@ -6,6 +6,8 @@ const (
 	typeBigInt     = "bigint"
 	typeFloat      = "float"
 	typeDouble     = "double"
+	typeDecimal    = "decimal"
+	typeBit        = "bit"
 	typeTinyInt    = "tinyint"
 	typeSmallInt   = "smallint"
 	typeLongBlob   = "longblob"
@@ -20,8 +22,12 @@ const (
 	typeLongText   = "longtext"
 	typeTinyText   = "tinytext"
 	typeMediumText = "mediumtext"
+	typeEnum       = "enum"
+	typeSet        = "set"
 	typeDate       = "date"
 	typeDatetime   = "datetime"
 	typeTimestamp  = "timestamp"
+	typeTime       = "time"
+	typeYear       = "year"
 	typeJSON       = "json"
 )
@@ -3,6 +3,7 @@ package oracle
 import (
 	"database/sql/driver"
 	"fmt"
+	"sync/atomic"
 
 	go_ora "github.com/sijms/go-ora/v2"
 	"go.uber.org/zap"
@@ -12,10 +13,24 @@ import (
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
+var _ rdbms_utils.PooledConnection = (*pooledConnection)(nil)
+
+// pooledConnection is a physical connection to Oracle that can be shared between requests
+type pooledConnection struct {
+	*go_ora.Connection
+	// Set when a query has failed, so the state of the session is unknown
+	broken atomic.Bool
+}
+
+func (c *pooledConnection) Reusable() bool {
+	return !c.broken.Load()
+}
+
 var _ rdbms_utils.Connection = (*connection)(nil)
 
 type connection struct {
 	conn               *go_ora.Connection
+	pooled             *pooledConnection
 	queryLogger        common.QueryLogger
 	dataSourceInstance *api_common.TGenericDataSourceInstance
 	tableName          string
@@ -41,6 +56,8 @@ func (c *connection) Query(queryParams *rdbms_utils.QueryParams) (rdbms_utils.Ro
 
 	out, err := c.conn.QueryContext(queryParams.Ctx, queryParams.QueryText, valueArgs)
 	if err != nil {
+		c.pooled.broken.Store(true)
+
 		return nil, fmt.Errorf("query with context: %w", err)
 	}
 
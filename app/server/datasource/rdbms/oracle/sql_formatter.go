@@ -9,13 +9,19 @@ import (
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ rdbms_utils.SQLFormatter = (*sqlFormatter)(nil)
 
+// rownumColumnName is an alias for the ROWNUM pseudocolumn used in subqueries
+const rownumColumnName = "rownum__"
+
 type sqlFormatter struct {
 	rdbms_utils.SQLFormatterDefault
 	cfg *config.TPushdownConfig
+	// Oracle versions older than 12c do not support row limiting clause
+	useRownumForLimit bool
 }
 
 func (f *sqlFormatter) supportsType(typeID Ydb.Type_PrimitiveTypeId) bool {
@@ -44,6 +50,8 @@ func (f *sqlFormatter) supportsType(typeID Ydb.Type_PrimitiveTypeId) bool {
 		return true
 	case Ydb.Type_TIMESTAMP:
 		return f.cfg.EnableTimestampPushdown
+	case Ydb.Type_DATE:
+		return f.cfg.EnableTimestampPushdown
 	default:
 		return false
 	}
@@ -72,6 +80,8 @@ func (f sqlFormatter) SupportsExpression(expression *api_service_protos.TExpress
 		return false
 	case *api_service_protos.TExpression_Null:
 		return true
+	case *api_service_protos.TExpression_DateTimeFunction:
+		return f.SupportsExpression(e.DateTimeFunction.Value)
 	default:
 		return false
 	}
@@ -92,10 +102,237 @@ func (f sqlFormatter) FormatWhat(what *api_service_protos.TSelect_TWhat, _ strin
 	return rdbms_utils.FormatWhatDefault(f, what), nil
 }
 
+// Oracle places NULL values after the others in ascending order
+func (f sqlFormatter) FormatOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return rdbms_utils.FormatOrderByDefault(f, orderBy, true)
+}
+
+func (f sqlFormatter) MaxInSetParameters() int {
+	return rdbms_utils.MaxInSetParametersFromConfig(f.cfg)
+}
+
+func (sqlFormatter) LargeInSetMode() rdbms_utils.LargeInSetMode {
+	return rdbms_utils.LargeInSetModeValues
+}
+
+// FormatInValues embeds the large IN sets into the query as a table of values made with `UNION ALL`,
+// because Oracle has no table value constructor (before 23ai) and allows only 1000 expressions in the IN list.
+func (sqlFormatter) FormatInValues(value string, set []any) (string, error) {
+	var sb strings.Builder
+
+	sb.WriteString("(")
+	sb.WriteString(value)
+	sb.WriteString(" IN (")
+
+	for i, element := range set {
+		literal, err := rdbms_utils.FormatIntegerLiteral(element)
+		if err != nil {
+			return "", fmt.Errorf("format set element #%d: %w", i, err)
+		}
+
+		if i != 0 {
+			sb.WriteString(" UNION ALL ")
+		}
+
+		sb.WriteString("SELECT ")
+		sb.WriteString(literal)
+		sb.WriteString(" FROM DUAL")
+	}
+
+	sb.WriteString("))")
+
+	return sb.String(), nil
+}
+
+// FormatDateTimeFunction casts the results of EXTRACT, which returns NUMBER, to the precision of the inferred types.
+// TRUNC returns DATE with zero time, which is read as Date. Oracle allows to extract HOUR
+// from TIMESTAMP values only, so DATE values are cast to TIMESTAMP first.
+func (sqlFormatter) FormatDateTimeFunction(
+	function api_service_protos.TExpression_TDateTimeFunction_EFunction,
+	value string,
+) (string, error) {
+	switch function {
+	case api_service_protos.TExpression_TDateTimeFunction_TO_DATE:
+		return fmt.Sprintf("TRUNC(%s)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_YEAR:
+		return fmt.Sprintf("CAST(EXTRACT(YEAR FROM %s) AS NUMBER(5))", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_MONTH:
+		return fmt.Sprintf("CAST(EXTRACT(MONTH FROM %s) AS NUMBER(3))", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_DAY_OF_MONTH:
+		return fmt.Sprintf("CAST(EXTRACT(DAY FROM %s) AS NUMBER(3))", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_HOUR:
+		return fmt.Sprintf("CAST(EXTRACT(HOUR FROM CAST(%s AS TIMESTAMP)) AS NUMBER(3))", value), nil
+	default:
+		return "", fmt.Errorf("function %v: %w", function, common.ErrUnimplementedOperation)
+	}
+}
+
+// FormatComputedColumn converts the expression to the inferred type, because all the numbers are
+// of NUMBER type in Oracle, and the way of reading them depends on the precision and the scale.
+// Oracle has neither unsigned nor small integer types, so only Uint8 and Uint16 expressions are supported
+// as the results of datetime functions: they are read from the numbers of the matching precision.
+func (sqlFormatter) FormatComputedColumn(expression string, ydbType *Ydb.Type, name string) (string, error) {
+	typeName, err := castTypeName(ydbType)
+	if err != nil {
+		return "", fmt.Errorf("cast type name: %w", err)
+	}
+
+	return fmt.Sprintf("CAST(%s AS %s) AS %s", expression, typeName, name), nil
+}
+
+// castTypeName returns the name of Oracle type that is mapped back to the given YDB type.
+// All the values are nullable in Oracle, so the optional types are unwrapped.
+func castTypeName(ydbType *Ydb.Type) (string, error) {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	if decimalType := ydbType.GetDecimalType(); decimalType != nil {
+		return fmt.Sprintf("NUMBER(%d, %d)", decimalType.Precision, decimalType.Scale), nil
+	}
+
+	switch ydbType.GetTypeId() {
+	case Ydb.Type_UINT8:
+		return "NUMBER(3)", nil
+	case Ydb.Type_UINT16:
+		return "NUMBER(5)", nil
+	case Ydb.Type_INT64:
+		// the values are read as int64 regardless of the precision, which is enough for any int64 value
+		return "NUMBER(19)", nil
+	case Ydb.Type_DOUBLE:
+		return "BINARY_DOUBLE", nil
+	case Ydb.Type_DATE, Ydb.Type_DATETIME:
+		return "DATE", nil
+	case Ydb.Type_TIMESTAMP:
+		return "TIMESTAMP", nil
+	default:
+		return "", fmt.Errorf("type %v: %w", ydbType, common.ErrUnsupportedExpression)
+	}
+}
+
 func (f sqlFormatter) FormatFrom(tableName string) string {
 	return f.SanitiseIdentifier(tableName)
 }
 
-func NewSQLFormatter(cfg *config.TPushdownConfig) rdbms_utils.SQLFormatter {
-	return sqlFormatter{cfg: cfg}
+func (f sqlFormatter) RenderSelectQueryText(
+	parts *rdbms_utils.SelectQueryParts,
+	split *api_service_protos.TSplit,
+) (string, error) {
+	if parts.Limit != 0 && f.useRownumForLimit {
+		return renderSelectQueryTextWithRownum(parts, f.formatAliases(parts, split.GetSelect().GetWhat())), nil
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("SELECT ")
+	sb.WriteString(parts.SelectClause)
+	sb.WriteString(" FROM ")
+	sb.WriteString(parts.FromClause)
+
+	if parts.WhereClause != "" {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(parts.WhereClause)
+	}
+
+	sb.WriteString(rdbms_utils.FormatOrderByClauseDefault(parts))
+
+	if parts.Limit != 0 {
+		if parts.Offset != 0 {
+			sb.WriteString(fmt.Sprintf(" OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", parts.Offset, parts.Limit))
+		} else {
+			sb.WriteString(fmt.Sprintf(" FETCH FIRST %d ROWS ONLY", parts.Limit))
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// formatAliases lists the names of the columns exposed by the subquery to the outer queries.
+// The outer queries cannot repeat the SELECT clause, since the computed columns
+// refer to the columns of the table and to the query parameters.
+func (f sqlFormatter) formatAliases(parts *rdbms_utils.SelectQueryParts, what *api_service_protos.TSelect_TWhat) string {
+	// no columns were requested, so the SELECT clause is a constant
+	if len(what.GetItems()) == 0 {
+		return parts.SelectClause
+	}
+
+	aliases := make([]string, 0, len(what.GetItems()))
+
+	for _, item := range what.GetItems() {
+		switch payload := item.GetPayload().(type) {
+		case *api_service_protos.TSelect_TWhat_TItem_Column:
+			aliases = append(aliases, f.SanitiseIdentifier(payload.Column.GetName()))
+		case *api_service_protos.TSelect_TWhat_TItem_ComputedColumn:
+			aliases = append(aliases, f.SanitiseIdentifier(payload.ComputedColumn.GetName()))
+		}
+	}
+
+	return strings.Join(aliases, ", ")
+}
+
+// renderSelectQueryTextWithRownum limits the number of rows with ROWNUM pseudocolumn.
+// Since ROWNUM is assigned before the rows are skipped, OFFSET requires a subquery.
+// ROWNUM is also assigned before the rows are sorted, so the sorting is done in a subquery too.
+// The queries over the subqueries select only the aliases of the columns.
+func renderSelectQueryTextWithRownum(parts *rdbms_utils.SelectQueryParts, aliases string) string {
+	if parts.OrderByClause != "" {
+		var inner strings.Builder
+
+		inner.WriteString("(SELECT ")
+		inner.WriteString(parts.SelectClause)
+		inner.WriteString(" FROM ")
+		inner.WriteString(parts.FromClause)
+
+		if parts.WhereClause != "" {
+			inner.WriteString(" WHERE ")
+			inner.WriteString(parts.WhereClause)
+		}
+
+		inner.WriteString(rdbms_utils.FormatOrderByClauseDefault(parts))
+		inner.WriteString(")")
+
+		parts = &rdbms_utils.SelectQueryParts{
+			SelectClause: aliases,
+			FromClause:   inner.String(),
+			Limit:        parts.Limit,
+			Offset:       parts.Offset,
+		}
+	}
+
+	var sb strings.Builder
+
+	if parts.Offset != 0 {
+		sb.WriteString("SELECT ")
+		sb.WriteString(aliases)
+		sb.WriteString(" FROM (SELECT ")
+		sb.WriteString(parts.SelectClause)
+		sb.WriteString(", ROWNUM AS \"")
+		sb.WriteString(rownumColumnName)
+		sb.WriteString("\"")
+	} else {
+		sb.WriteString("SELECT ")
+		sb.WriteString(parts.SelectClause)
+	}
+
+	sb.WriteString(" FROM ")
+	sb.WriteString(parts.FromClause)
+	sb.WriteString(" WHERE ")
+
+	if parts.WhereClause != "" {
+		sb.WriteString("(")
+		sb.WriteString(parts.WhereClause)
+		sb.WriteString(") AND ")
+	}
+
+	sb.WriteString(fmt.Sprintf("ROWNUM <= %d", parts.Limit+parts.Offset))
+
+	if parts.Offset != 0 {
+		sb.WriteString(fmt.Sprintf(") WHERE \"%s\" > %d", rownumColumnName, parts.Offset))
+	}
+
+	return sb.String()
+}
+
+func NewSQLFormatter(cfg *config.TPushdownConfig, useRownumForLimit bool) rdbms_utils.SQLFormatter {
+	return sqlFormatter{cfg: cfg, useRownumForLimit: useRownumForLimit}
 }
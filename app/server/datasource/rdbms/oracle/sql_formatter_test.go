@@ -0,0 +1,479 @@
+package oracle
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ydb "github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestMakeSelectQuery(t *testing.T) {
+	type testCase struct {
+		testName    string
+		formatter   rdbms_utils.SQLFormatter
+		selectReq   *api_service_protos.TSelect
+		outputQuery string
+		err         error
+	}
+
+	logger := common.NewTestLogger(t)
+	pushdownCfg := &config.TPushdownConfig{}
+
+	tcs := []testCase{
+		{
+			testName:  "no_limit",
+			formatter: NewSQLFormatter(pushdownCfg, false),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  0,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_ORACLE,
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM "tab"`,
+			err:         nil,
+		},
+		{
+			testName:  "fetch_first",
+			formatter: NewSQLFormatter(pushdownCfg, false),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_ORACLE,
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM "tab" FETCH FIRST 10 ROWS ONLY`,
+			err:         nil,
+		},
+		{
+			testName:  "fetch_first_offset",
+			formatter: NewSQLFormatter(pushdownCfg, false),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_IsNull{
+							IsNull: &api_service_protos.TPredicate_TIsNull{
+								Value: rdbms_utils.NewColumnExpression("col1"),
+							},
+						},
+					},
+				},
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 5,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_ORACLE,
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE ("col1" IS NULL) OFFSET 5 ROWS FETCH NEXT 10 ROWS ONLY`,
+			err:         nil,
+		},
+		{
+			testName:  "rownum",
+			formatter: NewSQLFormatter(pushdownCfg, true),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_ORACLE,
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE ROWNUM <= 10`,
+			err:         nil,
+		},
+		{
+			testName:  "rownum_with_filter",
+			formatter: NewSQLFormatter(pushdownCfg, true),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_IsNull{
+							IsNull: &api_service_protos.TPredicate_TIsNull{
+								Value: rdbms_utils.NewColumnExpression("col1"),
+							},
+						},
+					},
+				},
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_ORACLE,
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE (("col1" IS NULL)) AND ROWNUM <= 10`,
+			err:         nil,
+		},
+		{
+			testName:  "rownum_offset",
+			formatter: NewSQLFormatter(pushdownCfg, true),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_IsNull{
+							IsNull: &api_service_protos.TPredicate_TIsNull{
+								Value: rdbms_utils.NewColumnExpression("col1"),
+							},
+						},
+					},
+				},
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 5,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_ORACLE,
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM (SELECT "col0", "col1", ROWNUM AS "rownum__" FROM "tab" WHERE (("col1" IS NULL)) AND ROWNUM <= 15) WHERE "rownum__" > 5`,
+			err:         nil,
+		},
+		{
+			testName:  "order_by_fetch_first",
+			formatter: NewSQLFormatter(pushdownCfg, false),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What:    rdbms_utils.NewDefaultWhat(),
+				OrderBy: rdbms_utils.NewDefaultOrderBy(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_ORACLE,
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM "tab" ORDER BY "col0" DESC NULLS LAST, "col1" ASC NULLS FIRST FETCH FIRST 10 ROWS ONLY`,
+			err:         nil,
+		},
+		{
+			testName:  "rownum_order_by_offset",
+			formatter: NewSQLFormatter(pushdownCfg, true),
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What:    rdbms_utils.NewDefaultWhat(),
+				OrderBy: rdbms_utils.NewDefaultOrderBy(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 5,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_ORACLE,
+				},
+			},
+			outputQuery: `SELECT "col0", "col1" FROM (SELECT "col0", "col1", ROWNUM AS "rownum__" FROM (SELECT "col0", "col1" FROM "tab" ORDER BY "col0" DESC NULLS LAST, "col1" ASC NULLS FIRST) WHERE ROWNUM <= 15) WHERE "rownum__" > 5`,
+			err:         nil,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			readSplitsQuery, err := rdbms_utils.MakeSelectQuery(
+				context.Background(),
+				logger,
+				tc.formatter,
+				&api_service_protos.TSplit{Select: tc.selectReq},
+				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+				tc.selectReq.From.Table,
+			)
+
+			if tc.err != nil {
+				require.True(t, errors.Is(err, tc.err), err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.outputQuery, readSplitsQuery.QueryText)
+			require.Equal(t, []*ydb.Type{
+				common.MakePrimitiveType(ydb.Type_INT32),
+				common.MakePrimitiveType(ydb.Type_STRING),
+			}, common.YDBColumnsToYDBTypes(readSplitsQuery.YdbColumns))
+		})
+	}
+}
+
+func TestFormatComputedColumn(t *testing.T) {
+	type testCase struct {
+		testName string
+		ydbType  *ydb.Type
+		output   string
+		err      error
+	}
+
+	formatter := NewSQLFormatter(nil, false)
+
+	tcs := []testCase{
+		{
+			testName: "int64",
+			ydbType:  common.MakePrimitiveType(ydb.Type_INT64),
+			output:   `CAST(("col0" * "col1") AS NUMBER(19)) AS "product"`,
+		},
+		{
+			testName: "optional_decimal",
+			ydbType:  common.MakeOptionalType(common.MakeDecimalType(10, 2)),
+			output:   `CAST(("col0" * "col1") AS NUMBER(10, 2)) AS "product"`,
+		},
+		{
+			testName: "double",
+			ydbType:  common.MakePrimitiveType(ydb.Type_DOUBLE),
+			output:   `CAST(("col0" * "col1") AS BINARY_DOUBLE) AS "product"`,
+		},
+		{
+			testName: "uint16",
+			ydbType:  common.MakePrimitiveType(ydb.Type_UINT16),
+			output:   `CAST(("col0" * "col1") AS NUMBER(5)) AS "product"`,
+		},
+		{
+			testName: "date",
+			ydbType:  common.MakePrimitiveType(ydb.Type_DATE),
+			output:   `CAST(("col0" * "col1") AS DATE) AS "product"`,
+		},
+		{
+			testName: "int32",
+			ydbType:  common.MakePrimitiveType(ydb.Type_INT32),
+			err:      common.ErrUnsupportedExpression,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			output, err := formatter.FormatComputedColumn(`("col0" * "col1")`, tc.ydbType, `"product"`)
+			if tc.err != nil {
+				require.True(t, errors.Is(err, tc.err), err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestFormatDateTimeFunction(t *testing.T) {
+	type testCase struct {
+		function api_service_protos.TExpression_TDateTimeFunction_EFunction
+		output   string
+	}
+
+	formatter := NewSQLFormatter(nil, false)
+
+	// the results are cast to the types mapped back to the ones inferred for the functions
+	tcs := []testCase{
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_TO_DATE,
+			output:   `TRUNC("col0")`,
+		},
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_GET_YEAR,
+			output:   `CAST(EXTRACT(YEAR FROM "col0") AS NUMBER(5))`,
+		},
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_GET_MONTH,
+			output:   `CAST(EXTRACT(MONTH FROM "col0") AS NUMBER(3))`,
+		},
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_GET_DAY_OF_MONTH,
+			output:   `CAST(EXTRACT(DAY FROM "col0") AS NUMBER(3))`,
+		},
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_GET_HOUR,
+			output:   `CAST(EXTRACT(HOUR FROM CAST("col0" AS TIMESTAMP)) AS NUMBER(3))`,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.function.String(), func(t *testing.T) {
+			output, err := formatter.FormatDateTimeFunction(tc.function, `"col0"`)
+			require.NoError(t, err)
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestMakeSelectQueryWithInSet(t *testing.T) {
+	type testCase struct {
+		testName    string
+		filter      *api_service_protos.TPredicate
+		outputQuery string
+		outputArgs  []any
+	}
+
+	logger := common.NewTestLogger(t)
+	formatter := NewSQLFormatter(&config.TPushdownConfig{MaxInSetParameters: 2}, false)
+
+	tcs := []testCase{
+		{
+			testName: "separate_parameters",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt64ValueExpression(1),
+				rdbms_utils.NewInt64ValueExpression(2),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE ("col0" IN (:1, :2))`,
+			outputArgs:  []any{int64(1), int64(2)},
+		},
+		{
+			testName: "values",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt64ValueExpression(1),
+				rdbms_utils.NewInt64ValueExpression(2),
+				rdbms_utils.NewInt64ValueExpression(3),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE ("col0" IN (SELECT 1 FROM DUAL UNION ALL SELECT 2 FROM DUAL UNION ALL SELECT 3 FROM DUAL))`,
+			outputArgs:  []any{},
+		},
+		{
+			// the large set cannot be made of the values of different types, so the predicate is not pushed down
+			testName: "values_of_different_types",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt64ValueExpression(1),
+				rdbms_utils.NewInt64ValueExpression(2),
+				rdbms_utils.NewInt32ValueExpression(3),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab"`,
+			outputArgs:  []any{},
+		},
+		{
+			// the limit on the number of parameters applies to the whole query
+			testName: "parameters_per_query",
+			filter: rdbms_utils.NewConjunctionPredicate(
+				rdbms_utils.NewInPredicate("col0",
+					rdbms_utils.NewInt64ValueExpression(1),
+					rdbms_utils.NewInt64ValueExpression(2),
+				),
+				rdbms_utils.NewInPredicate("col0",
+					rdbms_utils.NewInt64ValueExpression(3),
+					rdbms_utils.NewInt64ValueExpression(4),
+				),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE (("col0" IN (:1, :2)) AND ("col0" IN (SELECT 3 FROM DUAL UNION ALL SELECT 4 FROM DUAL)))`,
+			outputArgs:  []any{int64(1), int64(2)},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			split := &api_service_protos.TSplit{
+				Select: &api_service_protos.TSelect{
+					From:  &api_service_protos.TSelect_TFrom{Table: "tab"},
+					What:  rdbms_utils.NewDefaultWhat(),
+					Where: &api_service_protos.TSelect_TWhere{FilterTyped: tc.filter},
+					DataSourceInstance: &api_common.TGenericDataSourceInstance{
+						Kind: api_common.EGenericDataSourceKind_ORACLE,
+					},
+				},
+			}
+
+			readSplitsQuery, err := rdbms_utils.MakeSelectQuery(
+				context.Background(),
+				logger,
+				formatter,
+				split,
+				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+				"tab",
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputQuery, readSplitsQuery.QueryText)
+			require.Equal(t, tc.outputArgs, readSplitsQuery.QueryArgs.Values())
+		})
+	}
+}
+
+func TestMakeSelectQueryWithComputedColumnAndRownum(t *testing.T) {
+	what := rdbms_utils.NewDefaultWhat()
+	what.Items = append(what.Items, &api_service_protos.TSelect_TWhat_TItem{
+		Payload: &api_service_protos.TSelect_TWhat_TItem_ComputedColumn{
+			ComputedColumn: &api_service_protos.TSelect_TWhat_TComputedColumn{
+				Name:       "x",
+				Expression: rdbms_utils.NewInt64ValueExpression(42),
+			},
+		},
+	})
+
+	split := &api_service_protos.TSplit{
+		Select: &api_service_protos.TSelect{
+			From:    &api_service_protos.TSelect_TFrom{Table: "tab"},
+			What:    what,
+			OrderBy: rdbms_utils.NewDefaultOrderBy(),
+			Limit: &api_service_protos.TSelect_TLimit{
+				Limit:  10,
+				Offset: 5,
+			},
+			DataSourceInstance: &api_common.TGenericDataSourceInstance{
+				Kind: api_common.EGenericDataSourceKind_ORACLE,
+			},
+		},
+	}
+
+	readSplitsQuery, err := rdbms_utils.MakeSelectQuery(
+		context.Background(),
+		common.NewTestLogger(t),
+		NewSQLFormatter(&config.TPushdownConfig{}, true),
+		split,
+		api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+		"tab",
+	)
+	require.NoError(t, err)
+
+	// the computed column is evaluated only in the innermost query, the outer queries refer to its alias
+	require.Equal(t,
+		`SELECT "col0", "col1", "x" FROM (SELECT "col0", "col1", "x", ROWNUM AS "rownum__" FROM `+
+			`(SELECT "col0", "col1", CAST(:1 AS NUMBER(19)) AS "x" FROM "tab" ORDER BY "col0" DESC NULLS LAST, "col1" ASC NULLS FIRST) `+
+			`WHERE ROWNUM <= 15) WHERE "rownum__" > 5`,
+		readSplitsQuery.QueryText,
+	)
+	require.Equal(t, []any{int64(42)}, readSplitsQuery.QueryArgs.Values())
+}
@@ -1,6 +1,7 @@
 package oracle
 
 import (
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 )
@@ -8,7 +9,13 @@ import (
 func TableMetadataQuery(request *api_service_protos.TDescribeTableRequest) (string, *rdbms_utils.QueryArgs) {
 	// TODO YQ-3413: synonym tables and from other users.
 	// TODO YQ-3454: all capitalize
-	query := "SELECT column_name, data_type FROM user_tab_columns WHERE table_name = :1"
+	// The precision and scale of NUMBER columns are required to choose the appropriate YDB type:
+	// they are described like `NUMBER(p,s)`, or `NUMBER(*,s)` if the precision is not set (like for INTEGER columns).
+	query := "SELECT column_name, " +
+		"CASE WHEN data_type = 'NUMBER' AND data_scale IS NOT NULL " +
+		"THEN 'NUMBER(' || NVL(TO_CHAR(data_precision), '*') || ',' || data_scale || ')' " +
+		"ELSE data_type END " +
+		"FROM user_tab_columns WHERE table_name = :1"
 
 	var args rdbms_utils.QueryArgs
 
@@ -16,3 +23,29 @@ func TableMetadataQuery(request *api_service_protos.TDescribeTableRequest) (stri
 
 	return query, &args
 }
+
+// TableKeyQuery returns the columns of the primary key of the table in the order of the key
+func TableKeyQuery(_ *api_common.TGenericDataSourceInstance, tableName string) (string, *rdbms_utils.QueryArgs) {
+	query := "SELECT c.column_name, " +
+		"CASE WHEN c.data_type = 'NUMBER' AND c.data_scale IS NOT NULL " +
+		"THEN 'NUMBER(' || NVL(TO_CHAR(c.data_precision), '*') || ',' || c.data_scale || ')' " +
+		"ELSE c.data_type END " +
+		"FROM user_constraints k " +
+		"JOIN user_cons_columns cc ON cc.constraint_name = k.constraint_name " +
+		"JOIN user_tab_columns c ON c.table_name = cc.table_name AND c.column_name = cc.column_name " +
+		"WHERE k.constraint_type = 'P' AND k.table_name = :1 " +
+		"ORDER BY cc.position"
+
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(tableName)
+
+	return query, &args
+}
+
+func TableListQuery(_ *api_service_protos.TListTablesRequest) (string, *rdbms_utils.QueryArgs) {
+	// TODO YQ-3413: synonym tables and from other users.
+	query := "SELECT table_name FROM user_tables UNION SELECT view_name FROM user_views ORDER BY 1"
+
+	return query, &rdbms_utils.QueryArgs{}
+}
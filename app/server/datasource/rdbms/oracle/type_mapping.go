@@ -3,9 +3,12 @@ package oracle
 import (
 	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/shopspring/decimal"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
@@ -20,9 +23,12 @@ import (
 var _ datasource.TypeMapper = typeMapper{}
 
 type typeMapper struct {
+	isNumber        *regexp.Regexp
 	isTimestamp     *regexp.Regexp
 	isTimestampWTZ  *regexp.Regexp
 	isTimestampWLTZ *regexp.Regexp
+	isIntervalYM    *regexp.Regexp
+	isIntervalDS    *regexp.Regexp
 }
 
 func (tm typeMapper) SQLTypeToYDBColumn(columnName, typeName string, rules *api_service_protos.TTypeMappingSettings) (*Ydb.Column, error) {
@@ -38,10 +44,12 @@ func (tm typeMapper) SQLTypeToYDBColumn(columnName, typeName string, rules *api_
 	// Reference table: https://github.com/ydb-platform/fq-connector-go/blob/main/docs/type_mapping_table.md
 	switch {
 	case typeName == "NUMBER":
-		// TODO: NUMBER(p, s) can be float. Should convert to Decimal
-		// 	Note: NUMBER can be from 1 to 22 bytes. Has wider range than Int64 or YDB Decimal. Possible representation - string
-		//  		Possible optimisation: if p > 16 then to string, else to int64
-		ydbType = common.MakePrimitiveType(Ydb.Type_INT64)
+		// NUMBER without precision and scale keeps up to 38 significant digits with any exponent,
+		// so its values are passed in their textual representation
+		ydbType = common.MakePrimitiveType(Ydb.Type_UTF8)
+	case tm.isNumber.MatchString(typeName):
+		matches := tm.isNumber.FindStringSubmatch(typeName)
+		ydbType, err = numberToYDBType(matches[1], matches[2])
 	// YQ-3498: go-ora driver has a bug when reading BINARY_FLOAT -1.1, gives -1.2
 	// case typeName == "BINARY_FLOAT":
 	// 	ydbType = common.MakePrimitiveType(Ydb.Type_FLOAT) // driver giver float64 in driver.Value
@@ -65,6 +73,8 @@ func (tm typeMapper) SQLTypeToYDBColumn(columnName, typeName string, rules *api_
 		tm.isTimestampWTZ.MatchString(typeName),
 		tm.isTimestampWLTZ.MatchString(typeName):
 		ydbType, err = common.MakeYdbDateTimeType(Ydb.Type_TIMESTAMP, rules.GetDateTimeFormat())
+	case tm.isIntervalYM.MatchString(typeName), tm.isIntervalDS.MatchString(typeName):
+		ydbType = common.MakePrimitiveType(Ydb.Type_INTERVAL)
 	default:
 		return nil, fmt.Errorf("convert type '%s': %w", typeName, common.ErrDataTypeNotSupported)
 	}
@@ -82,6 +92,51 @@ func (tm typeMapper) SQLTypeToYDBColumn(columnName, typeName string, rules *api_
 	}, nil
 }
 
+// numberMaxInt64Precision is the maximum number of decimal digits that always fit into int64
+const numberMaxInt64Precision = 18
+
+// numberToYDBType maps NUMBER(p,s) to Int64 if its values are integers fitting into it,
+// to YDB Decimal if they fit into its precision, and to string otherwise.
+func numberToYDBType(precisionStr, scaleStr string) (*Ydb.Type, error) {
+	scale, err := strconv.ParseInt(scaleStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parse scale: %w", err)
+	}
+
+	// NUMBER(*,0) stands for the integer types like INTEGER and SMALLINT, which are traditionally mapped to Int64
+	if precisionStr == "*" {
+		if scale == 0 {
+			return common.MakePrimitiveType(Ydb.Type_INT64), nil
+		}
+
+		precisionStr = "38"
+	}
+
+	precision, err := strconv.ParseInt(precisionStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parse precision: %w", err)
+	}
+
+	// negative scale means rounding to the left of the decimal point, so such values are integers too
+	if scale <= 0 {
+		precision -= scale
+		scale = 0
+
+		if precision <= numberMaxInt64Precision {
+			return common.MakePrimitiveType(Ydb.Type_INT64), nil
+		}
+	}
+
+	// scale may be greater than precision for the values like NUMBER(2,4): 0.0099
+	precision = max(precision, scale)
+
+	if precision > common.DecimalMaxPrecision {
+		return common.MakePrimitiveType(Ydb.Type_UTF8), nil
+	}
+
+	return common.MakeDecimalType(uint32(precision), uint32(scale)), nil
+}
+
 //nolint:gocyclo
 func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion.Collection) (paging.RowTransformer[any], error) {
 	_ = ydbTypes
@@ -104,8 +159,13 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 	for i, typeName := range types {
 		switch typeName {
 		case "NUMBER":
-			acceptors = append(acceptors, new(*int64))
-			appenders = append(appenders, utils.MakeAppenderNullable[int64, int64, *array.Int64Builder](cc.Int64()))
+			acceptor, appender, err := makeNumberAcceptorAppender(ydbTypes[i], cc)
+			if err != nil {
+				return nil, fmt.Errorf("make number acceptor and appender: %w", err)
+			}
+
+			acceptors = append(acceptors, acceptor)
+			appenders = append(appenders, appender)
 		case "NCHAR", "CHAR", "LongVarChar", "LONG", "ROWID", "UROWID":
 			acceptors = append(acceptors, new(*string))
 			appenders = append(appenders, utils.MakeAppenderNullable[string, string, *array.StringBuilder](cc.String()))
@@ -151,6 +211,9 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 			case Ydb.Type_UTF8:
 				appenders = append(appenders,
 					utils.MakeAppenderNullable[time.Time, string, *array.StringBuilder](cc.DatetimeToString()))
+			case Ydb.Type_DATE:
+				// the results of TRUNC have zero time
+				appenders = append(appenders, utils.MakeAppenderNullable[time.Time, uint16, *array.Uint16Builder](cc.Date()))
 			case Ydb.Type_DATETIME:
 				appenders = append(appenders, utils.MakeAppenderNullable[time.Time, uint32, *array.Uint32Builder](cc.Datetime()))
 			default:
@@ -176,6 +239,12 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 			default:
 				return nil, fmt.Errorf("unexpected ydb type %v with sql type %s: %w", ydbType, typeName, common.ErrDataTypeNotSupported)
 			}
+		case "IntervalYM_DTY": // INTERVAL YEAR TO MONTH
+			acceptors = append(acceptors, new(*string))
+			appenders = append(appenders, utils.MakeAppenderNullable[string, int64, *array.Int64Builder](intervalYMConverter{}))
+		case "IntervalDS_DTY": // INTERVAL DAY TO SECOND
+			acceptors = append(acceptors, new(*string))
+			appenders = append(appenders, utils.MakeAppenderNullable[string, int64, *array.Int64Builder](intervalDSConverter{}))
 		default:
 			return nil, fmt.Errorf("convert type '%s': %w", typeName, common.ErrDataTypeNotSupported)
 		}
@@ -184,10 +253,155 @@ func transformerFromSQLTypes(types []string, ydbTypes []*Ydb.Type, cc conversion
 	return paging.NewRowTransformer[any](acceptors, appenders, nil), nil
 }
 
+// go-ora driver returns NUMBER values as strings
+func makeNumberAcceptorAppender(
+	ydbType *Ydb.Type,
+	cc conversion.Collection,
+) (any, func(acceptor any, builder array.Builder) error, error) {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	if decimalType := ydbType.GetDecimalType(); decimalType != nil {
+		return new(*string),
+			utils.MakeAppenderNullable[string, []byte, *array.FixedSizeBinaryBuilder](numberToDecimalConverter{decimalType: decimalType}),
+			nil
+	}
+
+	switch ydbType.GetTypeId() {
+	case Ydb.Type_INT64:
+		return new(*int64), utils.MakeAppenderNullable[int64, int64, *array.Int64Builder](cc.Int64()), nil
+	case Ydb.Type_UINT8:
+		// the results of datetime functions
+		return new(*int64),
+			utils.MakeAppenderNullable[int64, uint8, *array.Uint8Builder](conversion.SignedToUnsigned[int64, uint8]()),
+			nil
+	case Ydb.Type_UINT16:
+		return new(*int64),
+			utils.MakeAppenderNullable[int64, uint16, *array.Uint16Builder](conversion.SignedToUnsigned[int64, uint16]()),
+			nil
+	case Ydb.Type_UTF8:
+		return new(*string), utils.MakeAppenderNullable[string, string, *array.StringBuilder](cc.String()), nil
+	default:
+		return nil, nil, fmt.Errorf("unexpected ydb type %v for number: %w", ydbType, common.ErrDataTypeNotSupported)
+	}
+}
+
+type numberToDecimalConverter struct {
+	decimalType *Ydb.DecimalType
+}
+
+func (c numberToDecimalConverter) Convert(in *string) ([]byte, error) {
+	value, err := decimal.NewFromString(*in)
+	if err != nil {
+		return nil, fmt.Errorf("parse number '%s': %w", *in, err)
+	}
+
+	unscaled, err := common.RescaleDecimal(value.Coefficient(), value.Exponent(), c.decimalType.Scale)
+	if err != nil {
+		return nil, fmt.Errorf("rescale decimal: %w", err)
+	}
+
+	return common.DecimalToBytes(unscaled, c.decimalType.Precision)
+}
+
+// YDB Interval has a fixed length, so the months of INTERVAL YEAR TO MONTH are considered to be 30 days long
+// like in PostgreSQL's interval normalization functions.
+const intervalDaysPerMonth = 30
+
+// intervalYMConverter converts INTERVAL YEAR TO MONTH represented by go-ora driver like `+YY-MM`
+type intervalYMConverter struct{}
+
+func (intervalYMConverter) Convert(in *string) (int64, error) {
+	unsigned, negative := cutIntervalSign(*in)
+
+	var years, months int64
+
+	if _, err := fmt.Sscanf(unsigned, "%d-%d", &years, &months); err != nil {
+		return 0, fmt.Errorf("parse interval '%s': %w", *in, err)
+	}
+
+	days := (years*12 + months) * intervalDaysPerMonth
+	if negative {
+		days = -days
+	}
+
+	return common.DaysAndMicrosecondsToYDBInterval(days, 0)
+}
+
+// intervalDSConverter converts INTERVAL DAY TO SECOND represented by go-ora driver like `+DD HH:MM:SS.FFFFFF`.
+// The number of fractional digits depends on the precision of the column, and there are none for SECOND(0).
+type intervalDSConverter struct{}
+
+func (intervalDSConverter) Convert(in *string) (int64, error) {
+	unsigned, negative := cutIntervalSign(*in)
+	unsigned, fraction, _ := strings.Cut(unsigned, ".")
+
+	var days, hours, minutes, seconds int64
+
+	if _, err := fmt.Sscanf(unsigned, "%d %d:%d:%d", &days, &hours, &minutes, &seconds); err != nil {
+		return 0, fmt.Errorf("parse interval '%s': %w", *in, err)
+	}
+
+	microseconds, err := parseIntervalFraction(fraction)
+	if err != nil {
+		return 0, fmt.Errorf("parse interval '%s': %w", *in, err)
+	}
+
+	microseconds += (time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second).Microseconds()
+
+	if negative {
+		days, microseconds = -days, -microseconds
+	}
+
+	return common.DaysAndMicrosecondsToYDBInterval(days, microseconds)
+}
+
+// parseIntervalFraction converts the fractional digits of seconds into microseconds,
+// the digits beyond microsecond precision are truncated
+func parseIntervalFraction(fraction string) (int64, error) {
+	const microsecondDigits = 6
+
+	if len(fraction) > microsecondDigits {
+		fraction = fraction[:microsecondDigits]
+	}
+
+	var microseconds int64
+
+	for i := 0; i < microsecondDigits; i++ {
+		microseconds *= 10
+
+		if i >= len(fraction) {
+			continue
+		}
+
+		if fraction[i] < '0' || fraction[i] > '9' {
+			return 0, fmt.Errorf("unexpected fraction of seconds '%s'", fraction)
+		}
+
+		microseconds += int64(fraction[i] - '0')
+	}
+
+	return microseconds, nil
+}
+
+func cutIntervalSign(value string) (string, bool) {
+	if unsigned, found := strings.CutPrefix(value, "-"); found {
+		return unsigned, true
+	}
+
+	return strings.TrimPrefix(value, "+"), false
+}
+
 func NewTypeMapper() datasource.TypeMapper {
 	return typeMapper{
+		isNumber:        regexp.MustCompile(`^NUMBER\((\*|\d+),(-?\d+)\)$`),
 		isTimestamp:     regexp.MustCompile(`TIMESTAMP\((.+)\)$`),
 		isTimestampWTZ:  regexp.MustCompile(`TIMESTAMP\((.+)\) WITH TIME ZONE$`),
 		isTimestampWLTZ: regexp.MustCompile(`TIMESTAMP\((.+)\) WITH LOCAL TIME ZONE$`),
+		isIntervalYM:    regexp.MustCompile(`^INTERVAL YEAR\(\d+\) TO MONTH$`),
+		isIntervalDS:    regexp.MustCompile(`^INTERVAL DAY\(\d+\) TO SECOND\(\d+\)$`),
 	}
 }
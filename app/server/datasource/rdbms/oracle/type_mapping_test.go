@@ -0,0 +1,116 @@
+package oracle
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/ptr"
+)
+
+func TestSQLTypeToYDBColumn(t *testing.T) {
+	rules := &api_service_protos.TTypeMappingSettings{DateTimeFormat: api_service_protos.EDateTimeFormat_YQL_FORMAT}
+
+	testCases := []struct {
+		typeName string
+		expected *Ydb.Type
+	}{
+		{typeName: "NUMBER(*,0)", expected: common.MakePrimitiveType(Ydb.Type_INT64)},
+		{typeName: "NUMBER(18,0)", expected: common.MakePrimitiveType(Ydb.Type_INT64)},
+		{typeName: "NUMBER(16,-2)", expected: common.MakePrimitiveType(Ydb.Type_INT64)},
+		{typeName: "NUMBER(30,0)", expected: common.MakeDecimalType(30, 0)},
+		{typeName: "NUMBER(10,2)", expected: common.MakeDecimalType(10, 2)},
+		{typeName: "NUMBER(2,4)", expected: common.MakeDecimalType(4, 4)},
+		{typeName: "NUMBER(*,2)", expected: common.MakePrimitiveType(Ydb.Type_UTF8)},
+		{typeName: "NUMBER(38,10)", expected: common.MakePrimitiveType(Ydb.Type_UTF8)},
+		{typeName: "NUMBER", expected: common.MakePrimitiveType(Ydb.Type_UTF8)},
+		{typeName: "INTERVAL YEAR(2) TO MONTH", expected: common.MakePrimitiveType(Ydb.Type_INTERVAL)},
+		{typeName: "INTERVAL DAY(2) TO SECOND(6)", expected: common.MakePrimitiveType(Ydb.Type_INTERVAL)},
+		{typeName: "TIMESTAMP(6) WITH TIME ZONE", expected: common.MakePrimitiveType(Ydb.Type_TIMESTAMP)},
+		{typeName: "RAW", expected: common.MakePrimitiveType(Ydb.Type_STRING)},
+	}
+
+	tm := NewTypeMapper()
+
+	for _, tc := range testCases {
+		column, err := tm.SQLTypeToYDBColumn("col", tc.typeName, rules)
+		require.NoError(t, err, tc.typeName)
+		require.Equal(t, common.MakeOptionalType(tc.expected), column.Type, tc.typeName)
+	}
+}
+
+func TestNumberToDecimalConverter(t *testing.T) {
+	converter := numberToDecimalConverter{decimalType: &Ydb.DecimalType{Precision: 10, Scale: 2}}
+
+	for input, expected := range map[string]int64{"12.34": 1234, "-0.5": -50, "7": 700} {
+		actual, err := converter.Convert(ptr.String(input))
+		require.NoError(t, err)
+
+		data, err := common.DecimalToBytes(big.NewInt(expected), 10)
+		require.NoError(t, err)
+		require.Equal(t, data, actual, input)
+	}
+
+	_, err := converter.Convert(ptr.String("1.234"))
+	require.True(t, errors.Is(err, common.ErrValueOutOfTypeBounds))
+}
+
+func TestIntervalConverters(t *testing.T) {
+	t.Run("year to month", func(t *testing.T) {
+		actual, err := intervalYMConverter{}.Convert(ptr.String("+01-02"))
+		require.NoError(t, err)
+		require.Equal(t, (14 * 30 * 24 * time.Hour).Microseconds(), actual)
+
+		actual, err = intervalYMConverter{}.Convert(ptr.String("-00-01"))
+		require.NoError(t, err)
+		require.Equal(t, -(30 * 24 * time.Hour).Microseconds(), actual)
+
+		// 200 years do not fit into YDB Interval
+		_, err = intervalYMConverter{}.Convert(ptr.String("+200-00"))
+		require.True(t, errors.Is(err, common.ErrValueOutOfTypeBounds))
+	})
+
+	t.Run("day to second", func(t *testing.T) {
+		actual, err := intervalDSConverter{}.Convert(ptr.String("+01 02:03:04.000005"))
+		require.NoError(t, err)
+		require.Equal(t, (26*time.Hour + 3*time.Minute + 4*time.Second + 5*time.Microsecond).Microseconds(), actual)
+
+		actual, err = intervalDSConverter{}.Convert(ptr.String("-00 03:00:00.000000"))
+		require.NoError(t, err)
+		require.Equal(t, (-3 * time.Hour).Microseconds(), actual)
+
+		_, err = intervalDSConverter{}.Convert(ptr.String("+999999 00:00:00.000000"))
+		require.True(t, errors.Is(err, common.ErrValueOutOfTypeBounds))
+	})
+
+	t.Run("day to second with different fraction precision", func(t *testing.T) {
+		// SECOND(0) columns have no fraction at all
+		actual, err := intervalDSConverter{}.Convert(ptr.String("+00 00:00:07"))
+		require.NoError(t, err)
+		require.Equal(t, (7 * time.Second).Microseconds(), actual)
+
+		// the fraction is scaled by its length: `.5` stands for 500 milliseconds
+		actual, err = intervalDSConverter{}.Convert(ptr.String("+00 00:00:01.5"))
+		require.NoError(t, err)
+		require.Equal(t, (1500 * time.Millisecond).Microseconds(), actual)
+
+		actual, err = intervalDSConverter{}.Convert(ptr.String("-00 00:00:00.012"))
+		require.NoError(t, err)
+		require.Equal(t, -(12 * time.Millisecond).Microseconds(), actual)
+
+		// nanoseconds of SECOND(9) are truncated
+		actual, err = intervalDSConverter{}.Convert(ptr.String("+00 00:00:00.123456789"))
+		require.NoError(t, err)
+		require.Equal(t, int64(123456), actual)
+
+		_, err = intervalDSConverter{}.Convert(ptr.String("+00 00:00:00.1x"))
+		require.Error(t, err)
+	})
+}
@@ -3,9 +3,12 @@ package postgresql
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"go.uber.org/zap"
 
@@ -45,8 +48,24 @@ func (r rows) MakeTransformer(ydbColumns []*Ydb.Column, cc conversion.Collection
 	return transformerFromOIDs(oids, common.YDBColumnsToYDBTypes(ydbColumns), cc)
 }
 
+// pooledConnection is a physical connection to PostgreSQL that can be shared between requests
+type pooledConnection struct {
+	*pgx.Conn
+	// Set when the connection could not be reset, so its state is unknown
+	broken atomic.Bool
+}
+
+func (c *pooledConnection) Reusable() bool {
+	return !c.broken.Load() && !c.Conn.IsClosed() && !c.Conn.PgConn().IsBusy()
+}
+
+func (c *pooledConnection) Close() error {
+	return c.Conn.Close(context.TODO())
+}
+
 type connection struct {
 	*pgx.Conn
+	pooled             *pooledConnection
 	queryLogger        common.QueryLogger
 	dataSourceInstance *api_common.TGenericDataSourceInstance
 	tableName          string
@@ -56,10 +75,32 @@ func (c *connection) Close() error {
 	return c.Conn.Close(context.TODO())
 }
 
+// transformArgs converts the values that are not supported by the driver
+func transformArgs(src *rdbms_utils.QueryArgs) []any {
+	dst := make([]any, len(src.Values()))
+
+	for i, v := range src.Values() {
+		switch t := v.(type) {
+		case time.Duration:
+			dst[i] = pgtype.Interval{Microseconds: t.Microseconds(), Valid: true}
+		case *time.Duration:
+			if t != nil {
+				dst[i] = pgtype.Interval{Microseconds: t.Microseconds(), Valid: true}
+			} else {
+				dst[i] = pgtype.Interval{}
+			}
+		default:
+			dst[i] = v
+		}
+	}
+
+	return dst
+}
+
 func (c *connection) Query(params *rdbms_utils.QueryParams) (rdbms_utils.Rows, error) {
 	c.queryLogger.Dump(params.QueryText, params.QueryArgs.Values()...)
 
-	out, err := c.Conn.Query(params.Ctx, params.QueryText, params.QueryArgs.Values()...)
+	out, err := c.Conn.Query(params.Ctx, params.QueryText, transformArgs(params.QueryArgs)...)
 	if err != nil {
 		return nil, fmt.Errorf("query error: %w", err)
 	}
@@ -128,31 +169,46 @@ func (c *connectionManager) Make(
 	openCtx, openCtxCancel := context.WithTimeout(ctx, common.MustDurationFromString(c.cfg.GetOpenConnectionTimeout()))
 	defer openCtxCancel()
 
-	conn, err := pgx.ConnectConfig(openCtx, connCfg)
-	if err != nil {
-		return nil, fmt.Errorf("connect config: %w", err)
-	}
+	// The schema is a part of the data source instance, so the pooled connections
+	// always have the proper search path.
+	pooled, err := c.AcquireConnection(openCtx, logger, dsi, func() (rdbms_utils.PooledConnection, error) {
+		conn, err := pgx.ConnectConfig(openCtx, connCfg)
+		if err != nil {
+			return nil, fmt.Errorf("connect config: %w", err)
+		}
 
-	// set schema (public by default)
+		// set schema (public by default)
 
-	searchPath := fmt.Sprintf("set search_path=%s", c.schemaGetter(dsi))
+		searchPath := fmt.Sprintf("set search_path=%s", c.schemaGetter(dsi))
 
-	if _, err = conn.Exec(openCtx, searchPath); err != nil {
-		return nil, fmt.Errorf("exec: %w", err)
+		if _, err = conn.Exec(openCtx, searchPath); err != nil {
+			common.LogCloserError(logger, &pooledConnection{Conn: conn}, "close connection")
+
+			return nil, fmt.Errorf("exec: %w", err)
+		}
+
+		return &pooledConnection{Conn: conn}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection: %w", err)
 	}
 
+	conn := pooled.(*pooledConnection)
 	queryLogger := c.QueryLoggerFactory.Make(logger)
 
-	return []rdbms_utils.Connection{&connection{conn, queryLogger, dsi, params.TableName}}, nil
+	return []rdbms_utils.Connection{&connection{conn.Conn, conn, queryLogger, dsi, params.TableName}}, nil
 }
 
-func (*connectionManager) Release(ctx context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
+func (c *connectionManager) Release(ctx context.Context, logger *zap.Logger, cs []rdbms_utils.Connection) {
 	for _, conn := range cs {
 		if err := conn.(*connection).Conn.DeallocateAll(ctx); err != nil {
 			logger.Error("deallocate prepared statements", zap.Error(err))
+
+			// the state of the connection is unknown, so the pool closes it instead of reusing
+			conn.(*connection).pooled.broken.Store(true)
 		}
 
-		common.LogCloserError(logger, conn, "close connection")
+		c.ReleaseConnection(logger, conn.(*connection).pooled)
 	}
 }
 
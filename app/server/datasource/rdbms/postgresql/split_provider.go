@@ -0,0 +1,212 @@
+package postgresql
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var _ rdbms_utils.SplitProvider = (*SplitProvider)(nil)
+
+// SplitProvider cuts PostgreSQL-like tables into the ranges of physical pages.
+// The number of pages is estimated with `pg_class.relpages` statistics, which may be outdated,
+// so the last split is always unbounded in order not to lose rows.
+// The splits are read in different transactions, and ctid of a row changes when the row is updated
+// or the table is rewritten (VACUUM FULL, CLUSTER), so the tables modified during reading
+// may have some rows read twice or missed.
+// Greenplum tables are not split: there is no TID Range Scan in Greenplum,
+// and the same ctid values are found on every segment.
+type SplitProvider struct {
+	cfg *config.TPostgreSQLConfig_TSplitting
+}
+
+func (s SplitProvider) ListSplits(
+	params *rdbms_utils.ListSplitsParams,
+) error {
+	resultChan, slct, ctx, logger := params.ResultChan, params.Select, params.Ctx, params.Logger
+
+	if !s.cfg.GetEnabled() {
+		return listSingleSplit(ctx, slct, resultChan)
+	}
+
+	var cs []rdbms_utils.Connection
+
+	err := params.MakeConnectionRetrier.Run(ctx, logger,
+		func() error {
+			var makeConnErr error
+
+			makeConnectionParams := &rdbms_utils.ConnectionParams{
+				Ctx:                ctx,
+				Logger:             logger,
+				DataSourceInstance: slct.GetDataSourceInstance(),
+				TableName:          slct.GetFrom().GetTable(),
+				QueryPhase:         rdbms_utils.QueryPhaseListSplits,
+			}
+
+			cs, makeConnErr = params.ConnectionManager.Make(makeConnectionParams)
+			if makeConnErr != nil {
+				return fmt.Errorf("make connection: %w", makeConnErr)
+			}
+
+			return nil
+		},
+	)
+
+	if err != nil {
+		return fmt.Errorf("retry: %w", err)
+	}
+
+	defer params.ConnectionManager.Release(ctx, logger, cs)
+
+	pages, err := s.getTablePages(ctx, logger, cs[0])
+	if err != nil {
+		return fmt.Errorf("get table pages: %w", err)
+	}
+
+	ranges := makeCtidRanges(pages, s.cfg.GetPagesPerSplit(), s.getMaxSplitCount(params.Request))
+
+	logger.Info("determined table page ranges", zap.Uint64("pages", pages), zap.Int("splits", len(ranges)))
+
+	if len(ranges) < 2 {
+		return listSingleSplit(ctx, slct, resultChan)
+	}
+
+	for _, ctidRange := range ranges {
+		description := &TSplitDescription{
+			Payload: &TSplitDescription_CtidRange{
+				CtidRange: ctidRange,
+			},
+		}
+
+		select {
+		case resultChan <- &datasource.ListSplitResult{Slct: slct, Description: description}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (s SplitProvider) MaySplitTables() bool {
+	return s.cfg.GetEnabled()
+}
+
+func (SplitProvider) getTablePages(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn rdbms_utils.Connection,
+) (uint64, error) {
+	// The search path has been already set to the requested schema by the connection manager
+	queryText := `SELECT c.relpages FROM pg_class c JOIN pg_namespace n ON c.relnamespace = n.oid
+		WHERE n.nspname = current_schema() AND c.relname = $1`
+
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(conn.TableName())
+
+	rows, err := conn.Query(&rdbms_utils.QueryParams{
+		Ctx:       ctx,
+		Logger:    logger,
+		QueryText: queryText,
+		QueryArgs: &args,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("query: %w", err)
+	}
+
+	defer func() { common.LogCloserError(logger, rows, "close rows") }()
+
+	var pages int32
+
+	// If the table is not found (e. g. it's a view), it will be read within a single split.
+	for rows.Next() {
+		if err = rows.Scan(&pages); err != nil {
+			return 0, fmt.Errorf("rows scan: %w", err)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("rows iteration: %w", err)
+	}
+
+	// relpages may be negative for partitioned tables
+	if pages < 0 {
+		return 0, nil
+	}
+
+	return uint64(pages), nil
+}
+
+func (s SplitProvider) getMaxSplitCount(request *api_service_protos.TListSplitsRequest) uint64 {
+	maxSplitCount := s.cfg.GetMaxSplitCount()
+	requested := uint64(request.GetMaxSplitCount())
+
+	if requested != 0 && (maxSplitCount == 0 || requested < maxSplitCount) {
+		maxSplitCount = requested
+	}
+
+	return maxSplitCount
+}
+
+// makeCtidRanges splits the table pages into the ranges of approximately equal size.
+// The first range starts from the beginning of the table, the last one is unbounded.
+func makeCtidRanges(pages, pagesPerSplit, maxSplitCount uint64) []*TSplitDescription_TCtidRange {
+	if pagesPerSplit == 0 {
+		return nil
+	}
+
+	splitCount := (pages + pagesPerSplit - 1) / pagesPerSplit
+	if maxSplitCount != 0 && splitCount > maxSplitCount {
+		splitCount = maxSplitCount
+	}
+
+	if splitCount < 2 {
+		return nil
+	}
+
+	step := (pages + splitCount - 1) / splitCount
+	ranges := make([]*TSplitDescription_TCtidRange, 0, splitCount)
+
+	for i := uint64(0); i < splitCount; i++ {
+		ctidRange := &TSplitDescription_TCtidRange{
+			BeginPage: i * step,
+			EndPage:   (i + 1) * step,
+		}
+
+		if i == splitCount-1 {
+			ctidRange.EndPage = 0
+		}
+
+		ranges = append(ranges, ctidRange)
+	}
+
+	return ranges
+}
+
+func listSingleSplit(
+	ctx context.Context,
+	slct *api_service_protos.TSelect,
+	resultChan chan<- *datasource.ListSplitResult,
+) error {
+	select {
+	case resultChan <- &datasource.ListSplitResult{Slct: slct, Description: nil}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+func NewSplitProvider(cfg *config.TPostgreSQLConfig_TSplitting) SplitProvider {
+	return SplitProvider{
+		cfg: cfg,
+	}
+}
@@ -0,0 +1,72 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeCtidRanges(t *testing.T) {
+	type testCase struct {
+		testName      string
+		pages         uint64
+		pagesPerSplit uint64
+		maxSplitCount uint64
+		expected      []*TSplitDescription_TCtidRange
+	}
+
+	tcs := []testCase{
+		{
+			testName:      "empty_table",
+			pages:         0,
+			pagesPerSplit: 10,
+			expected:      nil,
+		},
+		{
+			testName:      "single_split",
+			pages:         10,
+			pagesPerSplit: 10,
+			expected:      nil,
+		},
+		{
+			testName:      "several_splits",
+			pages:         25,
+			pagesPerSplit: 10,
+			expected: []*TSplitDescription_TCtidRange{
+				{BeginPage: 0, EndPage: 9},
+				{BeginPage: 9, EndPage: 18},
+				{BeginPage: 18, EndPage: 0},
+			},
+		},
+		{
+			testName:      "max_split_count",
+			pages:         100,
+			pagesPerSplit: 10,
+			maxSplitCount: 2,
+			expected: []*TSplitDescription_TCtidRange{
+				{BeginPage: 0, EndPage: 50},
+				{BeginPage: 50, EndPage: 0},
+			},
+		},
+		{
+			testName:      "zero_pages_per_split",
+			pages:         100,
+			pagesPerSplit: 0,
+			expected:      nil,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			actual := makeCtidRanges(tc.pages, tc.pagesPerSplit, tc.maxSplitCount)
+			require.Len(t, actual, len(tc.expected))
+
+			for i := range tc.expected {
+				require.Equal(t, tc.expected[i].BeginPage, actual[i].BeginPage)
+				require.Equal(t, tc.expected[i].EndPage, actual[i].EndPage)
+			}
+		})
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"strings"
 
+	"google.golang.org/protobuf/encoding/protojson"
+
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ rdbms_utils.SQLFormatter = (*sqlFormatter)(nil)
@@ -47,6 +50,10 @@ func (f *sqlFormatter) supportsType(typeID Ydb.Type_PrimitiveTypeId) bool {
 		return false
 	case Ydb.Type_TIMESTAMP:
 		return f.cfg.EnableTimestampPushdown
+	case Ydb.Type_DATE:
+		return f.cfg.EnableTimestampPushdown
+	case Ydb.Type_INTERVAL:
+		return true
 	default:
 		return false
 	}
@@ -56,6 +63,8 @@ func (f sqlFormatter) supportsConstantValueExpression(t *Ydb.Type) bool {
 	switch v := t.Type.(type) {
 	case *Ydb.Type_TypeId:
 		return f.supportsType(v.TypeId)
+	case *Ydb.Type_DecimalType:
+		return true
 	case *Ydb.Type_OptionalType:
 		return f.supportsConstantValueExpression(v.OptionalType.Item)
 	default:
@@ -73,6 +82,8 @@ func (f sqlFormatter) SupportsExpression(expression *api_service_protos.TExpress
 		return false
 	case *api_service_protos.TExpression_Null:
 		return true
+	case *api_service_protos.TExpression_DateTimeFunction:
+		return f.SupportsExpression(e.DateTimeFunction.Value)
 	default:
 		return false
 	}
@@ -95,10 +106,138 @@ func (f sqlFormatter) FormatWhat(what *api_service_protos.TSelect_TWhat, _ strin
 	return rdbms_utils.FormatWhatDefault(f, what), nil
 }
 
+// PostgreSQL places NULL values after the others in ascending order
+func (f sqlFormatter) FormatOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return rdbms_utils.FormatOrderByDefault(f, orderBy, true)
+}
+
+func (f sqlFormatter) MaxInSetParameters() int {
+	return rdbms_utils.MaxInSetParametersFromConfig(f.cfg)
+}
+
+func (sqlFormatter) LargeInSetMode() rdbms_utils.LargeInSetMode {
+	return rdbms_utils.LargeInSetModeArray
+}
+
+// FormatDateTimeFunction casts the results of the functions to the types mapped back to the inferred ones:
+// `date_trunc` returns timestamp rather than date, and EXTRACT returns numeric.
+// There are no unsigned types in PostgreSQL, so Uint16 and Uint8 are read from integer and smallint.
+func (sqlFormatter) FormatDateTimeFunction(
+	function api_service_protos.TExpression_TDateTimeFunction_EFunction,
+	value string,
+) (string, error) {
+	switch function {
+	case api_service_protos.TExpression_TDateTimeFunction_TO_DATE:
+		return fmt.Sprintf("CAST(%s AS date)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_YEAR:
+		return fmt.Sprintf("CAST(EXTRACT(YEAR FROM %s) AS integer)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_MONTH:
+		return fmt.Sprintf("CAST(EXTRACT(MONTH FROM %s) AS smallint)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_DAY_OF_MONTH:
+		return fmt.Sprintf("CAST(EXTRACT(DAY FROM %s) AS smallint)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_HOUR:
+		return fmt.Sprintf("CAST(EXTRACT(HOUR FROM %s) AS smallint)", value), nil
+	default:
+		return "", fmt.Errorf("function %v: %w", function, common.ErrUnimplementedOperation)
+	}
+}
+
+// FormatComputedColumn converts the expression to the inferred type, because PostgreSQL
+// widens the results of arithmetical expressions and some functions (e.g. EXTRACT returns numeric).
+// PostgreSQL has no unsigned integer types, so only Uint8 and Uint16 expressions are supported:
+// they are read from the wider signed types.
+func (sqlFormatter) FormatComputedColumn(expression string, ydbType *Ydb.Type, name string) (string, error) {
+	typeName, err := castTypeName(ydbType)
+	if err != nil {
+		return "", fmt.Errorf("cast type name: %w", err)
+	}
+
+	return fmt.Sprintf("CAST(%s AS %s) AS %s", expression, typeName, name), nil
+}
+
+// castTypeName returns the name of PostgreSQL type that is mapped back to the given YDB type.
+// All the values are nullable in PostgreSQL, so the optional types are unwrapped.
+func castTypeName(ydbType *Ydb.Type) (string, error) {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	if decimalType := ydbType.GetDecimalType(); decimalType != nil {
+		return fmt.Sprintf("numeric(%d, %d)", decimalType.Precision, decimalType.Scale), nil
+	}
+
+	switch ydbType.GetTypeId() {
+	case Ydb.Type_BOOL:
+		return "boolean", nil
+	case Ydb.Type_INT16, Ydb.Type_UINT8:
+		return "smallint", nil
+	case Ydb.Type_INT32, Ydb.Type_UINT16:
+		return "integer", nil
+	case Ydb.Type_INT64:
+		return "bigint", nil
+	case Ydb.Type_FLOAT:
+		return "real", nil
+	case Ydb.Type_DOUBLE:
+		return "double precision", nil
+	case Ydb.Type_STRING:
+		return "bytea", nil
+	case Ydb.Type_UTF8:
+		return "text", nil
+	case Ydb.Type_DATE:
+		return "date", nil
+	case Ydb.Type_TIMESTAMP:
+		return "timestamp", nil
+	default:
+		return "", fmt.Errorf("type %v: %w", ydbType, common.ErrUnsupportedExpression)
+	}
+}
+
+// FormatInArray passes the large IN sets as a single array parameter
+func (sqlFormatter) FormatInArray(value, arrayPlaceholder string) (string, error) {
+	return fmt.Sprintf("(%s = ANY(%s))", value, arrayPlaceholder), nil
+}
+
 func (f sqlFormatter) FormatFrom(tableName string) string {
 	return f.SanitiseIdentifier(tableName)
 }
 
+// RenderSelectQueryText restricts the query with the range of physical pages
+// if the table has been split by the split provider.
+func (f sqlFormatter) RenderSelectQueryText(
+	parts *rdbms_utils.SelectQueryParts,
+	split *api_service_protos.TSplit,
+) (string, error) {
+	if len(split.GetDescription()) == 0 {
+		return f.SQLFormatterDefault.RenderSelectQueryText(parts, split)
+	}
+
+	var splitDescription TSplitDescription
+
+	if err := protojson.Unmarshal(split.GetDescription(), &splitDescription); err != nil {
+		return "", fmt.Errorf("unmarshal split description: %w", err)
+	}
+
+	ctidRange := splitDescription.GetCtidRange()
+	if ctidRange == nil {
+		return "", fmt.Errorf("unknown split description type: %T", splitDescription.GetPayload())
+	}
+
+	conditions := []string{fmt.Sprintf("ctid >= '(%d,0)'::tid", ctidRange.BeginPage)}
+
+	if ctidRange.EndPage != 0 {
+		conditions = append(conditions, fmt.Sprintf("ctid < '(%d,0)'::tid", ctidRange.EndPage))
+	}
+
+	if parts.WhereClause != "" {
+		conditions = append([]string{"(" + parts.WhereClause + ")"}, conditions...)
+	}
+
+	modifiedParts := *parts
+	modifiedParts.WhereClause = strings.Join(conditions, " AND ")
+
+	return f.SQLFormatterDefault.RenderSelectQueryText(&modifiedParts, split)
+}
+
 func NewSQLFormatter(cfg *config.TPushdownConfig) rdbms_utils.SQLFormatter {
 	return sqlFormatter{cfg: cfg}
 }
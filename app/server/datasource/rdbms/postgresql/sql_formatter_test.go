@@ -4,13 +4,17 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	ydb "github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
@@ -453,6 +457,219 @@ func TestMakeSelectQuery(t *testing.T) {
 			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32)},
 			err:            nil,
 		},
+		{
+			testName: "limit",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			outputQuery:    `SELECT "col0", "col1" FROM "tab" LIMIT 10`,
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "limit_offset",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 5,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			outputQuery:    `SELECT "col0", "col1" FROM "tab" LIMIT 10 OFFSET 5`,
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "offset_without_limit",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  0,
+					Offset: 5,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			outputQuery:    `SELECT "col0", "col1" FROM "tab"`,
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "limit_with_filter",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_IsNull{
+							IsNull: &api_service_protos.TPredicate_TIsNull{
+								Value: rdbms_utils.NewColumnExpression("col1"),
+							},
+						},
+					},
+				},
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 5,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			outputQuery:    `SELECT "col0", "col1" FROM "tab" WHERE ("col1" IS NULL) LIMIT 10 OFFSET 5`,
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "limit_with_unsupported_predicate",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_Between{
+							Between: &api_service_protos.TPredicate_TBetween{
+								Value:    rdbms_utils.NewColumnExpression("col2"),
+								Least:    rdbms_utils.NewColumnExpression("col1"),
+								Greatest: rdbms_utils.NewColumnExpression("col3"),
+							},
+						},
+					},
+				},
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 5,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			outputQuery:    `SELECT "col0", "col1" FROM "tab"`,
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "decimal_filter",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_Comparison{
+							Comparison: &api_service_protos.TPredicate_TComparison{
+								Operation: api_service_protos.TPredicate_TComparison_GE,
+								LeftValue: rdbms_utils.NewColumnExpression("col2"),
+								RightValue: &api_service_protos.TExpression{
+									Payload: &api_service_protos.TExpression_TypedValue{
+										TypedValue: &ydb.TypedValue{
+											Type: common.MakeDecimalType(10, 2),
+											// -12.34 as a 128-bit two's complement integer
+											Value: &ydb.Value{
+												Value:    &ydb.Value_Low_128{Low_128: ^uint64(1234) + 1},
+												High_128: ^uint64(0),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			outputQuery:    `SELECT "col0", "col1" FROM "tab" WHERE ("col2" >= $1)`,
+			outputArgs:     []any{"-12.34"},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "interval_filter",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_Comparison{
+							Comparison: &api_service_protos.TPredicate_TComparison{
+								Operation: api_service_protos.TPredicate_TComparison_L,
+								LeftValue: rdbms_utils.NewColumnExpression("col2"),
+								RightValue: &api_service_protos.TExpression{
+									Payload: &api_service_protos.TExpression_TypedValue{
+										TypedValue: common.MakeTypedValue(
+											common.MakePrimitiveType(ydb.Type_INTERVAL),
+											(90 * time.Minute).Microseconds(),
+										),
+									},
+								},
+							},
+						},
+					},
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			outputQuery:    `SELECT "col0", "col1" FROM "tab" WHERE ("col2" < $1)`,
+			outputArgs:     []any{90 * time.Minute},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "order_by_limit",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What:    rdbms_utils.NewDefaultWhat(),
+				OrderBy: rdbms_utils.NewDefaultOrderBy(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+			outputQuery:    `SELECT "col0", "col1" FROM "tab" ORDER BY "col0" DESC NULLS LAST, "col1" ASC NULLS FIRST LIMIT 10`,
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
 	}
 
 	for _, tc := range tcs {
@@ -479,3 +696,446 @@ func TestMakeSelectQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeSelectQueryWithCtidRange(t *testing.T) {
+	type testCase struct {
+		testName    string
+		where       *api_service_protos.TSelect_TWhere
+		ctidRange   *TSplitDescription_TCtidRange
+		outputQuery string
+	}
+
+	logger := common.NewTestLogger(t)
+	formatter := NewSQLFormatter(nil)
+
+	tcs := []testCase{
+		{
+			testName:    "bounded_range",
+			ctidRange:   &TSplitDescription_TCtidRange{BeginPage: 0, EndPage: 100},
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE ctid >= '(0,0)'::tid AND ctid < '(100,0)'::tid`,
+		},
+		{
+			testName:    "unbounded_range",
+			ctidRange:   &TSplitDescription_TCtidRange{BeginPage: 100},
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE ctid >= '(100,0)'::tid`,
+		},
+		{
+			testName: "range_with_filter",
+			where: &api_service_protos.TSelect_TWhere{
+				FilterTyped: &api_service_protos.TPredicate{
+					Payload: &api_service_protos.TPredicate_IsNull{
+						IsNull: &api_service_protos.TPredicate_TIsNull{
+							Value: rdbms_utils.NewColumnExpression("col1"),
+						},
+					},
+				},
+			},
+			ctidRange:   &TSplitDescription_TCtidRange{BeginPage: 100, EndPage: 200},
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE (("col1" IS NULL)) AND ctid >= '(100,0)'::tid AND ctid < '(200,0)'::tid`,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			description, err := protojson.Marshal(&TSplitDescription{
+				Payload: &TSplitDescription_CtidRange{CtidRange: tc.ctidRange},
+			})
+			require.NoError(t, err)
+
+			split := &api_service_protos.TSplit{
+				Select: &api_service_protos.TSelect{
+					From:  &api_service_protos.TSelect_TFrom{Table: "tab"},
+					What:  rdbms_utils.NewDefaultWhat(),
+					Where: tc.where,
+					DataSourceInstance: &api_common.TGenericDataSourceInstance{
+						Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+					},
+				},
+				Payload: &api_service_protos.TSplit_Description{Description: description},
+			}
+
+			readSplitsQuery, err := rdbms_utils.MakeSelectQuery(
+				context.Background(),
+				logger,
+				formatter,
+				split,
+				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+				"tab",
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputQuery, readSplitsQuery.QueryText)
+		})
+	}
+}
+
+func TestMakeSelectQueryWithInSet(t *testing.T) {
+	type testCase struct {
+		testName    string
+		filter      *api_service_protos.TPredicate
+		outputQuery string
+		outputArgs  []any
+	}
+
+	logger := common.NewTestLogger(t)
+	formatter := NewSQLFormatter(&config.TPushdownConfig{MaxInSetParameters: 2})
+
+	tcs := []testCase{
+		{
+			testName: "separate_parameters",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE ("col0" IN ($1, $2))`,
+			outputArgs:  []any{int32(1), int32(2)},
+		},
+		{
+			testName: "array",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+				rdbms_utils.NewInt32ValueExpression(3),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE ("col0" = ANY($1))`,
+			outputArgs:  []any{[]int32{1, 2, 3}},
+		},
+		{
+			// the large set cannot be made of the values of different types, so the predicate is not pushed down
+			testName: "array_of_different_types",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+				rdbms_utils.NewInt64ValueExpression(3),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab"`,
+			outputArgs:  []any{},
+		},
+		{
+			// the limit on the number of parameters applies to the whole query
+			testName: "parameters_per_query",
+			filter: rdbms_utils.NewConjunctionPredicate(
+				rdbms_utils.NewInPredicate("col0",
+					rdbms_utils.NewInt32ValueExpression(1),
+					rdbms_utils.NewInt32ValueExpression(2),
+				),
+				rdbms_utils.NewInPredicate("col0",
+					rdbms_utils.NewInt32ValueExpression(3),
+					rdbms_utils.NewInt32ValueExpression(4),
+				),
+			),
+			outputQuery: `SELECT "col0", "col1" FROM "tab" WHERE (("col0" IN ($1, $2)) AND ("col0" = ANY($3)))`,
+			outputArgs:  []any{int32(1), int32(2), []int32{3, 4}},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			split := &api_service_protos.TSplit{
+				Select: &api_service_protos.TSelect{
+					From:  &api_service_protos.TSelect_TFrom{Table: "tab"},
+					What:  rdbms_utils.NewDefaultWhat(),
+					Where: &api_service_protos.TSelect_TWhere{FilterTyped: tc.filter},
+					DataSourceInstance: &api_common.TGenericDataSourceInstance{
+						Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+					},
+				},
+			}
+
+			readSplitsQuery, err := rdbms_utils.MakeSelectQuery(
+				context.Background(),
+				logger,
+				formatter,
+				split,
+				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+				"tab",
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputQuery, readSplitsQuery.QueryText)
+			require.Equal(t, tc.outputArgs, readSplitsQuery.QueryArgs.Values())
+		})
+	}
+}
+
+func TestMakeSelectQueryWithKeyCursor(t *testing.T) {
+	type testCase struct {
+		testName         string
+		where            *api_service_protos.TSelect_TWhere
+		lastKey          []*ydb.TypedValue
+		rowsDelivered    uint64
+		outputQuery      string
+		outputArgs       []any
+		limitExhausted   bool
+		keyColumnIndices []int
+	}
+
+	logger := common.NewTestLogger(t)
+	formatter := NewSQLFormatter(nil)
+
+	keyColumns := []*ydb.Column{
+		{Name: "col0", Type: common.MakePrimitiveType(ydb.Type_INT32)},
+		{Name: "id", Type: common.MakePrimitiveType(ydb.Type_INT64)},
+	}
+
+	tcs := []testCase{
+		{
+			testName:         "initial",
+			outputQuery:      `SELECT "col0", "col1", "id" FROM "tab" ORDER BY "col0" ASC NULLS FIRST, "id" ASC NULLS FIRST LIMIT 10`,
+			outputArgs:       []any{},
+			keyColumnIndices: []int{0, 2},
+		},
+		{
+			testName: "resumed",
+			where: &api_service_protos.TSelect_TWhere{
+				FilterTyped: &api_service_protos.TPredicate{
+					Payload: &api_service_protos.TPredicate_IsNull{
+						IsNull: &api_service_protos.TPredicate_TIsNull{
+							Value: rdbms_utils.NewColumnExpression("col1"),
+						},
+					},
+				},
+			},
+			lastKey: []*ydb.TypedValue{
+				common.MakeTypedValue(common.MakePrimitiveType(ydb.Type_INT32), int32(1)),
+				common.MakeTypedValue(common.MakePrimitiveType(ydb.Type_INT64), int64(2)),
+			},
+			rowsDelivered: 4,
+			outputQuery: `SELECT "col0", "col1", "id" FROM "tab" WHERE (("col1" IS NULL)) AND (("col0" > $1) OR (("col0" = $2) AND ("id" > $3))) ` +
+				`ORDER BY "col0" ASC NULLS FIRST, "id" ASC NULLS FIRST LIMIT 6`,
+			outputArgs:       []any{int32(1), int32(1), int64(2)},
+			keyColumnIndices: []int{0, 2},
+		},
+		{
+			testName: "limit_exhausted",
+			lastKey: []*ydb.TypedValue{
+				common.MakeTypedValue(common.MakePrimitiveType(ydb.Type_INT32), int32(1)),
+				common.MakeTypedValue(common.MakePrimitiveType(ydb.Type_INT64), int64(2)),
+			},
+			rowsDelivered: 10,
+			outputQuery: `SELECT "col0", "col1", "id" FROM "tab" WHERE (("col0" > $1) OR (("col0" = $2) AND ("id" > $3))) ` +
+				`ORDER BY "col0" ASC NULLS FIRST, "id" ASC NULLS FIRST LIMIT 10`,
+			outputArgs:       []any{int32(1), int32(1), int64(2)},
+			limitExhausted:   true,
+			keyColumnIndices: []int{0, 2},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			split := &api_service_protos.TSplit{
+				Select: &api_service_protos.TSelect{
+					From:  &api_service_protos.TSelect_TFrom{Table: "tab"},
+					What:  rdbms_utils.NewDefaultWhat(),
+					Where: tc.where,
+					Limit: &api_service_protos.TSelect_TLimit{Limit: 10},
+					DataSourceInstance: &api_common.TGenericDataSourceInstance{
+						Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+					},
+				},
+			}
+
+			keyCursor := &rdbms_utils.KeyCursor{
+				Columns:       keyColumns,
+				LastKey:       tc.lastKey,
+				RowsDelivered: tc.rowsDelivered,
+			}
+
+			readSplitsQuery, err := rdbms_utils.MakeSelectQueryWithKeyCursor(
+				context.Background(),
+				logger,
+				formatter,
+				split,
+				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+				"tab",
+				keyCursor,
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputQuery, readSplitsQuery.QueryText)
+			require.Equal(t, tc.outputArgs, readSplitsQuery.QueryArgs.Values())
+			require.Equal(t, tc.limitExhausted, readSplitsQuery.LimitExhausted)
+			require.Equal(t, tc.keyColumnIndices, readSplitsQuery.KeyColumnIndices)
+			require.Equal(t, 2, readSplitsQuery.RequestedColumns)
+		})
+	}
+
+	t.Run("explicit_order", func(t *testing.T) {
+		split := &api_service_protos.TSplit{
+			Select: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{Table: "tab"},
+				What: rdbms_utils.NewDefaultWhat(),
+				OrderBy: &api_service_protos.TSelect_TOrderBy{
+					Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{{Column: "col1"}},
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_POSTGRESQL,
+				},
+			},
+		}
+
+		_, err := rdbms_utils.MakeSelectQueryWithKeyCursor(
+			context.Background(),
+			logger,
+			formatter,
+			split,
+			api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+			"tab",
+			&rdbms_utils.KeyCursor{Columns: keyColumns},
+		)
+		require.True(t, errors.Is(err, common.ErrInvalidRequest))
+	})
+}
+
+func TestPushdownCapabilities(t *testing.T) {
+	formatter := NewSQLFormatter(&config.TPushdownConfig{EnableTimestampPushdown: false})
+
+	expected := &api_service_protos.TPushdownCapabilities{
+		ComparisonOperations: []api_service_protos.TPredicate_TComparison_EOperation{
+			api_service_protos.TPredicate_TComparison_L,
+			api_service_protos.TPredicate_TComparison_LE,
+			api_service_protos.TPredicate_TComparison_EQ,
+			api_service_protos.TPredicate_TComparison_NE,
+			api_service_protos.TPredicate_TComparison_GE,
+			api_service_protos.TPredicate_TComparison_G,
+		},
+		ValueTypes: []ydb.Type_PrimitiveTypeId{
+			ydb.Type_BOOL,
+			ydb.Type_INT8,
+			ydb.Type_INT16,
+			ydb.Type_INT32,
+			ydb.Type_INT64,
+			ydb.Type_FLOAT,
+			ydb.Type_DOUBLE,
+			ydb.Type_INTERVAL,
+		},
+		Negation:        true,
+		Disjunction:     true,
+		IsNull:          true,
+		In:              true,
+		Coalesce:        true,
+		Limit:           true,
+		OrderBy:         true,
+		ComputedColumns: true,
+		DateTimeFunctions: []api_service_protos.TExpression_TDateTimeFunction_EFunction{
+			api_service_protos.TExpression_TDateTimeFunction_TO_DATE,
+			api_service_protos.TExpression_TDateTimeFunction_GET_YEAR,
+			api_service_protos.TExpression_TDateTimeFunction_GET_MONTH,
+			api_service_protos.TExpression_TDateTimeFunction_GET_DAY_OF_MONTH,
+			api_service_protos.TExpression_TDateTimeFunction_GET_HOUR,
+		},
+	}
+
+	actual := rdbms_utils.ProbePushdownCapabilities(formatter, api_common.EGenericDataSourceKind_POSTGRESQL, true)
+	require.True(t, proto.Equal(expected, actual), "expected: %v\nactual: %v", expected, actual)
+}
+
+func TestFormatComputedColumn(t *testing.T) {
+	type testCase struct {
+		testName string
+		ydbType  *ydb.Type
+		output   string
+		err      error
+	}
+
+	formatter := NewSQLFormatter(nil)
+
+	tcs := []testCase{
+		{
+			testName: "int32",
+			ydbType:  common.MakePrimitiveType(ydb.Type_INT32),
+			output:   `CAST(("col0" * "col1") AS integer) AS "product"`,
+		},
+		{
+			testName: "optional_int64",
+			ydbType:  common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_INT64)),
+			output:   `CAST(("col0" * "col1") AS bigint) AS "product"`,
+		},
+		{
+			testName: "optional_decimal",
+			ydbType:  common.MakeOptionalType(common.MakeDecimalType(10, 2)),
+			output:   `CAST(("col0" * "col1") AS numeric(10, 2)) AS "product"`,
+		},
+		{
+			testName: "date",
+			ydbType:  common.MakePrimitiveType(ydb.Type_DATE),
+			output:   `CAST(("col0" * "col1") AS date) AS "product"`,
+		},
+		{
+			testName: "uint8",
+			ydbType:  common.MakePrimitiveType(ydb.Type_UINT8),
+			output:   `CAST(("col0" * "col1") AS smallint) AS "product"`,
+		},
+		{
+			testName: "optional_uint16",
+			ydbType:  common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_UINT16)),
+			output:   `CAST(("col0" * "col1") AS integer) AS "product"`,
+		},
+		{
+			testName: "uint32",
+			ydbType:  common.MakePrimitiveType(ydb.Type_UINT32),
+			err:      common.ErrUnsupportedExpression,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			output, err := formatter.FormatComputedColumn(`("col0" * "col1")`, tc.ydbType, `"product"`)
+			if tc.err != nil {
+				require.True(t, errors.Is(err, tc.err), err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
+
+func TestFormatDateTimeFunction(t *testing.T) {
+	type testCase struct {
+		function api_service_protos.TExpression_TDateTimeFunction_EFunction
+		output   string
+	}
+
+	formatter := NewSQLFormatter(nil)
+
+	// the results are cast to the types mapped back to the ones inferred for the functions
+	tcs := []testCase{
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_TO_DATE,
+			output:   `CAST("col0" AS date)`,
+		},
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_GET_YEAR,
+			output:   `CAST(EXTRACT(YEAR FROM "col0") AS integer)`,
+		},
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_GET_MONTH,
+			output:   `CAST(EXTRACT(MONTH FROM "col0") AS smallint)`,
+		},
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_GET_DAY_OF_MONTH,
+			output:   `CAST(EXTRACT(DAY FROM "col0") AS smallint)`,
+		},
+		{
+			function: api_service_protos.TExpression_TDateTimeFunction_GET_HOUR,
+			output:   `CAST(EXTRACT(HOUR FROM "col0") AS smallint)`,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.function.String(), func(t *testing.T) {
+			output, err := formatter.FormatDateTimeFunction(tc.function, `"col0"`)
+			require.NoError(t, err)
+			require.Equal(t, tc.output, output)
+		})
+	}
+}
@@ -9,7 +9,19 @@ func TableMetadataQuery(
 	request *api_service_protos.TDescribeTableRequest,
 	schema string,
 ) (string, *rdbms_utils.QueryArgs) {
-	query := "SELECT column_name, data_type FROM information_schema.columns WHERE table_name = $1 AND table_schema = $2"
+	// The precision and scale of numeric columns are required to choose the appropriate YDB type;
+	// array columns are described with the type of their elements followed by brackets, like `integer[]`.
+	query := "SELECT c.column_name, " +
+		"CASE WHEN c.data_type = 'numeric' AND c.numeric_precision IS NOT NULL " +
+		"THEN format('numeric(%s,%s)', c.numeric_precision, c.numeric_scale) " +
+		"WHEN c.data_type = 'ARRAY' THEN e.data_type || '[]' " +
+		"ELSE c.data_type END " +
+		"FROM information_schema.columns c " +
+		"LEFT JOIN information_schema.element_types e ON " +
+		"e.object_catalog = c.table_catalog AND e.object_schema = c.table_schema AND " +
+		"e.object_name = c.table_name AND e.object_type = 'TABLE' AND " +
+		"e.collection_type_identifier = c.dtd_identifier " +
+		"WHERE c.table_name = $1 AND c.table_schema = $2"
 
 	var args rdbms_utils.QueryArgs
 
@@ -18,3 +30,36 @@ func TableMetadataQuery(
 
 	return query, &args
 }
+
+// TableKeyQuery returns the columns of the primary key of the table in the order of the key
+func TableKeyQuery(tableName, schema string) (string, *rdbms_utils.QueryArgs) {
+	query := "SELECT c.column_name, c.data_type " +
+		"FROM information_schema.table_constraints t " +
+		"JOIN information_schema.key_column_usage k ON " +
+		"k.constraint_schema = t.constraint_schema AND k.constraint_name = t.constraint_name AND " +
+		"k.table_name = t.table_name " +
+		"JOIN information_schema.columns c ON " +
+		"c.table_schema = k.table_schema AND c.table_name = k.table_name AND c.column_name = k.column_name " +
+		"WHERE t.constraint_type = 'PRIMARY KEY' AND t.table_name = $1 AND t.table_schema = $2 " +
+		"ORDER BY k.ordinal_position"
+
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(tableName)
+	args.AddUntyped(schema)
+
+	return query, &args
+}
+
+func TableListQuery(
+	_ *api_service_protos.TListTablesRequest,
+	schema string,
+) (string, *rdbms_utils.QueryArgs) {
+	query := "SELECT table_name FROM information_schema.tables WHERE table_schema = $1 ORDER BY table_name"
+
+	var args rdbms_utils.QueryArgs
+
+	args.AddUntyped(schema)
+
+	return query, &args
+}
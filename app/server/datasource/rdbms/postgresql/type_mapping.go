@@ -2,6 +2,10 @@ package postgresql
 
 import (
 	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow/array"
@@ -20,14 +24,49 @@ import (
 
 var _ datasource.TypeMapper = typeMapper{}
 
-type typeMapper struct{}
+type typeMapper struct {
+	isNumeric *regexp.Regexp
+}
+
+func (tm typeMapper) SQLTypeToYDBColumn(columnName, typeName string, rules *api_service_protos.TTypeMappingSettings) (*Ydb.Column, error) {
+	ydbType, err := tm.sqlTypeToYDBType(typeName, rules)
+	if err != nil {
+		return nil, err
+	}
 
-func (typeMapper) SQLTypeToYDBColumn(columnName, typeName string, rules *api_service_protos.TTypeMappingSettings) (*Ydb.Column, error) {
+	return &Ydb.Column{
+		Name: columnName,
+		Type: ydbType,
+	}, nil
+}
+
+//nolint:gocyclo
+func (tm typeMapper) sqlTypeToYDBType(typeName string, rules *api_service_protos.TTypeMappingSettings) (*Ydb.Type, error) {
 	var (
 		ydbType *Ydb.Type
 		err     error
 	)
 
+	// Arrays of any dimension have the same type in PostgreSQL, but only one-dimensional ones can be read.
+	// Both arrays and their elements are nullable, so T[] becomes Optional<List<Optional<T>>>.
+	if itemTypeName, ok := strings.CutSuffix(typeName, "[]"); ok {
+		itemType, err := tm.sqlTypeToYDBType(itemTypeName, rules)
+		if err != nil {
+			return nil, fmt.Errorf("convert array item type: %w", err)
+		}
+
+		return common.MakeOptionalType(common.MakeListType(itemType)), nil
+	}
+
+	if matches := tm.isNumeric.FindStringSubmatch(typeName); matches != nil {
+		ydbType, err = numericToYDBType(matches[1], matches[2])
+		if err != nil {
+			return nil, fmt.Errorf("convert type '%s': %w", typeName, err)
+		}
+
+		return common.MakeOptionalType(ydbType), nil
+	}
+
 	// Reference table: https://github.com/ydb-platform/fq-connector-go/blob/main/docs/type_mapping_table.md
 	switch typeName {
 	case "boolean", "bool":
@@ -48,13 +87,18 @@ func (typeMapper) SQLTypeToYDBColumn(columnName, typeName string, rules *api_ser
 		ydbType = common.MakePrimitiveType(Ydb.Type_UTF8)
 	case "json":
 		ydbType = common.MakePrimitiveType(Ydb.Type_JSON)
-	// TODO: jsonb to YDB_Json_document
+	case "jsonb":
+		ydbType = common.MakePrimitiveType(Ydb.Type_JSON_DOCUMENT)
+	case "numeric":
+		// numeric without the precision specified can keep up to 131072 digits before the decimal point
+		ydbType = common.MakePrimitiveType(Ydb.Type_UTF8)
 	case "date":
 		ydbType, err = common.MakeYdbDateTimeType(Ydb.Type_DATE, rules.GetDateTimeFormat())
-	// TODO: PostgreSQL `time` data type has no direct counterparts in the YDB's type system;
-	// but it can be supported when the PG-compatible types are added to YDB:
-	// https://st.yandex-team.ru/YQ-2285
-	// case "time":
+	case "time without time zone":
+		// YDB has no time of day type, so the time is represented as the interval since midnight
+		ydbType, err = common.MakeYdbDateTimeType(Ydb.Type_INTERVAL, rules.GetDateTimeFormat())
+	case "interval":
+		ydbType = common.MakePrimitiveType(Ydb.Type_INTERVAL)
 	case "timestamp without time zone":
 		ydbType, err = common.MakeYdbDateTimeType(Ydb.Type_TIMESTAMP, rules.GetDateTimeFormat())
 	default:
@@ -67,166 +111,315 @@ func (typeMapper) SQLTypeToYDBColumn(columnName, typeName string, rules *api_ser
 
 	// In PostgreSQL all columns are actually nullable, hence we wrap every T in Optional<T>.
 	// See this issue for details: https://st.yandex-team.ru/YQ-2256
-	ydbType = common.MakeOptionalType(ydbType)
+	return common.MakeOptionalType(ydbType), nil
+}
 
-	return &Ydb.Column{
-		Name: columnName,
-		Type: ydbType,
-	}, nil
+// numericToYDBType maps numeric(p,s) to YDB Decimal if it fits its precision, otherwise to string.
+func numericToYDBType(precisionStr, scaleStr string) (*Ydb.Type, error) {
+	precision, err := strconv.ParseUint(precisionStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parse precision: %w", err)
+	}
+
+	scale, err := strconv.ParseUint(scaleStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("parse scale: %w", err)
+	}
+
+	if precision > common.DecimalMaxPrecision || scale > precision {
+		return common.MakePrimitiveType(Ydb.Type_UTF8), nil
+	}
+
+	return common.MakeDecimalType(uint32(precision), uint32(scale)), nil
 }
 
-//nolint:gocyclo,funlen
 func transformerFromOIDs(oids []uint32, ydbTypes []*Ydb.Type, cc conversion.Collection) (paging.RowTransformer[any], error) {
 	acceptors := make([]any, 0, len(oids))
 	appenders := make([]func(acceptor any, builder array.Builder) error, 0, len(oids))
 
 	for i, oid := range oids {
-		switch oid {
-		case pgtype.BoolOID:
-			acceptors = append(acceptors, new(pgtype.Bool))
-			appenders = append(appenders, func(acceptor any, builder array.Builder) error {
-				cast := acceptor.(*pgtype.Bool)
-
-				return appendValuePtrToArrowBuilder[bool, uint8, *array.Uint8Builder](&cast.Bool, builder, cast.Valid, cc.Bool())
-			})
-		case pgtype.Int2OID:
-			acceptors = append(acceptors, new(pgtype.Int2))
-			appenders = append(appenders, func(acceptor any, builder array.Builder) error {
+		acceptor, appender, err := makeAcceptorAppender(oid, ydbTypes[i], cc)
+		if err != nil {
+			return nil, fmt.Errorf("make acceptor and appender for type OID %d: %w", oid, err)
+		}
+
+		acceptors = append(acceptors, acceptor)
+		appenders = append(appenders, appender)
+	}
+
+	return paging.NewRowTransformer[any](acceptors, appenders, nil), nil
+}
+
+// arrayItemOIDs maps the OIDs of supported array types to the OIDs of their elements
+var arrayItemOIDs = map[uint32]uint32{
+	pgtype.BoolArrayOID:      pgtype.BoolOID,
+	pgtype.Int2ArrayOID:      pgtype.Int2OID,
+	pgtype.Int4ArrayOID:      pgtype.Int4OID,
+	pgtype.Int8ArrayOID:      pgtype.Int8OID,
+	pgtype.Float4ArrayOID:    pgtype.Float4OID,
+	pgtype.Float8ArrayOID:    pgtype.Float8OID,
+	pgtype.TextArrayOID:      pgtype.TextOID,
+	pgtype.BPCharArrayOID:    pgtype.BPCharOID,
+	pgtype.VarcharArrayOID:   pgtype.VarcharOID,
+	pgtype.JSONArrayOID:      pgtype.JSONOID,
+	pgtype.JSONBArrayOID:     pgtype.JSONBOID,
+	pgtype.ByteaArrayOID:     pgtype.ByteaOID,
+	pgtype.UUIDArrayOID:      pgtype.UUIDOID,
+	pgtype.DateArrayOID:      pgtype.DateOID,
+	pgtype.TimeArrayOID:      pgtype.TimeOID,
+	pgtype.TimestampArrayOID: pgtype.TimestampOID,
+	pgtype.IntervalArrayOID:  pgtype.IntervalOID,
+	pgtype.NumericArrayOID:   pgtype.NumericOID,
+}
+
+//nolint:gocyclo,funlen
+func makeAcceptorAppender(
+	oid uint32,
+	ydbType *Ydb.Type,
+	cc conversion.Collection,
+) (any, func(acceptor any, builder array.Builder) error, error) {
+	var (
+		acceptor any
+		appender func(acceptor any, builder array.Builder) error
+	)
+
+	if itemOID, ok := arrayItemOIDs[oid]; ok {
+		return makeListAcceptorAppender(itemOID, ydbType, cc)
+	}
+
+	switch oid {
+	case pgtype.BoolOID:
+		acceptor = new(pgtype.Bool)
+		appender = func(acceptor any, builder array.Builder) error {
+			cast := acceptor.(*pgtype.Bool)
+
+			return appendValuePtrToArrowBuilder[bool, uint8, *array.Uint8Builder](&cast.Bool, builder, cast.Valid, cc.Bool())
+		}
+	case pgtype.Int2OID:
+		acceptor = new(pgtype.Int2)
+
+		// the results of datetime functions inferred as Uint8 are read from smallint
+		if isUnsignedType(ydbType, Ydb.Type_UINT8) {
+			appender = func(acceptor any, builder array.Builder) error {
 				cast := acceptor.(*pgtype.Int2)
 
-				return appendValuePtrToArrowBuilder[int16, int16, *array.Int16Builder](&cast.Int16, builder, cast.Valid, cc.Int16())
-			})
-		case pgtype.Int4OID:
-			acceptors = append(acceptors, new(pgtype.Int4))
-			appenders = append(appenders, func(acceptor any, builder array.Builder) error {
+				return appendValuePtrToArrowBuilder[int16, uint8, *array.Uint8Builder](
+					&cast.Int16, builder, cast.Valid, conversion.SignedToUnsigned[int16, uint8]())
+			}
+
+			break
+		}
+
+		appender = func(acceptor any, builder array.Builder) error {
+			cast := acceptor.(*pgtype.Int2)
+
+			return appendValuePtrToArrowBuilder[int16, int16, *array.Int16Builder](&cast.Int16, builder, cast.Valid, cc.Int16())
+		}
+	case pgtype.Int4OID:
+		acceptor = new(pgtype.Int4)
+
+		// the results of datetime functions inferred as Uint16 are read from integer
+		if isUnsignedType(ydbType, Ydb.Type_UINT16) {
+			appender = func(acceptor any, builder array.Builder) error {
 				cast := acceptor.(*pgtype.Int4)
 
-				return appendValuePtrToArrowBuilder[int32, int32, *array.Int32Builder](&cast.Int32, builder, cast.Valid, cc.Int32())
-			})
-		case pgtype.Int8OID:
-			acceptors = append(acceptors, new(pgtype.Int8))
-			appenders = append(appenders, func(acceptor any, builder array.Builder) error {
-				cast := acceptor.(*pgtype.Int8)
-
-				return appendValuePtrToArrowBuilder[int64, int64, *array.Int64Builder](&cast.Int64, builder, cast.Valid, cc.Int64())
-			})
-		case pgtype.Float4OID:
-			acceptors = append(acceptors, new(pgtype.Float4))
-			appenders = append(appenders, func(acceptor any, builder array.Builder) error {
-				cast := acceptor.(*pgtype.Float4)
-
-				return appendValuePtrToArrowBuilder[float32, float32, *array.Float32Builder](
-					&cast.Float32, builder, cast.Valid, cc.Float32())
-			})
-		case pgtype.Float8OID:
-			acceptors = append(acceptors, new(pgtype.Float8))
-			appenders = append(appenders, func(acceptor any, builder array.Builder) error {
-				cast := acceptor.(*pgtype.Float8)
-
-				return appendValuePtrToArrowBuilder[float64, float64, *array.Float64Builder](
-					&cast.Float64, builder, cast.Valid, cc.Float64())
-			})
-		case pgtype.TextOID, pgtype.BPCharOID, pgtype.VarcharOID:
-			acceptors = append(acceptors, new(pgtype.Text))
-			appenders = append(appenders, func(acceptor any, builder array.Builder) error {
-				cast := acceptor.(*pgtype.Text)
-
-				return appendValuePtrToArrowBuilder[string, string, *array.StringBuilder](&cast.String, builder, cast.Valid, cc.String())
-			})
-		case pgtype.JSONOID:
-			acceptors = append(acceptors, new(pgtype.Text))
-			appenders = append(appenders, func(acceptor any, builder array.Builder) error {
-				cast := acceptor.(*pgtype.Text)
-
-				return appendValuePtrToArrowBuilder[string, string, *array.StringBuilder](&cast.String, builder, cast.Valid, cc.String())
-			})
-			// TODO: review all pgtype.json* types
-		case pgtype.ByteaOID:
-			acceptors = append(acceptors, new(*[]byte))
-			appenders = append(appenders, func(acceptor any, builder array.Builder) error {
-				// TODO: Bytea exists in the upstream library, but missing in jackx/pgx:
-				// https://github.com/jackc/pgtype/blob/v1.14.0/bytea.go
-				// https://github.com/jackc/pgx/blob/v5.3.1/pgtype/bytea.go
-				// https://github.com/jackc/pgx/issues/1714
-				cast := acceptor.(**[]byte)
-				if *cast != nil {
-					builder.(*array.BinaryBuilder).Append(**cast)
-				} else {
-					builder.(*array.BinaryBuilder).AppendNull()
-				}
-
-				return nil
-			})
-		case pgtype.DateOID:
-			acceptors = append(acceptors, new(pgtype.Date))
-
-			ydbTypeID, err := common.YdbTypeToYdbPrimitiveTypeID(ydbTypes[i])
-			if err != nil {
-				return nil, fmt.Errorf("ydb type to ydb primitive type id: %w", err)
+				return appendValuePtrToArrowBuilder[int32, uint16, *array.Uint16Builder](
+					&cast.Int32, builder, cast.Valid, conversion.SignedToUnsigned[int32, uint16]())
 			}
 
-			switch ydbTypeID {
-			case Ydb.Type_UTF8:
-				appenders = append(appenders, func(acceptor any, builder array.Builder) error {
-					cast := acceptor.(*pgtype.Date)
-
-					return appendValuePtrToArrowBuilder[time.Time, string, *array.StringBuilder](
-						&cast.Time, builder, cast.Valid, cc.DateToString())
-				})
-			case Ydb.Type_DATE:
-				appenders = append(appenders, func(acceptor any, builder array.Builder) error {
-					cast := acceptor.(*pgtype.Date)
-
-					return appendValuePtrToArrowBuilder[time.Time, uint16, *array.Uint16Builder](
-						&cast.Time, builder, cast.Valid, cc.Date())
-				})
-			default:
-				return nil, fmt.Errorf("unexpected ydb type %v with type oid %d: %w", ydbTypes[i], oid, common.ErrDataTypeNotSupported)
+			break
+		}
+
+		appender = func(acceptor any, builder array.Builder) error {
+			cast := acceptor.(*pgtype.Int4)
+
+			return appendValuePtrToArrowBuilder[int32, int32, *array.Int32Builder](&cast.Int32, builder, cast.Valid, cc.Int32())
+		}
+	case pgtype.Int8OID:
+		acceptor = new(pgtype.Int8)
+		appender = func(acceptor any, builder array.Builder) error {
+			cast := acceptor.(*pgtype.Int8)
+
+			return appendValuePtrToArrowBuilder[int64, int64, *array.Int64Builder](&cast.Int64, builder, cast.Valid, cc.Int64())
+		}
+	case pgtype.Float4OID:
+		acceptor = new(pgtype.Float4)
+		appender = func(acceptor any, builder array.Builder) error {
+			cast := acceptor.(*pgtype.Float4)
+
+			return appendValuePtrToArrowBuilder[float32, float32, *array.Float32Builder](
+				&cast.Float32, builder, cast.Valid, cc.Float32())
+		}
+	case pgtype.Float8OID:
+		acceptor = new(pgtype.Float8)
+		appender = func(acceptor any, builder array.Builder) error {
+			cast := acceptor.(*pgtype.Float8)
+
+			return appendValuePtrToArrowBuilder[float64, float64, *array.Float64Builder](
+				&cast.Float64, builder, cast.Valid, cc.Float64())
+		}
+	case pgtype.TextOID, pgtype.BPCharOID, pgtype.VarcharOID:
+		acceptor = new(pgtype.Text)
+		appender = func(acceptor any, builder array.Builder) error {
+			cast := acceptor.(*pgtype.Text)
+
+			return appendValuePtrToArrowBuilder[string, string, *array.StringBuilder](&cast.String, builder, cast.Valid, cc.String())
+		}
+	case pgtype.JSONOID:
+		acceptor = new(pgtype.Text)
+		appender = func(acceptor any, builder array.Builder) error {
+			cast := acceptor.(*pgtype.Text)
+
+			return appendValuePtrToArrowBuilder[string, string, *array.StringBuilder](&cast.String, builder, cast.Valid, cc.String())
+		}
+		// TODO: review all pgtype.json* types
+	case pgtype.ByteaOID:
+		acceptor = new(*[]byte)
+		appender = func(acceptor any, builder array.Builder) error {
+			// TODO: Bytea exists in the upstream library, but missing in jackx/pgx:
+			// https://github.com/jackc/pgtype/blob/v1.14.0/bytea.go
+			// https://github.com/jackc/pgx/blob/v5.3.1/pgtype/bytea.go
+			// https://github.com/jackc/pgx/issues/1714
+			cast := acceptor.(**[]byte)
+			if *cast != nil {
+				builder.(*array.BinaryBuilder).Append(**cast)
+			} else {
+				builder.(*array.BinaryBuilder).AppendNull()
 			}
-		case pgtype.TimestampOID:
-			acceptors = append(acceptors, new(pgtype.Timestamp))
 
-			ydbTypeID, err := common.YdbTypeToYdbPrimitiveTypeID(ydbTypes[i])
-			if err != nil {
-				return nil, fmt.Errorf("ydb type to ydb primitive type id: %w", err)
+			return nil
+		}
+	case pgtype.DateOID:
+		acceptor = new(pgtype.Date)
+
+		ydbTypeID, err := common.YdbTypeToYdbPrimitiveTypeID(ydbType)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ydb type to ydb primitive type id: %w", err)
+		}
+
+		switch ydbTypeID {
+		case Ydb.Type_UTF8:
+			appender = func(acceptor any, builder array.Builder) error {
+				cast := acceptor.(*pgtype.Date)
+
+				return appendValuePtrToArrowBuilder[time.Time, string, *array.StringBuilder](
+					&cast.Time, builder, cast.Valid, cc.DateToString())
 			}
+		case Ydb.Type_DATE:
+			appender = func(acceptor any, builder array.Builder) error {
+				cast := acceptor.(*pgtype.Date)
 
-			switch ydbTypeID {
-			case Ydb.Type_UTF8:
-				appenders = append(appenders, func(acceptor any, builder array.Builder) error {
-					cast := acceptor.(*pgtype.Timestamp)
-
-					return appendValuePtrToArrowBuilder[time.Time, string, *array.StringBuilder](
-						&cast.Time, builder, cast.Valid, cc.TimestampToString(true))
-				})
-			case Ydb.Type_TIMESTAMP:
-				appenders = append(appenders, func(acceptor any, builder array.Builder) error {
-					cast := acceptor.(*pgtype.Timestamp)
-
-					return appendValuePtrToArrowBuilder[time.Time, uint64, *array.Uint64Builder](
-						&cast.Time, builder, cast.Valid, cc.Timestamp())
-				})
-			default:
-				return nil, fmt.Errorf("unexpected ydb type %v with type oid %d: %w", ydbTypes[i], oid, common.ErrDataTypeNotSupported)
+				return appendValuePtrToArrowBuilder[time.Time, uint16, *array.Uint16Builder](
+					&cast.Time, builder, cast.Valid, cc.Date())
 			}
-		case pgtype.UUIDOID:
-			acceptors = append(acceptors, new(*uuid.UUID))
-			appenders = append(appenders, func(acceptor any, builder array.Builder) error {
-				cast := acceptor.(**uuid.UUID)
-				if *cast != nil {
-					builder.(*array.BinaryBuilder).Append([]byte((**cast).String()))
-				} else {
-					builder.(*array.BinaryBuilder).AppendNull()
-				}
-
-				return nil
-			})
 		default:
-			return nil, fmt.Errorf("convert type OID %d: %w", oid, common.ErrDataTypeNotSupported)
+			return nil, nil, fmt.Errorf("unexpected ydb type %v with type oid %d: %w", ydbType, oid, common.ErrDataTypeNotSupported)
+		}
+	case pgtype.TimestampOID:
+		acceptor = new(pgtype.Timestamp)
+
+		ydbTypeID, err := common.YdbTypeToYdbPrimitiveTypeID(ydbType)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ydb type to ydb primitive type id: %w", err)
 		}
+
+		switch ydbTypeID {
+		case Ydb.Type_UTF8:
+			appender = func(acceptor any, builder array.Builder) error {
+				cast := acceptor.(*pgtype.Timestamp)
+
+				return appendValuePtrToArrowBuilder[time.Time, string, *array.StringBuilder](
+					&cast.Time, builder, cast.Valid, cc.TimestampToString(true))
+			}
+		case Ydb.Type_TIMESTAMP:
+			appender = func(acceptor any, builder array.Builder) error {
+				cast := acceptor.(*pgtype.Timestamp)
+
+				return appendValuePtrToArrowBuilder[time.Time, uint64, *array.Uint64Builder](
+					&cast.Time, builder, cast.Valid, cc.Timestamp())
+			}
+		default:
+			return nil, nil, fmt.Errorf("unexpected ydb type %v with type oid %d: %w", ydbType, oid, common.ErrDataTypeNotSupported)
+		}
+	case pgtype.NumericOID:
+		return makeNumericAcceptorAppender(ydbType, cc)
+	case pgtype.TimeOID:
+		acceptor = new(pgtype.Time)
+
+		ydbTypeID, err := common.YdbTypeToYdbPrimitiveTypeID(ydbType)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ydb type to ydb primitive type id: %w", err)
+		}
+
+		switch ydbTypeID {
+		case Ydb.Type_UTF8:
+			appender = appendTimeToStringBuilder
+		case Ydb.Type_INTERVAL:
+			appender = func(acceptor any, builder array.Builder) error {
+				cast := acceptor.(*pgtype.Time)
+
+				return appendValuePtrToArrowBuilder[int64, int64, *array.Int64Builder](
+					&cast.Microseconds, builder, cast.Valid, cc.Int64())
+			}
+		default:
+			return nil, nil, fmt.Errorf("unexpected ydb type %v with type oid %d: %w", ydbType, oid, common.ErrDataTypeNotSupported)
+		}
+	case pgtype.IntervalOID:
+		acceptor = new(pgtype.Interval)
+		appender = appendIntervalToArrowBuilder
+	case pgtype.JSONBOID:
+		acceptor = new(pgtype.Text)
+		appender = func(acceptor any, builder array.Builder) error {
+			cast := acceptor.(*pgtype.Text)
+
+			return appendValuePtrToArrowBuilder[string, []byte, *array.BinaryBuilder](
+				&cast.String, builder, cast.Valid, cc.StringToBytes())
+		}
+	case pgtype.UUIDOID:
+		acceptor = new(*uuid.UUID)
+		appender = func(acceptor any, builder array.Builder) error {
+			cast := acceptor.(**uuid.UUID)
+			if *cast != nil {
+				builder.(*array.BinaryBuilder).Append([]byte((**cast).String()))
+			} else {
+				builder.(*array.BinaryBuilder).AppendNull()
+			}
+
+			return nil
+		}
+	default:
+		return nil, nil, fmt.Errorf("convert type OID %d: %w", oid, common.ErrDataTypeNotSupported)
 	}
 
-	return paging.NewRowTransformer[any](acceptors, appenders, nil), nil
+	return acceptor, appender, nil
+}
+
+// makeListAcceptorAppender handles PostgreSQL arrays: both the array itself and its elements are nullable.
+func makeListAcceptorAppender(
+	itemOID uint32,
+	ydbType *Ydb.Type,
+	cc conversion.Collection,
+) (any, func(acceptor any, builder array.Builder) error, error) {
+	listType := ydbType.GetOptionalType().GetItem().GetListType()
+	if listType == nil {
+		return nil, nil, fmt.Errorf("unexpected ydb type %v for array: %w", ydbType, common.ErrDataTypeNotSupported)
+	}
+
+	itemAcceptor, itemAppender, err := makeAcceptorAppender(itemOID, listType.Item, cc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("array item: %w", err)
+	}
+
+	return utils.MakeListAcceptor(itemAcceptor, true), utils.MakeListAppender(itemAppender, true), nil
+}
+
+// isUnsignedType checks if the (possibly optional) YDB type is the given unsigned type
+func isUnsignedType(ydbType *Ydb.Type, typeID Ydb.Type_PrimitiveTypeId) bool {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	return ydbType.GetTypeId() == typeID
 }
 
 func appendValuePtrToArrowBuilder[
@@ -248,4 +441,114 @@ func appendValuePtrToArrowBuilder[
 	return utils.AppendValueToArrowBuilder[IN, OUT, AB](value, builder, conv)
 }
 
-func NewTypeMapper() datasource.TypeMapper { return typeMapper{} }
+func makeNumericAcceptorAppender(
+	ydbType *Ydb.Type,
+	cc conversion.Collection,
+) (any, func(acceptor any, builder array.Builder) error, error) {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	if decimalType := ydbType.GetDecimalType(); decimalType != nil {
+		appender := func(acceptor any, builder array.Builder) error {
+			return appendNumericToDecimalBuilder(acceptor.(*pgtype.Numeric), builder, decimalType)
+		}
+
+		return new(pgtype.Numeric), appender, nil
+	}
+
+	if ydbType.GetTypeId() == Ydb.Type_UTF8 {
+		// numeric values are passed in their textual representation
+		appender := func(acceptor any, builder array.Builder) error {
+			cast := acceptor.(*pgtype.Text)
+
+			return appendValuePtrToArrowBuilder[string, string, *array.StringBuilder](&cast.String, builder, cast.Valid, cc.String())
+		}
+
+		return new(pgtype.Text), appender, nil
+	}
+
+	return nil, nil, fmt.Errorf("unexpected ydb type %v for numeric: %w", ydbType, common.ErrDataTypeNotSupported)
+}
+
+func appendNumericToDecimalBuilder(value *pgtype.Numeric, builder array.Builder, decimalType *Ydb.DecimalType) error {
+	if !value.Valid {
+		builder.AppendNull()
+
+		return nil
+	}
+
+	var (
+		unscaled *big.Int
+		err      error
+	)
+
+	switch {
+	case value.NaN:
+		unscaled = common.DecimalNaN()
+	case value.InfinityModifier != pgtype.Finite:
+		unscaled = common.DecimalInf(value.InfinityModifier == pgtype.NegativeInfinity)
+	case value.Int == nil:
+		unscaled = new(big.Int)
+	default:
+		unscaled, err = common.RescaleDecimal(value.Int, value.Exp, decimalType.Scale)
+		if err != nil {
+			return fmt.Errorf("rescale decimal: %w", err)
+		}
+	}
+
+	data, err := common.DecimalToBytes(unscaled, decimalType.Precision)
+	if err != nil {
+		return fmt.Errorf("decimal to bytes: %w", err)
+	}
+
+	builder.(*array.FixedSizeBinaryBuilder).Append(data)
+
+	return nil
+}
+
+func appendIntervalToArrowBuilder(acceptor any, builder array.Builder) error {
+	cast := acceptor.(*pgtype.Interval)
+	if !cast.Valid {
+		builder.AppendNull()
+
+		return nil
+	}
+
+	// YDB Interval has a fixed length, so a month is considered to be 30 days long like in PostgreSQL's own
+	// interval normalization functions.
+	microseconds, err := common.DaysAndMicrosecondsToYDBInterval(int64(cast.Months)*30+int64(cast.Days), cast.Microseconds)
+	if err != nil {
+		return fmt.Errorf("days and microseconds to YDB interval: %w", err)
+	}
+
+	builder.(*array.Int64Builder).Append(microseconds)
+
+	return nil
+}
+
+func appendTimeToStringBuilder(acceptor any, builder array.Builder) error {
+	cast := acceptor.(*pgtype.Time)
+	if !cast.Valid {
+		builder.AppendNull()
+
+		return nil
+	}
+
+	// PostgreSQL allows 24:00:00 as the end of a day
+	if cast.Microseconds == int64(24*time.Hour/time.Microsecond) {
+		builder.(*array.StringBuilder).Append("24:00:00")
+
+		return nil
+	}
+
+	builder.(*array.StringBuilder).Append(time.UnixMicro(cast.Microseconds).UTC().Format("15:04:05.999999"))
+
+	return nil
+}
+
+func NewTypeMapper() datasource.TypeMapper {
+	return typeMapper{
+		isNumeric: regexp.MustCompile(`^numeric\((\d+),(\d+)\)$`),
+	}
+}
@@ -0,0 +1,114 @@
+package postgresql
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestSQLTypeToYDBColumn(t *testing.T) {
+	rules := &api_service_protos.TTypeMappingSettings{DateTimeFormat: api_service_protos.EDateTimeFormat_YQL_FORMAT}
+
+	testCases := []struct {
+		typeName string
+		expected *Ydb.Type
+	}{
+		{typeName: "numeric(10,2)", expected: common.MakeDecimalType(10, 2)},
+		{typeName: "numeric(35,0)", expected: common.MakeDecimalType(35, 0)},
+		{typeName: "numeric(36,2)", expected: common.MakePrimitiveType(Ydb.Type_UTF8)},
+		{typeName: "numeric", expected: common.MakePrimitiveType(Ydb.Type_UTF8)},
+		{typeName: "time without time zone", expected: common.MakePrimitiveType(Ydb.Type_INTERVAL)},
+		{typeName: "interval", expected: common.MakePrimitiveType(Ydb.Type_INTERVAL)},
+		{typeName: "jsonb", expected: common.MakePrimitiveType(Ydb.Type_JSON_DOCUMENT)},
+		{
+			typeName: "integer[]",
+			expected: common.MakeListType(common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT32))),
+		},
+		{
+			typeName: "character varying[]",
+			expected: common.MakeListType(common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8))),
+		},
+	}
+
+	tm := NewTypeMapper()
+
+	for _, tc := range testCases {
+		column, err := tm.SQLTypeToYDBColumn("col", tc.typeName, rules)
+		require.NoError(t, err, tc.typeName)
+		require.Equal(t, common.MakeOptionalType(tc.expected), column.Type, tc.typeName)
+	}
+
+	_, err := tm.SQLTypeToYDBColumn("col", "point[]", rules)
+	require.ErrorIs(t, err, common.ErrDataTypeNotSupported)
+}
+
+func TestAppendNumericToDecimalBuilder(t *testing.T) {
+	builder := array.NewFixedSizeBinaryBuilder(memory.NewGoAllocator(), &arrow.FixedSizeBinaryType{ByteWidth: common.DecimalSize})
+	defer builder.Release()
+
+	decimalType := &Ydb.DecimalType{Precision: 10, Scale: 2}
+
+	values := []*pgtype.Numeric{
+		{Int: big.NewInt(-1234), Exp: -2, Valid: true}, // -12.34
+		{Int: big.NewInt(5), Exp: 0, Valid: true},      // 5.00
+		{Valid: false},
+		{NaN: true, Valid: true},
+	}
+
+	for _, value := range values {
+		require.NoError(t, appendNumericToDecimalBuilder(value, builder, decimalType))
+	}
+
+	result := builder.NewFixedSizeBinaryArray()
+	defer result.Release()
+
+	expected := []*big.Int{big.NewInt(-1234), big.NewInt(500), nil, common.DecimalNaN()}
+
+	for i, value := range expected {
+		if value == nil {
+			require.True(t, result.IsNull(i))
+
+			continue
+		}
+
+		data, err := common.DecimalToBytes(value, decimalType.Precision)
+		require.NoError(t, err)
+		require.Equal(t, data, result.Value(i))
+	}
+
+	// the value exceeding the precision of the column
+	err := appendNumericToDecimalBuilder(&pgtype.Numeric{Int: big.NewInt(1), Exp: 10, Valid: true}, builder, decimalType)
+	require.True(t, errors.Is(err, common.ErrValueOutOfTypeBounds))
+}
+
+func TestAppendIntervalToArrowBuilder(t *testing.T) {
+	builder := array.NewInt64Builder(memory.NewGoAllocator())
+	defer builder.Release()
+
+	// 1 month 2 days 03:00:00
+	interval := &pgtype.Interval{Months: 1, Days: 2, Microseconds: (3 * time.Hour).Microseconds(), Valid: true}
+	require.NoError(t, appendIntervalToArrowBuilder(interval, builder))
+	require.NoError(t, appendIntervalToArrowBuilder(&pgtype.Interval{}, builder))
+
+	result := builder.NewInt64Array()
+	defer result.Release()
+
+	require.Equal(t, (32*24*time.Hour + 3*time.Hour).Microseconds(), result.Value(0))
+	require.True(t, result.IsNull(1))
+
+	// 1000 years do not fit into YDB Interval
+	err := appendIntervalToArrowBuilder(&pgtype.Interval{Months: 12000, Valid: true}, builder)
+	require.True(t, errors.Is(err, common.ErrValueOutOfTypeBounds))
+}
@@ -68,7 +68,7 @@ func TestSchemaBuilder(t *testing.T) {
 				{"String", &Ydb.Type{Type: &Ydb.Type_TypeId{TypeId: Ydb.Type_STRING}}},
 			},
 			unsupportedTypes: []nameToType{
-				{"UUID", nil}, // yet unsupported
+				{"Int128", nil}, // yet unsupported
 			},
 		},
 	}
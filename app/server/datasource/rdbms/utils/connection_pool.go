@@ -0,0 +1,637 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics"
+)
+
+// PooledConnection is a physical connection to a data source that can be reused by the subsequent requests.
+type PooledConnection interface {
+	// Ping checks that the connection is still alive before reusing it after a long idle period.
+	Ping(ctx context.Context) error
+	// Reusable reports whether the connection is in a clean state and can be returned to the pool.
+	Reusable() bool
+	// Close terminates the connection.
+	Close() error
+}
+
+// DialFunc opens a new physical connection to a data source.
+type DialFunc func() (PooledConnection, error)
+
+// ConnectionPool keeps idle connections to relational data sources, so that the requests
+// to the same database do not pay for the TCP, TLS and authentication handshakes every time.
+// Connections are pooled by data source kind, endpoint, database and the hash of credentials and options.
+type ConnectionPool struct {
+	logger  *zap.Logger
+	cfg     *config.TConnectionPoolConfig
+	metrics *connectionPoolMetrics
+
+	idleTimeout       time.Duration
+	maxLifetime       time.Duration
+	healthCheckPeriod time.Duration
+
+	mutex     sync.Mutex
+	entries   map[string]*connectionPoolEntry
+	inUse     map[PooledConnection]*pooledConnection
+	sharedDBs map[string]*SharedDB
+	closed    bool
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+type connectionPoolEntry struct {
+	kind  string
+	idle  []*pooledConnection // the most recently released connections are at the end
+	slots chan struct{}       // limits the number of connections in use; nil means no limit
+	refs  int                 // number of the requests using the entry
+}
+
+type pooledConnection struct {
+	conn       PooledConnection
+	entry      *connectionPoolEntry
+	createdAt  time.Time
+	releasedAt time.Time
+}
+
+// SharedDB is a database/sql handle shared by all the requests with the same pool key.
+// database/sql keeps its own pool of physical connections, so the drivers available only via
+// database/sql share a single handle limited with the settings of the connection pool
+// instead of pooling the whole handles, each of which would open its own connections.
+type SharedDB struct {
+	*sql.DB
+	key        string
+	refs       int // number of the requests using the handle
+	releasedAt time.Time
+}
+
+const (
+	evictionReasonIdleTimeout = "idle_timeout"
+	evictionReasonMaxLifetime = "max_lifetime"
+	evictionReasonNotReusable = "not_reusable"
+	evictionReasonOverflow    = "overflow"
+	evictionReasonPoolClosed  = "pool_closed"
+)
+
+// Acquire returns an idle connection for the data source instance or dials a new one.
+func (p *ConnectionPool) Acquire(
+	ctx context.Context,
+	logger *zap.Logger,
+	dsi *api_common.TGenericDataSourceInstance,
+	dial DialFunc,
+) (PooledConnection, error) {
+	key, err := makeConnectionPoolKey(dsi)
+	if err != nil {
+		return nil, fmt.Errorf("make connection pool key: %w", err)
+	}
+
+	entry, err := p.refEntry(key, dsi.Kind.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.slots != nil {
+		select {
+		case entry.slots <- struct{}{}:
+		default:
+			p.metrics.waits.With(map[string]string{"kind": entry.kind}).Inc()
+
+			select {
+			case entry.slots <- struct{}{}:
+			case <-ctx.Done():
+				p.unrefEntry(entry)
+
+				return nil, fmt.Errorf("wait for connection: %w", ctx.Err())
+			}
+		}
+	}
+
+	conn, err := p.acquire(ctx, logger, entry, dial)
+	if err != nil {
+		p.freeSlot(entry)
+		p.unrefEntry(entry)
+
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (p *ConnectionPool) acquire(
+	ctx context.Context,
+	logger *zap.Logger,
+	entry *connectionPoolEntry,
+	dial DialFunc,
+) (PooledConnection, error) {
+	tags := map[string]string{"kind": entry.kind}
+
+	for {
+		pc := p.popIdle(entry)
+		if pc == nil {
+			break
+		}
+
+		now := time.Now()
+
+		if reason := p.expirationReason(pc, now); reason != "" {
+			p.evict(logger, pc, reason)
+
+			continue
+		}
+
+		if now.Sub(pc.releasedAt) > p.healthCheckPeriod {
+			if err := pc.conn.Ping(ctx); err != nil {
+				logger.Warn("pooled connection health check failed", zap.Error(err))
+				p.metrics.healthCheckFailures.With(tags).Inc()
+				common.LogCloserError(logger, pc.conn, "close pooled connection")
+
+				continue
+			}
+		}
+
+		p.markInUse(pc)
+		p.metrics.reuses.With(tags).Inc()
+
+		return pc.conn, nil
+	}
+
+	conn, err := dial()
+	if err != nil {
+		p.metrics.dialErrors.With(tags).Inc()
+
+		return nil, err
+	}
+
+	p.markInUse(&pooledConnection{conn: conn, entry: entry, createdAt: time.Now()})
+	p.metrics.dials.With(tags).Inc()
+
+	return conn, nil
+}
+
+// Release returns the connection to the pool or closes it if it cannot be reused.
+func (p *ConnectionPool) Release(logger *zap.Logger, conn PooledConnection) {
+	p.mutex.Lock()
+
+	pc, ok := p.inUse[conn]
+	if !ok {
+		p.mutex.Unlock()
+		common.LogCloserError(logger, conn, "close unknown connection")
+
+		return
+	}
+
+	delete(p.inUse, conn)
+
+	entry := pc.entry
+	tags := map[string]string{"kind": entry.kind}
+	p.metrics.inUse.With(tags).Add(-1)
+
+	var reason string
+
+	switch {
+	case p.closed:
+		reason = evictionReasonPoolClosed
+	case !conn.Reusable():
+		reason = evictionReasonNotReusable
+	case len(entry.idle) >= int(p.cfg.MaxIdleConnectionsPerKey):
+		reason = evictionReasonOverflow
+	default:
+		reason = p.expirationReason(pc, time.Now())
+	}
+
+	if reason == "" {
+		pc.releasedAt = time.Now()
+		entry.idle = append(entry.idle, pc)
+		p.metrics.idle.With(tags).Add(1)
+	}
+
+	p.mutex.Unlock()
+
+	if reason != "" {
+		p.metrics.evictions.With(map[string]string{"kind": entry.kind, "reason": reason}).Inc()
+		common.LogCloserError(logger, conn, "close pooled connection")
+	}
+
+	p.freeSlot(entry)
+	p.unrefEntry(entry)
+}
+
+// Close terminates all the idle connections; connections in use are closed when they are released.
+func (p *ConnectionPool) Close() error {
+	p.mutex.Lock()
+
+	if p.closed {
+		p.mutex.Unlock()
+
+		return nil
+	}
+
+	p.closed = true
+
+	var idle []*pooledConnection
+
+	for key, entry := range p.entries {
+		idle = append(idle, entry.idle...)
+		p.metrics.idle.With(map[string]string{"kind": entry.kind}).Add(-int64(len(entry.idle)))
+		entry.idle = nil
+
+		delete(p.entries, key)
+	}
+
+	// the handles in use are closed when they are released
+	var unusedDBs []*SharedDB
+
+	for key, db := range p.sharedDBs {
+		if db.refs == 0 {
+			unusedDBs = append(unusedDBs, db)
+		}
+
+		delete(p.sharedDBs, key)
+	}
+
+	p.mutex.Unlock()
+
+	for _, db := range unusedDBs {
+		common.LogCloserError(p.logger, db, "close shared database handle")
+	}
+
+	close(p.done)
+	p.wg.Wait()
+
+	for _, pc := range idle {
+		p.metrics.evictions.With(map[string]string{"kind": pc.entry.kind, "reason": evictionReasonPoolClosed}).Inc()
+		common.LogCloserError(p.logger, pc.conn, "close pooled connection")
+	}
+
+	return nil
+}
+
+func (p *ConnectionPool) refEntry(key, kind string) (*connectionPoolEntry, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.closed {
+		return nil, fmt.Errorf("connection pool is closed")
+	}
+
+	entry, ok := p.entries[key]
+	if !ok {
+		entry = &connectionPoolEntry{kind: kind}
+
+		if p.cfg.MaxConnectionsPerKey > 0 {
+			entry.slots = make(chan struct{}, p.cfg.MaxConnectionsPerKey)
+		}
+
+		p.entries[key] = entry
+	}
+
+	entry.refs++
+
+	return entry, nil
+}
+
+func (p *ConnectionPool) unrefEntry(entry *connectionPoolEntry) {
+	p.mutex.Lock()
+	entry.refs--
+	p.mutex.Unlock()
+}
+
+func (*ConnectionPool) freeSlot(entry *connectionPoolEntry) {
+	if entry.slots != nil {
+		<-entry.slots
+	}
+}
+
+func (p *ConnectionPool) popIdle(entry *connectionPoolEntry) *pooledConnection {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if len(entry.idle) == 0 {
+		return nil
+	}
+
+	pc := entry.idle[len(entry.idle)-1]
+	entry.idle = entry.idle[:len(entry.idle)-1]
+	p.metrics.idle.With(map[string]string{"kind": entry.kind}).Add(-1)
+
+	return pc
+}
+
+func (p *ConnectionPool) markInUse(pc *pooledConnection) {
+	p.mutex.Lock()
+	// the time spent in use doesn't count towards the idle timeout
+	pc.releasedAt = time.Time{}
+	p.inUse[pc.conn] = pc
+	p.mutex.Unlock()
+
+	p.metrics.inUse.With(map[string]string{"kind": pc.entry.kind}).Add(1)
+}
+
+func (p *ConnectionPool) expirationReason(pc *pooledConnection, now time.Time) string {
+	if now.Sub(pc.createdAt) > p.maxLifetime {
+		return evictionReasonMaxLifetime
+	}
+
+	if !pc.releasedAt.IsZero() && now.Sub(pc.releasedAt) > p.idleTimeout {
+		return evictionReasonIdleTimeout
+	}
+
+	return ""
+}
+
+func (p *ConnectionPool) evict(logger *zap.Logger, pc *pooledConnection, reason string) {
+	p.metrics.evictions.With(map[string]string{"kind": pc.entry.kind, "reason": reason}).Inc()
+	common.LogCloserError(logger, pc.conn, "close pooled connection")
+}
+
+// evictExpired closes expired idle connections and drops the entries that are no longer used.
+func (p *ConnectionPool) evictExpired() {
+	now := time.Now()
+
+	type expired struct {
+		pc     *pooledConnection
+		reason string
+	}
+
+	var evicted []expired
+
+	p.mutex.Lock()
+
+	for key, entry := range p.entries {
+		alive := entry.idle[:0]
+
+		for _, pc := range entry.idle {
+			if reason := p.expirationReason(pc, now); reason != "" {
+				evicted = append(evicted, expired{pc: pc, reason: reason})
+				p.metrics.idle.With(map[string]string{"kind": entry.kind}).Add(-1)
+			} else {
+				alive = append(alive, pc)
+			}
+		}
+
+		entry.idle = alive
+
+		if len(entry.idle) == 0 && entry.refs == 0 {
+			delete(p.entries, key)
+		}
+	}
+
+	var unusedDBs []*SharedDB
+
+	for key, db := range p.sharedDBs {
+		if db.refs == 0 && now.Sub(db.releasedAt) > p.idleTimeout {
+			unusedDBs = append(unusedDBs, db)
+
+			delete(p.sharedDBs, key)
+		}
+	}
+
+	p.mutex.Unlock()
+
+	for _, e := range evicted {
+		p.evict(p.logger, e.pc, e.reason)
+	}
+
+	for _, db := range unusedDBs {
+		common.LogCloserError(p.logger, db, "close shared database handle")
+	}
+}
+
+// AcquireSharedDB returns the database/sql handle for the data source instance or opens a new one.
+func (p *ConnectionPool) AcquireSharedDB(
+	logger *zap.Logger,
+	dsi *api_common.TGenericDataSourceInstance,
+	open func() (*sql.DB, error),
+) (*SharedDB, error) {
+	key, err := makeConnectionPoolKey(dsi)
+	if err != nil {
+		return nil, fmt.Errorf("make connection pool key: %w", err)
+	}
+
+	if db := p.refSharedDB(key); db != nil {
+		return db, nil
+	}
+
+	// the handle is opened without the lock held, so the concurrent requests may open it twice
+	opened, err := open()
+	if err != nil {
+		p.metrics.dialErrors.With(map[string]string{"kind": dsi.Kind.String()}).Inc()
+
+		return nil, err
+	}
+
+	p.metrics.dials.With(map[string]string{"kind": dsi.Kind.String()}).Inc()
+
+	opened.SetMaxOpenConns(int(p.cfg.MaxConnectionsPerKey))
+	opened.SetMaxIdleConns(int(p.cfg.MaxIdleConnectionsPerKey))
+	opened.SetConnMaxIdleTime(p.idleTimeout)
+	opened.SetConnMaxLifetime(p.maxLifetime)
+
+	p.mutex.Lock()
+
+	if p.closed {
+		p.mutex.Unlock()
+		common.LogCloserError(logger, opened, "close shared database handle")
+
+		return nil, fmt.Errorf("connection pool is closed")
+	}
+
+	db, ok := p.sharedDBs[key]
+	if !ok {
+		db = &SharedDB{DB: opened, key: key}
+		p.sharedDBs[key] = db
+	}
+
+	db.refs++
+
+	p.mutex.Unlock()
+
+	if ok {
+		common.LogCloserError(logger, opened, "close redundant shared database handle")
+	}
+
+	return db, nil
+}
+
+func (p *ConnectionPool) refSharedDB(key string) *SharedDB {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	db, ok := p.sharedDBs[key]
+	if !ok || p.closed {
+		return nil
+	}
+
+	db.refs++
+
+	return db
+}
+
+// ReleaseSharedDB marks the handle as unused by the request; it is closed after the idle timeout.
+func (p *ConnectionPool) ReleaseSharedDB(logger *zap.Logger, db *SharedDB) {
+	p.mutex.Lock()
+
+	db.refs--
+	db.releasedAt = time.Now()
+
+	// the pool has been closed while the handle was in use
+	_, pooled := p.sharedDBs[db.key]
+	closeDB := !pooled && db.refs == 0
+
+	p.mutex.Unlock()
+
+	if closeDB {
+		common.LogCloserError(logger, db, "close shared database handle")
+	}
+}
+
+// minEvictionPeriod prevents the eviction loop from spinning when the idle timeout is very short
+const minEvictionPeriod = time.Second
+
+func (p *ConnectionPool) runEvictionLoop(period time.Duration) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.evictExpired()
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// makeConnectionPoolKey identifies the connections that can be used interchangeably.
+// Credentials and data source specific options are hashed, so that secrets do not appear in the key.
+func makeConnectionPoolKey(dsi *api_common.TGenericDataSourceInstance) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(dsi)
+	if err != nil {
+		return "", fmt.Errorf("marshal data source instance: %w", err)
+	}
+
+	return fmt.Sprintf(
+		"%v/%s/%s/%x",
+		dsi.Kind, common.EndpointToString(dsi.Endpoint), dsi.Database, sha256.Sum256(data),
+	), nil
+}
+
+type connectionPoolMetrics struct {
+	dials               metrics.CounterVec
+	dialErrors          metrics.CounterVec
+	reuses              metrics.CounterVec
+	waits               metrics.CounterVec
+	healthCheckFailures metrics.CounterVec
+	evictions           metrics.CounterVec
+	idle                metrics.IntGaugeVec
+	inUse               metrics.IntGaugeVec
+}
+
+func newConnectionPoolMetrics(registry metrics.Registry) *connectionPoolMetrics {
+	registry = registry.WithPrefix("connection_pool")
+
+	return &connectionPoolMetrics{
+		dials:               registry.CounterVec("dials_total", []string{"kind"}),
+		dialErrors:          registry.CounterVec("dial_errors_total", []string{"kind"}),
+		reuses:              registry.CounterVec("reuses_total", []string{"kind"}),
+		waits:               registry.CounterVec("waits_total", []string{"kind"}),
+		healthCheckFailures: registry.CounterVec("health_check_failures_total", []string{"kind"}),
+		evictions:           registry.CounterVec("evictions_total", []string{"kind", "reason"}),
+		idle:                registry.IntGaugeVec("idle_connections", []string{"kind"}),
+		inUse:               registry.IntGaugeVec("in_use_connections", []string{"kind"}),
+	}
+}
+
+func NewConnectionPool(
+	logger *zap.Logger,
+	cfg *config.TConnectionPoolConfig,
+	registry metrics.Registry,
+) *ConnectionPool {
+	p := &ConnectionPool{
+		logger:            logger,
+		cfg:               cfg,
+		metrics:           newConnectionPoolMetrics(registry),
+		idleTimeout:       common.MustDurationFromString(cfg.IdleTimeout),
+		maxLifetime:       common.MustDurationFromString(cfg.MaxLifetime),
+		healthCheckPeriod: common.MustDurationFromString(cfg.HealthCheckPeriod),
+		entries:           make(map[string]*connectionPoolEntry),
+		inUse:             make(map[PooledConnection]*pooledConnection),
+		sharedDBs:         make(map[string]*SharedDB),
+		done:              make(chan struct{}),
+	}
+
+	p.wg.Add(1)
+
+	go p.runEvictionLoop(max(p.idleTimeout/2, minEvictionPeriod))
+
+	return p
+}
+
+// AcquireConnection takes the connection from the pool or dials a new one if pooling is disabled.
+func (b *ConnectionManagerBase) AcquireConnection(
+	ctx context.Context,
+	logger *zap.Logger,
+	dsi *api_common.TGenericDataSourceInstance,
+	dial DialFunc,
+) (PooledConnection, error) {
+	if b.ConnectionPool == nil {
+		return dial()
+	}
+
+	return b.ConnectionPool.Acquire(ctx, logger, dsi, dial)
+}
+
+// ReleaseConnection returns the connection to the pool or closes it if pooling is disabled.
+func (b *ConnectionManagerBase) ReleaseConnection(logger *zap.Logger, conn PooledConnection) {
+	if b.ConnectionPool == nil {
+		common.LogCloserError(logger, conn, "close connection")
+
+		return
+	}
+
+	b.ConnectionPool.Release(logger, conn)
+}
+
+// AcquireSharedDB takes the shared database/sql handle from the pool
+// or opens a new one owned by the request if pooling is disabled.
+func (b *ConnectionManagerBase) AcquireSharedDB(
+	logger *zap.Logger,
+	dsi *api_common.TGenericDataSourceInstance,
+	open func() (*sql.DB, error),
+) (*SharedDB, error) {
+	if b.ConnectionPool == nil {
+		db, err := open()
+		if err != nil {
+			return nil, err
+		}
+
+		return &SharedDB{DB: db}, nil
+	}
+
+	return b.ConnectionPool.AcquireSharedDB(logger, dsi, open)
+}
+
+// ReleaseSharedDB returns the handle to the pool or closes it if pooling is disabled.
+func (b *ConnectionManagerBase) ReleaseSharedDB(logger *zap.Logger, db *SharedDB) {
+	if b.ConnectionPool == nil {
+		common.LogCloserError(logger, db, "close database handle")
+
+		return
+	}
+
+	b.ConnectionPool.ReleaseSharedDB(logger, db)
+}
@@ -0,0 +1,262 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/solomon"
+)
+
+type testPooledConnection struct {
+	id       int
+	reusable bool
+	pingErr  error
+	closed   bool
+}
+
+func (c *testPooledConnection) Ping(context.Context) error { return c.pingErr }
+
+func (c *testPooledConnection) Reusable() bool { return c.reusable }
+
+func (c *testPooledConnection) Close() error {
+	c.closed = true
+
+	return nil
+}
+
+type testDialer struct {
+	dialed []*testPooledConnection
+}
+
+func (d *testDialer) dial() (PooledConnection, error) {
+	conn := &testPooledConnection{id: len(d.dialed), reusable: true}
+	d.dialed = append(d.dialed, conn)
+
+	return conn, nil
+}
+
+func makeTestConnectionPool(t *testing.T, cfg *config.TConnectionPoolConfig) *ConnectionPool {
+	pool := NewConnectionPool(common.NewTestLogger(t), cfg, solomon.NewRegistry(nil))
+	t.Cleanup(func() { require.NoError(t, pool.Close()) })
+
+	return pool
+}
+
+func makeTestConnectionPoolConfig() *config.TConnectionPoolConfig {
+	return &config.TConnectionPoolConfig{
+		Enabled:                  true,
+		MaxConnectionsPerKey:     2,
+		MaxIdleConnectionsPerKey: 1,
+		IdleTimeout:              "1m",
+		MaxLifetime:              "30m",
+		HealthCheckPeriod:        "10s",
+	}
+}
+
+func makeTestDataSourceInstance(database, password string) *api_common.TGenericDataSourceInstance {
+	return &api_common.TGenericDataSourceInstance{
+		Kind:     api_common.EGenericDataSourceKind_POSTGRESQL,
+		Endpoint: &api_common.TGenericEndpoint{Host: "localhost", Port: 5432},
+		Database: database,
+		Credentials: &api_common.TGenericCredentials{
+			Payload: &api_common.TGenericCredentials_Basic{
+				Basic: &api_common.TGenericCredentials_TBasic{Username: "user", Password: password},
+			},
+		},
+	}
+}
+
+func TestConnectionPool(t *testing.T) {
+	ctx := context.Background()
+	logger := common.NewTestLogger(t)
+	dsi := makeTestDataSourceInstance("db", "password")
+
+	t.Run("reuse", func(t *testing.T) {
+		pool := makeTestConnectionPool(t, makeTestConnectionPoolConfig())
+		dialer := &testDialer{}
+
+		conn1, err := pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+		pool.Release(logger, conn1)
+
+		conn2, err := pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+		require.Same(t, conn1, conn2)
+		require.Len(t, dialer.dialed, 1)
+		pool.Release(logger, conn2)
+
+		// different credentials or database must not share the connection
+		conn3, err := pool.Acquire(ctx, logger, makeTestDataSourceInstance("db", "another_password"), dialer.dial)
+		require.NoError(t, err)
+		require.NotSame(t, conn1, conn3)
+
+		conn4, err := pool.Acquire(ctx, logger, makeTestDataSourceInstance("another_db", "password"), dialer.dial)
+		require.NoError(t, err)
+		require.NotSame(t, conn1, conn4)
+		require.Len(t, dialer.dialed, 3)
+	})
+
+	t.Run("idle overflow", func(t *testing.T) {
+		pool := makeTestConnectionPool(t, makeTestConnectionPoolConfig())
+		dialer := &testDialer{}
+
+		conn1, err := pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+
+		conn2, err := pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+
+		pool.Release(logger, conn1)
+		pool.Release(logger, conn2)
+
+		// only one idle connection is kept
+		require.False(t, dialer.dialed[0].closed)
+		require.True(t, dialer.dialed[1].closed)
+	})
+
+	t.Run("not reusable", func(t *testing.T) {
+		pool := makeTestConnectionPool(t, makeTestConnectionPoolConfig())
+		dialer := &testDialer{}
+
+		conn, err := pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+
+		conn.(*testPooledConnection).reusable = false
+		pool.Release(logger, conn)
+		require.True(t, dialer.dialed[0].closed)
+
+		_, err = pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+		require.Len(t, dialer.dialed, 2)
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		pool := makeTestConnectionPool(t, makeTestConnectionPoolConfig())
+		dialer := &testDialer{}
+
+		conn, err := pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+		pool.Release(logger, conn)
+
+		// emulate the connection idle for too long
+		for _, entry := range pool.entries {
+			for _, pc := range entry.idle {
+				pc.releasedAt = pc.releasedAt.Add(-2 * time.Minute)
+			}
+		}
+
+		pool.evictExpired()
+		require.True(t, dialer.dialed[0].closed)
+		require.Empty(t, pool.entries)
+
+		conn, err = pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+		pool.Release(logger, conn)
+
+		// emulate the connection living for too long
+		for _, entry := range pool.entries {
+			for _, pc := range entry.idle {
+				pc.createdAt = pc.createdAt.Add(-time.Hour)
+			}
+		}
+
+		_, err = pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+		require.True(t, dialer.dialed[1].closed)
+		require.Len(t, dialer.dialed, 3)
+	})
+
+	t.Run("held longer than idle timeout", func(t *testing.T) {
+		pool := makeTestConnectionPool(t, makeTestConnectionPoolConfig())
+		dialer := &testDialer{}
+
+		conn, err := pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+		pool.Release(logger, conn)
+
+		// the connection has been idle for almost the whole idle timeout
+		for _, entry := range pool.entries {
+			for _, pc := range entry.idle {
+				pc.releasedAt = pc.releasedAt.Add(-50 * time.Second)
+			}
+		}
+
+		conn, err = pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+
+		// the time spent in use is not counted as idle time
+		for _, pc := range pool.inUse {
+			require.True(t, pc.releasedAt.IsZero())
+		}
+
+		pool.Release(logger, conn)
+		require.False(t, dialer.dialed[0].closed)
+		require.Len(t, dialer.dialed, 1)
+	})
+
+	t.Run("health check", func(t *testing.T) {
+		pool := makeTestConnectionPool(t, makeTestConnectionPoolConfig())
+		dialer := &testDialer{}
+
+		conn, err := pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+		pool.Release(logger, conn)
+
+		// the connection idle for longer than the health check period is pinged
+		conn.(*testPooledConnection).pingErr = errors.New("connection reset by peer")
+
+		for _, entry := range pool.entries {
+			for _, pc := range entry.idle {
+				pc.releasedAt = pc.releasedAt.Add(-30 * time.Second)
+			}
+		}
+
+		conn2, err := pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+		require.NotSame(t, conn, conn2)
+		require.True(t, dialer.dialed[0].closed)
+	})
+
+	t.Run("connections limit", func(t *testing.T) {
+		pool := makeTestConnectionPool(t, makeTestConnectionPoolConfig())
+		dialer := &testDialer{}
+
+		conn1, err := pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+
+		_, err = pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+
+		// the third request waits until the context expires
+		waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err = pool.Acquire(waitCtx, logger, dsi, dialer.dial)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// the third request gets the released connection
+		go pool.Release(logger, conn1)
+
+		conn3, err := pool.Acquire(ctx, logger, dsi, dialer.dial)
+		require.NoError(t, err)
+		require.Same(t, conn1, conn3)
+		require.Len(t, dialer.dialed, 2)
+	})
+
+	t.Run("dial error", func(t *testing.T) {
+		pool := makeTestConnectionPool(t, makeTestConnectionPoolConfig())
+		dialErr := errors.New("connection refused")
+
+		for i := 0; i < 3; i++ {
+			_, err := pool.Acquire(ctx, logger, dsi, func() (PooledConnection, error) { return nil, dialErr })
+			require.ErrorIs(t, err, dialErr)
+		}
+	})
+}
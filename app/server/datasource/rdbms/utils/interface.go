@@ -54,6 +54,7 @@ const (
 	QueryPhaseDescribeTable
 	QueryPhaseListSplits
 	QueryPhaseReadSplits
+	QueryPhaseListTables
 )
 
 type ConnectionParams struct {
@@ -75,12 +76,22 @@ type ConnectionManager interface {
 
 type ConnectionManagerBase struct {
 	QueryLoggerFactory common.QueryLoggerFactory
+	// ConnectionPool is shared by all the connection managers; nil means that pooling is disabled
+	ConnectionPool *ConnectionPool
 }
 
 type SelectQueryParts struct {
 	SelectClause string
 	FromClause   string
 	WhereClause  string
+	// Sort keys placed after ORDER BY; empty string means no ordering
+	OrderByClause string
+	// Maximum number of rows to return; zero value means no limit
+	Limit uint64
+	// Number of rows to skip; taken into account only when Limit is set
+	Offset uint64
+	// Arguments of the clauses above; the renderer appends the arguments of the conditions it adds to the query
+	QueryArgs *QueryArgs
 }
 
 type SQLFormatter interface {
@@ -95,6 +106,9 @@ type SQLFormatter interface {
 	// FormatFrom builds a substring containing the literals
 	// that must be placed after FROM (`SELECT ... FROM <this>`).
 	FormatFrom(tableName string) string
+	// FormatOrderBy builds a substring containing the sort keys
+	// that must be placed after ORDER BY (`SELECT ... ORDER BY <this>`) if possible
+	FormatOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) (string, error)
 	// RenderSelectQueryText composes final query text from the given clauses.
 	// Particular implementation may mix-in some additional parts into the query.
 	RenderSelectQueryText(parts *SelectQueryParts, split *api_service_protos.TSplit) (string, error)
@@ -110,6 +124,21 @@ type SQLFormatter interface {
 	FormatIf(predicateExpr, thenExpr, elseExpr string) (string, error)
 	// Renders `CAST(valueExpr AS ydbType)` predicate pushdown if possible
 	FormatCast(valueExpr string, ydbType *Ydb.Type) (string, error)
+	// Renders the date and time function (e.g. `toYear(value)`) if possible
+	FormatDateTimeFunction(function api_service_protos.TExpression_TDateTimeFunction_EFunction, value string) (string, error)
+	// Renders `CAST(expression AS type) AS name` item of the SELECT clause if possible.
+	// The expression is converted to the type inferred by the connector, because the data source
+	// may choose another type for the result, and the values are read according to the result type.
+	FormatComputedColumn(expression string, ydbType *Ydb.Type, name string) (string, error)
+	// MaxInSetParameters returns the maximum number of the query parameters that can be taken by the IN sets;
+	// the sets exceeding it are passed to the data source in the way defined by LargeInSetMode
+	MaxInSetParameters() int
+	// LargeInSetMode returns the way of passing the IN sets that would exceed the number of query parameters
+	LargeInSetMode() LargeInSetMode
+	// Renders `value IN array` predicate pushdown for the set passed as a single array parameter if possible
+	FormatInArray(value, arrayPlaceholder string) (string, error)
+	// Renders `value IN (table of values)` predicate pushdown for the set embedded into the query as literals if possible
+	FormatInValues(value string, set []any) (string, error)
 	// TransformPredicateComparison transforms the comparison predicate
 	// (may be useful for some special data sources)
 	TransformPredicateComparison(src *api_service_protos.TPredicate_TComparison) (
@@ -127,6 +156,27 @@ type SchemaProvider interface {
 	) (*api_service_protos.TSchema, error)
 }
 
+// TableListProvider enumerates the tables available within the database the connection is bound to
+type TableListProvider interface {
+	ListTables(
+		ctx context.Context,
+		logger *zap.Logger,
+		conn Connection,
+		request *api_service_protos.TListTablesRequest,
+	) ([]string, error)
+}
+
+// KeyProvider discovers the unique key of the table (e. g. the primary key).
+// Reading the rows in the key order allows to resume the interrupted reading right after the last delivered row.
+type KeyProvider interface {
+	// GetKey returns the key columns in the order of the key, or nil if the table has no suitable key
+	GetKey(
+		ctx context.Context,
+		logger *zap.Logger,
+		conn Connection,
+	) ([]*Ydb.Column, error)
+}
+
 type ListSplitsParams struct {
 	Ctx                   context.Context
 	Logger                *zap.Logger
@@ -142,4 +192,7 @@ type ListSplitsParams struct {
 // SplitProvider generates stream of splits - the description of the parts of a large external table
 type SplitProvider interface {
 	ListSplits(*ListSplitsParams) error
+	// MaySplitTables reports whether a table may be read within several splits
+	// (or within a split with a description, which is treated the same way)
+	MaySplitTables() bool
 }
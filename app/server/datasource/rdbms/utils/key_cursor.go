@@ -0,0 +1,281 @@
+package utils
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// KeyCursor makes the query return the rows in the order of the unique key of the table,
+// starting right after the key of the last delivered row. Unlike the row offset, the key value
+// allows to resume the reading without losing or duplicating rows.
+type KeyCursor struct {
+	// Key columns in the order of the key
+	Columns []*Ydb.Column
+	// Key value of the last delivered row; empty if the table is read from the beginning
+	LastKey []*Ydb.TypedValue
+	// The number of rows delivered up to the last key; they are subtracted from the LIMIT
+	RowsDelivered uint64
+}
+
+// GetLastKey returns the key value of the last delivered row, it's safe to call it for nil cursor
+func (c *KeyCursor) GetLastKey() []*Ydb.TypedValue {
+	if c == nil {
+		return nil
+	}
+
+	return c.LastKey
+}
+
+// GetRowsDelivered returns the number of rows delivered up to the last key, it's safe to call it for nil cursor
+func (c *KeyCursor) GetRowsDelivered() uint64 {
+	if c == nil {
+		return 0
+	}
+
+	return c.RowsDelivered
+}
+
+// orderBy returns the ascending order of the key columns
+func (c *KeyCursor) orderBy() *api_service_protos.TSelect_TOrderBy {
+	orderBy := &api_service_protos.TSelect_TOrderBy{}
+
+	for _, column := range c.Columns {
+		orderBy.Keys = append(orderBy.Keys, &api_service_protos.TSelect_TOrderBy_TSortKey{
+			Column: column.Name,
+			Order:  api_service_protos.TSelect_TOrderBy_ASC,
+		})
+	}
+
+	return orderBy
+}
+
+// IsKeyTypeSupported reports whether the values of the type can be compared in the same way
+// by the data source and by the connector, so that the column can be a part of the key cursor.
+// Only the integers and the binary strings are supported: the text is compared according to the collation,
+// which may ignore the case or the trailing spaces, so the value returned to the client may not be equal
+// to the stored one in the terms of the cursor predicate.
+func IsKeyTypeSupported(ydbType *Ydb.Type) bool {
+	if optional := ydbType.GetOptionalType(); optional != nil {
+		ydbType = optional.Item
+	}
+
+	switch ydbType.GetTypeId() {
+	case Ydb.Type_INT8, Ydb.Type_INT16, Ydb.Type_INT32, Ydb.Type_INT64,
+		Ydb.Type_UINT8, Ydb.Type_UINT16, Ydb.Type_UINT32, Ydb.Type_UINT64,
+		Ydb.Type_STRING:
+		return true
+	default:
+		return false
+	}
+}
+
+// addKeyColumns appends the key columns missing in the projection to its end
+// and returns the positions of the key columns within the extended projection.
+func addKeyColumns(
+	what *api_service_protos.TSelect_TWhat,
+	keyColumns []*Ydb.Column,
+) (*api_service_protos.TSelect_TWhat, []int) {
+	items := make([]*api_service_protos.TSelect_TWhat_TItem, len(what.GetItems()), len(what.GetItems())+len(keyColumns))
+	copy(items, what.GetItems())
+
+	indices := make([]int, len(keyColumns))
+
+	for i, keyColumn := range keyColumns {
+		indices[i] = -1
+
+		for j, item := range items {
+			if item.GetColumn().GetName() == keyColumn.Name {
+				indices[i] = j
+
+				break
+			}
+		}
+
+		if indices[i] < 0 {
+			items = append(items, &api_service_protos.TSelect_TWhat_TItem{
+				Payload: &api_service_protos.TSelect_TWhat_TItem_Column{Column: keyColumn},
+			})
+			indices[i] = len(items) - 1
+		}
+	}
+
+	return &api_service_protos.TSelect_TWhat{Items: items}, indices
+}
+
+// formatKeyCursorPredicate renders the predicate selecting the rows following the last key
+// in the lexicographical order: `k1 > v1 OR (k1 = v1 AND k2 > v2) OR ...`
+func formatKeyCursorPredicate(
+	formatter SQLFormatter,
+	args *QueryArgs,
+	keyCursor *KeyCursor,
+	dataSourceKind api_common.EGenericDataSourceKind,
+) (string, error) {
+	if len(keyCursor.LastKey) != len(keyCursor.Columns) {
+		return "", fmt.Errorf(
+			"key value has %d items, while the key has %d columns: %w",
+			len(keyCursor.LastKey), len(keyCursor.Columns), common.ErrInvalidRequest)
+	}
+
+	makeComparison := func(i int, operation api_service_protos.TPredicate_TComparison_EOperation) *api_service_protos.TPredicate {
+		return &api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_Comparison{
+				Comparison: &api_service_protos.TPredicate_TComparison{
+					Operation: operation,
+					LeftValue: &api_service_protos.TExpression{
+						Payload: &api_service_protos.TExpression_Column{Column: keyCursor.Columns[i].Name},
+					},
+					RightValue: &api_service_protos.TExpression{
+						Payload: &api_service_protos.TExpression_TypedValue{TypedValue: keyCursor.LastKey[i]},
+					},
+				},
+			},
+		}
+	}
+
+	disjunction := &api_service_protos.TPredicate_TDisjunction{}
+
+	for i := range keyCursor.Columns {
+		conjunction := &api_service_protos.TPredicate_TConjunction{}
+
+		for j := 0; j < i; j++ {
+			conjunction.Operands = append(conjunction.Operands, makeComparison(j, api_service_protos.TPredicate_TComparison_EQ))
+		}
+
+		conjunction.Operands = append(conjunction.Operands, makeComparison(i, api_service_protos.TPredicate_TComparison_G))
+
+		disjunction.Operands = append(disjunction.Operands, &api_service_protos.TPredicate{
+			Payload: &api_service_protos.TPredicate_Conjunction{Conjunction: conjunction},
+		})
+	}
+
+	pb := &predicateBuilder{formatter: formatter, args: args, dataSourceKind: dataSourceKind}
+
+	// The predicate is not a top-level one, so it is either rendered entirely or not rendered at all
+	result, err := pb.formatPredicate(
+		&api_service_protos.TPredicate{Payload: &api_service_protos.TPredicate_Disjunction{Disjunction: disjunction}},
+		false,
+		false,
+	)
+	if err != nil {
+		return "", fmt.Errorf("format predicate: %w", err)
+	}
+
+	return result, nil
+}
+
+// MakeKeyValue converts the value of the key column kept in the row acceptor into the typed value.
+// The acceptors of the different data sources are the (multiple) pointers to the Go primitive types
+// or the types implementing driver.Valuer.
+//
+//nolint:gocyclo
+func MakeKeyValue(acceptor any, ydbType *Ydb.Type) (*Ydb.TypedValue, error) {
+	if optional := ydbType.GetOptionalType(); optional != nil {
+		ydbType = optional.Item
+	}
+
+	value := reflect.ValueOf(acceptor)
+
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, fmt.Errorf("key column value is NULL")
+		}
+
+		if valuer, ok := value.Interface().(driver.Valuer); ok {
+			v, err := valuer.Value()
+			if err != nil {
+				return nil, fmt.Errorf("get driver value: %w", err)
+			}
+
+			if v == nil {
+				return nil, fmt.Errorf("key column value is NULL")
+			}
+
+			value = reflect.ValueOf(v)
+
+			break
+		}
+
+		value = value.Elem()
+	}
+
+	out := &Ydb.TypedValue{Type: ydbType, Value: &Ydb.Value{}}
+
+	switch ydbType.GetTypeId() {
+	case Ydb.Type_INT8, Ydb.Type_INT16, Ydb.Type_INT32, Ydb.Type_INT64:
+		var v int64
+
+		var err error
+
+		switch {
+		case value.CanInt():
+			v = value.Int()
+		case value.CanUint():
+			v = int64(value.Uint())
+		case value.Kind() == reflect.String:
+			// some drivers return the numbers as strings
+			if v, err = strconv.ParseInt(value.String(), 10, 64); err != nil {
+				return nil, fmt.Errorf("parse integer key value: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("unexpected value type %v for the key column of type %v", value.Type(), ydbType)
+		}
+
+		if ydbType.GetTypeId() == Ydb.Type_INT64 {
+			out.Value.Value = &Ydb.Value_Int64Value{Int64Value: v}
+		} else {
+			out.Value.Value = &Ydb.Value_Int32Value{Int32Value: int32(v)}
+		}
+	case Ydb.Type_UINT8, Ydb.Type_UINT16, Ydb.Type_UINT32, Ydb.Type_UINT64:
+		var v uint64
+
+		var err error
+
+		switch {
+		case value.CanUint():
+			v = value.Uint()
+		case value.CanInt():
+			v = uint64(value.Int())
+		case value.Kind() == reflect.String:
+			if v, err = strconv.ParseUint(value.String(), 10, 64); err != nil {
+				return nil, fmt.Errorf("parse unsigned integer key value: %w", err)
+			}
+		default:
+			return nil, fmt.Errorf("unexpected value type %v for the key column of type %v", value.Type(), ydbType)
+		}
+
+		if ydbType.GetTypeId() == Ydb.Type_UINT64 {
+			out.Value.Value = &Ydb.Value_Uint64Value{Uint64Value: v}
+		} else {
+			out.Value.Value = &Ydb.Value_Uint32Value{Uint32Value: uint32(v)}
+		}
+	case Ydb.Type_STRING, Ydb.Type_UTF8:
+		var v string
+
+		switch {
+		case value.Kind() == reflect.String:
+			v = value.String()
+		case value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Uint8:
+			v = string(value.Bytes())
+		default:
+			return nil, fmt.Errorf("unexpected value type %v for the key column of type %v", value.Type(), ydbType)
+		}
+
+		if ydbType.GetTypeId() == Ydb.Type_STRING {
+			out.Value.Value = &Ydb.Value_BytesValue{BytesValue: []byte(v)}
+		} else {
+			out.Value.Value = &Ydb.Value_TextValue{TextValue: v}
+		}
+	default:
+		return nil, fmt.Errorf("key column of type %v: %w", ydbType, common.ErrDataTypeNotSupported)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestMakeKeyValue(t *testing.T) {
+	type testCase struct {
+		testName string
+		acceptor any
+		ydbType  *Ydb.Type
+		expected *Ydb.TypedValue
+	}
+
+	int32Value := int32(1)
+	int32Pointer := &int32Value
+	numberString := "9007199254740993"
+
+	tcs := []testCase{
+		{
+			testName: "pointer_to_pointer",
+			acceptor: &int32Pointer,
+			ydbType:  common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT32)),
+			expected: common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT32), int32(1)),
+		},
+		{
+			testName: "driver_valuer",
+			acceptor: &sql.NullInt64{Int64: 2, Valid: true},
+			ydbType:  common.MakePrimitiveType(Ydb.Type_INT64),
+			expected: common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT64), int64(2)),
+		},
+		{
+			testName: "number_as_string",
+			acceptor: &numberString,
+			ydbType:  common.MakePrimitiveType(Ydb.Type_INT64),
+			expected: common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT64), int64(9007199254740993)),
+		},
+		{
+			testName: "unsigned",
+			acceptor: new(uint16),
+			ydbType:  common.MakePrimitiveType(Ydb.Type_UINT16),
+			expected: &Ydb.TypedValue{
+				Type:  common.MakePrimitiveType(Ydb.Type_UINT16),
+				Value: &Ydb.Value{Value: &Ydb.Value_Uint32Value{Uint32Value: 0}},
+			},
+		},
+		{
+			testName: "bytes",
+			acceptor: &[]byte{'a', 'b'},
+			ydbType:  common.MakePrimitiveType(Ydb.Type_STRING),
+			expected: common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_STRING), []byte("ab")),
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			actual, err := MakeKeyValue(tc.acceptor, tc.ydbType)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tc.expected, actual), actual.String())
+		})
+	}
+
+	t.Run("null", func(t *testing.T) {
+		var nullPointer *int32
+
+		_, err := MakeKeyValue(&nullPointer, common.MakePrimitiveType(Ydb.Type_INT32))
+		require.Error(t, err)
+
+		_, err = MakeKeyValue(&sql.NullInt64{}, common.MakePrimitiveType(Ydb.Type_INT64))
+		require.Error(t, err)
+	})
+
+	t.Run("unsupported_type", func(t *testing.T) {
+		_, err := MakeKeyValue(new(float64), common.MakePrimitiveType(Ydb.Type_DOUBLE))
+		require.True(t, errors.Is(err, common.ErrDataTypeNotSupported))
+	})
+}
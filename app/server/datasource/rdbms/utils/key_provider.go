@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+type defaultKeyProvider struct {
+	typeMapper      datasource.TypeMapper
+	getArgsAndQuery func(dsi *api_common.TGenericDataSourceInstance, tableName string) (string, *QueryArgs)
+}
+
+var _ KeyProvider = (*defaultKeyProvider)(nil)
+
+func (f *defaultKeyProvider) GetKey(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn Connection,
+) ([]*Ydb.Column, error) {
+	query, args := f.getArgsAndQuery(conn.DataSourceInstance(), conn.TableName())
+
+	queryParams := &QueryParams{
+		Ctx:       ctx,
+		Logger:    logger,
+		QueryText: query,
+		QueryArgs: args,
+	}
+
+	rows, err := conn.Query(queryParams)
+	if err != nil {
+		return nil, fmt.Errorf("query builder error: %w", err)
+	}
+
+	defer func() { common.LogCloserError(logger, rows, "close rows") }()
+
+	var (
+		columnName *string
+		typeName   *string
+		columns    []*Ydb.Column
+		supported  = true
+	)
+
+	// Key columns are not returned to the client, so the default type mapping is suitable for them
+	typeMappingSettings := &api_service_protos.TTypeMappingSettings{
+		DateTimeFormat: api_service_protos.EDateTimeFormat_YQL_FORMAT,
+	}
+
+	for rows.Next() {
+		if err = rows.Scan(&columnName, &typeName); err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+
+		column, err := f.typeMapper.SQLTypeToYDBColumn(*columnName, *typeName, typeMappingSettings)
+		if err != nil || !IsKeyTypeSupported(column.Type) {
+			logger.Debug("key column type is not supported", zap.String("column", *columnName), zap.String("type", *typeName))
+
+			supported = false
+
+			continue
+		}
+
+		columns = append(columns, column)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration: %w", err)
+	}
+
+	if !supported {
+		return nil, nil
+	}
+
+	return columns, nil
+}
+
+func NewDefaultKeyProvider(
+	typeMapper datasource.TypeMapper,
+	getArgsAndQueryFunc func(dsi *api_common.TGenericDataSourceInstance, tableName string) (string, *QueryArgs),
+) KeyProvider {
+	return &defaultKeyProvider{
+		typeMapper:      typeMapper,
+		getArgsAndQuery: getArgsAndQueryFunc,
+	}
+}
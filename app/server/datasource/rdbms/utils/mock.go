@@ -63,6 +63,18 @@ func (m *ConnectionManagerMock) Release(_ context.Context, _ *zap.Logger, cs []C
 	m.Called(cs)
 }
 
+var _ KeyProvider = (*KeyProviderMock)(nil)
+
+type KeyProviderMock struct {
+	mock.Mock
+}
+
+func (m *KeyProviderMock) GetKey(_ context.Context, _ *zap.Logger, conn Connection) ([]*Ydb.Column, error) {
+	args := m.Called(conn)
+
+	return args.Get(0).([]*Ydb.Column), args.Error(1)
+}
+
 var _ Rows = (*RowsMock)(nil)
 
 type RowsMock struct {
@@ -99,6 +111,16 @@ func (m *RowsMock) Scan(dest ...any) error {
 			case **int32:
 				**t = row[i].(int32)
 			case **string:
+				if row[i] == nil {
+					*t = nil
+
+					continue
+				}
+
+				if *t == nil {
+					*t = new(string)
+				}
+
 				**t = row[i].(string)
 			}
 		}
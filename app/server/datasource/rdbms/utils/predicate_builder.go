@@ -6,11 +6,13 @@ import (
 	"time"
 
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/datasource"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
@@ -62,7 +64,13 @@ func (pb *predicateBuilder) formatPrimitiveValue(
 		pb.args.AddTyped(value.Type, v.Int32Value)
 		return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
 	case *Ydb.Value_Uint32Value:
+		if value.Type.GetTypeId() == Ydb.Type_DATE {
+			pb.args.AddTyped(value.Type, makeDate(v.Uint32Value))
+			return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
+		}
+
 		pb.args.AddTyped(value.Type, v.Uint32Value)
+
 		return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
 	case *Ydb.Value_Int64Value:
 		switch value.Type.GetTypeId() {
@@ -73,6 +81,9 @@ func (pb *predicateBuilder) formatPrimitiveValue(
 			// YQL Timestamp is always UTC
 			pb.args.AddTyped(value.Type, time.UnixMicro(v.Int64Value).UTC())
 			return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
+		case Ydb.Type_INTERVAL:
+			pb.args.AddTyped(value.Type, time.Duration(v.Int64Value)*time.Microsecond)
+			return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
 		default:
 			return "", fmt.Errorf("unsupported type '%T': %w", v, common.ErrUnimplementedTypedValue)
 		}
@@ -90,6 +101,20 @@ func (pb *predicateBuilder) formatPrimitiveValue(
 		return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
 	case *Ydb.Value_TextValue:
 		pb.args.AddTyped(value.Type, v.TextValue)
+		return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
+	case *Ydb.Value_Low_128:
+		if value.Type.GetTypeId() == Ydb.Type_UUID {
+			pb.args.AddTyped(value.Type, formatUUIDValue(v.Low_128, value.Value.High_128))
+			return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
+		}
+
+		decimal, err := formatDecimalValue(value.Type.GetDecimalType(), v.Low_128, value.Value.High_128)
+		if err != nil {
+			return "", fmt.Errorf("format decimal value: %w", err)
+		}
+
+		pb.args.AddTyped(value.Type, decimal)
+
 		return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
 	case *Ydb.Value_NullFlagValue:
 		placeholder, err := pb.formatNullFlagValue(value)
@@ -103,6 +128,7 @@ func (pb *predicateBuilder) formatPrimitiveValue(
 	}
 }
 
+//nolint:gocyclo
 func (pb *predicateBuilder) formatOptionalValue(value *Ydb.TypedValue) (string, error) {
 	switch v := value.Value.Value.(type) {
 	case *Ydb.Value_BoolValue:
@@ -112,10 +138,26 @@ func (pb *predicateBuilder) formatOptionalValue(value *Ydb.TypedValue) (string,
 		pb.args.AddTyped(value.Type, &v.Int32Value)
 		return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
 	case *Ydb.Value_Uint32Value:
+		if value.Type.GetOptionalType().GetItem().GetTypeId() == Ydb.Type_DATE {
+			date := makeDate(v.Uint32Value)
+			pb.args.AddTyped(value.Type, &date)
+
+			return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
+		}
+
 		pb.args.AddTyped(value.Type, &v.Uint32Value)
+
 		return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
 	case *Ydb.Value_Int64Value:
+		if value.Type.GetOptionalType().GetItem().GetTypeId() == Ydb.Type_INTERVAL {
+			interval := time.Duration(v.Int64Value) * time.Microsecond
+			pb.args.AddTyped(value.Type, &interval)
+
+			return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
+		}
+
 		pb.args.AddTyped(value.Type, &v.Int64Value)
+
 		return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
 	case *Ydb.Value_Uint64Value:
 		pb.args.AddTyped(value.Type, &v.Uint64Value)
@@ -131,6 +173,22 @@ func (pb *predicateBuilder) formatOptionalValue(value *Ydb.TypedValue) (string,
 		return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
 	case *Ydb.Value_TextValue:
 		pb.args.AddTyped(value.Type, &v.TextValue)
+		return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
+	case *Ydb.Value_Low_128:
+		if value.Type.GetOptionalType().GetItem().GetTypeId() == Ydb.Type_UUID {
+			uuid := formatUUIDValue(v.Low_128, value.Value.High_128)
+			pb.args.AddTyped(value.Type, &uuid)
+
+			return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
+		}
+
+		decimal, err := formatDecimalValue(value.Type.GetOptionalType().GetItem().GetDecimalType(), v.Low_128, value.Value.High_128)
+		if err != nil {
+			return "", fmt.Errorf("format decimal value: %w", err)
+		}
+
+		pb.args.AddTyped(value.Type, &decimal)
+
 		return pb.formatter.GetPlaceholder(pb.args.Count() - 1), nil
 	case *Ydb.Value_NullFlagValue:
 		placeholder, err := pb.formatNullFlagValue(value)
@@ -185,14 +243,43 @@ func (pb *predicateBuilder) formatNullFlagValue(value *Ydb.TypedValue) (string,
 			return addTypedNull[[]byte](pb, value.Type)
 		case Ydb.Type_UTF8:
 			return addTypedNull[string](pb, value.Type)
+		case Ydb.Type_INTERVAL:
+			return addTypedNull[time.Duration](pb, value.Type)
+		case Ydb.Type_DATE:
+			return addTypedNull[time.Time](pb, value.Type)
+		case Ydb.Type_UUID:
+			// UUIDs are passed in their textual representation
+			return addTypedNull[string](pb, value.Type)
 		default:
 			return "", fmt.Errorf("unsupported primitive type '%v': %w", innerType, common.ErrUnimplementedTypedValue)
 		}
+	case *Ydb.Type_DecimalType:
+		// decimals are passed in their textual representation
+		return addTypedNull[string](pb, value.Type)
 	default:
 		return "", fmt.Errorf("unsupported type '%v': %w", innerType, common.ErrUnimplementedTypedValue)
 	}
 }
 
+// makeDate converts YQL Date (the number of days since the epoch) into the midnight of this day in UTC
+func makeDate(days uint32) time.Time {
+	return time.Unix(int64(days)*int64((24*time.Hour)/time.Second), 0).UTC()
+}
+
+// formatDecimalValue renders YDB Decimal in a plain notation that is understood by all the databases
+func formatDecimalValue(decimalType *Ydb.DecimalType, low, high uint64) (string, error) {
+	if decimalType == nil {
+		return "", fmt.Errorf("decimal value of non-decimal type: %w", common.ErrDataTypeMismatch)
+	}
+
+	return common.DecimalToString(common.DecimalFromHalves(low, high), decimalType.Scale)
+}
+
+// formatUUIDValue renders YDB Uuid in its canonical textual representation
+func formatUUIDValue(low, high uint64) string {
+	return common.UUIDFromHalves(low, high).String()
+}
+
 func (pb *predicateBuilder) formatColumn(col string) string {
 	return pb.formatter.SanitiseIdentifier(col)
 }
@@ -237,6 +324,7 @@ func (pb *predicateBuilder) formatArithmeticalExpression(
 	return fmt.Sprintf("(%s%s%s)", left, operation, right), nil
 }
 
+//nolint:gocyclo
 func (pb *predicateBuilder) formatExpression(
 	expression *api_service_protos.TExpression,
 	embedBool bool, // remove after YQ-4191, KIKIMR-22852 is fixed
@@ -278,6 +366,11 @@ func (pb *predicateBuilder) formatExpression(
 		if err != nil {
 			return result, fmt.Errorf("format cast expression '%v': %w", e.Cast, err)
 		}
+	case *api_service_protos.TExpression_DateTimeFunction:
+		result, err = pb.formatDateTimeFunction(e.DateTimeFunction, embedBool)
+		if err != nil {
+			return result, fmt.Errorf("format date time function '%v': %w", e.DateTimeFunction, err)
+		}
 	default:
 		return "", fmt.Errorf("type: %T: %w", e, common.ErrUnimplementedExpression)
 	}
@@ -285,6 +378,46 @@ func (pb *predicateBuilder) formatExpression(
 	return result, nil
 }
 
+// formatWhat renders the SELECT clause containing the computed columns
+func (pb *predicateBuilder) formatWhat(what *api_service_protos.TSelect_TWhat) (string, error) {
+	// The computed columns are converted to the types inferred by the connector
+	ydbColumns, err := common.SelectWhatToYDBColumns(what)
+	if err != nil {
+		return "", fmt.Errorf("convert Select.What to YDB columns: %w", err)
+	}
+
+	var sb strings.Builder
+
+	for i, item := range what.GetItems() {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+
+		switch payload := item.GetPayload().(type) {
+		case *api_service_protos.TSelect_TWhat_TItem_Column:
+			sb.WriteString(pb.formatColumn(payload.Column.GetName()))
+		case *api_service_protos.TSelect_TWhat_TItem_ComputedColumn:
+			name := payload.ComputedColumn.GetName()
+
+			expression, err := pb.formatExpression(payload.ComputedColumn.GetExpression(), false)
+			if err != nil {
+				return "", fmt.Errorf("format expression of computed column '%s': %w", name, err)
+			}
+
+			computedColumn, err := pb.formatter.FormatComputedColumn(expression, ydbColumns[i].Type, pb.formatColumn(name))
+			if err != nil {
+				return "", fmt.Errorf("formatter format computed column '%s': %w", name, err)
+			}
+
+			sb.WriteString(computedColumn)
+		default:
+			return "", fmt.Errorf("item #%d (%v): %w", i, item, common.ErrUnimplementedExpression)
+		}
+	}
+
+	return sb.String(), nil
+}
+
 func (pb *predicateBuilder) formatIf(
 	expression *api_service_protos.TExpression_TIf,
 	embedBool bool,
@@ -330,6 +463,23 @@ func (pb *predicateBuilder) formatCast(
 	return result, nil
 }
 
+func (pb *predicateBuilder) formatDateTimeFunction(
+	expression *api_service_protos.TExpression_TDateTimeFunction,
+	embedBool bool,
+) (string, error) {
+	valueExpr, err := pb.formatExpression(expression.Value, embedBool)
+	if err != nil {
+		return "", fmt.Errorf("format value: %w", err)
+	}
+
+	result, err := pb.formatter.FormatDateTimeFunction(expression.Function, valueExpr)
+	if err != nil {
+		return "", fmt.Errorf("formatter format date time function: %w", err)
+	}
+
+	return result, nil
+}
+
 //nolint:gocyclo
 func (pb *predicateBuilder) formatComparison(
 	comparisonInitial *api_service_protos.TPredicate_TComparison,
@@ -534,6 +684,148 @@ func (pb *predicateBuilder) formatIsNotNull(
 	return fmt.Sprintf("(%s IS NOT NULL)", statement), nil
 }
 
+func (pb *predicateBuilder) formatIn(
+	in *api_service_protos.TPredicate_TIn,
+	embedBool bool, // remove after YQ-4191, KIKIMR-22852 is fixed
+) (string, error) {
+	if len(in.Set) == 0 {
+		return "", fmt.Errorf("empty set: %w", common.ErrUnsupportedExpression)
+	}
+
+	value, err := pb.formatExpression(in.Value, embedBool)
+	if err != nil {
+		return "", fmt.Errorf("format value: %w", err)
+	}
+
+	// Large sets may exceed the limits on the number of query parameters,
+	// which apply to the whole query rather than to a single predicate
+	if pb.args.Count()+len(in.Set) > pb.formatter.MaxInSetParameters() {
+		return pb.formatLargeIn(value, in.Set)
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("(")
+	sb.WriteString(value)
+	sb.WriteString(" IN (")
+
+	for i, expression := range in.Set {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+
+		element, err := pb.formatExpression(expression, embedBool)
+		if err != nil {
+			return "", fmt.Errorf("format set element #%d: %w", i, err)
+		}
+
+		sb.WriteString(element)
+	}
+
+	sb.WriteString("))")
+
+	return sb.String(), nil
+}
+
+// formatLargeIn passes all the set elements either as a single array parameter or as a table of literals.
+// Only the sets consisting of the non-optional values of the same type are supported.
+func (pb *predicateBuilder) formatLargeIn(value string, set []*api_service_protos.TExpression) (string, error) {
+	mode := pb.formatter.LargeInSetMode()
+	if mode == LargeInSetModeNone {
+		return "", fmt.Errorf("set of %d elements: %w", len(set), common.ErrUnsupportedExpression)
+	}
+
+	elementType := set[0].GetTypedValue().GetType()
+	if elementType == nil || elementType.GetOptionalType() != nil {
+		return "", fmt.Errorf("set element %v: %w", set[0], common.ErrUnsupportedExpression)
+	}
+
+	// render the elements with the scratch builder to obtain their values converted for the driver
+	scratch := &predicateBuilder{formatter: pb.formatter, args: &QueryArgs{}, dataSourceKind: pb.dataSourceKind}
+
+	for i, expression := range set {
+		if !proto.Equal(expression.GetTypedValue().GetType(), elementType) {
+			return "", fmt.Errorf("set element #%d has type different from %v: %w", i, elementType, common.ErrUnsupportedExpression)
+		}
+
+		if !pb.formatter.SupportsExpression(expression) {
+			return "", fmt.Errorf("set element #%d: %w", i, common.ErrUnsupportedExpression)
+		}
+
+		if _, err := scratch.formatPrimitiveValue(expression.GetTypedValue(), false); err != nil {
+			return "", fmt.Errorf("format set element #%d: %w", i, err)
+		}
+	}
+
+	if mode == LargeInSetModeValues {
+		result, err := pb.formatter.FormatInValues(value, scratch.args.Values())
+		if err != nil {
+			return "", fmt.Errorf("formatter format in values: %w", err)
+		}
+
+		return result, nil
+	}
+
+	array, err := makeTypedArray(scratch.args.Values())
+	if err != nil {
+		return "", fmt.Errorf("make array of type %v: %w", elementType, err)
+	}
+
+	pb.args.AddTyped(common.MakeListType(elementType), array)
+
+	result, err := pb.formatter.FormatInArray(value, pb.formatter.GetPlaceholder(pb.args.Count()-1))
+	if err != nil {
+		return "", fmt.Errorf("formatter format in array: %w", err)
+	}
+
+	return result, nil
+}
+
+// makeTypedArray converts the values of the same type into a slice of this type, that is understood by the drivers
+func makeTypedArray(values []any) (any, error) {
+	switch values[0].(type) {
+	case bool:
+		return makeTypedSlice[bool](values)
+	case int32:
+		return makeTypedSlice[int32](values)
+	case uint32:
+		return makeTypedSlice[uint32](values)
+	case int64:
+		return makeTypedSlice[int64](values)
+	case uint64:
+		return makeTypedSlice[uint64](values)
+	case float32:
+		return makeTypedSlice[float32](values)
+	case float64:
+		return makeTypedSlice[float64](values)
+	case []byte:
+		return makeTypedSlice[[]byte](values)
+	case string:
+		return makeTypedSlice[string](values)
+	case time.Time:
+		return makeTypedSlice[time.Time](values)
+	case time.Duration:
+		return makeTypedSlice[time.Duration](values)
+	default:
+		return nil, fmt.Errorf("unsupported value type '%T': %w", values[0], common.ErrUnimplementedTypedValue)
+	}
+}
+
+func makeTypedSlice[T any](values []any) ([]T, error) {
+	out := make([]T, len(values))
+
+	for i, value := range values {
+		typedValue, ok := value.(T)
+		if !ok {
+			return nil, fmt.Errorf("value #%d has type '%T' instead of '%T': %w", i, value, out[0], common.ErrDataTypeMismatch)
+		}
+
+		out[i] = typedValue
+	}
+
+	return out, nil
+}
+
 func (pb *predicateBuilder) formatCoalesce(
 	coalesce *api_service_protos.TPredicate_TCoalesce,
 ) (string, error) {
@@ -627,6 +919,11 @@ func (pb *predicateBuilder) formatPredicate(
 		if err != nil {
 			return "", fmt.Errorf("format expression: %w", err)
 		}
+	case *api_service_protos.TPredicate_In:
+		result, err = pb.formatIn(p.In, embedBool)
+		if err != nil {
+			return "", fmt.Errorf("format in: %w", err)
+		}
 	case *api_service_protos.TPredicate_Coalesce:
 		result, err = pb.formatCoalesce(p.Coalesce)
 		if err != nil {
@@ -644,18 +941,21 @@ func (pb *predicateBuilder) formatPredicate(
 	return result, nil
 }
 
+// formatWhereClause renders WHERE clause; the returned flag reports
+// whether the predicate has been pushed down entirely, without any parts omitted.
 func formatWhereClause(
 	logger *zap.Logger,
 	filtering api_service_protos.TReadSplitsRequest_EFiltering,
 	formatter SQLFormatter,
+	args *QueryArgs, // the arguments of the WHERE clause are appended to the given ones
 	where *api_service_protos.TSelect_TWhere,
 	dataSourceKind api_common.EGenericDataSourceKind, // remove after YQ-4191, KIKIMR-22852 is fixed
-) (string, *QueryArgs, error) {
+) (string, *QueryArgs, bool, error) {
 	if where.FilterTyped == nil {
-		return "", nil, fmt.Errorf("unexpected nil filter: %w", common.ErrInvalidRequest)
+		return "", nil, false, fmt.Errorf("unexpected nil filter: %w", common.ErrInvalidRequest)
 	}
 
-	pb := &predicateBuilder{formatter: formatter, args: &QueryArgs{}, dataSourceKind: dataSourceKind}
+	pb := &predicateBuilder{formatter: formatter, args: args, dataSourceKind: dataSourceKind}
 
 	clause, err := pb.formatPredicate(where.FilterTyped, true, false)
 
@@ -669,15 +969,83 @@ func formatWhereClause(
 
 		if common.OptionalFilteringAllowedErrors.Match(err) {
 			logger.Warn("considering pushdown error as acceptable", zap.Error(err))
-			return clause, pb.args, nil
+			return clause, pb.args, false, nil
 		}
 
-		return clause, pb.args, err
+		return clause, pb.args, err == nil && len(pb.conjunctionErrors) == 0, err
 	case api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY:
 		// Pushdowning every expression is mandatory in this mode.
 		// If connector doesn't support some types or expressions, the request will fail.
-		return clause, pb.args, err
+		return clause, pb.args, err == nil, err
 	default:
-		return "", nil, fmt.Errorf("unknown filtering mode: %d", filtering)
+		return "", nil, false, fmt.Errorf("unknown filtering mode: %d", filtering)
+	}
+}
+
+// NewPredicateChecker returns the checker of the predicates that can be rendered with the given formatter
+func NewPredicateChecker(
+	formatter SQLFormatter,
+	dataSourceKind api_common.EGenericDataSourceKind,
+) datasource.PredicateChecker {
+	return func(predicate *api_service_protos.TPredicate) error {
+		where := &api_service_protos.TSelect_TWhere{FilterTyped: predicate}
+		_, _, _, err := formatWhereClause(
+			zap.NewNop(), api_service_protos.TReadSplitsRequest_FILTERING_MANDATORY, formatter, &QueryArgs{}, where, dataSourceKind)
+
+		return err
 	}
 }
+
+// ProbePushdownCapabilities determines what can be pushed down to the data source with the given formatter.
+// LIMIT is rendered by every SQL formatter, but OFFSET is not applied to a part of the table,
+// so LIMIT is reported only for the data sources reading every table within a single split.
+func ProbePushdownCapabilities(
+	formatter SQLFormatter,
+	dataSourceKind api_common.EGenericDataSourceKind,
+	limit bool,
+) *api_service_protos.TPushdownCapabilities {
+	checkPredicate := NewPredicateChecker(formatter, dataSourceKind)
+
+	checkOrderBy := func(orderBy *api_service_protos.TSelect_TOrderBy) error {
+		_, err := formatter.FormatOrderBy(orderBy)
+
+		return err
+	}
+
+	checkWhat := func(what *api_service_protos.TSelect_TWhat) error {
+		_, _, err := formatWhat(formatter, &QueryArgs{}, what, "", dataSourceKind)
+
+		return err
+	}
+
+	out := datasource.ProbePushdownCapabilities(checkPredicate, checkOrderBy, checkWhat, limit)
+
+	if out.In {
+		out.MaxInSetSize = probeMaxInSetSize(checkPredicate, formatter, out.ValueTypes)
+	}
+
+	return out
+}
+
+// probeMaxInSetSize returns zero if the sets exceeding the limit on the number of parameters
+// can be pushed down for every value type, and the limit itself otherwise
+func probeMaxInSetSize(
+	checkPredicate datasource.PredicateChecker,
+	formatter SQLFormatter,
+	valueTypes []Ydb.Type_PrimitiveTypeId,
+) uint64 {
+	maxInSetParameters := formatter.MaxInSetParameters()
+
+	for _, typeID := range valueTypes {
+		// the types that cannot be used in IN predicates at all are not taken into account
+		if checkPredicate(datasource.MakeInPredicate(typeID, 1)) != nil {
+			continue
+		}
+
+		if checkPredicate(datasource.MakeInPredicate(typeID, maxInSetParameters+1)) != nil {
+			return uint64(maxInSetParameters)
+		}
+	}
+
+	return 0
+}
@@ -12,11 +12,12 @@ func _() {
 	_ = x[QueryPhaseDescribeTable-1]
 	_ = x[QueryPhaseListSplits-2]
 	_ = x[QueryPhaseReadSplits-3]
+	_ = x[QueryPhaseListTables-4]
 }
 
-const _QueryPhase_name = "QueryPhaseUnspecifiedQueryPhaseDescribeTableQueryPhaseListSplitsQueryPhaseReadSplits"
+const _QueryPhase_name = "QueryPhaseUnspecifiedQueryPhaseDescribeTableQueryPhaseListSplitsQueryPhaseReadSplitsQueryPhaseListTables"
 
-var _QueryPhase_index = [...]uint8{0, 21, 44, 64, 84}
+var _QueryPhase_index = [...]uint8{0, 21, 44, 64, 84, 104}
 
 func (i QueryPhase) String() string {
 	if i < 0 || i >= QueryPhase(len(_QueryPhase_index)-1) {
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
@@ -27,13 +28,13 @@ func makeTSelectTWhatForEmptyColumnsRequest() *api_service_protos.TSelect_TWhat
 
 func formatWhat(
 	formatter SQLFormatter,
+	args *QueryArgs, // the arguments of the computed columns are appended to the given ones
 	src *api_service_protos.TSelect_TWhat,
 	tableName string,
+	dataSourceKind api_common.EGenericDataSourceKind,
 ) (string, *api_service_protos.TSelect_TWhat, error) {
-	columns := common.SelectWhatToYDBColumns(src)
-
 	// If no columns were requested, select some constant to construct valid SQL statement
-	if len(columns) == 0 {
+	if len(src.GetItems()) == 0 {
 		// YQ-3314: is needed only in select COUNT(*) for ydb datasource.
 		// 		In PostgreSQL or ClickHouse type_mapper is based on typeNames that are extracted
 		// 		from column.DatabaseTypeName().
@@ -43,6 +44,18 @@ func formatWhat(
 		return "0", dst, nil
 	}
 
+	// Computed columns are rendered with the same machinery as the predicates
+	if common.SelectWhatHasComputedColumns(src) {
+		pb := &predicateBuilder{formatter: formatter, args: args, dataSourceKind: dataSourceKind}
+
+		out, err := pb.formatWhat(src)
+		if err != nil {
+			return "", nil, fmt.Errorf("format select with computed columns: %w", err)
+		}
+
+		return out, src, nil
+	}
+
 	out, err := formatter.FormatWhat(src, tableName)
 	if err != nil {
 		return "", nil, fmt.Errorf("format select: %w", err)
@@ -64,3 +77,41 @@ func FormatWhatDefault(formatter SQLFormatter, src *api_service_protos.TSelect_T
 
 	return sb.String()
 }
+
+// FormatOrderByDefault renders the sort keys in a way that most SQL dialects understand.
+// YQL treats NULL as the smallest value. Dialects that place NULLs differently by default
+// need explicit NULLS FIRST / NULLS LAST modifiers.
+func FormatOrderByDefault(
+	formatter SQLFormatter,
+	src *api_service_protos.TSelect_TOrderBy,
+	withNullsOrdering bool,
+) (string, error) {
+	var sb strings.Builder
+
+	for i, key := range src.GetKeys() {
+		if i != 0 {
+			sb.WriteString(", ")
+		}
+
+		sb.WriteString(formatter.SanitiseIdentifier(key.Column))
+
+		switch key.Order {
+		case api_service_protos.TSelect_TOrderBy_ASC:
+			sb.WriteString(" ASC")
+
+			if withNullsOrdering {
+				sb.WriteString(" NULLS FIRST")
+			}
+		case api_service_protos.TSelect_TOrderBy_DESC:
+			sb.WriteString(" DESC")
+
+			if withNullsOrdering {
+				sb.WriteString(" NULLS LAST")
+			}
+		default:
+			return "", fmt.Errorf("unknown sort order '%v' for column '%s': %w", key.Order, key.Column, common.ErrInvalidRequest)
+		}
+	}
+
+	return sb.String(), nil
+}
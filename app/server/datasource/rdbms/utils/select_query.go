@@ -16,6 +16,13 @@ type SelectQuery struct {
 	QueryParams
 	// Types and names of the columns that will be returned by the query in terms of YDB type system.
 	YdbColumns []*Ydb.Column
+	// The number of the leading columns requested by the client; the rest of the columns
+	// are the key columns added to the query to make the key cursor.
+	RequestedColumns int
+	// Positions of the key columns within the returned columns; empty if the query is made without a key cursor
+	KeyColumnIndices []int
+	// The LIMIT has been reached before the last key, so the query must not be executed
+	LimitExhausted bool
 }
 
 func MakeSelectQuery(
@@ -25,20 +32,53 @@ func MakeSelectQuery(
 	split *api_service_protos.TSplit,
 	filtering api_service_protos.TReadSplitsRequest_EFiltering,
 	tableName string,
+) (*SelectQuery, error) {
+	return MakeSelectQueryWithKeyCursor(ctx, logger, formatter, split, filtering, tableName, nil)
+}
+
+// MakeSelectQueryWithKeyCursor makes the query returning the rows in the order of the key
+// and following the last key of the cursor. The key columns are appended to the requested ones if necessary.
+// The split must not require any other ordering.
+//
+//nolint:funlen,gocyclo
+func MakeSelectQueryWithKeyCursor(
+	ctx context.Context,
+	logger *zap.Logger,
+	formatter SQLFormatter,
+	split *api_service_protos.TSplit,
+	filtering api_service_protos.TReadSplitsRequest_EFiltering,
+	tableName string,
+	keyCursor *KeyCursor,
 ) (*SelectQuery, error) {
 	var (
 		parts        SelectQueryParts
 		modifiedWhat *api_service_protos.TSelect_TWhat
-		err          error
+		// the arguments of the SELECT clause precede the ones of the WHERE clause
+		queryArgs        = &QueryArgs{}
+		what             = split.Select.What
+		keyColumnIndices []int
+		err              error
 	)
 
+	if keyCursor != nil {
+		if split.Select.GetOrderBy() != nil {
+			return nil, fmt.Errorf("key cursor cannot be used with the explicit ordering: %w", common.ErrInvalidRequest)
+		}
+
+		what, keyColumnIndices = addKeyColumns(what, keyCursor.Columns)
+	}
+
 	// Render SELECT clause
-	parts.SelectClause, modifiedWhat, err = formatWhat(formatter, split.Select.What, tableName)
+	parts.SelectClause, modifiedWhat, err = formatWhat(
+		formatter, queryArgs, what, tableName, split.Select.GetDataSourceInstance().GetKind())
 	if err != nil {
 		return nil, fmt.Errorf("format select clause: %w", err)
 	}
 
-	ydbColumns := common.SelectWhatToYDBColumns(modifiedWhat)
+	ydbColumns, err := common.SelectWhatToYDBColumns(modifiedWhat)
+	if err != nil {
+		return nil, fmt.Errorf("convert Select.What to YDB columns: %w", err)
+	}
 
 	// Render FROM clause
 	if tableName == "" {
@@ -52,12 +92,14 @@ func MakeSelectQuery(
 		return nil, fmt.Errorf("validate where clause: %w", err)
 	}
 
-	var queryArgs *QueryArgs
+	wherePushedEntirely := true
+
 	if split.Select.Where != nil {
-		parts.WhereClause, queryArgs, err = formatWhereClause(
+		parts.WhereClause, queryArgs, wherePushedEntirely, err = formatWhereClause(
 			logger,
 			filtering,
 			formatter,
+			queryArgs,
 			split.Select.Where,
 			split.Select.DataSourceInstance.Kind,
 		)
@@ -67,7 +109,50 @@ func MakeSelectQuery(
 		}
 	}
 
+	// Render the condition selecting the rows following the last delivered key
+	if len(keyCursor.GetLastKey()) > 0 {
+		keyClause, err := formatKeyCursorPredicate(formatter, queryArgs, keyCursor, split.Select.DataSourceInstance.Kind)
+		if err != nil {
+			return nil, fmt.Errorf("format key cursor predicate: %w", err)
+		}
+
+		if parts.WhereClause == "" {
+			parts.WhereClause = keyClause
+		} else {
+			parts.WhereClause = fmt.Sprintf("(%s) AND %s", parts.WhereClause, keyClause)
+		}
+	}
+
+	// Render ORDER BY clause; the data source must have rejected the ordering it can't provide while listing splits
+	orderBy := split.Select.GetOrderBy()
+	if keyCursor != nil {
+		orderBy = keyCursor.orderBy()
+	}
+
+	if orderBy != nil {
+		parts.OrderByClause, err = formatter.FormatOrderBy(orderBy)
+		if err != nil {
+			return nil, fmt.Errorf("format order by clause: %w", err)
+		}
+	}
+
+	// Render LIMIT and OFFSET
+	parts.Limit, parts.Offset = makeLimitOffset(logger, split, wherePushedEntirely)
+
+	// The rows delivered before the last key have already passed the OFFSET
+	limitExhausted := false
+
+	if parts.Limit != 0 && keyCursor.GetRowsDelivered() > 0 {
+		if keyCursor.GetRowsDelivered() >= parts.Limit {
+			limitExhausted = true
+		} else {
+			parts.Limit, parts.Offset = parts.Limit-keyCursor.GetRowsDelivered(), 0
+		}
+	}
+
 	// Render whole query
+	parts.QueryArgs = queryArgs
+
 	queryText, err := formatter.RenderSelectQueryText(&parts, split)
 	if err != nil {
 		return nil, fmt.Errorf("render query text: %w", err)
@@ -78,8 +163,40 @@ func MakeSelectQuery(
 			Ctx:       ctx,
 			Logger:    logger,
 			QueryText: queryText,
-			QueryArgs: queryArgs,
+			QueryArgs: parts.QueryArgs,
 		},
-		YdbColumns: ydbColumns,
+		YdbColumns:       ydbColumns,
+		RequestedColumns: len(split.Select.What.GetItems()),
+		KeyColumnIndices: keyColumnIndices,
+		LimitExhausted:   limitExhausted,
 	}, nil
 }
+
+// makeLimitOffset decides whether it's safe to push LIMIT and OFFSET down to the data source.
+// If some parts of the predicate are evaluated on the YDB side, the data source
+// must return all the rows, otherwise the rows matching the predicate may be lost.
+// OFFSET can be applied only when the whole table is read within a single split.
+func makeLimitOffset(
+	logger *zap.Logger,
+	split *api_service_protos.TSplit,
+	wherePushedEntirely bool,
+) (uint64, uint64) {
+	limit := split.Select.GetLimit()
+	if limit.GetLimit() == 0 {
+		return 0, 0
+	}
+
+	if !wherePushedEntirely {
+		logger.Warn("LIMIT pushdown is disabled because the WHERE clause has not been pushed down entirely")
+
+		return 0, 0
+	}
+
+	if limit.GetOffset() != 0 && len(split.GetDescription()) != 0 {
+		logger.Warn("LIMIT pushdown is disabled because OFFSET cannot be applied to a part of the table")
+
+		return 0, 0
+	}
+
+	return limit.GetLimit(), limit.GetOffset()
+}
@@ -21,6 +21,10 @@ func (defaultSplitProvider) ListSplits(
 	return nil
 }
 
+func (defaultSplitProvider) MaySplitTables() bool {
+	return false
+}
+
 func NewDefaultSplitProvider() SplitProvider {
 	return &defaultSplitProvider{}
 }
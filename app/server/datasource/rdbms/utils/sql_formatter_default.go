@@ -1,11 +1,13 @@
 package utils
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
 
@@ -31,9 +33,39 @@ func (SQLFormatterDefault) RenderSelectQueryText(
 		sb.WriteString(parts.WhereClause)
 	}
 
+	sb.WriteString(FormatOrderByClauseDefault(parts))
+	sb.WriteString(FormatLimitOffsetDefault(parts))
+
 	return sb.String(), nil
 }
 
+// FormatOrderByClauseDefault renders ` ORDER BY $order_by` suffix if the ordering was requested.
+func FormatOrderByClauseDefault(parts *SelectQueryParts) string {
+	if parts.OrderByClause == "" {
+		return ""
+	}
+
+	return " ORDER BY " + parts.OrderByClause
+}
+
+// FormatLimitOffsetDefault renders ` LIMIT $limit [OFFSET $offset]` suffix
+// that is understood by most of the SQL dialects.
+func FormatLimitOffsetDefault(parts *SelectQueryParts) string {
+	if parts.Limit == 0 {
+		return ""
+	}
+
+	if parts.Offset == 0 {
+		return fmt.Sprintf(" LIMIT %d", parts.Limit)
+	}
+
+	return fmt.Sprintf(" LIMIT %d OFFSET %d", parts.Limit, parts.Offset)
+}
+
+func (SQLFormatterDefault) FormatOrderBy(_ *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return "", common.ErrUnimplementedOperation
+}
+
 func (SQLFormatterDefault) FormatStartsWith(_, _ string) (string, error) {
 	return "", common.ErrUnimplementedOperation
 }
@@ -58,6 +90,65 @@ func (SQLFormatterDefault) FormatCast(_ string, _ *Ydb.Type) (string, error) {
 	return "", common.ErrUnimplementedOperation
 }
 
+func (SQLFormatterDefault) FormatDateTimeFunction(
+	_ api_service_protos.TExpression_TDateTimeFunction_EFunction,
+	_ string,
+) (string, error) {
+	return "", common.ErrUnimplementedOperation
+}
+
+func (SQLFormatterDefault) FormatComputedColumn(_ string, _ *Ydb.Type, _ string) (string, error) {
+	return "", common.ErrUnimplementedOperation
+}
+
+func (SQLFormatterDefault) LargeInSetMode() LargeInSetMode {
+	return LargeInSetModeNone
+}
+
+func (SQLFormatterDefault) FormatInArray(_, _ string) (string, error) {
+	return "", common.ErrUnimplementedOperation
+}
+
+func (SQLFormatterDefault) FormatInValues(_ string, _ []any) (string, error) {
+	return "", common.ErrUnimplementedOperation
+}
+
+// LargeInSetMode defines the way of passing the IN sets that would exceed the number of query parameters
+type LargeInSetMode int8
+
+const (
+	// The large sets are not pushed down
+	LargeInSetModeNone LargeInSetMode = iota
+	// The set is passed as a single parameter of array (list) type
+	LargeInSetModeArray
+	// The set is embedded into the query as a table of literal values
+	LargeInSetModeValues
+)
+
+// FormatIntegerLiteral renders the integer element of the IN set embedded into the query.
+// The other values are rejected unless the data source is able to render them itself.
+func FormatIntegerLiteral(value any) (string, error) {
+	switch value.(type) {
+	case int8, int16, int32, int64, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(value), nil
+	default:
+		return "", fmt.Errorf("literal of type '%T': %w", value, common.ErrUnsupportedExpression)
+	}
+}
+
+// defaultMaxInSetParameters keeps the number of query parameters
+// below the limits of the drivers (e.g. MS SQL Server allows 2100 parameters per query)
+const defaultMaxInSetParameters = 1000
+
+// MaxInSetParametersFromConfig returns the limit of the IN set parameters per query taken from the pushdown settings
+func MaxInSetParametersFromConfig(cfg *config.TPushdownConfig) int {
+	if cfg.GetMaxInSetParameters() == 0 {
+		return defaultMaxInSetParameters
+	}
+
+	return int(cfg.GetMaxInSetParameters())
+}
+
 func (SQLFormatterDefault) TransformPredicateComparison(src *api_service_protos.TPredicate_TComparison) (
 	*api_service_protos.TPredicate_TComparison, error) {
 	return src, nil
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+type defaultTableListProvider struct {
+	getArgsAndQuery func(request *api_service_protos.TListTablesRequest) (string, *QueryArgs)
+}
+
+var _ TableListProvider = (*defaultTableListProvider)(nil)
+
+func (f *defaultTableListProvider) ListTables(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn Connection,
+	request *api_service_protos.TListTablesRequest,
+) ([]string, error) {
+	query, args := f.getArgsAndQuery(request)
+
+	queryParams := &QueryParams{
+		Ctx:       ctx,
+		Logger:    logger,
+		QueryText: query,
+		QueryArgs: args,
+	}
+
+	rows, err := conn.Query(queryParams)
+	if err != nil {
+		return nil, fmt.Errorf("query builder error: %w", err)
+	}
+
+	defer func() { common.LogCloserError(logger, rows, "close rows") }()
+
+	var (
+		tableName *string
+		tables    []string
+	)
+
+	for rows.Next() {
+		if err = rows.Scan(&tableName); err != nil {
+			return nil, fmt.Errorf("rows scan: %w", err)
+		}
+
+		// some catalogs may contain objects without a name, they cannot be queried anyway
+		if tableName == nil {
+			continue
+		}
+
+		tables = append(tables, *tableName)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration: %w", err)
+	}
+
+	return tables, nil
+}
+
+func NewDefaultTableListProvider(
+	getArgsAndQueryFunc func(request *api_service_protos.TListTablesRequest) (string, *QueryArgs),
+) TableListProvider {
+	return &defaultTableListProvider{
+		getArgsAndQuery: getArgsAndQueryFunc,
+	}
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+func TestDefaultTableListProvider(t *testing.T) {
+	const query = "SELECT table_name FROM tables"
+
+	rows := &RowsMock{
+		PredefinedData: [][]any{{"a"}, {nil}, {"b"}},
+	}
+
+	rows.On("Next").Return(true).Times(3)
+	rows.On("Next").Return(false).Once()
+	rows.On("Scan", mock.Anything).Return(nil).Times(3)
+	rows.On("Err").Return(nil).Once()
+	rows.On("Close").Return(nil).Once()
+
+	conn := &ConnectionMock{}
+	conn.On("Query", query).Return(rows, nil).Once()
+
+	provider := NewDefaultTableListProvider(func(*api_service_protos.TListTablesRequest) (string, *QueryArgs) {
+		return query, &QueryArgs{}
+	})
+
+	tables, err := provider.ListTables(context.Background(), zap.NewNop(), conn, &api_service_protos.TListTablesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, tables)
+
+	mock.AssertExpectationsForObjects(t, conn, rows)
+}
@@ -36,6 +36,16 @@ func NewDefaultWhat() *api_service_protos.TSelect_TWhat {
 	}
 }
 
+// NewDefaultOrderBy sorts the columns of the default select in different directions
+func NewDefaultOrderBy() *api_service_protos.TSelect_TOrderBy {
+	return &api_service_protos.TSelect_TOrderBy{
+		Keys: []*api_service_protos.TSelect_TOrderBy_TSortKey{
+			{Column: "col0", Order: api_service_protos.TSelect_TOrderBy_DESC},
+			{Column: "col1", Order: api_service_protos.TSelect_TOrderBy_ASC},
+		},
+	}
+}
+
 func NewColumnExpression(name string) *api_service_protos.TExpression {
 	return &api_service_protos.TExpression{
 		Payload: &api_service_protos.TExpression_Column{
@@ -123,6 +133,27 @@ func NewNestedValueExpression(val string) *api_service_protos.TExpression {
 	}
 }
 
+// NewInPredicate generates `column IN set` predicate
+func NewInPredicate(column string, set ...*api_service_protos.TExpression) *api_service_protos.TPredicate {
+	return &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_In{
+			In: &api_service_protos.TPredicate_TIn{
+				Value: NewColumnExpression(column),
+				Set:   set,
+			},
+		},
+	}
+}
+
+// NewConjunctionPredicate generates conjunction of the predicates
+func NewConjunctionPredicate(operands ...*api_service_protos.TPredicate) *api_service_protos.TPredicate {
+	return &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Conjunction{
+			Conjunction: &api_service_protos.TPredicate_TConjunction{Operands: operands},
+		},
+	}
+}
+
 func MakeTestSplit() *api_service_protos.TSplit {
 	return &api_service_protos.TSplit{
 		Select: &api_service_protos.TSelect{
@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/apache/arrow/go/v13/arrow/array"
 	"go.uber.org/zap"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 	ydb_sdk "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	"github.com/ydb-platform/fq-connector-go/app/config"
@@ -34,7 +36,8 @@ func (r rowsDatabaseSQL) MakeTransformer(ydbColumns []*Ydb.Column, cc conversion
 		typeNames = append(typeNames, column.DatabaseTypeName())
 	}
 
-	transformer, err := transformerFromSQLTypes(typeNames, common.YDBColumnsToYDBTypes(ydbColumns), cc)
+	transformer, err := transformerFromSQLTypes(
+		typeNames, common.YDBColumnsToYDBTypes(ydbColumns), cc, makeDatabaseSQLValueAcceptorAppender)
 	if err != nil {
 		return nil, fmt.Errorf("transformer from sql types: %w", err)
 	}
@@ -42,6 +45,47 @@ func (r rowsDatabaseSQL) MakeTransformer(ydbColumns []*Ydb.Column, cc conversion
 	return transformer, nil
 }
 
+// makeDatabaseSQLValueAcceptorAppender makes the acceptor for whatever the driver returns.
+// The driver unwraps optional values, returns NULLs as nil and converts some of the types on its own:
+// DyNumber comes as a string, and Uuid comes as a wrapper around the bytes of both halves in big-endian order.
+func makeDatabaseSQLValueAcceptorAppender(ydbType *Ydb.Type) (any, func(acceptor any, builder array.Builder) error) {
+	appender := func(acceptor any, builder array.Builder) error {
+		//nolint:forcetypeassert
+		switch v := (*acceptor.(*any)).(type) {
+		case nil:
+			builder.AppendNull()
+		case string:
+			//nolint:forcetypeassert
+			builder.(*array.StringBuilder).Append(v)
+		case types.UUIDBytesWithIssue1501Type:
+			bigEndian := v.AsBytesArray()
+			littleEndian := make([]byte, len(bigEndian))
+
+			for i, b := range bigEndian {
+				littleEndian[len(bigEndian)-1-i] = b
+			}
+
+			//nolint:forcetypeassert
+			builder.(*array.FixedSizeBinaryBuilder).Append(littleEndian)
+		case types.Value:
+			value, err := valueToYDB(v)
+			if err != nil {
+				return fmt.Errorf("value to YDB: %w", err)
+			}
+
+			if err := appendValueToArrowBuilder(ydbType, value, builder); err != nil {
+				return fmt.Errorf("append value to Arrow builder: %w", err)
+			}
+		default:
+			return fmt.Errorf("unexpected value %v of type %T: %w", v, v, common.ErrDataTypeNotSupported)
+		}
+
+		return nil
+	}
+
+	return new(any), appender
+}
+
 var _ rdbms_utils.Connection = (*connectionDatabaseSQL)(nil)
 
 type connectionDatabaseSQL struct {
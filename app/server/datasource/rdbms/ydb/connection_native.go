@@ -8,11 +8,13 @@ import (
 	"io"
 	"time"
 
+	"github.com/apache/arrow/go/v13/arrow/array"
 	"go.uber.org/zap"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 	ydb_sdk "github.com/ydb-platform/ydb-go-sdk/v3"
 	ydb_sdk_query "github.com/ydb-platform/ydb-go-sdk/v3/query"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
 
 	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
@@ -87,7 +89,8 @@ func (r *rowsNative) MakeTransformer(ydbColumns []*Ydb.Column, cc conversion.Col
 		typeNames = append(typeNames, columnType.Yql())
 	}
 
-	transformer, err := transformerFromSQLTypes(typeNames, common.YDBColumnsToYDBTypes(ydbColumns), cc)
+	transformer, err := transformerFromSQLTypes(
+		typeNames, common.YDBColumnsToYDBTypes(ydbColumns), cc, makeNativeValueAcceptorAppender)
 	if err != nil {
 		return nil, fmt.Errorf("transformer from sql types: %w", err)
 	}
@@ -95,6 +98,26 @@ func (r *rowsNative) MakeTransformer(ydbColumns []*Ydb.Column, cc conversion.Col
 	return transformer, nil
 }
 
+// makeNativeValueAcceptorAppender makes the acceptor for the raw SDK value;
+// optional values keep their wrapper, so NULLs are handled by the value appender.
+func makeNativeValueAcceptorAppender(ydbType *Ydb.Type) (any, func(acceptor any, builder array.Builder) error) {
+	appender := func(acceptor any, builder array.Builder) error {
+		//nolint:forcetypeassert
+		value, err := valueToYDB(*acceptor.(*types.Value))
+		if err != nil {
+			return fmt.Errorf("value to YDB: %w", err)
+		}
+
+		if err := appendValueToArrowBuilder(ydbType, value, builder); err != nil {
+			return fmt.Errorf("append value to Arrow builder: %w", err)
+		}
+
+		return nil
+	}
+
+	return new(types.Value), appender
+}
+
 func (r *rowsNative) Err() error {
 	return r.err
 }
@@ -138,6 +161,18 @@ func (c *connectionNative) Query(params *rdbms_utils.QueryParams) (rdbms_utils.R
 			for i, arg := range params.QueryArgs.Values() {
 				placeholder := c.formatter.GetPlaceholder(i)
 
+				// large IN sets are passed as lists
+				if listType := params.QueryArgs.Get(i).YdbType.GetListType(); listType != nil {
+					listValue, err := makeListValue(arg, listType.Item)
+					if err != nil {
+						return fmt.Errorf("make list value: %w", err)
+					}
+
+					paramsBuilder = paramsBuilder.Param(placeholder).Any(listValue)
+
+					continue
+				}
+
 				switch t := arg.(type) {
 				case bool:
 					paramsBuilder = paramsBuilder.Param(placeholder).Bool(t)
@@ -195,6 +230,8 @@ func (c *connectionNative) Query(params *rdbms_utils.QueryParams) (rdbms_utils.R
 					switch params.QueryArgs.Get(i).YdbType.GetTypeId() {
 					case Ydb.Type_TIMESTAMP:
 						paramsBuilder = paramsBuilder.Param(placeholder).Timestamp(t)
+					case Ydb.Type_DATE:
+						paramsBuilder = paramsBuilder.Param(placeholder).Date(t)
 					default:
 						return fmt.Errorf("unsupported type: %v (%T): %w", arg, arg, common.ErrUnimplementedPredicateType)
 					}
@@ -202,6 +239,8 @@ func (c *connectionNative) Query(params *rdbms_utils.QueryParams) (rdbms_utils.R
 					switch params.QueryArgs.Get(i).YdbType.GetOptionalType().GetItem().GetTypeId() {
 					case Ydb.Type_TIMESTAMP:
 						paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Timestamp(t).EndOptional()
+					case Ydb.Type_DATE:
+						paramsBuilder = paramsBuilder.Param(placeholder).BeginOptional().Date(t).EndOptional()
 					default:
 						return fmt.Errorf("unsupported type: %v (%T): %w", arg, arg, common.ErrUnimplementedPredicateType)
 					}
@@ -294,6 +333,17 @@ func (c *connectionNative) rewriteQuery(params *rdbms_utils.QueryParams) (string
 	for i, arg := range params.QueryArgs.GetAll() {
 		var primitiveTypeID Ydb.Type_PrimitiveTypeId
 
+		if listType := arg.YdbType.GetListType(); listType != nil {
+			typeName, err := primitiveYqlTypeName(listType.Item.GetTypeId())
+			if err != nil {
+				return "", fmt.Errorf("get YQL type name from value %v: %w", arg, err)
+			}
+
+			buf.WriteString(fmt.Sprintf("DECLARE $p%d AS List<%s>;\n", i, typeName)) //nolint:revive
+
+			continue
+		}
+
 		if arg.YdbType.GetOptionalType() != nil {
 			internalType := arg.YdbType.GetOptionalType().GetItem()
 
@@ -324,6 +374,53 @@ func (c *connectionNative) rewriteQuery(params *rdbms_utils.QueryParams) (string
 	return buf.String(), nil
 }
 
+// makeListValue converts the array made of the IN set elements into YDB list
+func makeListValue(arg any, itemType *Ydb.Type) (types.Value, error) {
+	switch t := arg.(type) {
+	case []bool:
+		return makeListValueOf(t, types.BoolValue), nil
+	case []int32:
+		return makeListValueOf(t, types.Int32Value), nil
+	case []uint32:
+		return makeListValueOf(t, types.Uint32Value), nil
+	case []int64:
+		return makeListValueOf(t, types.Int64Value), nil
+	case []uint64:
+		return makeListValueOf(t, types.Uint64Value), nil
+	case []float32:
+		return makeListValueOf(t, types.FloatValue), nil
+	case []float64:
+		return makeListValueOf(t, types.DoubleValue), nil
+	case []string:
+		return makeListValueOf(t, types.TextValue), nil
+	case [][]byte:
+		return makeListValueOf(t, types.BytesValue), nil
+	case []time.Duration:
+		return makeListValueOf(t, types.IntervalValueFromDuration), nil
+	case []time.Time:
+		switch itemType.GetTypeId() {
+		case Ydb.Type_TIMESTAMP:
+			return makeListValueOf(t, types.TimestampValueFromTime), nil
+		case Ydb.Type_DATE:
+			return makeListValueOf(t, types.DateValueFromTime), nil
+		default:
+			return nil, fmt.Errorf("unsupported item type: %v: %w", itemType, common.ErrUnimplementedPredicateType)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type: %T: %w", arg, common.ErrUnimplementedPredicateType)
+	}
+}
+
+func makeListValueOf[T any](items []T, makeItem func(T) types.Value) types.Value {
+	values := make([]types.Value, len(items))
+
+	for i, item := range items {
+		values[i] = makeItem(item)
+	}
+
+	return types.ListValue(values...)
+}
+
 func (c *connectionNative) Logger() *zap.Logger {
 	return c.queryLogger.Logger
 }
@@ -0,0 +1,283 @@
+package ydb
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	table_options "github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+)
+
+// makeKeyRanges converts the boundaries of the table partitions into the ranges of the first primary key column values.
+// Composite key boundaries are truncated to the first column, so the neighbouring partitions
+// sharing the same value of the first column are read within the same split.
+func makeKeyRanges(
+	logger *zap.Logger,
+	desc *table_options.Description,
+	maxSplitCount uint64,
+) ([]*TSplitDescription_TDataShard_TKeyRange, error) {
+	if len(desc.PrimaryKey) == 0 || len(desc.KeyRanges) < 2 {
+		return nil, nil
+	}
+
+	columnName := desc.PrimaryKey[0]
+
+	var columnType *Ydb.Type
+
+	for _, column := range desc.Columns {
+		if column.Name == columnName {
+			columnType = column.Type.ToYDB()
+		}
+	}
+
+	if columnType == nil {
+		return nil, fmt.Errorf("primary key column '%s' is missing in table description", columnName)
+	}
+
+	typeID := unwrapOptionalType(columnType).GetTypeId()
+	if !supportsKeyRangeType(typeID) {
+		logger.Warn(
+			"key ranges are not supported for the type of the first primary key column, fallback to single split",
+			zap.String("column", columnName),
+			zap.Stringer("type", columnType),
+		)
+
+		return nil, nil
+	}
+
+	boundaries, err := makeKeyBoundaries(desc.KeyRanges)
+	if err != nil {
+		return nil, fmt.Errorf("make key boundaries: %w", err)
+	}
+
+	boundaries = selectKeyBoundaries(boundaries, maxSplitCount)
+
+	keyRanges := make([]*TSplitDescription_TDataShard_TKeyRange, 0, len(boundaries)+1)
+
+	for i := 0; i <= len(boundaries); i++ {
+		keyRange := &TSplitDescription_TDataShard_TKeyRange{ColumnName: columnName}
+
+		if i > 0 {
+			keyRange.From = &Ydb.TypedValue{Type: columnType, Value: boundaries[i-1]}
+		}
+
+		if i < len(boundaries) {
+			keyRange.To = &Ydb.TypedValue{Type: columnType, Value: boundaries[i]}
+		}
+
+		keyRanges = append(keyRanges, keyRange)
+	}
+
+	return keyRanges, nil
+}
+
+// makeKeyBoundaries extracts the distinct values of the first key column from the upper bounds of partitions.
+// NULL values are skipped, since NULL is the least value and it's always kept in the first range.
+func makeKeyBoundaries(keyRanges []table_options.KeyRange) ([]*Ydb.Value, error) {
+	var boundaries []*Ydb.Value
+
+	for _, keyRange := range keyRanges {
+		// The last partition is unbounded from above
+		if keyRange.To == nil {
+			continue
+		}
+
+		items, err := types.TupleItems(keyRange.To)
+		if err != nil {
+			return nil, fmt.Errorf("tuple items: %w", err)
+		}
+
+		if len(items) == 0 {
+			continue
+		}
+
+		var dst driver.Value
+
+		if err := types.CastTo(items[0], &dst); err != nil {
+			return nil, fmt.Errorf("cast to driver value: %w", err)
+		}
+
+		if dst == nil {
+			continue
+		}
+
+		boundary, err := makeKeyValue(dst)
+		if err != nil {
+			return nil, fmt.Errorf("make key value: %w", err)
+		}
+
+		if len(boundaries) > 0 && proto.Equal(boundaries[len(boundaries)-1], boundary) {
+			continue
+		}
+
+		boundaries = append(boundaries, boundary)
+	}
+
+	return boundaries, nil
+}
+
+// selectKeyBoundaries picks evenly distributed boundaries to make no more than maxSplitCount ranges
+func selectKeyBoundaries(boundaries []*Ydb.Value, maxSplitCount uint64) []*Ydb.Value {
+	rangeCount := uint64(len(boundaries)) + 1
+	if maxSplitCount == 0 || rangeCount <= maxSplitCount {
+		return boundaries
+	}
+
+	selected := make([]*Ydb.Value, 0, maxSplitCount-1)
+
+	for i := uint64(1); i < maxSplitCount; i++ {
+		selected = append(selected, boundaries[i*rangeCount/maxSplitCount-1])
+	}
+
+	return selected
+}
+
+func makeKeyValue(src driver.Value) (*Ydb.Value, error) {
+	switch v := src.(type) {
+	case bool:
+		return &Ydb.Value{Value: &Ydb.Value_BoolValue{BoolValue: v}}, nil
+	case int8:
+		return &Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: int32(v)}}, nil
+	case int16:
+		return &Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: int32(v)}}, nil
+	case int32:
+		return &Ydb.Value{Value: &Ydb.Value_Int32Value{Int32Value: v}}, nil
+	case int64:
+		return &Ydb.Value{Value: &Ydb.Value_Int64Value{Int64Value: v}}, nil
+	case uint8:
+		return &Ydb.Value{Value: &Ydb.Value_Uint32Value{Uint32Value: uint32(v)}}, nil
+	case uint16:
+		return &Ydb.Value{Value: &Ydb.Value_Uint32Value{Uint32Value: uint32(v)}}, nil
+	case uint32:
+		return &Ydb.Value{Value: &Ydb.Value_Uint32Value{Uint32Value: v}}, nil
+	case uint64:
+		return &Ydb.Value{Value: &Ydb.Value_Uint64Value{Uint64Value: v}}, nil
+	case string:
+		return &Ydb.Value{Value: &Ydb.Value_TextValue{TextValue: v}}, nil
+	case []byte:
+		return &Ydb.Value{Value: &Ydb.Value_BytesValue{BytesValue: v}}, nil
+	case time.Time:
+		return &Ydb.Value{Value: &Ydb.Value_Uint64Value{Uint64Value: uint64(v.UnixMicro())}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key value type: %T", src)
+	}
+}
+
+func supportsKeyRangeType(typeID Ydb.Type_PrimitiveTypeId) bool {
+	switch typeID {
+	case Ydb.Type_BOOL,
+		Ydb.Type_INT8, Ydb.Type_INT16, Ydb.Type_INT32, Ydb.Type_INT64,
+		Ydb.Type_UINT8, Ydb.Type_UINT16, Ydb.Type_UINT32, Ydb.Type_UINT64,
+		Ydb.Type_STRING, Ydb.Type_UTF8, Ydb.Type_TIMESTAMP:
+		return true
+	default:
+		return false
+	}
+}
+
+func unwrapOptionalType(t *Ydb.Type) *Ydb.Type {
+	if optionalType := t.GetOptionalType(); optionalType != nil {
+		return optionalType.GetItem()
+	}
+
+	return t
+}
+
+// formatKeyRange renders the key range as a predicate over the first primary key column.
+// The bounds are passed as typed query parameters: the values of String keys are arbitrary bytes,
+// and inlining them into the query text would require the exact reproduction of YQL literal escaping.
+func (f SQLFormatter) formatKeyRange(
+	keyRange *TSplitDescription_TDataShard_TKeyRange,
+	args *rdbms_utils.QueryArgs,
+) (string, error) {
+	if args == nil {
+		return "", fmt.Errorf("query arguments are not set")
+	}
+
+	column := f.SanitiseIdentifier(keyRange.ColumnName)
+
+	var from, to string
+
+	if keyRange.From != nil {
+		placeholder, err := f.addKeyArg(args, keyRange.From)
+		if err != nil {
+			return "", fmt.Errorf("add lower bound: %w", err)
+		}
+
+		from = fmt.Sprintf("%s >= %s", column, placeholder)
+	}
+
+	if keyRange.To != nil {
+		placeholder, err := f.addKeyArg(args, keyRange.To)
+		if err != nil {
+			return "", fmt.Errorf("add upper bound: %w", err)
+		}
+
+		to = fmt.Sprintf("%s < %s", column, placeholder)
+
+		// NULL is the least value, so it belongs to the first range
+		if keyRange.From == nil && keyRange.To.Type.GetOptionalType() != nil {
+			to = fmt.Sprintf("(%s IS NULL OR %s)", column, to)
+		}
+	}
+
+	switch {
+	case from != "" && to != "":
+		return from + " AND " + to, nil
+	case from != "":
+		return from, nil
+	case to != "":
+		return to, nil
+	default:
+		return "", fmt.Errorf("key range is unbounded from both sides")
+	}
+}
+
+// addKeyArg appends the key bound to the query arguments and returns its placeholder.
+// Bounds are never NULL, so they are passed with the non-optional type of the key column.
+func (f SQLFormatter) addKeyArg(args *rdbms_utils.QueryArgs, value *Ydb.TypedValue) (string, error) {
+	ydbType := unwrapOptionalType(value.Type)
+
+	var arg any
+
+	switch typeID := ydbType.GetTypeId(); typeID {
+	case Ydb.Type_BOOL:
+		arg = value.Value.GetBoolValue()
+	case Ydb.Type_INT8:
+		arg = int8(value.Value.GetInt32Value())
+	case Ydb.Type_INT16:
+		arg = int16(value.Value.GetInt32Value())
+	case Ydb.Type_INT32:
+		arg = value.Value.GetInt32Value()
+	case Ydb.Type_INT64:
+		arg = value.Value.GetInt64Value()
+	case Ydb.Type_UINT8:
+		arg = uint8(value.Value.GetUint32Value())
+	case Ydb.Type_UINT16:
+		arg = uint16(value.Value.GetUint32Value())
+	case Ydb.Type_UINT32:
+		arg = value.Value.GetUint32Value()
+	case Ydb.Type_UINT64:
+		arg = value.Value.GetUint64Value()
+	case Ydb.Type_STRING:
+		arg = value.Value.GetBytesValue()
+	case Ydb.Type_UTF8:
+		arg = value.Value.GetTextValue()
+	case Ydb.Type_TIMESTAMP:
+		arg = time.UnixMicro(int64(value.Value.GetUint64Value())).UTC()
+	default:
+		return "", fmt.Errorf("unsupported key type: %v", typeID)
+	}
+
+	placeholder := f.GetPlaceholder(args.Count())
+	args.AddTyped(ydbType, arg)
+
+	return placeholder, nil
+}
@@ -0,0 +1,168 @@
+package ydb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	ydb "github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	table_options "github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestMakeKeyRanges(t *testing.T) {
+	logger := common.NewTestLogger(t)
+
+	boundary := func(v int32) types.Value {
+		return types.TupleValue(types.OptionalValue(types.Int32Value(v)), types.NullValue(types.TypeUTF8))
+	}
+
+	desc := &table_options.Description{
+		PrimaryKey: []string{"id", "name"},
+		Columns: []table_options.Column{
+			{Name: "id", Type: types.Optional(types.TypeInt32)},
+			{Name: "name", Type: types.Optional(types.TypeUTF8)},
+		},
+		KeyRanges: []table_options.KeyRange{
+			{From: nil, To: boundary(10)},
+			{From: boundary(10), To: boundary(20)},
+			// partitions sharing the same value of the first key column are merged
+			{From: boundary(20), To: boundary(20)},
+			{From: boundary(20), To: boundary(30)},
+			{From: boundary(30), To: nil},
+		},
+	}
+
+	t.Run("all_partitions", func(t *testing.T) {
+		keyRanges, err := makeKeyRanges(logger, desc, 0)
+		require.NoError(t, err)
+		require.Len(t, keyRanges, 4)
+
+		require.Nil(t, keyRanges[0].From)
+		require.Equal(t, int32(10), keyRanges[0].To.Value.GetInt32Value())
+		require.Equal(t, int32(10), keyRanges[1].From.Value.GetInt32Value())
+		require.Equal(t, int32(20), keyRanges[1].To.Value.GetInt32Value())
+		require.Equal(t, int32(20), keyRanges[2].From.Value.GetInt32Value())
+		require.Equal(t, int32(30), keyRanges[2].To.Value.GetInt32Value())
+		require.Equal(t, int32(30), keyRanges[3].From.Value.GetInt32Value())
+		require.Nil(t, keyRanges[3].To)
+	})
+
+	t.Run("max_split_count", func(t *testing.T) {
+		keyRanges, err := makeKeyRanges(logger, desc, 2)
+		require.NoError(t, err)
+		require.Len(t, keyRanges, 2)
+
+		require.Nil(t, keyRanges[0].From)
+		require.Equal(t, int32(20), keyRanges[0].To.Value.GetInt32Value())
+		require.Equal(t, int32(20), keyRanges[1].From.Value.GetInt32Value())
+		require.Nil(t, keyRanges[1].To)
+	})
+
+	t.Run("single_split", func(t *testing.T) {
+		keyRanges, err := makeKeyRanges(logger, desc, 1)
+		require.NoError(t, err)
+		require.Len(t, keyRanges, 1)
+	})
+}
+
+func TestRenderSelectQueryTextWithKeyRange(t *testing.T) {
+	type testCase struct {
+		testName    string
+		keyRange    *TSplitDescription_TDataShard_TKeyRange
+		outputQuery string
+		outputArgs  []any
+	}
+
+	logger := common.NewTestLogger(t)
+	formatter := NewSQLFormatter(config.TYdbConfig_MODE_QUERY_SERVICE_NATIVE, &config.TPushdownConfig{})
+
+	optionalInt32 := common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_INT32))
+	utf8 := common.MakePrimitiveType(ydb.Type_UTF8)
+	str := common.MakePrimitiveType(ydb.Type_STRING)
+
+	tcs := []testCase{
+		{
+			testName: "first_range",
+			keyRange: &TSplitDescription_TDataShard_TKeyRange{
+				ColumnName: "id",
+				To:         common.MakeTypedValue(optionalInt32, int32(10)),
+			},
+			outputQuery: "SELECT `col0`, `col1` FROM `tab` WHERE (`id` IS NULL OR `id` < $p0)",
+			outputArgs:  []any{int32(10)},
+		},
+		{
+			testName: "middle_range",
+			keyRange: &TSplitDescription_TDataShard_TKeyRange{
+				ColumnName: "id",
+				From:       common.MakeTypedValue(optionalInt32, int32(10)),
+				To:         common.MakeTypedValue(optionalInt32, int32(20)),
+			},
+			outputQuery: "SELECT `col0`, `col1` FROM `tab` WHERE `id` >= $p0 AND `id` < $p1",
+			outputArgs:  []any{int32(10), int32(20)},
+		},
+		{
+			testName: "last_range_utf8",
+			keyRange: &TSplitDescription_TDataShard_TKeyRange{
+				ColumnName: "name",
+				From:       common.MakeTypedValue(utf8, "a\"b"),
+			},
+			outputQuery: "SELECT `col0`, `col1` FROM `tab` WHERE `name` >= $p0",
+			outputArgs:  []any{"a\"b"},
+		},
+		{
+			testName: "binary_string",
+			keyRange: &TSplitDescription_TDataShard_TKeyRange{
+				ColumnName: "name",
+				From:       &ydb.TypedValue{Type: str, Value: &ydb.Value{Value: &ydb.Value_BytesValue{BytesValue: []byte{0xff, '\\', 'x'}}}},
+				To:         &ydb.TypedValue{Type: str, Value: &ydb.Value{Value: &ydb.Value_BytesValue{BytesValue: []byte{0xff, 0x00}}}},
+			},
+			outputQuery: "SELECT `col0`, `col1` FROM `tab` WHERE `name` >= $p0 AND `name` < $p1",
+			outputArgs:  []any{[]byte{0xff, '\\', 'x'}, []byte{0xff, 0x00}},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			description, err := protojson.Marshal(&TSplitDescription{
+				Payload: &TSplitDescription_DataShard{
+					DataShard: &TSplitDescription_TDataShard{KeyRange: tc.keyRange},
+				},
+			})
+			require.NoError(t, err)
+
+			split := &api_service_protos.TSplit{
+				Select: &api_service_protos.TSelect{
+					From: &api_service_protos.TSelect_TFrom{Table: "tab"},
+					What: rdbms_utils.NewDefaultWhat(),
+					DataSourceInstance: &api_common.TGenericDataSourceInstance{
+						Kind: api_common.EGenericDataSourceKind_YDB,
+					},
+				},
+				Payload: &api_service_protos.TSplit_Description{Description: description},
+			}
+
+			readSplitsQuery, err := rdbms_utils.MakeSelectQuery(
+				context.Background(),
+				logger,
+				formatter,
+				split,
+				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+				"tab",
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputQuery, readSplitsQuery.QueryText)
+			require.Equal(t, tc.outputArgs, readSplitsQuery.QueryArgs.Values())
+		})
+	}
+}
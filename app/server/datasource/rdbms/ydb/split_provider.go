@@ -64,12 +64,12 @@ func (s SplitProvider) ListSplits(
 	conn := cs[0]
 
 	// Find out the type of a table
-	storeType, err := s.getTableStoreType(ctx, logger, conn)
+	desc, err := s.describeTable(ctx, logger, conn)
 	if err != nil {
-		return fmt.Errorf("get table store type: %w", err)
+		return fmt.Errorf("describe table: %w", err)
 	}
 
-	switch storeType {
+	switch desc.StoreType {
 	case table_options.StoreTypeColumn:
 		logger.Info("column shard table discovered")
 
@@ -85,45 +85,58 @@ func (s SplitProvider) ListSplits(
 				return fmt.Errorf("list single split: %w", err)
 			}
 		}
-	case table_options.StoreTypeRow:
-		logger.Info("data shard table discovered")
+	case table_options.StoreTypeRow, table_options.StoreTypeUnspecified:
+		// Unspecified store type is observed with OLTP tables at: 24.3.11.13
+		logger.Info("data shard table discovered", zap.Any("store_type", desc.StoreType))
 
-		if err = s.listSingleSplit(ctx, logger, conn, slct, resultChan); err != nil {
-			return fmt.Errorf("list splits column shard: %w", err)
-		}
-	case table_options.StoreTypeUnspecified:
-		// Observed with OLTP tables at: 24.3.11.13
-		logger.Warn("table store type is unspecified, fallback to default (single split per table)")
+		if s.cfg.EnabledOnDataShards {
+			maxSplitCount := uint64(params.Request.GetMaxSplitCount())
 
-		if err = s.listSingleSplit(ctx, logger, conn, slct, resultChan); err != nil {
-			return fmt.Errorf("list single split: %w", err)
+			if err = s.listSplitsDataShard(ctx, logger, conn, desc, maxSplitCount, slct, resultChan); err != nil {
+				return fmt.Errorf("list splits data shard: %w", err)
+			}
+		} else {
+			if err = s.listSingleSplit(ctx, logger, conn, slct, resultChan); err != nil {
+				return fmt.Errorf("list single split: %w", err)
+			}
 		}
 	default:
-		return fmt.Errorf("unsupported table store type: %v", storeType)
+		return fmt.Errorf("unsupported table store type: %v", desc.StoreType)
 	}
 
 	return nil
 }
 
-func (SplitProvider) getTableStoreType(
+// MaySplitTables always returns true, because even a single split of YDB table has a description
+func (SplitProvider) MaySplitTables() bool {
+	return true
+}
+
+func (s SplitProvider) describeTable(
 	ctx context.Context,
 	logger *zap.Logger,
 	conn rdbms_utils.Connection,
-) (table_options.StoreType, error) {
+) (*table_options.Description, error) {
 	var (
 		driver = conn.(Connection).Driver()
 		prefix = path.Join(conn.DataSourceInstance().Database, conn.TableName())
 		desc   table_options.Description
+		opts   []table_options.DescribeTableOption
 	)
 
-	logger.Debug("obtaining table store type", zap.String("prefix", prefix))
+	// Partition boundaries are required only for data shard splitting
+	if s.cfg.EnabledOnDataShards {
+		opts = append(opts, table_options.WithShardKeyBounds())
+	}
+
+	logger.Debug("describing table", zap.String("prefix", prefix))
 
 	err := driver.Table().Do(
 		ctx,
-		func(ctx context.Context, s table.Session) error {
+		func(ctx context.Context, session table.Session) error {
 			var errInner error
 
-			desc, errInner = s.DescribeTable(ctx, prefix)
+			desc, errInner = session.DescribeTable(ctx, prefix, opts...)
 			if errInner != nil {
 				return fmt.Errorf("describe table '%v': %w", prefix, errInner)
 			}
@@ -133,12 +146,12 @@ func (SplitProvider) getTableStoreType(
 		table.WithIdempotent(),
 	)
 	if err != nil {
-		return table_options.StoreTypeUnspecified, fmt.Errorf("get table description: %w", err)
+		return nil, fmt.Errorf("get table description: %w", err)
 	}
 
 	logger.Info("determined table store type", zap.Any("store_type", desc.StoreType))
 
-	return desc.StoreType, nil
+	return &desc, nil
 }
 
 func (s SplitProvider) listSplitsColumnShard(
@@ -183,6 +196,50 @@ func (s SplitProvider) listSplitsColumnShard(
 	return nil
 }
 
+func (s SplitProvider) listSplitsDataShard(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn rdbms_utils.Connection,
+	desc *table_options.Description,
+	maxSplitCount uint64,
+	slct *api_service_protos.TSelect,
+	resultChan chan<- *datasource.ListSplitResult,
+) error {
+	keyRanges, err := makeKeyRanges(logger, desc, maxSplitCount)
+	if err != nil {
+		return fmt.Errorf("make key ranges: %w", err)
+	}
+
+	// Tables consisting of a single partition are read within a single split
+	if len(keyRanges) < 2 {
+		if err := s.listSingleSplit(ctx, logger, conn, slct, resultChan); err != nil {
+			return fmt.Errorf("list single split: %w", err)
+		}
+
+		return nil
+	}
+
+	logger.Info("discovered data shard key ranges", zap.Int("total", len(keyRanges)))
+
+	for _, keyRange := range keyRanges {
+		description := &TSplitDescription{
+			Payload: &TSplitDescription_DataShard{
+				DataShard: &TSplitDescription_TDataShard{
+					KeyRange: keyRange,
+				},
+			},
+		}
+
+		select {
+		case resultChan <- makeSplit(slct, description):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
 func (SplitProvider) doQueryTabletIDs(
 	ctx context.Context,
 	session query.Session,
@@ -286,7 +343,7 @@ func (SplitProvider) listSingleSplit(
 	slct *api_service_protos.TSelect,
 	resultChan chan<- *datasource.ListSplitResult,
 ) error {
-	// Data shard splitting is not supported yet
+	// Data shard split without key range covers the whole table
 	splitDescription := &TSplitDescription{
 		Payload: &TSplitDescription_DataShard{
 			DataShard: &TSplitDescription_TDataShard{},
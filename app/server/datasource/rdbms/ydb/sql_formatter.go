@@ -11,6 +11,7 @@ import (
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
 	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ rdbms_utils.SQLFormatter = (*SQLFormatter)(nil)
@@ -54,6 +55,8 @@ func (f SQLFormatter) supportsTypeForPushdown(typeID Ydb.Type_PrimitiveTypeId) b
 		return false
 	case Ydb.Type_TIMESTAMP:
 		return f.cfg.EnableTimestampPushdown
+	case Ydb.Type_DATE:
+		return f.cfg.EnableTimestampPushdown
 	default:
 		return false
 	}
@@ -86,6 +89,8 @@ func (f SQLFormatter) SupportsExpression(expression *api_service_protos.TExpress
 		return f.SupportsExpression(e.If.ThenExpression) && f.SupportsExpression(e.If.ElseExpression)
 	case *api_service_protos.TExpression_Cast:
 		return f.SupportsExpression(e.Cast.Value)
+	case *api_service_protos.TExpression_DateTimeFunction:
+		return f.SupportsExpression(e.DateTimeFunction.Value)
 	default:
 		return false
 	}
@@ -140,7 +145,7 @@ func (f SQLFormatter) RenderSelectQueryText(
 	case *TSplitDescription_DataShard:
 		queryText, err = f.renderSelectQueryTextForDataShard(parts, splitDescription.GetDataShard())
 		if err != nil {
-			return "", fmt.Errorf("render select query text for column shard: %w", err)
+			return "", fmt.Errorf("render select query text for data shard: %w", err)
 		}
 	default:
 		return "", fmt.Errorf("unknown split description type: %T (%v)", t, t)
@@ -174,14 +179,32 @@ func (SQLFormatter) RenderSelectQueryTextForColumnShard(
 		sb.WriteString(parts.WhereClause)
 	}
 
+	sb.WriteString(rdbms_utils.FormatOrderByClauseDefault(parts))
+	sb.WriteString(rdbms_utils.FormatLimitOffsetDefault(parts))
+
 	return sb.String(), nil
 }
 
 func (f SQLFormatter) renderSelectQueryTextForDataShard(
 	parts *rdbms_utils.SelectQueryParts,
-	_ *TSplitDescription_TDataShard,
+	dataShard *TSplitDescription_TDataShard,
 ) (string, error) {
-	queryText, err := f.SQLFormatterDefault.RenderSelectQueryText(parts, nil)
+	modifiedParts := *parts
+
+	if keyRange := dataShard.GetKeyRange(); keyRange != nil {
+		condition, err := f.formatKeyRange(keyRange, modifiedParts.QueryArgs)
+		if err != nil {
+			return "", fmt.Errorf("format key range: %w", err)
+		}
+
+		if parts.WhereClause != "" {
+			modifiedParts.WhereClause = "(" + parts.WhereClause + ") AND " + condition
+		} else {
+			modifiedParts.WhereClause = condition
+		}
+	}
+
+	queryText, err := f.SQLFormatterDefault.RenderSelectQueryText(&modifiedParts, nil)
 	if err != nil {
 		return "", fmt.Errorf("default select query render: %w", err)
 	}
@@ -205,6 +228,43 @@ func (f SQLFormatter) FormatWhat(what *api_service_protos.TSelect_TWhat, _ strin
 	return rdbms_utils.FormatWhatDefault(f, what), nil
 }
 
+func (f SQLFormatter) FormatOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) (string, error) {
+	return rdbms_utils.FormatOrderByDefault(f, orderBy, false)
+}
+
+func (f SQLFormatter) MaxInSetParameters() int {
+	return rdbms_utils.MaxInSetParametersFromConfig(f.cfg)
+}
+
+func (SQLFormatter) LargeInSetMode() rdbms_utils.LargeInSetMode {
+	return rdbms_utils.LargeInSetModeArray
+}
+
+// FormatInArray passes the large IN sets as a single parameter of List type
+func (SQLFormatter) FormatInArray(value, arrayPlaceholder string) (string, error) {
+	return fmt.Sprintf("(%s IN %s)", value, arrayPlaceholder), nil
+}
+
+func (SQLFormatter) FormatDateTimeFunction(
+	function api_service_protos.TExpression_TDateTimeFunction_EFunction,
+	value string,
+) (string, error) {
+	switch function {
+	case api_service_protos.TExpression_TDateTimeFunction_TO_DATE:
+		return fmt.Sprintf("DateTime::MakeDate(%s)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_YEAR:
+		return fmt.Sprintf("DateTime::GetYear(%s)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_MONTH:
+		return fmt.Sprintf("DateTime::GetMonth(%s)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_DAY_OF_MONTH:
+		return fmt.Sprintf("DateTime::GetDayOfMonth(%s)", value), nil
+	case api_service_protos.TExpression_TDateTimeFunction_GET_HOUR:
+		return fmt.Sprintf("DateTime::GetHour(%s)", value), nil
+	default:
+		return "", fmt.Errorf("function %v: %w", function, common.ErrUnimplementedOperation)
+	}
+}
+
 func (SQLFormatter) FormatRegexp(left, right string) (string, error) {
 	return fmt.Sprintf("(%s REGEXP %s)", left, right), nil
 }
@@ -228,6 +288,29 @@ func (SQLFormatter) FormatCast(value string, ydbType *Ydb.Type) (string, error)
 	return fmt.Sprintf("CAST(%s AS %s)", value, typeName), nil
 }
 
+// FormatComputedColumn converts the expression to the inferred type. The inference follows
+// the rules of YQL, so the conversion changes nothing but the type of the parameters.
+func (SQLFormatter) FormatComputedColumn(expression string, ydbType *Ydb.Type, name string) (string, error) {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	var typeName string
+
+	if decimalType := ydbType.GetDecimalType(); decimalType != nil {
+		typeName = fmt.Sprintf("Decimal(%d, %d)", decimalType.Precision, decimalType.Scale)
+	} else {
+		var err error
+
+		typeName, err = primitiveYqlTypeName(ydbType.GetTypeId())
+		if err != nil {
+			return "", fmt.Errorf("primitive YQL type name: %v: %w", err, common.ErrUnsupportedExpression)
+		}
+	}
+
+	return fmt.Sprintf("CAST(%s AS %s) AS %s", expression, typeName, name), nil
+}
+
 func NewSQLFormatter(mode config.TYdbConfig_Mode, cfg *config.TPushdownConfig) SQLFormatter {
 	return SQLFormatter{
 		mode: mode,
@@ -503,6 +503,254 @@ func TestMakeSelectQuery(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			testName: "limit",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_YDB,
+				},
+			},
+			outputQuery:    "SELECT `col0`, `col1` FROM `tab` LIMIT 10",
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "limit_with_filter",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_IsNull{
+							IsNull: &api_service_protos.TPredicate_TIsNull{
+								Value: rdbms_utils.NewColumnExpression("col1"),
+							},
+						},
+					},
+				},
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_YDB,
+				},
+			},
+			outputQuery:    "SELECT `col0`, `col1` FROM `tab` WHERE (`col1` IS NULL) LIMIT 10",
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "limit_offset_within_split",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 5,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_YDB,
+				},
+			},
+			outputQuery:    "SELECT `col0`, `col1` FROM `tab`",
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "computed_columns",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: &api_service_protos.TSelect_TWhat{
+					Items: []*api_service_protos.TSelect_TWhat_TItem{
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+								Column: &ydb.Column{
+									Name: "col0",
+									Type: common.MakePrimitiveType(ydb.Type_INT32),
+								},
+							},
+						},
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_ComputedColumn{
+								ComputedColumn: &api_service_protos.TSelect_TWhat_TComputedColumn{
+									Name: "capped",
+									Expression: &api_service_protos.TExpression{
+										Payload: &api_service_protos.TExpression_If{
+											If: &api_service_protos.TExpression_TIf{
+												Predicate: &api_service_protos.TPredicate{
+													Payload: &api_service_protos.TPredicate_Comparison{
+														Comparison: &api_service_protos.TPredicate_TComparison{
+															Operation:  api_service_protos.TPredicate_TComparison_L,
+															LeftValue:  rdbms_utils.NewColumnExpression("col0"),
+															RightValue: rdbms_utils.NewInt32ValueExpression(100),
+														},
+													},
+												},
+												ThenExpression: rdbms_utils.NewColumnExpression("col0"),
+												ElseExpression: rdbms_utils.NewInt32ValueExpression(100),
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Payload: &api_service_protos.TSelect_TWhat_TItem_ComputedColumn{
+								ComputedColumn: &api_service_protos.TSelect_TWhat_TComputedColumn{
+									Name: "wide",
+									Expression: &api_service_protos.TExpression{
+										Payload: &api_service_protos.TExpression_Cast{
+											Cast: &api_service_protos.TExpression_TCast{
+												Value: rdbms_utils.NewColumnExpression("col1"),
+												Type:  common.MakePrimitiveType(ydb.Type_INT64),
+											},
+										},
+									},
+									// col1 is not selected, so the type must be given explicitly
+									Type: common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_INT64)),
+								},
+							},
+						},
+					},
+				},
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_Comparison{
+							Comparison: &api_service_protos.TPredicate_TComparison{
+								Operation:  api_service_protos.TPredicate_TComparison_G,
+								LeftValue:  rdbms_utils.NewColumnExpression("col0"),
+								RightValue: rdbms_utils.NewInt32ValueExpression(10),
+							},
+						},
+					},
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_YDB,
+				},
+			},
+			// the arguments of the SELECT clause go first
+			outputQuery: "SELECT `col0`, CAST(IF((`col0` < ?), `col0`, ?) AS Int32) AS `capped`, " +
+				"CAST(CAST(`col1` AS Int64) AS Int64) AS `wide` FROM `tab` WHERE (`col0` > ?)",
+			outputArgs: []any{int32(100), int32(100), int32(10)},
+			outputYdbTypes: []*ydb.Type{
+				common.MakePrimitiveType(ydb.Type_INT32),
+				common.MakePrimitiveType(ydb.Type_INT32),
+				common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_INT64)),
+			},
+			err: nil,
+		},
+		{
+			testName: "date_time_functions",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What: rdbms_utils.NewDefaultWhat(),
+				Where: &api_service_protos.TSelect_TWhere{
+					FilterTyped: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_Conjunction{
+							Conjunction: &api_service_protos.TPredicate_TConjunction{
+								Operands: []*api_service_protos.TPredicate{
+									{
+										Payload: &api_service_protos.TPredicate_Comparison{
+											Comparison: &api_service_protos.TPredicate_TComparison{
+												Operation: api_service_protos.TPredicate_TComparison_EQ,
+												LeftValue: &api_service_protos.TExpression{
+													Payload: &api_service_protos.TExpression_DateTimeFunction{
+														DateTimeFunction: &api_service_protos.TExpression_TDateTimeFunction{
+															Function: api_service_protos.TExpression_TDateTimeFunction_TO_DATE,
+															Value:    rdbms_utils.NewColumnExpression("col2"),
+														},
+													},
+												},
+												RightValue: &api_service_protos.TExpression{
+													Payload: &api_service_protos.TExpression_TypedValue{
+														TypedValue: &ydb.TypedValue{
+															Type:  common.MakePrimitiveType(ydb.Type_DATE),
+															Value: &ydb.Value{Value: &ydb.Value_Uint32Value{Uint32Value: 19723}},
+														},
+													},
+												},
+											},
+										},
+									},
+									{
+										Payload: &api_service_protos.TPredicate_Comparison{
+											Comparison: &api_service_protos.TPredicate_TComparison{
+												Operation: api_service_protos.TPredicate_TComparison_GE,
+												LeftValue: &api_service_protos.TExpression{
+													Payload: &api_service_protos.TExpression_DateTimeFunction{
+														DateTimeFunction: &api_service_protos.TExpression_TDateTimeFunction{
+															Function: api_service_protos.TExpression_TDateTimeFunction_GET_HOUR,
+															Value:    rdbms_utils.NewColumnExpression("col2"),
+														},
+													},
+												},
+												RightValue: &api_service_protos.TExpression{
+													Payload: &api_service_protos.TExpression_TypedValue{
+														TypedValue: &ydb.TypedValue{
+															Type:  common.MakePrimitiveType(ydb.Type_UINT8),
+															Value: &ydb.Value{Value: &ydb.Value_Uint32Value{Uint32Value: 9}},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_YDB,
+				},
+			},
+			outputQuery: "SELECT `col0`, `col1` FROM `tab` " +
+				"WHERE ((DateTime::MakeDate(`col2`) = ?) AND (DateTime::GetHour(`col2`) >= ?))",
+			outputArgs:     []any{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), uint32(9)},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
+		{
+			testName: "order_by_limit",
+			selectReq: &api_service_protos.TSelect{
+				From: &api_service_protos.TSelect_TFrom{
+					Table: "tab",
+				},
+				What:    rdbms_utils.NewDefaultWhat(),
+				OrderBy: rdbms_utils.NewDefaultOrderBy(),
+				Limit: &api_service_protos.TSelect_TLimit{
+					Limit:  10,
+					Offset: 0,
+				},
+				DataSourceInstance: &api_common.TGenericDataSourceInstance{
+					Kind: api_common.EGenericDataSourceKind_YDB,
+				},
+			},
+			outputQuery:    "SELECT `col0`, `col1` FROM `tab` ORDER BY `col0` DESC, `col1` ASC LIMIT 10",
+			outputArgs:     []any{},
+			outputYdbTypes: []*ydb.Type{common.MakePrimitiveType(ydb.Type_INT32), common.MakePrimitiveType(ydb.Type_STRING)},
+			err:            nil,
+		},
 	}
 
 	for _, tc := range tcs {
@@ -549,3 +797,103 @@ func TestMakeSelectQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeSelectQueryWithInSet(t *testing.T) {
+	type testCase struct {
+		testName    string
+		filter      *api_service_protos.TPredicate
+		outputQuery string
+		outputArgs  []any
+	}
+
+	logger := common.NewTestLogger(t)
+	formatter := NewSQLFormatter(config.TYdbConfig_MODE_QUERY_SERVICE_NATIVE, &config.TPushdownConfig{MaxInSetParameters: 2})
+
+	tcs := []testCase{
+		{
+			testName: "separate_parameters",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+			),
+			outputQuery: "SELECT `col0`, `col1` FROM `tab` WHERE (`col0` IN ($p0, $p1))",
+			outputArgs:  []any{int32(1), int32(2)},
+		},
+		{
+			testName: "list",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+				rdbms_utils.NewInt32ValueExpression(3),
+			),
+			outputQuery: "SELECT `col0`, `col1` FROM `tab` WHERE (`col0` IN $p0)",
+			outputArgs:  []any{[]int32{1, 2, 3}},
+		},
+		{
+			// the large set cannot be made of the values of different types, so the predicate is not pushed down
+			testName: "list_of_different_types",
+			filter: rdbms_utils.NewInPredicate("col0",
+				rdbms_utils.NewInt32ValueExpression(1),
+				rdbms_utils.NewInt32ValueExpression(2),
+				rdbms_utils.NewInt64ValueExpression(3),
+			),
+			outputQuery: "SELECT `col0`, `col1` FROM `tab`",
+			outputArgs:  []any{},
+		},
+		{
+			// the limit on the number of parameters applies to the whole query
+			testName: "parameters_per_query",
+			filter: rdbms_utils.NewConjunctionPredicate(
+				rdbms_utils.NewInPredicate("col0",
+					rdbms_utils.NewInt32ValueExpression(1),
+					rdbms_utils.NewInt32ValueExpression(2),
+				),
+				rdbms_utils.NewInPredicate("col0",
+					rdbms_utils.NewInt32ValueExpression(3),
+					rdbms_utils.NewInt32ValueExpression(4),
+				),
+			),
+			outputQuery: "SELECT `col0`, `col1` FROM `tab` WHERE ((`col0` IN ($p0, $p1)) AND (`col0` IN $p2))",
+			outputArgs:  []any{int32(1), int32(2), []int32{3, 4}},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.testName, func(t *testing.T) {
+			splitDescriptionBytes, err := protojson.Marshal(&TSplitDescription{
+				Payload: &TSplitDescription_DataShard{
+					DataShard: &TSplitDescription_TDataShard{},
+				},
+			})
+			require.NoError(t, err)
+
+			split := &api_service_protos.TSplit{
+				Select: &api_service_protos.TSelect{
+					From:  &api_service_protos.TSelect_TFrom{Table: "tab"},
+					What:  rdbms_utils.NewDefaultWhat(),
+					Where: &api_service_protos.TSelect_TWhere{FilterTyped: tc.filter},
+					DataSourceInstance: &api_common.TGenericDataSourceInstance{
+						Kind: api_common.EGenericDataSourceKind_YDB,
+					},
+				},
+				Payload: &api_service_protos.TSplit_Description{
+					Description: splitDescriptionBytes,
+				},
+			}
+
+			readSplitsQuery, err := rdbms_utils.MakeSelectQuery(
+				context.Background(),
+				logger,
+				formatter,
+				split,
+				api_service_protos.TReadSplitsRequest_FILTERING_OPTIONAL,
+				"tab",
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputQuery, readSplitsQuery.QueryText)
+			require.Equal(t, tc.outputArgs, readSplitsQuery.QueryArgs.Values())
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package ydb
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"go.uber.org/zap"
+
+	ydb_sdk "github.com/ydb-platform/ydb-go-sdk/v3"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	rdbms_utils "github.com/ydb-platform/fq-connector-go/app/server/datasource/rdbms/utils"
+)
+
+type tableListProvider struct {
+}
+
+var _ rdbms_utils.TableListProvider = (*tableListProvider)(nil)
+
+func (tableListProvider) ListTables(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn rdbms_utils.Connection,
+	_ *api_service_protos.TListTablesRequest,
+) ([]string, error) {
+	var (
+		driver   = conn.(Connection).Driver()
+		database = conn.DataSourceInstance().Database
+		tables   []string
+	)
+
+	logger.Debug("listing tables", zap.String("database", database))
+
+	if err := walkDirectory(ctx, driver, database, "", &tables); err != nil {
+		return nil, fmt.Errorf("walk directory '%s': %w", database, err)
+	}
+
+	return tables, nil
+}
+
+// walkDirectory recursively traverses the scheme tree and collects the paths of row and column tables
+// relative to the database root. Service directories (like `.sys`) are skipped.
+func walkDirectory(ctx context.Context, driver *ydb_sdk.Driver, database, relativePath string, tables *[]string) error {
+	dir, err := driver.Scheme().ListDirectory(ctx, path.Join(database, relativePath))
+	if err != nil {
+		return fmt.Errorf("list directory: %w", err)
+	}
+
+	for _, child := range dir.Children {
+		if strings.HasPrefix(child.Name, ".") {
+			continue
+		}
+
+		childPath := path.Join(relativePath, child.Name)
+
+		switch {
+		case child.IsTable(), child.IsColumnTable():
+			*tables = append(*tables, childPath)
+		case child.IsDirectory():
+			if err := walkDirectory(ctx, driver, database, childPath, tables); err != nil {
+				return fmt.Errorf("walk directory '%s': %w", childPath, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func NewTableListProvider() rdbms_utils.TableListProvider {
+	return &tableListProvider{}
+}
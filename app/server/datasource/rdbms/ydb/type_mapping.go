@@ -3,6 +3,8 @@ package ydb
 import (
 	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow/array"
@@ -43,6 +45,17 @@ const (
 	typeDatetime     = "Datetime"
 	typeTimestamp    = "Timestamp"
 	typeJSONDocument = "JsonDocument"
+	typeUUID         = "Uuid"
+	typeDyNumber     = "DyNumber"
+)
+
+const (
+	typeOptional = "Optional"
+	typeDecimal  = "Decimal"
+	typeList     = "List"
+	typeTuple    = "Tuple"
+	typeStruct   = "Struct"
+	typeDict     = "Dict"
 )
 
 func primitiveYqlTypeName(typeId Ydb.Type_PrimitiveTypeId) (string, error) {
@@ -73,6 +86,8 @@ func primitiveYqlTypeName(typeId Ydb.Type_PrimitiveTypeId) (string, error) {
 		return typeString, nil
 	case Ydb.Type_UTF8:
 		return typeUtf8, nil
+	case Ydb.Type_DATE:
+		return typeDate, nil
 	case Ydb.Type_TIMESTAMP:
 		return typeTimestamp, nil
 	default:
@@ -81,27 +96,172 @@ func primitiveYqlTypeName(typeId Ydb.Type_PrimitiveTypeId) (string, error) {
 }
 
 func (typeMapper) SQLTypeToYDBColumn(columnName, typeName string, _rules *api_service_protos.TTypeMappingSettings) (*Ydb.Column, error) {
+	ydbType, err := makeTypeFromString(typeName)
+	if err != nil {
+		return nil, fmt.Errorf("make type: %w", err)
+	}
+
+	return &Ydb.Column{Name: columnName, Type: ydbType}, nil
+}
+
+// makeTypeFromString parses the YQL type name like `Optional<List<Decimal(22,9)>>`
+//
+//nolint:gocyclo
+func makeTypeFromString(typeName string) (*Ydb.Type, error) {
+	name, args, err := splitTypeName(typeName)
+	if err != nil {
+		return nil, fmt.Errorf("split type name '%s': %w", typeName, err)
+	}
+
+	switch name {
+	case typeOptional, typeList:
+		if len(args) != 1 {
+			return nil, fmt.Errorf("type '%s' must have exactly one argument", typeName)
+		}
+
+		itemType, err := makeTypeFromString(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("make item type: %w", err)
+		}
+
+		if name == typeOptional {
+			return common.MakeOptionalType(itemType), nil
+		}
+
+		return common.MakeListType(itemType), nil
+	case typeTuple:
+		elements := make([]*Ydb.Type, 0, len(args))
+
+		for _, arg := range args {
+			element, err := makeTypeFromString(arg)
+			if err != nil {
+				return nil, fmt.Errorf("make tuple element type: %w", err)
+			}
+
+			elements = append(elements, element)
+		}
+
+		return common.MakeTupleType(elements), nil
+	case typeStruct:
+		members := make([]*Ydb.StructMember, 0, len(args))
+
+		for _, arg := range args {
+			member, err := makeStructMemberFromString(arg)
+			if err != nil {
+				return nil, fmt.Errorf("make struct member: %w", err)
+			}
+
+			members = append(members, member)
+		}
+
+		return common.MakeStructType(members), nil
+	case typeDict:
+		if len(args) != 2 {
+			return nil, fmt.Errorf("type '%s' must have exactly two arguments", typeName)
+		}
+
+		keyType, err := makeTypeFromString(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("make dict key type: %w", err)
+		}
+
+		payloadType, err := makeTypeFromString(args[1])
+		if err != nil {
+			return nil, fmt.Errorf("make dict payload type: %w", err)
+		}
+
+		return common.MakeDictType(keyType, payloadType), nil
+	case typeDecimal:
+		if len(args) != 2 {
+			return nil, fmt.Errorf("type '%s' must have precision and scale", typeName)
+		}
+
+		precision, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("parse decimal precision: %w", err)
+		}
+
+		scale, err := strconv.ParseUint(args[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("parse decimal scale: %w", err)
+		}
+
+		return common.MakeDecimalType(uint32(precision), uint32(scale)), nil
+	default:
+		if args != nil {
+			return nil, fmt.Errorf("convert type '%s': %w", typeName, common.ErrDataTypeNotSupported)
+		}
+
+		return makePrimitiveTypeFromString(name)
+	}
+}
+
+// splitTypeName splits the YQL type name into the name itself and the top level arguments
+// enclosed in the angle brackets or parentheses; args are nil for the types without them.
+func splitTypeName(typeName string) (string, []string, error) {
+	begin := strings.IndexAny(typeName, "<(")
+	if begin < 0 {
+		return typeName, nil, nil
+	}
+
+	closing := byte('>')
+	if typeName[begin] == '(' {
+		closing = ')'
+	}
+
+	if typeName[len(typeName)-1] != closing {
+		return "", nil, fmt.Errorf("unbalanced brackets")
+	}
+
 	var (
-		ydbType *Ydb.Type
-		err     error
+		args   []string
+		depth  int
+		quoted bool
+		start  = begin + 1
+		inner  = typeName[:len(typeName)-1]
 	)
 
-	optional := false
-	if matches := isOptional.FindStringSubmatch(typeName); len(matches) > 0 {
-		optional = true
-		typeName = matches[1]
+	for i := start; i < len(inner); i++ {
+		switch c := inner[i]; {
+		case c == '\'':
+			quoted = !quoted
+		case quoted:
+		case c == '<' || c == '(':
+			depth++
+		case c == '>' || c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			args = append(args, strings.TrimSpace(inner[start:i]))
+			start = i + 1
+		}
 	}
 
-	ydbType, err = makePrimitiveTypeFromString(typeName)
-	if err != nil {
-		return nil, fmt.Errorf("make type: %w", err)
+	if depth != 0 || quoted {
+		return "", nil, fmt.Errorf("unbalanced brackets")
 	}
 
-	if optional {
-		ydbType = common.MakeOptionalType(ydbType)
+	args = append(args, strings.TrimSpace(inner[start:]))
+
+	return typeName[:begin], args, nil
+}
+
+// makeStructMemberFromString parses the struct member written like `'name':Type`
+func makeStructMemberFromString(member string) (*Ydb.StructMember, error) {
+	if !strings.HasPrefix(member, "'") {
+		return nil, fmt.Errorf("member '%s' has no quoted name", member)
 	}
 
-	return &Ydb.Column{Name: columnName, Type: ydbType}, nil
+	name, typeName, found := strings.Cut(member[1:], "':")
+	if !found {
+		return nil, fmt.Errorf("member '%s' has no type", member)
+	}
+
+	ydbType, err := makeTypeFromString(typeName)
+	if err != nil {
+		return nil, fmt.Errorf("make type of member '%s': %w", name, err)
+	}
+
+	return &Ydb.StructMember{Name: name, Type: ydbType}, nil
 }
 
 //nolint:gocyclo
@@ -147,12 +307,26 @@ func makePrimitiveTypeFromString(typeName string) (*Ydb.Type, error) {
 	case typeJSONDocument:
 		// This inconsistency is due to KIKIMR-22201
 		return common.MakePrimitiveType(Ydb.Type_JSON), nil
+	case typeUUID:
+		return common.MakePrimitiveType(Ydb.Type_UUID), nil
+	case typeDyNumber:
+		// DyNumber has no counterpart in Arrow, so it is passed in its textual representation
+		return common.MakePrimitiveType(Ydb.Type_UTF8), nil
 	default:
 		return nil, fmt.Errorf("convert type '%s': %w", typeName, common.ErrDataTypeNotSupported)
 	}
 }
 
-func transformerFromSQLTypes(typeNames []string, ydbTypes []*Ydb.Type, cc conversion.Collection) (paging.RowTransformer[any], error) {
+// valueAcceptorAppenderFactory makes the acceptor and appender for the column read as a YDB value
+// rather than as a Go value of its own; its implementation depends on the kind of connection.
+type valueAcceptorAppenderFactory func(ydbType *Ydb.Type) (any, func(acceptor any, builder array.Builder) error)
+
+func transformerFromSQLTypes(
+	typeNames []string,
+	ydbTypes []*Ydb.Type,
+	cc conversion.Collection,
+	makeValueAcceptorAppender valueAcceptorAppenderFactory,
+) (paging.RowTransformer[any], error) {
 	acceptors := make([]any, 0, len(typeNames))
 	appenders := make([]func(acceptor any, builder array.Builder) error, 0, len(typeNames))
 
@@ -164,6 +338,15 @@ func transformerFromSQLTypes(typeNames []string, ydbTypes []*Ydb.Type, cc conver
 			optional = true
 		}
 
+		if isReadAsValue(typeName, ydbTypes[i]) {
+			acceptor, appender := makeValueAcceptorAppender(ydbTypes[i])
+
+			acceptors = append(acceptors, acceptor)
+			appenders = append(appenders, appender)
+
+			continue
+		}
+
 		ydbTypeID, err := common.YdbTypeToYdbPrimitiveTypeID(ydbTypes[i])
 		if err != nil {
 			return nil, fmt.Errorf("ydb type to ydb primitive type id: %w", err)
@@ -181,6 +364,22 @@ func transformerFromSQLTypes(typeNames []string, ydbTypes []*Ydb.Type, cc conver
 	return paging.NewRowTransformer[any](acceptors, appenders, nil), nil
 }
 
+// isReadAsValue checks if the column has no Go acceptor of its own: Uuid and DyNumber have no
+// suitable Go representation in the SDK, and decimals and containers are not primitive at all.
+func isReadAsValue(typeName string, ydbType *Ydb.Type) bool {
+	if typeName == typeUUID || typeName == typeDyNumber {
+		return true
+	}
+
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		ydbType = optionalType.Item
+	}
+
+	_, isPrimitive := ydbType.Type.(*Ydb.Type_TypeId)
+
+	return !isPrimitive
+}
+
 //nolint:gocyclo
 func makeAcceptorAppender(
 	typeName string,
@@ -0,0 +1,73 @@
+package ydb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ydb "github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func TestSQLTypeToYDBColumn(t *testing.T) {
+	rules := &api_service_protos.TTypeMappingSettings{DateTimeFormat: api_service_protos.EDateTimeFormat_YQL_FORMAT}
+
+	testCases := []struct {
+		typeName string
+		expected *ydb.Type
+	}{
+		{typeName: "Int32", expected: common.MakePrimitiveType(ydb.Type_INT32)},
+		{typeName: "Optional<Utf8>", expected: common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_UTF8))},
+		{typeName: "Optional<Decimal(22,9)>", expected: common.MakeOptionalType(common.MakeDecimalType(22, 9))},
+		{typeName: "Uuid", expected: common.MakePrimitiveType(ydb.Type_UUID)},
+		{typeName: "Optional<DyNumber>", expected: common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_UTF8))},
+		{
+			typeName: "List<Optional<Int64>>",
+			expected: common.MakeListType(common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_INT64))),
+		},
+		{
+			typeName: "Tuple<Int32,Decimal(10,2),String>",
+			expected: common.MakeTupleType([]*ydb.Type{
+				common.MakePrimitiveType(ydb.Type_INT32),
+				common.MakeDecimalType(10, 2),
+				common.MakePrimitiveType(ydb.Type_STRING),
+			}),
+		},
+		{
+			typeName: "Struct<'id':Uint64,'tags':List<Utf8>,'a,b<c>':Uuid>",
+			expected: common.MakeStructType([]*ydb.StructMember{
+				{Name: "id", Type: common.MakePrimitiveType(ydb.Type_UINT64)},
+				{Name: "tags", Type: common.MakeListType(common.MakePrimitiveType(ydb.Type_UTF8))},
+				{Name: "a,b<c>", Type: common.MakePrimitiveType(ydb.Type_UUID)},
+			}),
+		},
+		{
+			typeName: "Optional<Dict<Utf8,Tuple<Int32,Int32>>>",
+			expected: common.MakeOptionalType(common.MakeDictType(
+				common.MakePrimitiveType(ydb.Type_UTF8),
+				common.MakeTupleType([]*ydb.Type{
+					common.MakePrimitiveType(ydb.Type_INT32),
+					common.MakePrimitiveType(ydb.Type_INT32),
+				}),
+			)),
+		},
+	}
+
+	tm := NewTypeMapper()
+
+	for _, tc := range testCases {
+		column, err := tm.SQLTypeToYDBColumn("col", tc.typeName, rules)
+		require.NoError(t, err, tc.typeName)
+		require.Equal(t, tc.expected, column.Type, tc.typeName)
+	}
+
+	for _, typeName := range []string{"Interval", "List<Interval>", "Int32<Int32>", "List<Int32", "Decimal(x,1)"} {
+		_, err := tm.SQLTypeToYDBColumn("col", typeName, rules)
+		require.Error(t, err, typeName)
+	}
+
+	_, err := tm.SQLTypeToYDBColumn("col", "Set<Int32>", rules)
+	require.ErrorIs(t, err, common.ErrDataTypeNotSupported)
+}
@@ -0,0 +1,171 @@
+package ydb
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/apache/arrow/go/v13/arrow/array"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	ydb_sdk "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+const valueParamName = "$value"
+
+// valueToYDB serializes the value obtained from the SDK into the protobuf representation
+func valueToYDB(value types.Value) (*Ydb.Value, error) {
+	params, err := ydb_sdk.ParamsBuilder().Param(valueParamName).Any(value).Build().ToYDB()
+	if err != nil {
+		return nil, fmt.Errorf("params to YDB: %w", err)
+	}
+
+	return params[valueParamName].GetValue(), nil
+}
+
+// appendValueToArrowBuilder walks through the protobuf value of the given YDB type
+// and appends it to the Arrow builder made for this type.
+//
+//nolint:gocyclo
+func appendValueToArrowBuilder(ydbType *Ydb.Type, value *Ydb.Value, builder array.Builder) error {
+	switch t := ydbType.Type.(type) {
+	case *Ydb.Type_OptionalType:
+		switch v := value.Value.(type) {
+		case *Ydb.Value_NullFlagValue:
+			builder.AppendNull()
+
+			return nil
+		case *Ydb.Value_NestedValue:
+			return appendValueToArrowBuilder(t.OptionalType.Item, v.NestedValue, builder)
+		default:
+			return appendValueToArrowBuilder(t.OptionalType.Item, value, builder)
+		}
+	case *Ydb.Type_TaggedType:
+		return appendValueToArrowBuilder(t.TaggedType.Type, value, builder)
+	case *Ydb.Type_TypeId:
+		return appendPrimitiveValueToArrowBuilder(t.TypeId, value, builder)
+	case *Ydb.Type_DecimalType:
+		//nolint:forcetypeassert
+		builder.(*array.FixedSizeBinaryBuilder).Append(int128ToBytes(value))
+	case *Ydb.Type_ListType:
+		//nolint:forcetypeassert
+		listBuilder := builder.(*array.ListBuilder)
+		listBuilder.Append(true)
+
+		for i, item := range value.Items {
+			if err := appendValueToArrowBuilder(t.ListType.Item, item, listBuilder.ValueBuilder()); err != nil {
+				return fmt.Errorf("append item #%d: %w", i, err)
+			}
+		}
+	case *Ydb.Type_TupleType:
+		if len(value.Items) != len(t.TupleType.Elements) {
+			return fmt.Errorf("expected %d tuple elements, got %d", len(t.TupleType.Elements), len(value.Items))
+		}
+
+		//nolint:forcetypeassert
+		structBuilder := builder.(*array.StructBuilder)
+		structBuilder.Append(true)
+
+		for i, item := range value.Items {
+			if err := appendValueToArrowBuilder(t.TupleType.Elements[i], item, structBuilder.FieldBuilder(i)); err != nil {
+				return fmt.Errorf("append tuple element #%d: %w", i, err)
+			}
+		}
+	case *Ydb.Type_StructType:
+		if len(value.Items) != len(t.StructType.Members) {
+			return fmt.Errorf("expected %d struct members, got %d", len(t.StructType.Members), len(value.Items))
+		}
+
+		//nolint:forcetypeassert
+		structBuilder := builder.(*array.StructBuilder)
+		structBuilder.Append(true)
+
+		for i, item := range value.Items {
+			member := t.StructType.Members[i]
+
+			if err := appendValueToArrowBuilder(member.Type, item, structBuilder.FieldBuilder(i)); err != nil {
+				return fmt.Errorf("append struct member %s: %w", member.Name, err)
+			}
+		}
+	case *Ydb.Type_DictType:
+		//nolint:forcetypeassert
+		mapBuilder := builder.(*array.MapBuilder)
+		mapBuilder.Append(true)
+
+		for i, pair := range value.Pairs {
+			if err := appendValueToArrowBuilder(t.DictType.Key, pair.Key, mapBuilder.KeyBuilder()); err != nil {
+				return fmt.Errorf("append key of pair #%d: %w", i, err)
+			}
+
+			if err := appendValueToArrowBuilder(t.DictType.Payload, pair.Payload, mapBuilder.ItemBuilder()); err != nil {
+				return fmt.Errorf("append payload of pair #%d: %w", i, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unexpected type %v: %w", ydbType, common.ErrDataTypeNotSupported)
+	}
+
+	return nil
+}
+
+//nolint:gocyclo,forcetypeassert
+func appendPrimitiveValueToArrowBuilder(typeID Ydb.Type_PrimitiveTypeId, value *Ydb.Value, builder array.Builder) error {
+	switch typeID {
+	case Ydb.Type_BOOL:
+		var out uint8
+		if value.GetBoolValue() {
+			out = 1
+		}
+
+		builder.(*array.Uint8Builder).Append(out)
+	case Ydb.Type_INT8:
+		builder.(*array.Int8Builder).Append(int8(value.GetInt32Value()))
+	case Ydb.Type_INT16:
+		builder.(*array.Int16Builder).Append(int16(value.GetInt32Value()))
+	case Ydb.Type_INT32:
+		builder.(*array.Int32Builder).Append(value.GetInt32Value())
+	case Ydb.Type_INT64:
+		builder.(*array.Int64Builder).Append(value.GetInt64Value())
+	case Ydb.Type_UINT8:
+		builder.(*array.Uint8Builder).Append(uint8(value.GetUint32Value()))
+	case Ydb.Type_UINT16:
+		builder.(*array.Uint16Builder).Append(uint16(value.GetUint32Value()))
+	case Ydb.Type_UINT32:
+		builder.(*array.Uint32Builder).Append(value.GetUint32Value())
+	case Ydb.Type_UINT64:
+		builder.(*array.Uint64Builder).Append(value.GetUint64Value())
+	case Ydb.Type_FLOAT:
+		builder.(*array.Float32Builder).Append(value.GetFloatValue())
+	case Ydb.Type_DOUBLE:
+		builder.(*array.Float64Builder).Append(value.GetDoubleValue())
+	case Ydb.Type_STRING:
+		builder.(*array.BinaryBuilder).Append(value.GetBytesValue())
+	case Ydb.Type_UTF8, Ydb.Type_JSON:
+		// DyNumber and JsonDocument values are kept as text as well
+		builder.(*array.StringBuilder).Append(value.GetTextValue())
+	case Ydb.Type_DATE:
+		builder.(*array.Uint16Builder).Append(uint16(value.GetUint32Value()))
+	case Ydb.Type_DATETIME:
+		builder.(*array.Uint32Builder).Append(value.GetUint32Value())
+	case Ydb.Type_TIMESTAMP:
+		builder.(*array.Uint64Builder).Append(value.GetUint64Value())
+	case Ydb.Type_UUID:
+		builder.(*array.FixedSizeBinaryBuilder).Append(int128ToBytes(value))
+	default:
+		return fmt.Errorf("unexpected primitive type id %v: %w", typeID, common.ErrDataTypeNotSupported)
+	}
+
+	return nil
+}
+
+// int128ToBytes serializes the 128-bit value (Decimal or Uuid) into the little-endian bytes
+// which is exactly how YDB passes these types in Arrow.
+func int128ToBytes(value *Ydb.Value) []byte {
+	out := make([]byte, 16)
+	binary.LittleEndian.PutUint64(out[:8], value.GetLow_128())
+	binary.LittleEndian.PutUint64(out[8:], value.High_128)
+
+	return out
+}
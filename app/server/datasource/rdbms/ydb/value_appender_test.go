@@ -0,0 +1,143 @@
+package ydb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	ydb "github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+var testUUID = uuid.MustParse("00112233-4455-6677-8899-aabbccddeeff")
+
+func TestNativeValueAppender(t *testing.T) {
+	ydbTypes := []*ydb.Type{
+		common.MakeOptionalType(common.MakeDecimalType(22, 9)),
+		common.MakePrimitiveType(ydb.Type_UUID),
+		common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_UTF8)),
+		common.MakeListType(common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_INT32))),
+		common.MakeStructType([]*ydb.StructMember{
+			{Name: "id", Type: common.MakePrimitiveType(ydb.Type_UINT64)},
+			{Name: "pair", Type: common.MakeTupleType([]*ydb.Type{
+				common.MakePrimitiveType(ydb.Type_BOOL),
+				common.MakePrimitiveType(ydb.Type_STRING),
+			})},
+		}),
+		common.MakeDictType(common.MakePrimitiveType(ydb.Type_UTF8), common.MakePrimitiveType(ydb.Type_DOUBLE)),
+	}
+
+	rows := [][]types.Value{
+		{
+			types.OptionalValue(types.DecimalValueFromBigInt(big.NewInt(-1234567890123), 22, 9)),
+			types.UuidValue(testUUID),
+			types.OptionalValue(types.DyNumberValue(".123e3")),
+			types.ListValue(types.OptionalValue(types.Int32Value(1)), types.NullValue(types.TypeInt32)),
+			types.StructValue(
+				types.StructFieldValue("id", types.Uint64Value(42)),
+				types.StructFieldValue("pair", types.TupleValue(types.BoolValue(true), types.BytesValue([]byte("abc")))),
+			),
+			types.DictValue(types.DictFieldValue(types.TextValue("pi"), types.DoubleValue(3.14))),
+		},
+		{
+			types.NullValue(types.DecimalType(22, 9)),
+			types.UuidValue(testUUID),
+			types.NullValue(types.TypeDyNumber),
+			types.ListValue(),
+			types.StructValue(
+				types.StructFieldValue("id", types.Uint64Value(0)),
+				types.StructFieldValue("pair", types.TupleValue(types.BoolValue(false), types.BytesValue(nil))),
+			),
+			types.DictValue(),
+		},
+	}
+
+	builders, err := common.YdbTypesToArrowBuilders(ydbTypes, memory.NewGoAllocator())
+	require.NoError(t, err)
+
+	for _, row := range rows {
+		for i, value := range row {
+			acceptor, appender := makeNativeValueAcceptorAppender(ydbTypes[i])
+			*acceptor.(*types.Value) = value
+			require.NoError(t, appender(acceptor, builders[i]), value.Yql())
+		}
+	}
+
+	expectedDecimal, err := common.DecimalToBytes(big.NewInt(-1234567890123), 22)
+	require.NoError(t, err)
+
+	decimals := builders[0].NewArray().(*array.FixedSizeBinary)
+	require.Equal(t, expectedDecimal, decimals.Value(0))
+	require.True(t, decimals.IsNull(1))
+
+	uuids := builders[1].NewArray().(*array.FixedSizeBinary)
+	require.Equal(t, common.UUIDToYDBBytes(testUUID), uuids.Value(0))
+
+	dyNumbers := builders[2].NewArray().(*array.String)
+	require.Equal(t, ".123e3", dyNumbers.Value(0))
+	require.True(t, dyNumbers.IsNull(1))
+
+	lists := builders[3].NewArray().(*array.List)
+	require.Equal(t, `[[1 (null)] []]`, lists.String())
+
+	structs := builders[4].NewArray().(*array.Struct)
+	require.Equal(t, `{[42 0] {[1 0] ["abc" ""]}}`, structs.String())
+
+	maps := builders[5].NewArray().(*array.Map)
+	require.Equal(t, `[{["pi"] [3.14]} {[] []}]`, maps.String())
+}
+
+func TestDatabaseSQLValueAppender(t *testing.T) {
+	ydbTypes := []*ydb.Type{
+		common.MakeOptionalType(common.MakeDecimalType(22, 9)),
+		common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_UUID)),
+		common.MakeOptionalType(common.MakePrimitiveType(ydb.Type_UTF8)),
+	}
+
+	// the driver unwraps optional values and returns some of the types in its own representation;
+	// Uuid halves are put in big-endian order, which is the reverse of the YDB one
+	var uuidBytes [common.UUIDSize]byte
+
+	ydbBytes := common.UUIDToYDBBytes(testUUID)
+	for i, b := range ydbBytes {
+		uuidBytes[len(ydbBytes)-1-i] = b
+	}
+
+	row := []any{
+		types.DecimalValueFromBigInt(big.NewInt(1), 22, 9),
+		types.NewUUIDBytesWithIssue1501(uuidBytes),
+		".1e1",
+	}
+
+	builders, err := common.YdbTypesToArrowBuilders(ydbTypes, memory.NewGoAllocator())
+	require.NoError(t, err)
+
+	for _, values := range [][]any{row, {nil, nil, nil}} {
+		for i, value := range values {
+			acceptor, appender := makeDatabaseSQLValueAcceptorAppender(ydbTypes[i])
+			*acceptor.(*any) = value
+			require.NoError(t, appender(acceptor, builders[i]))
+		}
+	}
+
+	expectedDecimal, err := common.DecimalToBytes(big.NewInt(1), 22)
+	require.NoError(t, err)
+
+	decimals := builders[0].NewArray().(*array.FixedSizeBinary)
+	require.Equal(t, expectedDecimal, decimals.Value(0))
+	require.True(t, decimals.IsNull(1))
+
+	uuids := builders[1].NewArray().(*array.FixedSizeBinary)
+	require.Equal(t, common.UUIDToYDBBytes(testUUID), uuids.Value(0))
+	require.True(t, uuids.IsNull(1))
+
+	dyNumbers := builders[2].NewArray().(*array.String)
+	require.Equal(t, ".1e1", dyNumbers.Value(0))
+	require.True(t, dyNumbers.IsNull(1))
+}
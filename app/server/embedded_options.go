@@ -119,6 +119,18 @@ func WithObjectIdYQLType(yqlType config.TMongoDbConfig_EObjectIdYqlType) Embedde
 	return &withObjectIdYqlType{yqlType: yqlType}
 }
 
+type withOpenSearchScoreColumn struct {
+	expose bool
+}
+
+func (o *withOpenSearchScoreColumn) apply(cfg *config.TServerConfig) {
+	cfg.Datasources.Opensearch.ExposeScoreColumn = o.expose
+}
+
+func WithOpenSearchScoreColumn(expose bool) EmbeddedOption {
+	return &withOpenSearchScoreColumn{expose: expose}
+}
+
 type withPushdownConfig struct {
 	pushdownConfig *config.TPushdownConfig
 }
@@ -0,0 +1,4 @@
+// Package filtering contains the evaluator of the predicates over the Arrow records.
+// It's used to filter the rows on the connector side when the data source
+// is not able to push the predicate down entirely.
+package filtering
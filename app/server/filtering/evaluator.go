@@ -0,0 +1,454 @@
+package filtering
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// ternary is a result of a predicate evaluation in three-valued logic of SQL
+type ternary int8
+
+const (
+	ternaryFalse ternary = iota
+	ternaryTrue
+	ternaryUnknown
+)
+
+func makeTernary(value bool) ternary {
+	if value {
+		return ternaryTrue
+	}
+
+	return ternaryFalse
+}
+
+func (t ternary) not() ternary {
+	switch t {
+	case ternaryTrue:
+		return ternaryFalse
+	case ternaryFalse:
+		return ternaryTrue
+	default:
+		return ternaryUnknown
+	}
+}
+
+// evaluationContext provides the values of the current row
+type evaluationContext struct {
+	getters []func(row int) any // indexed by column number, nil for the columns not referenced by the predicate
+	row     int
+}
+
+type expressionEvaluator func(ctx *evaluationContext) any
+
+type predicateEvaluator func(ctx *evaluationContext) ternary
+
+// compiler turns the predicate into the evaluator
+type compiler struct {
+	columnIndices map[string]int
+	columnTypes   []valueType
+	// columns referenced by the compiled predicates
+	referencedColumns map[int]struct{}
+}
+
+//nolint:gocyclo
+func (c *compiler) compilePredicate(predicate *api_service_protos.TPredicate) (predicateEvaluator, error) {
+	switch p := predicate.GetPayload().(type) {
+	case *api_service_protos.TPredicate_Negation:
+		operand, err := c.compilePredicate(p.Negation.GetOperand())
+		if err != nil {
+			return nil, fmt.Errorf("compile negation operand: %w", err)
+		}
+
+		return func(ctx *evaluationContext) ternary { return operand(ctx).not() }, nil
+	case *api_service_protos.TPredicate_Conjunction:
+		operands, err := c.compilePredicates(p.Conjunction.GetOperands())
+		if err != nil {
+			return nil, fmt.Errorf("compile conjunction operands: %w", err)
+		}
+
+		return makeConjunction(operands), nil
+	case *api_service_protos.TPredicate_Disjunction:
+		operands, err := c.compilePredicates(p.Disjunction.GetOperands())
+		if err != nil {
+			return nil, fmt.Errorf("compile disjunction operands: %w", err)
+		}
+
+		return makeDisjunction(operands), nil
+	case *api_service_protos.TPredicate_Coalesce:
+		operands, err := c.compilePredicates(p.Coalesce.GetOperands())
+		if err != nil {
+			return nil, fmt.Errorf("compile coalesce operands: %w", err)
+		}
+
+		return makeCoalesce(operands), nil
+	case *api_service_protos.TPredicate_IsNull:
+		value, _, err := c.compileExpression(p.IsNull.GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("compile is null: %w", err)
+		}
+
+		return func(ctx *evaluationContext) ternary { return makeTernary(value(ctx) == nil) }, nil
+	case *api_service_protos.TPredicate_IsNotNull:
+		value, _, err := c.compileExpression(p.IsNotNull.GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("compile is not null: %w", err)
+		}
+
+		return func(ctx *evaluationContext) ternary { return makeTernary(value(ctx) != nil) }, nil
+	case *api_service_protos.TPredicate_Comparison:
+		result, err := c.compileComparison(p.Comparison)
+		if err != nil {
+			return nil, fmt.Errorf("compile comparison: %w", err)
+		}
+
+		return result, nil
+	case *api_service_protos.TPredicate_In:
+		result, err := c.compileIn(p.In)
+		if err != nil {
+			return nil, fmt.Errorf("compile in: %w", err)
+		}
+
+		return result, nil
+	case *api_service_protos.TPredicate_Between:
+		result, err := c.compileBetween(p.Between)
+		if err != nil {
+			return nil, fmt.Errorf("compile between: %w", err)
+		}
+
+		return result, nil
+	case *api_service_protos.TPredicate_BoolExpression:
+		value, vt, err := c.compileExpression(p.BoolExpression.GetValue())
+		if err != nil {
+			return nil, fmt.Errorf("compile bool expression: %w", err)
+		}
+
+		if vt.class != classBool && vt.class != classNull {
+			return nil, fmt.Errorf("bool expression of type %v: %w", vt.typeID, common.ErrUnsupportedExpression)
+		}
+
+		return func(ctx *evaluationContext) ternary {
+			result := value(ctx)
+			if result == nil {
+				return ternaryUnknown
+			}
+
+			return makeTernary(result.(bool))
+		}, nil
+	case *api_service_protos.TPredicate_Regexp:
+		result, err := c.compileRegexp(p.Regexp)
+		if err != nil {
+			return nil, fmt.Errorf("compile regexp: %w", err)
+		}
+
+		return result, nil
+	default:
+		return nil, fmt.Errorf("%w, type: %T", common.ErrUnimplementedPredicateType, p)
+	}
+}
+
+func (c *compiler) compilePredicates(predicates []*api_service_protos.TPredicate) ([]predicateEvaluator, error) {
+	out := make([]predicateEvaluator, 0, len(predicates))
+
+	for _, predicate := range predicates {
+		evaluator, err := c.compilePredicate(predicate)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, evaluator)
+	}
+
+	return out, nil
+}
+
+func makeConjunction(operands []predicateEvaluator) predicateEvaluator {
+	return func(ctx *evaluationContext) ternary {
+		result := ternaryTrue
+
+		for _, operand := range operands {
+			switch operand(ctx) {
+			case ternaryFalse:
+				return ternaryFalse
+			case ternaryUnknown:
+				result = ternaryUnknown
+			default:
+			}
+		}
+
+		return result
+	}
+}
+
+func makeDisjunction(operands []predicateEvaluator) predicateEvaluator {
+	return func(ctx *evaluationContext) ternary {
+		result := ternaryFalse
+
+		for _, operand := range operands {
+			switch operand(ctx) {
+			case ternaryTrue:
+				return ternaryTrue
+			case ternaryUnknown:
+				result = ternaryUnknown
+			default:
+			}
+		}
+
+		return result
+	}
+}
+
+func makeCoalesce(operands []predicateEvaluator) predicateEvaluator {
+	return func(ctx *evaluationContext) ternary {
+		for _, operand := range operands {
+			if result := operand(ctx); result != ternaryUnknown {
+				return result
+			}
+		}
+
+		return ternaryUnknown
+	}
+}
+
+//nolint:gocyclo
+func (c *compiler) compileComparison(comparison *api_service_protos.TPredicate_TComparison) (predicateEvaluator, error) {
+	left, leftType, err := c.compileExpression(comparison.GetLeftValue())
+	if err != nil {
+		return nil, fmt.Errorf("compile left expression: %w", err)
+	}
+
+	right, rightType, err := c.compileExpression(comparison.GetRightValue())
+	if err != nil {
+		return nil, fmt.Errorf("compile right expression: %w", err)
+	}
+
+	if !leftType.compatibleWith(rightType) {
+		return nil, fmt.Errorf(
+			"comparison of %v with %v: %w", leftType.typeID, rightType.typeID, common.ErrUnsupportedExpression)
+	}
+
+	var match func(l, r any) bool
+
+	switch op := comparison.GetOperation(); op {
+	case api_service_protos.TPredicate_TComparison_L:
+		match = func(l, r any) bool { return compareValues(l, r) < 0 }
+	case api_service_protos.TPredicate_TComparison_LE:
+		match = func(l, r any) bool { return compareValues(l, r) <= 0 }
+	case api_service_protos.TPredicate_TComparison_EQ:
+		match = func(l, r any) bool { return compareValues(l, r) == 0 }
+	case api_service_protos.TPredicate_TComparison_NE:
+		match = func(l, r any) bool { return compareValues(l, r) != 0 }
+	case api_service_protos.TPredicate_TComparison_GE:
+		match = func(l, r any) bool { return compareValues(l, r) >= 0 }
+	case api_service_protos.TPredicate_TComparison_G:
+		match = func(l, r any) bool { return compareValues(l, r) > 0 }
+	case api_service_protos.TPredicate_TComparison_IND, api_service_protos.TPredicate_TComparison_ID:
+		// NULL values are compared as the ordinary ones
+		distinct := op == api_service_protos.TPredicate_TComparison_ID
+
+		return func(ctx *evaluationContext) ternary {
+			l, r := left(ctx), right(ctx)
+
+			if l == nil || r == nil {
+				return makeTernary((l == nil && r == nil) != distinct)
+			}
+
+			return makeTernary((compareValues(l, r) == 0) != distinct)
+		}, nil
+	case api_service_protos.TPredicate_TComparison_STARTS_WITH,
+		api_service_protos.TPredicate_TComparison_ENDS_WITH,
+		api_service_protos.TPredicate_TComparison_CONTAINS:
+		if leftType.class != classString && leftType.class != classNull ||
+			rightType.class != classString && rightType.class != classNull {
+			return nil, fmt.Errorf("operation %s over non-string values: %w", op, common.ErrUnsupportedExpression)
+		}
+
+		match = makeStringMatcher(op)
+	default:
+		return nil, fmt.Errorf("operation %s: %w", op, common.ErrUnimplementedOperation)
+	}
+
+	return func(ctx *evaluationContext) ternary {
+		l, r := left(ctx), right(ctx)
+
+		if l == nil || r == nil {
+			return ternaryUnknown
+		}
+
+		return makeTernary(match(l, r))
+	}, nil
+}
+
+func makeStringMatcher(op api_service_protos.TPredicate_TComparison_EOperation) func(l, r any) bool {
+	var match func(s, substr string) bool
+
+	switch op {
+	case api_service_protos.TPredicate_TComparison_STARTS_WITH:
+		match = strings.HasPrefix
+	case api_service_protos.TPredicate_TComparison_ENDS_WITH:
+		match = strings.HasSuffix
+	default:
+		match = strings.Contains
+	}
+
+	return func(l, r any) bool { return match(l.(string), r.(string)) }
+}
+
+func (c *compiler) compileIn(in *api_service_protos.TPredicate_TIn) (predicateEvaluator, error) {
+	value, valueType, err := c.compileExpression(in.GetValue())
+	if err != nil {
+		return nil, fmt.Errorf("compile value: %w", err)
+	}
+
+	set := make([]expressionEvaluator, 0, len(in.GetSet()))
+
+	for i, item := range in.GetSet() {
+		itemEvaluator, itemType, err := c.compileExpression(item)
+		if err != nil {
+			return nil, fmt.Errorf("compile set item #%d: %w", i, err)
+		}
+
+		if !valueType.compatibleWith(itemType) {
+			return nil, fmt.Errorf(
+				"set item #%d of %v for value of %v: %w", i, itemType.typeID, valueType.typeID, common.ErrUnsupportedExpression)
+		}
+
+		set = append(set, itemEvaluator)
+	}
+
+	return func(ctx *evaluationContext) ternary {
+		v := value(ctx)
+		if v == nil {
+			return ternaryUnknown
+		}
+
+		result := ternaryFalse
+
+		for _, item := range set {
+			switch itemValue := item(ctx); {
+			case itemValue == nil:
+				result = ternaryUnknown
+			case compareValues(v, itemValue) == 0:
+				return ternaryTrue
+			}
+		}
+
+		return result
+	}, nil
+}
+
+func (c *compiler) compileBetween(between *api_service_protos.TPredicate_TBetween) (predicateEvaluator, error) {
+	value, valueType, err := c.compileExpression(between.GetValue())
+	if err != nil {
+		return nil, fmt.Errorf("compile value: %w", err)
+	}
+
+	least, leastType, err := c.compileExpression(between.GetLeast())
+	if err != nil {
+		return nil, fmt.Errorf("compile least: %w", err)
+	}
+
+	greatest, greatestType, err := c.compileExpression(between.GetGreatest())
+	if err != nil {
+		return nil, fmt.Errorf("compile greatest: %w", err)
+	}
+
+	if !valueType.compatibleWith(leastType) || !valueType.compatibleWith(greatestType) {
+		return nil, fmt.Errorf("bounds of value of %v: %w", valueType.typeID, common.ErrUnsupportedExpression)
+	}
+
+	return func(ctx *evaluationContext) ternary {
+		v, l, g := value(ctx), least(ctx), greatest(ctx)
+
+		// the same as `value >= least AND value <= greatest`
+		lower, upper := ternaryUnknown, ternaryUnknown
+
+		if v != nil && l != nil {
+			lower = makeTernary(compareValues(v, l) >= 0)
+		}
+
+		if v != nil && g != nil {
+			upper = makeTernary(compareValues(v, g) <= 0)
+		}
+
+		switch {
+		case lower == ternaryFalse || upper == ternaryFalse:
+			return ternaryFalse
+		case lower == ternaryUnknown || upper == ternaryUnknown:
+			return ternaryUnknown
+		default:
+			return ternaryTrue
+		}
+	}, nil
+}
+
+func (c *compiler) compileRegexp(predicate *api_service_protos.TPredicate_TRegexp) (predicateEvaluator, error) {
+	value, valueType, err := c.compileExpression(predicate.GetValue())
+	if err != nil {
+		return nil, fmt.Errorf("compile value: %w", err)
+	}
+
+	if valueType.class != classString && valueType.class != classNull {
+		return nil, fmt.Errorf("regexp over %v: %w", valueType.typeID, common.ErrUnsupportedExpression)
+	}
+
+	// only the constant patterns are supported, so they are compiled once
+	typedValue := predicate.GetPattern().GetTypedValue()
+	if typedValue == nil {
+		return nil, fmt.Errorf("non-constant pattern: %w", common.ErrUnsupportedExpression)
+	}
+
+	pattern, _, err := makeConstant(typedValue)
+	if err != nil {
+		return nil, fmt.Errorf("make pattern: %w", err)
+	}
+
+	patternString, ok := pattern.(string)
+	if !ok {
+		return nil, fmt.Errorf("pattern %v: %w", pattern, common.ErrUnsupportedExpression)
+	}
+
+	re, err := regexp.Compile(patternString)
+	if err != nil {
+		return nil, fmt.Errorf("compile pattern '%s': %v: %w", patternString, err, common.ErrUnsupportedExpression)
+	}
+
+	return func(ctx *evaluationContext) ternary {
+		v := value(ctx)
+		if v == nil {
+			return ternaryUnknown
+		}
+
+		return makeTernary(re.MatchString(v.(string)))
+	}, nil
+}
+
+func (c *compiler) compileExpression(expression *api_service_protos.TExpression) (expressionEvaluator, valueType, error) {
+	switch e := expression.GetPayload().(type) {
+	case *api_service_protos.TExpression_Column:
+		index, exists := c.columnIndices[e.Column]
+		if !exists {
+			return nil, valueType{}, fmt.Errorf(
+				"column '%s' is either not selected, or of unsupported type: %w", e.Column, common.ErrUnsupportedExpression)
+		}
+
+		c.referencedColumns[index] = struct{}{}
+
+		return func(ctx *evaluationContext) any { return ctx.getters[index](ctx.row) }, c.columnTypes[index], nil
+	case *api_service_protos.TExpression_TypedValue:
+		value, vt, err := makeConstant(e.TypedValue)
+		if err != nil {
+			return nil, valueType{}, fmt.Errorf("make constant: %w", err)
+		}
+
+		return func(*evaluationContext) any { return value }, vt, nil
+	case *api_service_protos.TExpression_Null:
+		return func(*evaluationContext) any { return nil }, valueType{class: classNull}, nil
+	default:
+		return nil, valueType{}, fmt.Errorf("%w, type: %T", common.ErrUnimplementedExpression, e)
+	}
+}
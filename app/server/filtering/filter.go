@@ -0,0 +1,288 @@
+package filtering
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"go.uber.org/zap"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+// Filter evaluates the residual predicate, i.e. the part of the WHERE clause
+// that has not been pushed down to the data source, over the Arrow records.
+// Only the rows satisfying the predicate are kept.
+type Filter struct {
+	evaluate          predicateEvaluator
+	columnTypes       []valueType
+	referencedColumns []int
+	arrowAllocator    memory.Allocator
+}
+
+// Selection describes the rows of a record as the ascending half-open ranges of their indices
+type Selection [][2]int64
+
+// Rows returns the number of the selected rows
+func (s Selection) Rows() int64 {
+	var rows int64
+
+	for _, r := range s {
+		rows += r[1] - r[0]
+	}
+
+	return rows
+}
+
+// Slice keeps only the selected rows with the ordinal numbers within [from, to),
+// e.g. Slice(0, 10) keeps the first ten selected rows.
+func (s Selection) Slice(from, to int64) Selection {
+	var (
+		result Selection
+		passed int64
+	)
+
+	for _, r := range s {
+		start, end := r[0], r[1]
+
+		// skip the rows preceding the slice
+		if passed+end-start <= from {
+			passed += end - start
+
+			continue
+		}
+
+		if passed < from {
+			start += from - passed
+			passed = from
+		}
+
+		if passed+end-start > to {
+			end = start + to - passed
+		}
+
+		if start < end {
+			result = append(result, [2]int64{start, end})
+		}
+
+		passed += end - start
+
+		if passed >= to {
+			break
+		}
+	}
+
+	return result
+}
+
+// Apply returns the record containing only the rows satisfying the predicate.
+// The caller is responsible for releasing both records.
+func (f *Filter) Apply(record arrow.Record) (arrow.Record, error) {
+	selection, err := f.Select(record)
+	if err != nil {
+		return nil, fmt.Errorf("select: %w", err)
+	}
+
+	return f.Take(record, selection)
+}
+
+// Select returns the rows of the record satisfying the predicate
+func (f *Filter) Select(record arrow.Record) (Selection, error) {
+	ctx := &evaluationContext{getters: make([]func(row int) any, record.NumCols())}
+
+	for _, index := range f.referencedColumns {
+		getter, err := makeGetter(f.columnTypes[index], record.Column(index))
+		if err != nil {
+			return nil, fmt.Errorf("make getter for column #%d: %w", index, err)
+		}
+
+		ctx.getters[index] = getter
+	}
+
+	var (
+		selection Selection
+		totalRows = int(record.NumRows())
+		start     = -1
+	)
+
+	for ctx.row = 0; ctx.row < totalRows; ctx.row++ {
+		if f.evaluate(ctx) == ternaryTrue {
+			if start < 0 {
+				start = ctx.row
+			}
+
+			continue
+		}
+
+		if start >= 0 {
+			selection = append(selection, [2]int64{int64(start), int64(ctx.row)})
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		selection = append(selection, [2]int64{int64(start), int64(totalRows)})
+	}
+
+	return selection, nil
+}
+
+// Take returns the record containing only the selected rows.
+// The caller is responsible for releasing both records.
+func (f *Filter) Take(record arrow.Record, selection Selection) (arrow.Record, error) {
+	keptRows := selection.Rows()
+
+	if keptRows == record.NumRows() {
+		record.Retain()
+
+		return record, nil
+	}
+
+	columns := make([]arrow.Array, 0, record.NumCols())
+
+	defer func() {
+		for _, column := range columns {
+			column.Release()
+		}
+	}()
+
+	for i, column := range record.Columns() {
+		filtered, err := f.filterArray(column, selection)
+		if err != nil {
+			return nil, fmt.Errorf("filter column #%d: %w", i, err)
+		}
+
+		columns = append(columns, filtered)
+	}
+
+	return array.NewRecord(record.Schema(), columns, keptRows), nil
+}
+
+func (f *Filter) filterArray(column arrow.Array, ranges Selection) (arrow.Array, error) {
+	switch len(ranges) {
+	case 0:
+		return array.NewSlice(column, 0, 0), nil
+	case 1:
+		return array.NewSlice(column, ranges[0][0], ranges[0][1]), nil
+	}
+
+	slices := make([]arrow.Array, 0, len(ranges))
+
+	defer func() {
+		for _, slice := range slices {
+			slice.Release()
+		}
+	}()
+
+	for _, r := range ranges {
+		slices = append(slices, array.NewSlice(column, r[0], r[1]))
+	}
+
+	out, err := array.Concatenate(slices, f.arrowAllocator)
+	if err != nil {
+		return nil, fmt.Errorf("concatenate: %w", err)
+	}
+
+	return out, nil
+}
+
+// NewResidualFilter makes the filter for the conjuncts of the WHERE clause that are not pushed down to the data source.
+// checkPushdown returns an error for the predicate that cannot be pushed down; nil means that nothing is pushed down.
+//
+// The returned filter is nil if there is nothing to evaluate on the connector side.
+// The flags report whether the data source pushes down the WHERE clause entirely
+// and whether the connector is able to evaluate all the rest.
+func NewResidualFilter(
+	logger *zap.Logger,
+	arrowAllocator memory.Allocator,
+	slct *api_service_protos.TSelect,
+	checkPushdown func(predicate *api_service_protos.TPredicate) error,
+) (filter *Filter, pushedEntirely, evaluatedEntirely bool) {
+	where := slct.GetWhere().GetFilterTyped()
+	if where == nil {
+		return nil, true, true
+	}
+
+	conjuncts := []*api_service_protos.TPredicate{where}
+	if conjunction := where.GetConjunction(); conjunction != nil {
+		conjuncts = conjunction.Operands
+	}
+
+	c := newCompiler(slct.GetWhat())
+
+	var evaluators []predicateEvaluator
+
+	pushedEntirely, evaluatedEntirely = true, true
+
+	for _, conjunct := range conjuncts {
+		if checkPushdown != nil && checkPushdown(conjunct) == nil {
+			continue
+		}
+
+		pushedEntirely = false
+
+		evaluator, err := c.compilePredicate(conjunct)
+		if err != nil {
+			logger.Debug("predicate cannot be evaluated on the connector side", zap.Error(err))
+
+			evaluatedEntirely = false
+
+			continue
+		}
+
+		evaluators = append(evaluators, evaluator)
+	}
+
+	if len(evaluators) == 0 {
+		return nil, pushedEntirely, evaluatedEntirely
+	}
+
+	// Rows without columns cannot be filtered anyway
+	if len(c.columnTypes) == 0 {
+		return nil, pushedEntirely, false
+	}
+
+	referencedColumns := make([]int, 0, len(c.referencedColumns))
+	for index := range c.referencedColumns {
+		referencedColumns = append(referencedColumns, index)
+	}
+
+	sort.Ints(referencedColumns)
+
+	filter = &Filter{
+		evaluate:          makeConjunction(evaluators),
+		columnTypes:       c.columnTypes,
+		referencedColumns: referencedColumns,
+		arrowAllocator:    arrowAllocator,
+	}
+
+	return filter, pushedEntirely, evaluatedEntirely
+}
+
+func newCompiler(what *api_service_protos.TSelect_TWhat) *compiler {
+	c := &compiler{
+		columnIndices:     make(map[string]int, len(what.GetItems())),
+		columnTypes:       make([]valueType, len(what.GetItems())),
+		referencedColumns: make(map[int]struct{}),
+	}
+
+	for i, item := range what.GetItems() {
+		column := item.GetColumn()
+		if column == nil {
+			continue
+		}
+
+		vt, err := makeValueType(column.GetType())
+		if err != nil {
+			// the predicates over the columns of the unsupported types cannot be evaluated
+			continue
+		}
+
+		c.columnIndices[column.Name] = i
+		c.columnTypes[i] = vt
+	}
+
+	return c
+}
@@ -0,0 +1,260 @@
+package filtering
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+func makeTestSelect(where *api_service_protos.TPredicate) *api_service_protos.TSelect {
+	column := func(name string, ydbType *Ydb.Type) *api_service_protos.TSelect_TWhat_TItem {
+		return &api_service_protos.TSelect_TWhat_TItem{
+			Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+				Column: &Ydb.Column{Name: name, Type: ydbType},
+			},
+		}
+	}
+
+	return &api_service_protos.TSelect{
+		What: &api_service_protos.TSelect_TWhat{
+			Items: []*api_service_protos.TSelect_TWhat_TItem{
+				column("id", common.MakePrimitiveType(Ydb.Type_INT32)),
+				column("name", common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8))),
+				column("flag", common.MakePrimitiveType(Ydb.Type_BOOL)),
+			},
+		},
+		Where: &api_service_protos.TSelect_TWhere{FilterTyped: where},
+	}
+}
+
+// makeTestRecord builds the record with the rows (1, "a", true), (2, NULL, false), (3, "b", true), (4, "ab", false)
+func makeTestRecord(allocator memory.Allocator) arrow.Record {
+	ids := array.NewInt32Builder(allocator)
+	defer ids.Release()
+	ids.AppendValues([]int32{1, 2, 3, 4}, nil)
+
+	names := array.NewStringBuilder(allocator)
+	defer names.Release()
+	names.AppendValues([]string{"a", "", "b", "ab"}, []bool{true, false, true, true})
+
+	flags := array.NewUint8Builder(allocator)
+	defer flags.Release()
+	flags.AppendValues([]uint8{1, 0, 1, 0}, nil)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "flag", Type: arrow.PrimitiveTypes.Uint8},
+	}, nil)
+
+	columns := []arrow.Array{ids.NewArray(), names.NewArray(), flags.NewArray()}
+	defer func() {
+		for _, column := range columns {
+			column.Release()
+		}
+	}()
+
+	return array.NewRecord(schema, columns, 4)
+}
+
+func columnExpression(name string) *api_service_protos.TExpression {
+	return &api_service_protos.TExpression{
+		Payload: &api_service_protos.TExpression_Column{Column: name},
+	}
+}
+
+func valueExpression(ydbType Ydb.Type_PrimitiveTypeId, value any) *api_service_protos.TExpression {
+	return &api_service_protos.TExpression{
+		Payload: &api_service_protos.TExpression_TypedValue{
+			TypedValue: common.MakeTypedValue(common.MakePrimitiveType(ydbType), value),
+		},
+	}
+}
+
+func comparison(
+	op api_service_protos.TPredicate_TComparison_EOperation,
+	left, right *api_service_protos.TExpression,
+) *api_service_protos.TPredicate {
+	return &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Comparison{
+			Comparison: &api_service_protos.TPredicate_TComparison{Operation: op, LeftValue: left, RightValue: right},
+		},
+	}
+}
+
+func conjunction(operands ...*api_service_protos.TPredicate) *api_service_protos.TPredicate {
+	return &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Conjunction{
+			Conjunction: &api_service_protos.TPredicate_TConjunction{Operands: operands},
+		},
+	}
+}
+
+func negation(operand *api_service_protos.TPredicate) *api_service_protos.TPredicate {
+	return &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Negation{
+			Negation: &api_service_protos.TPredicate_TNegation{Operand: operand},
+		},
+	}
+}
+
+func TestFilter(t *testing.T) {
+	type testCase struct {
+		name string
+		// checks the predicates pushed down to the data source
+		checkPushdown     func(predicate *api_service_protos.TPredicate) error
+		where             *api_service_protos.TPredicate
+		pushedEntirely    bool
+		evaluatedEntirely bool
+		expectedIDs       []int32 // nil if filter is not expected
+	}
+
+	pushNothing := func(*api_service_protos.TPredicate) error { return errors.New("not supported") }
+
+	pushIDComparisons := func(predicate *api_service_protos.TPredicate) error {
+		if predicate.GetComparison().GetLeftValue().GetColumn() == "id" {
+			return nil
+		}
+
+		return errors.New("not supported")
+	}
+
+	tcs := []testCase{
+		{
+			name:          "pushed entirely",
+			checkPushdown: pushIDComparisons,
+			where: comparison(
+				api_service_protos.TPredicate_TComparison_G, columnExpression("id"), valueExpression(Ydb.Type_INT32, int32(1))),
+			pushedEntirely:    true,
+			evaluatedEntirely: true,
+		},
+		{
+			name:          "comparison",
+			checkPushdown: nil,
+			where: comparison(
+				api_service_protos.TPredicate_TComparison_GE, columnExpression("id"), valueExpression(Ydb.Type_INT64, int64(3))),
+			evaluatedEntirely: true,
+			expectedIDs:       []int32{3, 4},
+		},
+		{
+			name:          "pushed partially",
+			checkPushdown: pushIDComparisons,
+			where: conjunction(
+				comparison(api_service_protos.TPredicate_TComparison_G, columnExpression("id"), valueExpression(Ydb.Type_INT32, int32(1))),
+				&api_service_protos.TPredicate{
+					Payload: &api_service_protos.TPredicate_BoolExpression{
+						BoolExpression: &api_service_protos.TPredicate_TBoolExpression{Value: columnExpression("flag")},
+					},
+				},
+			),
+			evaluatedEntirely: true,
+			// the comparison is pushed down, so the first row is kept by the filter
+			expectedIDs: []int32{1, 3},
+		},
+		{
+			name:          "three-valued logic",
+			checkPushdown: pushNothing,
+			where: negation(
+				comparison(api_service_protos.TPredicate_TComparison_EQ, columnExpression("name"), valueExpression(Ydb.Type_UTF8, "a"))),
+			evaluatedEntirely: true,
+			expectedIDs:       []int32{3, 4},
+		},
+		{
+			name:          "string matching",
+			checkPushdown: pushNothing,
+			where: comparison(
+				api_service_protos.TPredicate_TComparison_STARTS_WITH, columnExpression("name"), valueExpression(Ydb.Type_UTF8, "a")),
+			evaluatedEntirely: true,
+			expectedIDs:       []int32{1, 4},
+		},
+		{
+			name:          "all rows kept",
+			checkPushdown: pushNothing,
+			where: &api_service_protos.TPredicate{
+				Payload: &api_service_protos.TPredicate_IsNotNull{
+					IsNotNull: &api_service_protos.TPredicate_TIsNotNull{Value: columnExpression("id")},
+				},
+			},
+			evaluatedEntirely: true,
+			expectedIDs:       []int32{1, 2, 3, 4},
+		},
+		{
+			name:          "unknown column",
+			checkPushdown: pushNothing,
+			where: conjunction(
+				comparison(api_service_protos.TPredicate_TComparison_EQ, columnExpression("col"), valueExpression(Ydb.Type_INT32, int32(1))),
+				comparison(api_service_protos.TPredicate_TComparison_NE, columnExpression("id"), valueExpression(Ydb.Type_INT32, int32(2))),
+			),
+			evaluatedEntirely: false,
+			expectedIDs:       []int32{1, 3, 4},
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			allocator := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer allocator.AssertSize(t, 0)
+
+			filter, pushedEntirely, evaluatedEntirely := NewResidualFilter(
+				common.NewTestLogger(t), allocator, makeTestSelect(tc.where), tc.checkPushdown)
+
+			require.Equal(t, tc.pushedEntirely, pushedEntirely)
+			require.Equal(t, tc.evaluatedEntirely, evaluatedEntirely)
+
+			if tc.expectedIDs == nil {
+				require.Nil(t, filter)
+
+				return
+			}
+
+			require.NotNil(t, filter)
+
+			record := makeTestRecord(allocator)
+			defer record.Release()
+
+			filtered, err := filter.Apply(record)
+			require.NoError(t, err)
+
+			defer filtered.Release()
+
+			require.Equal(t, int64(len(tc.expectedIDs)), filtered.NumRows())
+			require.Equal(t, tc.expectedIDs, filtered.Column(0).(*array.Int32).Int32Values())
+		})
+	}
+}
+
+func TestSelectionSlice(t *testing.T) {
+	selection := Selection{{1, 3}, {5, 6}, {7, 10}}
+
+	testCases := []struct {
+		name     string
+		from, to int64
+		expected Selection
+	}{
+		{name: "all rows", from: 0, to: 6, expected: selection},
+		{name: "head", from: 0, to: 3, expected: Selection{{1, 3}, {5, 6}}},
+		{name: "middle", from: 1, to: 4, expected: Selection{{2, 3}, {5, 6}, {7, 8}}},
+		{name: "tail", from: 3, to: 10, expected: Selection{{7, 10}}},
+		{name: "empty", from: 2, to: 2, expected: nil},
+		{name: "beyond", from: 6, to: 8, expected: nil},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, selection.Slice(tc.from, tc.to))
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package filtering
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// The values are represented with a handful of Go types: bool, int64, uint64, float64 and string;
+// nil stands for NULL.
+type valueClass int8
+
+const (
+	classNull valueClass = iota
+	classBool
+	classNumber
+	classString
+	// dates, timestamps and intervals can be compared only with the values of the same type
+	classTemporal
+)
+
+type valueType struct {
+	class  valueClass
+	typeID Ydb.Type_PrimitiveTypeId
+}
+
+func (t valueType) compatibleWith(other valueType) bool {
+	switch {
+	case t.class == classNull || other.class == classNull:
+		return true
+	case t.class != other.class:
+		return false
+	case t.class == classTemporal:
+		return t.typeID == other.typeID
+	default:
+		return true
+	}
+}
+
+func makeValueType(ydbType *Ydb.Type) (valueType, error) {
+	switch t := ydbType.GetType().(type) {
+	case *Ydb.Type_OptionalType:
+		return makeValueType(t.OptionalType.Item)
+	case *Ydb.Type_TaggedType:
+		return makeValueType(t.TaggedType.Type)
+	case *Ydb.Type_TypeId:
+		switch t.TypeId {
+		case Ydb.Type_BOOL:
+			return valueType{class: classBool, typeID: t.TypeId}, nil
+		case Ydb.Type_INT8, Ydb.Type_INT16, Ydb.Type_INT32, Ydb.Type_INT64,
+			Ydb.Type_UINT8, Ydb.Type_UINT16, Ydb.Type_UINT32, Ydb.Type_UINT64,
+			Ydb.Type_FLOAT, Ydb.Type_DOUBLE:
+			return valueType{class: classNumber, typeID: t.TypeId}, nil
+		case Ydb.Type_STRING, Ydb.Type_UTF8:
+			return valueType{class: classString, typeID: t.TypeId}, nil
+		case Ydb.Type_DATE, Ydb.Type_DATETIME, Ydb.Type_TIMESTAMP, Ydb.Type_INTERVAL:
+			return valueType{class: classTemporal, typeID: t.TypeId}, nil
+		default:
+		}
+	default:
+	}
+
+	return valueType{}, fmt.Errorf("type %v: %w", ydbType, common.ErrDataTypeNotSupported)
+}
+
+// makeConstant converts the typed value into the internal representation
+func makeConstant(typedValue *Ydb.TypedValue) (any, valueType, error) {
+	vt, err := makeValueType(typedValue.GetType())
+	if err != nil {
+		return nil, valueType{}, fmt.Errorf("make value type: %w", err)
+	}
+
+	switch v := typedValue.GetValue().GetValue().(type) {
+	case *Ydb.Value_NullFlagValue:
+		return nil, vt, nil
+	case *Ydb.Value_BoolValue:
+		return v.BoolValue, vt, nil
+	case *Ydb.Value_Int32Value:
+		return int64(v.Int32Value), vt, nil
+	case *Ydb.Value_Uint32Value:
+		return uint64(v.Uint32Value), vt, nil
+	case *Ydb.Value_Int64Value:
+		return v.Int64Value, vt, nil
+	case *Ydb.Value_Uint64Value:
+		return v.Uint64Value, vt, nil
+	case *Ydb.Value_FloatValue:
+		return float64(v.FloatValue), vt, nil
+	case *Ydb.Value_DoubleValue:
+		return v.DoubleValue, vt, nil
+	case *Ydb.Value_BytesValue:
+		return string(v.BytesValue), vt, nil
+	case *Ydb.Value_TextValue:
+		return v.TextValue, vt, nil
+	default:
+		return nil, valueType{}, fmt.Errorf("value %T: %w", v, common.ErrUnimplementedTypedValue)
+	}
+}
+
+// makeGetter makes the accessor to the values of the Arrow array built for the given type
+//
+//nolint:gocyclo
+func makeGetter(vt valueType, arr arrow.Array) (func(row int) any, error) {
+	var get func(row int) any
+
+	switch a := arr.(type) {
+	case *array.Uint8:
+		if vt.typeID == Ydb.Type_BOOL {
+			// YDB Bool is represented with Arrow Uint8
+			get = func(row int) any { return a.Value(row) != 0 }
+		} else {
+			get = func(row int) any { return uint64(a.Value(row)) }
+		}
+	case *array.Uint16:
+		get = func(row int) any { return uint64(a.Value(row)) }
+	case *array.Uint32:
+		get = func(row int) any { return uint64(a.Value(row)) }
+	case *array.Uint64:
+		get = func(row int) any { return a.Value(row) }
+	case *array.Int8:
+		get = func(row int) any { return int64(a.Value(row)) }
+	case *array.Int16:
+		get = func(row int) any { return int64(a.Value(row)) }
+	case *array.Int32:
+		get = func(row int) any { return int64(a.Value(row)) }
+	case *array.Int64:
+		get = func(row int) any { return a.Value(row) }
+	case *array.Float32:
+		get = func(row int) any { return float64(a.Value(row)) }
+	case *array.Float64:
+		get = func(row int) any { return a.Value(row) }
+	case *array.String:
+		get = func(row int) any { return a.Value(row) }
+	case *array.Binary:
+		get = func(row int) any { return string(a.Value(row)) }
+	default:
+		return nil, fmt.Errorf("array %T: %w", arr, common.ErrDataTypeNotSupported)
+	}
+
+	return func(row int) any {
+		if arr.IsNull(row) {
+			return nil
+		}
+
+		return get(row)
+	}, nil
+}
+
+// compareValues compares two non-null values of the compatible types
+func compareValues(left, right any) int {
+	switch l := left.(type) {
+	case bool:
+		r, _ := right.(bool)
+
+		switch {
+		case l == r:
+			return 0
+		case r:
+			return -1
+		default:
+			return 1
+		}
+	case string:
+		r, _ := right.(string)
+
+		return strings.Compare(l, r)
+	default:
+		return compareNumbers(left, right)
+	}
+}
+
+func compareNumbers(left, right any) int {
+	switch l := left.(type) {
+	case int64:
+		switch r := right.(type) {
+		case int64:
+			return compareOrdered(l, r)
+		case uint64:
+			if l < 0 {
+				return -1
+			}
+
+			return compareOrdered(uint64(l), r)
+		}
+	case uint64:
+		switch r := right.(type) {
+		case uint64:
+			return compareOrdered(l, r)
+		case int64:
+			if r < 0 {
+				return 1
+			}
+
+			return compareOrdered(l, uint64(r))
+		}
+	}
+
+	return compareOrdered(toFloat(left), toFloat(right))
+}
+
+func compareOrdered[T int64 | uint64 | float64](l, r T) int {
+	switch {
+	case l < r:
+		return -1
+	case l > r:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func toFloat(value any) float64 {
+	switch v := value.(type) {
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float64:
+		return v
+	default:
+		return 0
+	}
+}
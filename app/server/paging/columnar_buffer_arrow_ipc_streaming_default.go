@@ -11,6 +11,7 @@ import (
 	"go.uber.org/zap"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 )
 
 var _ ColumnarBuffer[any] = (*columnarBufferArrowIPCStreamingDefault[any])(nil)
@@ -20,6 +21,7 @@ type columnarBufferArrowIPCStreamingDefault[T Acceptor] struct {
 	builders       []array.Builder
 	schema         *arrow.Schema
 	logger         *zap.Logger
+	filtered       arrow.Record // rows kept by the filter; nil if the buffer has not been filtered
 }
 
 // AddRow saves a row obtained from the datasource into the buffer
@@ -33,8 +35,30 @@ func (cb *columnarBufferArrowIPCStreamingDefault[T]) addRow(transformer RowTrans
 	return nil
 }
 
-// ToResponse returns all the accumulated data and clears buffer
-func (cb *columnarBufferArrowIPCStreamingDefault[T]) ToResponse() (*api_service_protos.TReadSplitsResponse, error) {
+// applyFilter drops the rows rejected by the filter; it is called right before the buffer is sent
+func (cb *columnarBufferArrowIPCStreamingDefault[T]) applyFilter(filter *rowFilter) (filtering.Selection, uint64, error) {
+	record := cb.makeRecord()
+	defer record.Release()
+
+	filtered, selection, passed, err := filter.apply(record)
+	if err != nil {
+		return nil, 0, fmt.Errorf("apply filter: %w", err)
+	}
+
+	// empty buffer can be reused
+	if filtered.NumRows() == 0 {
+		filtered.Release()
+
+		return selection, passed, nil
+	}
+
+	cb.filtered = filtered
+
+	return selection, passed, nil
+}
+
+// makeRecord moves the accumulated data into a record
+func (cb *columnarBufferArrowIPCStreamingDefault[T]) makeRecord() arrow.Record {
 	// chunk consists of columns
 	chunk := make([]arrow.Array, 0, len(cb.builders))
 
@@ -49,6 +73,20 @@ func (cb *columnarBufferArrowIPCStreamingDefault[T]) ToResponse() (*api_service_
 		col.Release()
 	}
 
+	return record
+}
+
+// ToResponse returns all the accumulated data and clears buffer
+func (cb *columnarBufferArrowIPCStreamingDefault[T]) ToResponse() (*api_service_protos.TReadSplitsResponse, error) {
+	record := cb.filtered
+	cb.filtered = nil
+
+	if record == nil {
+		record = cb.makeRecord()
+	}
+
+	defer record.Release()
+
 	// prepare arrow writer
 	var buf bytes.Buffer
 
@@ -71,7 +109,13 @@ func (cb *columnarBufferArrowIPCStreamingDefault[T]) ToResponse() (*api_service_
 	return out, nil
 }
 
-func (cb *columnarBufferArrowIPCStreamingDefault[T]) TotalRows() int { return cb.builders[0].Len() }
+func (cb *columnarBufferArrowIPCStreamingDefault[T]) TotalRows() int {
+	if cb.filtered != nil {
+		return int(cb.filtered.NumRows())
+	}
+
+	return cb.builders[0].Len()
+}
 
 // Frees resources if buffer is no longer used
 func (cb *columnarBufferArrowIPCStreamingDefault[T]) Release() {
@@ -79,4 +123,8 @@ func (cb *columnarBufferArrowIPCStreamingDefault[T]) Release() {
 	for _, b := range cb.builders {
 		b.Release()
 	}
+
+	if cb.filtered != nil {
+		cb.filtered.Release()
+	}
 }
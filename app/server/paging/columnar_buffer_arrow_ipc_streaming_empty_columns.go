@@ -10,6 +10,8 @@ import (
 	"github.com/apache/arrow/go/v13/arrow/memory"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
+	"github.com/ydb-platform/fq-connector-go/common"
 )
 
 var _ ColumnarBuffer[any] = (*columnarBufferArrowIPCStreamingEmptyColumns[any])(nil)
@@ -34,6 +36,11 @@ func (cb *columnarBufferArrowIPCStreamingEmptyColumns[T]) addRow(transformer Row
 	return nil
 }
 
+// applyFilter is not supported, since the predicates cannot be evaluated over the rows without columns
+func (*columnarBufferArrowIPCStreamingEmptyColumns[T]) applyFilter(_ *rowFilter) (filtering.Selection, uint64, error) {
+	return nil, 0, fmt.Errorf("rows without columns cannot be filtered: %w", common.ErrInvariantViolation)
+}
+
 // ToResponse returns all the accumulated data and clears buffer
 func (cb *columnarBufferArrowIPCStreamingEmptyColumns[T]) ToResponse() (*api_service_protos.TReadSplitsResponse, error) {
 	columns := make([]arrow.Array, 0)
@@ -0,0 +1,167 @@
+package paging
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// MakeContinuationToken returns the token that will be used to track the positions of the sinks reading the split.
+// The tokens are issued only if the client asks for them with the continuation, which is empty
+// for the split read from the beginning, so nil is returned if there is no continuation in the request.
+// If the client asks to resume the reading, the token is decoded from the request
+// and checked to be signed with the secret and issued for the very same split.
+// Tokens are not issued without the secret.
+func MakeContinuationToken(
+	split *api_service_protos.TSplit,
+	continuation *api_service_protos.TContinuation,
+	secret string,
+) (*TContinuationToken, error) {
+	if continuation == nil {
+		return nil, nil
+	}
+
+	if secret == "" {
+		return nil, fmt.Errorf("continuation is disabled by the server configuration: %w", common.ErrInvalidRequest)
+	}
+
+	fingerprint, err := makeSplitFingerprint(split)
+	if err != nil {
+		return nil, fmt.Errorf("make split fingerprint: %w", err)
+	}
+
+	description := continuation.GetDescription()
+	if len(description) == 0 {
+		return &TContinuationToken{SplitFingerprint: fingerprint}, nil
+	}
+
+	if len(description) <= sha256.Size {
+		return nil, fmt.Errorf("continuation description is too short: %w", common.ErrInvalidRequest)
+	}
+
+	data, signature := description[:len(description)-sha256.Size], description[len(description)-sha256.Size:]
+	if !hmac.Equal(signature, signContinuationToken(data, secret)) {
+		return nil, fmt.Errorf("continuation token signature mismatch: %w", common.ErrInvalidRequest)
+	}
+
+	token := &TContinuationToken{}
+	if err := proto.Unmarshal(data, token); err != nil {
+		return nil, fmt.Errorf("unmarshal continuation token: %v: %w", err, common.ErrInvalidRequest)
+	}
+
+	if !bytes.Equal(token.SplitFingerprint, fingerprint) {
+		return nil, fmt.Errorf("continuation token was issued for another split: %w", common.ErrInvalidRequest)
+	}
+
+	return token, nil
+}
+
+// signContinuationToken computes HMAC-SHA256 of the serialized token,
+// so that the clients could not forge the positions within the data source
+func signContinuationToken(data []byte, secret string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(data)
+
+	return mac.Sum(nil)
+}
+
+// makeSplitFingerprint hashes the split, so that the continuation token can be bound to it
+func makeSplitFingerprint(split *api_service_protos.TSplit) ([]byte, error) {
+	// Marshaling caches the sizes of messages inside them, so the clone is marshaled
+	// to keep the split intact for the code comparing the messages reflectively.
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(proto.Clone(split))
+	if err != nil {
+		return nil, fmt.Errorf("marshal split: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return sum[:], nil
+}
+
+// continuationTracker keeps the positions of all the sinks reading the split
+// and issues continuation tokens for the pages sent to the client.
+type continuationTracker struct {
+	// Pages must be enqueued in the same order the tokens are issued,
+	// otherwise the token may describe the data that has not been delivered yet.
+	mutex  sync.Mutex
+	token  *TContinuationToken // nil if continuation tokens are not issued
+	secret string
+}
+
+// resumePositions returns the positions the sinks must resume reading from,
+// or nil if the split is read from the beginning.
+func (t *continuationTracker) resumePositions(totalSinks int) ([]*TContinuationToken_TPosition, error) {
+	if t.token == nil {
+		return nil, nil
+	}
+
+	if len(t.token.Positions) == 0 {
+		t.token.Positions = make([]*TContinuationToken_TPosition, totalSinks)
+		for i := range t.token.Positions {
+			t.token.Positions[i] = &TContinuationToken_TPosition{}
+		}
+
+		return nil, nil
+	}
+
+	if len(t.token.Positions) != totalSinks {
+		return nil, fmt.Errorf(
+			"continuation token describes %d sinks, while %d sinks are requested: %w",
+			len(t.token.Positions), totalSinks, common.ErrInvalidRequest)
+	}
+
+	result := make([]*TContinuationToken_TPosition, totalSinks)
+
+	for i, position := range t.token.Positions {
+		// Without a cursor the rows delivered before the interruption cannot be told apart
+		// from the other ones, because the data source does not guarantee the same order of rows
+		// for the repeated query.
+		if position.Cursor == nil && position.RowsDelivered > 0 && !position.Finished {
+			return nil, fmt.Errorf(
+				"sink #%d cannot be resumed, since the data source has not provided a cursor to resume from: %w",
+				i, common.ErrInvalidRequest)
+		}
+
+		result[i] = proto.Clone(position).(*TContinuationToken_TPosition)
+	}
+
+	return result, nil
+}
+
+// update saves the position of a sink and returns the token describing all the data delivered so far,
+// or nil if the tokens are not issued. Must be called under the mutex.
+func (t *continuationTracker) update(
+	sinkIndex int,
+	position *TContinuationToken_TPosition,
+) (*api_service_protos.TContinuation, error) {
+	if t.token == nil {
+		return nil, nil
+	}
+
+	t.token.Positions[sinkIndex] = &TContinuationToken_TPosition{
+		Cursor:          position.Cursor,
+		RowsAfterCursor: position.RowsAfterCursor,
+		RowsDelivered:   position.RowsDelivered,
+		Finished:        position.Finished,
+		RowsPassed:      position.RowsPassed,
+	}
+
+	data, err := proto.Marshal(t.token)
+	if err != nil {
+		return nil, fmt.Errorf("marshal continuation token: %w", err)
+	}
+
+	return &api_service_protos.TContinuation{
+		Payload: &api_service_protos.TContinuation_Description{
+			Description: append(data, signContinuationToken(data, t.secret)...),
+		},
+	}, nil
+}
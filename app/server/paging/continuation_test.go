@@ -0,0 +1,264 @@
+package paging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// testColumnarBuffer keeps the values of a single int32 column
+type testColumnarBuffer struct {
+	values []int32
+}
+
+func (b *testColumnarBuffer) addRow(transformer RowTransformer[any]) error {
+	b.values = append(b.values, *transformer.GetAcceptors()[0].(*int32))
+
+	return nil
+}
+
+func (*testColumnarBuffer) applyFilter(_ *rowFilter) (filtering.Selection, uint64, error) {
+	panic("not implemented")
+}
+
+func (*testColumnarBuffer) ToResponse() (*api_service_protos.TReadSplitsResponse, error) {
+	return &api_service_protos.TReadSplitsResponse{}, nil
+}
+
+func (*testColumnarBuffer) Release() {}
+
+func (b *testColumnarBuffer) TotalRows() int { return len(b.values) }
+
+type testColumnarBufferFactory struct{}
+
+func (testColumnarBufferFactory) MakeBuffer() (ColumnarBuffer[any], error) {
+	return &testColumnarBuffer{}, nil
+}
+
+const testSecret = "a-secret-that-is-at-least-32-bytes-long"
+
+func makeTestSplit(table string) *api_service_protos.TSplit {
+	return &api_service_protos.TSplit{
+		Select: &api_service_protos.TSelect{
+			DataSourceInstance: &api_common.TGenericDataSourceInstance{Kind: api_common.EGenericDataSourceKind_REDIS},
+			From:               &api_service_protos.TSelect_TFrom{Table: table},
+		},
+	}
+}
+
+func makeTestSinkFactory(t *testing.T, token *TContinuationToken, secret string) SinkFactory[any] {
+	return NewSinkFactory[any](
+		context.Background(),
+		common.NewTestLogger(t),
+		&config.TPagingConfig{RowsPerPage: 2, PrefetchQueueCapacity: 16, ContinuationTokenSecret: secret},
+		testColumnarBufferFactory{},
+		readLimiterNoop{},
+		token,
+		nil,
+		nil,
+	)
+}
+
+// readTestSplit emulates a data source that checkpoints the resume cursor it starts reading from
+// and then makes a checkpoint after every third row. Without cursors it only adds the rows.
+func readTestSplit(
+	t *testing.T,
+	split *api_service_protos.TSplit,
+	continuation *api_service_protos.TContinuation,
+	totalRows int32,
+	withCursor bool,
+) []*ReadResult[any] {
+	token, err := MakeContinuationToken(split, continuation, testSecret)
+	require.NoError(t, err)
+
+	sinkFactory := makeTestSinkFactory(t, token, testSecret)
+
+	sinks, err := sinkFactory.MakeSinks([]*SinkParams{{Logger: common.NewTestLogger(t)}})
+	require.NoError(t, err)
+
+	sink := sinks[0]
+
+	value := int32(sink.ResumePosition().GetCursor().GetScanCursor())
+	transformer := NewRowTransformer[any]([]any{&value}, nil, nil)
+
+	if withCursor {
+		sink.Checkpoint(&TCursor{Payload: &TCursor_ScanCursor{ScanCursor: uint64(value)}})
+	}
+
+	for value < totalRows {
+		value++
+
+		require.NoError(t, sink.AddRow(transformer))
+
+		if withCursor && value%3 == 0 {
+			sink.Checkpoint(&TCursor{Payload: &TCursor_ScanCursor{ScanCursor: uint64(value)}})
+		}
+	}
+
+	sink.Finish()
+
+	var results []*ReadResult[any]
+
+	for result := range sinkFactory.ResultQueue() {
+		require.NoError(t, result.Error)
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func TestContinuation(t *testing.T) {
+	split := makeTestSplit("example_1")
+
+	collectValues := func(results []*ReadResult[any]) []int32 {
+		var values []int32
+		for _, result := range results {
+			values = append(values, result.ColumnarBuffer.(*testColumnarBuffer).values...)
+		}
+
+		return values
+	}
+
+	// read the split from the beginning: pages are [1, 2], [3, 4], [5, 6], [7]
+	results := readTestSplit(t, split, &api_service_protos.TContinuation{}, 7, true)
+	require.Len(t, results, 4)
+	require.Equal(t, []int32{1, 2, 3, 4, 5, 6, 7}, collectValues(results))
+
+	for _, result := range results {
+		require.NotNil(t, result.Continuation)
+	}
+
+	t.Run("resume after interruption", func(t *testing.T) {
+		// the stream was broken after the second page: resume from cursor 3 skipping row 4
+		resumed := readTestSplit(t, split, results[1].Continuation, 7, true)
+		require.Equal(t, []int32{5, 6, 7}, collectValues(resumed))
+
+		// the last token describes the whole split
+		token, err := MakeContinuationToken(split, resumed[len(resumed)-1].Continuation, testSecret)
+		require.NoError(t, err)
+		require.Len(t, token.Positions, 1)
+		require.EqualValues(t, 7, token.Positions[0].RowsDelivered)
+		require.EqualValues(t, 6, token.Positions[0].GetCursor().GetScanCursor())
+		require.EqualValues(t, 1, token.Positions[0].RowsAfterCursor)
+		require.True(t, token.Positions[0].Finished)
+	})
+
+	t.Run("resume before checkpoint", func(t *testing.T) {
+		// the first page follows the initial checkpoint, so the rows are skipped from the beginning
+		resumed := readTestSplit(t, split, results[0].Continuation, 7, true)
+		require.Equal(t, []int32{3, 4, 5, 6, 7}, collectValues(resumed))
+	})
+
+	t.Run("token for another split", func(t *testing.T) {
+		_, err := MakeContinuationToken(makeTestSplit("example_2"), results[1].Continuation, testSecret)
+		require.True(t, errors.Is(err, common.ErrInvalidRequest))
+	})
+
+	t.Run("tampered token", func(t *testing.T) {
+		description := append([]byte(nil), results[1].Continuation.GetDescription()...)
+		description[0] ^= 0xFF
+
+		continuation := &api_service_protos.TContinuation{
+			Payload: &api_service_protos.TContinuation_Description{Description: description},
+		}
+
+		_, err := MakeContinuationToken(split, continuation, testSecret)
+		require.True(t, errors.Is(err, common.ErrInvalidRequest))
+	})
+
+	t.Run("token signed with another secret", func(t *testing.T) {
+		_, err := MakeContinuationToken(split, results[1].Continuation, testSecret+"-rotated")
+		require.True(t, errors.Is(err, common.ErrInvalidRequest))
+	})
+
+	t.Run("malformed token", func(t *testing.T) {
+		continuation := &api_service_protos.TContinuation{
+			Payload: &api_service_protos.TContinuation_Description{Description: []byte("garbage")},
+		}
+
+		_, err := MakeContinuationToken(split, continuation, testSecret)
+		require.True(t, errors.Is(err, common.ErrInvalidRequest))
+	})
+
+	t.Run("tokens not requested", func(t *testing.T) {
+		token, err := MakeContinuationToken(split, nil, testSecret)
+		require.NoError(t, err)
+		require.Nil(t, token)
+	})
+
+	t.Run("sinks number mismatch", func(t *testing.T) {
+		token, err := MakeContinuationToken(split, results[1].Continuation, testSecret)
+		require.NoError(t, err)
+
+		sinkFactory := makeTestSinkFactory(t, token, testSecret)
+
+		_, err = sinkFactory.MakeSinks([]*SinkParams{{Logger: common.NewTestLogger(t)}, {Logger: common.NewTestLogger(t)}})
+		require.True(t, errors.Is(err, common.ErrInvalidRequest))
+	})
+}
+
+func TestContinuationWithoutCursor(t *testing.T) {
+	split := makeTestSplit("example_1")
+
+	results := readTestSplit(t, split, &api_service_protos.TContinuation{}, 3, false)
+	require.Len(t, results, 2)
+
+	t.Run("partially delivered split", func(t *testing.T) {
+		// the rows may come in another order after the restart, so they can't be skipped
+		token, err := MakeContinuationToken(split, results[0].Continuation, testSecret)
+		require.NoError(t, err)
+
+		_, err = makeTestSinkFactory(t, token, testSecret).MakeSinks([]*SinkParams{{Logger: common.NewTestLogger(t)}})
+		require.True(t, errors.Is(err, common.ErrInvalidRequest))
+	})
+
+	t.Run("fully delivered split", func(t *testing.T) {
+		token, err := MakeContinuationToken(split, results[1].Continuation, testSecret)
+		require.NoError(t, err)
+
+		sinks, err := makeTestSinkFactory(t, token, testSecret).MakeSinks([]*SinkParams{{Logger: common.NewTestLogger(t)}})
+		require.NoError(t, err)
+		require.True(t, sinks[0].ResumePosition().GetFinished())
+	})
+}
+
+func TestContinuationDisabled(t *testing.T) {
+	split := makeTestSplit("example_1")
+
+	token, err := MakeContinuationToken(split, nil, "")
+	require.NoError(t, err)
+	require.Nil(t, token)
+
+	sinkFactory := makeTestSinkFactory(t, token, "")
+
+	sinks, err := sinkFactory.MakeSinks([]*SinkParams{{Logger: common.NewTestLogger(t)}})
+	require.NoError(t, err)
+	require.False(t, sinks[0].CheckpointsRequired())
+
+	value := int32(1)
+	require.NoError(t, sinks[0].AddRow(NewRowTransformer[any]([]any{&value}, nil, nil)))
+	sinks[0].Finish()
+
+	for result := range sinkFactory.ResultQueue() {
+		require.NoError(t, result.Error)
+		require.Nil(t, result.Continuation)
+	}
+
+	// the tokens cannot be requested
+	_, err = MakeContinuationToken(split, &api_service_protos.TContinuation{}, "")
+	require.True(t, errors.Is(err, common.ErrInvalidRequest))
+
+	// the tokens issued earlier are not accepted either
+	signed := readTestSplit(t, split, &api_service_protos.TContinuation{}, 3, true)
+
+	_, err = MakeContinuationToken(split, signed[0].Continuation, "")
+	require.True(t, errors.Is(err, common.ErrInvalidRequest))
+}
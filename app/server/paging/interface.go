@@ -6,6 +6,7 @@ import (
 	"go.uber.org/zap"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 )
 
 // Acceptor is a fundamental type class that is used during data extraction from the data source
@@ -24,6 +25,9 @@ type RowTransformer[T Acceptor] interface {
 type ColumnarBuffer[T Acceptor] interface {
 	// addRow saves a row obtained from the datasource into the columnar buffer
 	addRow(rowTransformer RowTransformer[T]) error
+	// applyFilter drops the rows rejected by the filter and returns the selection of the kept rows
+	// along with the number of rows satisfying the predicate
+	applyFilter(filter *rowFilter) (filtering.Selection, uint64, error)
 	// ToResponse returns all the accumulated data and clears buffer
 	ToResponse() (*api_service_protos.TReadSplitsResponse, error)
 	// Release frees resources if buffer is no longer used
@@ -40,10 +44,12 @@ type ColumnarBufferFactory[T Acceptor] interface {
 // 1. a buffer (e. g. page) packed with data
 // 2. stats describing data that is kept in buffer
 // 3. result of read operation (potentially with error)
-// 4. flag marking this stream as completed
+// 4. continuation token allowing to resume reading after this buffer
+// 5. flag marking this stream as completed
 type ReadResult[T Acceptor] struct {
 	ColumnarBuffer    ColumnarBuffer[T]
 	Stats             *api_service_protos.TReadSplitsResponse_TStats
+	Continuation      *api_service_protos.TContinuation
 	Error             error
 	IsTerminalMessage bool
 	Logger            *zap.Logger // logger annotated with the data source instance description
@@ -54,6 +60,19 @@ type Sink[T Acceptor] interface {
 	// AddRow saves the row obtained from a stream incoming from an external data source.
 	AddRow(rowTransformer RowTransformer[T]) error
 
+	// Checkpoint reports that the data source is able to resume reading from the cursor
+	// right after the rows that have been already added to the sink.
+	Checkpoint(cursor *TCursor)
+
+	// CheckpointsRequired reports that the continuation tokens are issued for the pages of the sink,
+	// so the data source should provide the cursors, even if it costs some extra work.
+	CheckpointsRequired() bool
+
+	// ResumePosition returns the position reached by the sink before the interruption of the previous request,
+	// or nil if the data must be read from the beginning. The rows delivered after the position's cursor
+	// are skipped by the sink, so the data source only has to start reading from the cursor.
+	ResumePosition() *TContinuationToken_TPosition
+
 	// Finish reports the successful (!) completion of data stream reading.
 	// Never call this method if the request has failed.
 	// This method can be called only once.
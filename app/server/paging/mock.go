@@ -5,6 +5,7 @@ import (
 	"go.uber.org/zap"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 )
 
 var _ Sink[any] = (*SinkMock)(nil)
@@ -23,6 +24,20 @@ func (m *SinkMock) AddError(err error) {
 	m.Called(err)
 }
 
+func (m *SinkMock) Checkpoint(cursor *TCursor) {
+	m.Called(cursor)
+}
+
+func (m *SinkMock) CheckpointsRequired() bool {
+	return m.Called().Bool(0)
+}
+
+func (m *SinkMock) ResumePosition() *TContinuationToken_TPosition {
+	position, _ := m.Called().Get(0).(*TContinuationToken_TPosition)
+
+	return position
+}
+
 func (m *SinkMock) Finish() {
 	m.Called()
 }
@@ -66,6 +81,11 @@ func (*ColumnarBufferMock) addRow(_ RowTransformer[any]) error {
 	panic("not implemented") // TODO: Implement
 }
 
+//nolint:unused
+func (*ColumnarBufferMock) applyFilter(_ *rowFilter) (filtering.Selection, uint64, error) {
+	panic("not implemented") // TODO: Implement
+}
+
 func (m *ColumnarBufferMock) ToResponse() (*api_service_protos.TReadSplitsResponse, error) {
 	args := m.Called()
 
@@ -0,0 +1,88 @@
+package paging
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/apache/arrow/go/v13/arrow"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
+)
+
+// rowFilter drops the rows that the data source has not filtered by itself.
+// LIMIT and OFFSET cannot be pushed down along with the incomplete predicate,
+// so they are applied here to the rows satisfying the predicate.
+// The filter is shared across all the sinks reading the split.
+type rowFilter struct {
+	filter *filtering.Filter
+	limit  uint64 // zero means no limit
+	offset uint64
+
+	mutex      sync.Mutex
+	rowsPassed uint64 // the number of rows satisfying the predicate in all the sinks
+}
+
+// apply returns the record containing only the rows satisfying the predicate and fitting into LIMIT and OFFSET,
+// the selection of these rows, and the number of rows satisfying the predicate.
+// The caller is responsible for releasing both records.
+func (rf *rowFilter) apply(record arrow.Record) (arrow.Record, filtering.Selection, uint64, error) {
+	selection, err := rf.filter.Select(record)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("select rows: %w", err)
+	}
+
+	passed := uint64(selection.Rows())
+
+	rf.mutex.Lock()
+	first := rf.rowsPassed
+	rf.rowsPassed += passed
+	rf.mutex.Unlock()
+
+	// the ordinal numbers of the rows of this record are [first, first + passed)
+	from := min(passed, rf.offset-min(rf.offset, first))
+	to := passed
+
+	if rf.limit != 0 {
+		to = min(passed, rf.offset+rf.limit-min(rf.offset+rf.limit, first))
+	}
+
+	selection = selection.Slice(int64(from), int64(max(from, to)))
+
+	filtered, err := rf.filter.Take(record, selection)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("take rows: %w", err)
+	}
+
+	return filtered, selection, passed, nil
+}
+
+// exhausted reports that LIMIT is reached, so the rest of the rows can be dropped without evaluation
+func (rf *rowFilter) exhausted() bool {
+	if rf.limit == 0 {
+		return false
+	}
+
+	rf.mutex.Lock()
+	defer rf.mutex.Unlock()
+
+	return rf.rowsPassed >= rf.offset+rf.limit
+}
+
+// resume takes into account the rows that have passed the filter before the interruption of the previous request
+func (rf *rowFilter) resume(positions []*TContinuationToken_TPosition) {
+	rf.mutex.Lock()
+	defer rf.mutex.Unlock()
+
+	for _, position := range positions {
+		rf.rowsPassed += position.RowsPassed
+	}
+}
+
+func newRowFilter(filter *filtering.Filter, limit *api_service_protos.TSelect_TLimit) *rowFilter {
+	return &rowFilter{
+		filter: filter,
+		limit:  limit.GetLimit(),
+		offset: limit.GetOffset(),
+	}
+}
@@ -0,0 +1,172 @@
+package paging
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
+	"github.com/ydb-platform/fq-connector-go/common"
+)
+
+// makeTestFilter makes the filter keeping the rows with id > 2
+func makeTestFilter(t *testing.T, allocator memory.Allocator) (*api_service_protos.TSelect_TWhat, *filtering.Filter) {
+	what := &api_service_protos.TSelect_TWhat{
+		Items: []*api_service_protos.TSelect_TWhat_TItem{
+			{
+				Payload: &api_service_protos.TSelect_TWhat_TItem_Column{
+					Column: &Ydb.Column{Name: "id", Type: common.MakePrimitiveType(Ydb.Type_INT32)},
+				},
+			},
+		},
+	}
+
+	where := &api_service_protos.TPredicate{
+		Payload: &api_service_protos.TPredicate_Comparison{
+			Comparison: &api_service_protos.TPredicate_TComparison{
+				Operation: api_service_protos.TPredicate_TComparison_G,
+				LeftValue: &api_service_protos.TExpression{
+					Payload: &api_service_protos.TExpression_Column{Column: "id"},
+				},
+				RightValue: &api_service_protos.TExpression{
+					Payload: &api_service_protos.TExpression_TypedValue{
+						TypedValue: common.MakeTypedValue(common.MakePrimitiveType(Ydb.Type_INT32), int32(2)),
+					},
+				},
+			},
+		},
+	}
+
+	filter, _, _ := filtering.NewResidualFilter(
+		common.NewTestLogger(t),
+		allocator,
+		&api_service_protos.TSelect{What: what, Where: &api_service_protos.TSelect_TWhere{FilterTyped: where}},
+		nil,
+	)
+	require.NotNil(t, filter)
+
+	return what, filter
+}
+
+func TestRowFilter(t *testing.T) {
+	testCases := []struct {
+		name        string
+		limit       *api_service_protos.TSelect_TLimit
+		expectedIDs []int32
+	}{
+		{
+			name:        "no limit",
+			expectedIDs: []int32{3, 4, 5, 6, 7, 8, 9, 10},
+		},
+		{
+			name:        "limit",
+			limit:       &api_service_protos.TSelect_TLimit{Limit: 4},
+			expectedIDs: []int32{3, 4, 5, 6},
+		},
+		{
+			name:        "limit and offset",
+			limit:       &api_service_protos.TSelect_TLimit{Limit: 4, Offset: 3},
+			expectedIDs: []int32{6, 7, 8, 9},
+		},
+		{
+			name:        "offset beyond the data",
+			limit:       &api_service_protos.TSelect_TLimit{Limit: 4, Offset: 8},
+			expectedIDs: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			allocator := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer allocator.AssertSize(t, 0)
+
+			logger := common.NewTestLogger(t)
+			what, filter := makeTestFilter(t, allocator)
+
+			columnarBufferFactory, err := NewColumnarBufferFactory[any](
+				logger, allocator, api_service_protos.TReadSplitsRequest_ARROW_IPC_STREAMING, what)
+			require.NoError(t, err)
+
+			sinkFactory := NewSinkFactory[any](
+				context.Background(),
+				logger,
+				&config.TPagingConfig{RowsPerPage: 3, PrefetchQueueCapacity: 16},
+				columnarBufferFactory,
+				readLimiterNoop{},
+				nil,
+				filter,
+				tc.limit,
+			)
+
+			sinks, err := sinkFactory.MakeSinks([]*SinkParams{{Logger: logger}})
+			require.NoError(t, err)
+
+			appenders := []func(acceptor any, builder array.Builder) error{
+				func(acceptor any, builder array.Builder) error {
+					builder.(*array.Int32Builder).Append(*acceptor.(*int32))
+
+					return nil
+				},
+			}
+
+			for id := int32(1); id <= 10; id++ {
+				value := id
+				require.NoError(t, sinks[0].AddRow(NewRowTransformer[any]([]any{&value}, appenders, nil)))
+			}
+
+			sinks[0].Finish()
+
+			var (
+				actualIDs []int32
+				pageRows  uint64
+			)
+
+			for result := range sinkFactory.ResultQueue() {
+				require.NoError(t, result.Error)
+
+				response, err := result.ColumnarBuffer.ToResponse()
+				require.NoError(t, err)
+				result.ColumnarBuffer.Release()
+
+				reader, err := ipc.NewReader(bytes.NewReader(response.GetArrowIpcStreaming()))
+				require.NoError(t, err)
+
+				for reader.Next() {
+					actualIDs = append(actualIDs, reader.Record().Column(0).(*array.Int32).Int32Values()...)
+				}
+
+				reader.Release()
+
+				pageRows += result.Stats.Rows
+			}
+
+			// the stats describe only the rows sent to the client
+			require.Equal(t, tc.expectedIDs, actualIDs)
+			require.Equal(t, uint64(len(tc.expectedIDs)), pageRows)
+			require.Equal(t, uint64(len(tc.expectedIDs)), sinkFactory.FinalStats().Rows)
+			require.Equal(t, uint64(len(tc.expectedIDs))*4, sinkFactory.FinalStats().Bytes)
+		})
+	}
+}
+
+func TestRowFilterResume(t *testing.T) {
+	_, filter := makeTestFilter(t, memory.NewGoAllocator())
+
+	rf := newRowFilter(filter, &api_service_protos.TSelect_TLimit{Limit: 4})
+	require.False(t, rf.exhausted())
+
+	// the rows passed by all the sinks before the interruption count towards LIMIT
+	rf.resume([]*TContinuationToken_TPosition{{RowsPassed: 3}, {RowsPassed: 1}})
+	require.True(t, rf.exhausted())
+}
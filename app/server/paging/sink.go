@@ -32,6 +32,14 @@ type sinkImpl[T Acceptor] struct {
 	logger         *zap.Logger              // annotated logger
 	state          sinkState                // flag showing if it's ready to return data
 	ctx            context.Context          // client context
+
+	continuationTracker *continuationTracker          // issues continuation tokens for the outgoing pages
+	sinkIndex           int                           // index of this sink within the split
+	position            *TContinuationToken_TPosition // describes the rows added to this sink
+	resumePosition      *TContinuationToken_TPosition // position reached before the interruption of the previous request
+	rowsToSkip          uint64                        // rows that have been already delivered before the interruption
+
+	rowFilter *rowFilter // drops the rows not filtered by the data source; nil if the data source filters them itself
 }
 
 func (s *sinkImpl[T]) AddRow(rowTransformer RowTransformer[T]) error {
@@ -39,6 +47,21 @@ func (s *sinkImpl[T]) AddRow(rowTransformer RowTransformer[T]) error {
 		panic(s.unexpectedState(sinkOperational))
 	}
 
+	// Rows that have been delivered before the interruption of the previous request are omitted
+	if s.rowsToSkip > 0 {
+		s.rowsToSkip--
+
+		return nil
+	}
+
+	// Once LIMIT is reached, the rest of the rows are dropped without buffering
+	if s.rowFilter != nil && s.rowFilter.exhausted() {
+		s.position.RowsAfterCursor++
+		s.position.RowsDelivered++
+
+		return nil
+	}
+
 	if err := s.readLimiter.addRow(); err != nil {
 		return fmt.Errorf("add row to read limiter: %w", err)
 	}
@@ -67,18 +90,65 @@ func (s *sinkImpl[T]) AddRow(rowTransformer RowTransformer[T]) error {
 		return fmt.Errorf("add row to buffer: %w", err)
 	}
 
+	s.position.RowsAfterCursor++
+	s.position.RowsDelivered++
+
 	return nil
 }
 
+func (s *sinkImpl[T]) Checkpoint(cursor *TCursor) {
+	if s.state != sinkOperational {
+		panic(s.unexpectedState(sinkOperational))
+	}
+
+	// If the sink is still skipping the rows delivered before the interruption,
+	// the rest of them follows the new cursor
+	s.position.Cursor = cursor
+	s.position.RowsAfterCursor = s.rowsToSkip
+}
+
+func (s *sinkImpl[T]) CheckpointsRequired() bool {
+	return s.continuationTracker.token != nil
+}
+
+func (s *sinkImpl[T]) ResumePosition() *TContinuationToken_TPosition {
+	return s.resumePosition
+}
+
 func (s *sinkImpl[T]) flush(makeNewBuffer bool, isTerminalMessage bool) error {
+	// The rows are filtered before the stats are taken, so that the stats describe only the data sent to the client
+	if s.rowFilter != nil && s.currBuffer.TotalRows() != 0 {
+		selection, passed, err := s.currBuffer.applyFilter(s.rowFilter)
+		if err != nil {
+			return fmt.Errorf("apply filter: %w", err)
+		}
+
+		s.trafficTracker.keepRows(selection)
+		s.position.RowsPassed += passed
+	}
+
 	if s.currBuffer.TotalRows() == 0 {
 		return nil
 	}
 
 	stats := s.trafficTracker.DumpStats(false)
 
+	s.position.Finished = isTerminalMessage
+
+	// Tokens must be issued in the same order the pages are enqueued
+	s.continuationTracker.mutex.Lock()
+
+	continuation, err := s.continuationTracker.update(s.sinkIndex, s.position)
+	if err != nil {
+		s.continuationTracker.mutex.Unlock()
+
+		return fmt.Errorf("update continuation: %w", err)
+	}
+
 	// enqueue message to GRPC stream
-	s.respondWith(s.currBuffer, stats, nil, isTerminalMessage)
+	s.respondWith(s.currBuffer, stats, continuation, nil, isTerminalMessage)
+
+	s.continuationTracker.mutex.Unlock()
 
 	// create empty buffer and reset counters
 	s.currBuffer = nil
@@ -105,7 +175,7 @@ func (s *sinkImpl[T]) Finish() {
 	if s.state == sinkOperational {
 		err := s.flush(false, true)
 		if err != nil {
-			s.respondWith(nil, nil, fmt.Errorf("flush: %w", err), true)
+			s.respondWith(nil, nil, nil, fmt.Errorf("flush: %w", err), true)
 			s.state = sinkFailed
 		} else {
 			s.state = sinkFinished
@@ -122,11 +192,13 @@ func (s *sinkImpl[T]) Finish() {
 func (s *sinkImpl[T]) respondWith(
 	buf ColumnarBuffer[T],
 	stats *api_service_protos.TReadSplitsResponse_TStats,
+	continuation *api_service_protos.TContinuation,
 	err error,
 	isTerminalMessage bool) {
 	result := &ReadResult[T]{
 		ColumnarBuffer:    buf,
 		Stats:             stats,
+		Continuation:      continuation,
 		Error:             err,
 		IsTerminalMessage: isTerminalMessage,
 		Logger:            s.logger,
@@ -5,9 +5,11 @@ import (
 	"fmt"
 
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 )
 
 type sinkFactoryState int8
@@ -31,6 +33,9 @@ type sinkFactoryImpl[T Acceptor] struct {
 	state         sinkFactoryState
 	totalSinks    int
 
+	continuationTracker *continuationTracker // shared across all the sinks reading the split
+	rowFilter           *rowFilter           // shared across all the sinks reading the split; optional
+
 	// Every sink has own traffic tracker, but factory keeps all created trackers during its lifetime
 	// to provide overall traffic stats.
 	trafficTrackers []*trafficTracker[T]
@@ -50,6 +55,16 @@ func (f *sinkFactoryImpl[T]) MakeSinks(params []*SinkParams) ([]Sink[T], error)
 	// Children sinks will use this channel to notify factory when the read is completed.
 	terminateChan := make(chan Sink[T], f.totalSinks)
 
+	resumePositions, err := f.continuationTracker.resumePositions(f.totalSinks)
+	if err != nil {
+		f.state = sinkFactoryFailed
+		return nil, fmt.Errorf("resume positions: %w", err)
+	}
+
+	if f.rowFilter != nil {
+		f.rowFilter.resume(resumePositions)
+	}
+
 	for i := 0; i < f.totalSinks; i++ {
 		buffer, err := f.bufferFactory.MakeBuffer()
 		if err != nil {
@@ -58,7 +73,7 @@ func (f *sinkFactoryImpl[T]) MakeSinks(params []*SinkParams) ([]Sink[T], error)
 		}
 
 		// preserve traffic tracker to obtain stats in future
-		trafficTracker := newTrafficTracker[T](f.cfg)
+		trafficTracker := newTrafficTracker[T](f.cfg, f.rowFilter != nil)
 		f.trafficTrackers = append(f.trafficTrackers, trafficTracker)
 
 		sink := &sinkImpl[T]{
@@ -71,6 +86,17 @@ func (f *sinkFactoryImpl[T]) MakeSinks(params []*SinkParams) ([]Sink[T], error)
 			logger:         params[i].Logger,
 			state:          sinkOperational,
 			ctx:            f.ctx,
+
+			continuationTracker: f.continuationTracker,
+			sinkIndex:           i,
+			position:            &TContinuationToken_TPosition{},
+			rowFilter:           f.rowFilter,
+		}
+
+		if resumePositions != nil {
+			sink.resumePosition = resumePositions[i]
+			sink.position = proto.Clone(resumePositions[i]).(*TContinuationToken_TPosition)
+			sink.rowsToSkip = resumePositions[i].RowsAfterCursor
 		}
 
 		result = append(result, sink)
@@ -137,6 +163,9 @@ func NewSinkFactory[T Acceptor](
 	cfg *config.TPagingConfig,
 	columnarBufferFactory ColumnarBufferFactory[T],
 	readLimiter ReadLimiter,
+	continuationToken *TContinuationToken,
+	filter *filtering.Filter, // optional
+	limit *api_service_protos.TSelect_TLimit, // applied after filtering
 ) SinkFactory[T] {
 	sf := &sinkFactoryImpl[T]{
 		state:         sinkFactoryIdle,
@@ -146,6 +175,12 @@ func NewSinkFactory[T Acceptor](
 		cfg:           cfg,
 		ctx:           ctx,
 		logger:        logger,
+
+		continuationTracker: &continuationTracker{token: continuationToken, secret: cfg.ContinuationTokenSecret},
+	}
+
+	if filter != nil {
+		sf.rowFilter = newRowFilter(filter, limit)
 	}
 
 	return sf
@@ -5,6 +5,7 @@ import (
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/filtering"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
 )
@@ -20,6 +21,10 @@ type trafficTracker[T Acceptor] struct {
 	// sums of bytes and rows accumulated since last flush
 	bytesCurr *utils.Counter[uint64]
 	rowsCurr  *utils.Counter[uint64]
+
+	// sizes of the rows accumulated since last flush; kept only if some of the rows may be dropped
+	keepRowSizes bool
+	rowSizes     []uint64
 }
 
 // tryAddRow checks if the addition of the next row
@@ -48,9 +53,29 @@ func (tt *trafficTracker[T]) tryAddRow(acceptors []T) (bool, error) {
 	tt.bytesCurr.Add(totalBytes)
 	tt.rowsCurr.Add(1)
 
+	if tt.keepRowSizes {
+		tt.rowSizes = append(tt.rowSizes, totalBytes)
+	}
+
 	return true, nil
 }
 
+// keepRows excludes the rows dropped by the filter from the counters,
+// so that the stats describe only the data sent to the client.
+func (tt *trafficTracker[T]) keepRows(selection filtering.Selection) {
+	var keptBytes uint64
+
+	for _, r := range selection {
+		for _, size := range tt.rowSizes[r[0]:r[1]] {
+			keptBytes += size
+		}
+	}
+
+	tt.bytesCurr.Sub(tt.bytesCurr.Value() - keptBytes)
+	tt.rowsCurr.Sub(tt.rowsCurr.Value() - uint64(selection.Rows()))
+	tt.rowSizes = tt.rowSizes[:0]
+}
+
 func (tt *trafficTracker[T]) maybeInit(acceptors []T) error {
 	if tt.sizePattern == nil {
 		// lazy initialization when the first row is ready
@@ -95,6 +120,7 @@ func (tt *trafficTracker[T]) checkPageSizeLimit(bytesDelta, rowsDelta uint64) (b
 func (tt *trafficTracker[T]) refreshCounters() {
 	tt.bytesCurr = tt.bytesTotal.MakeChild()
 	tt.rowsCurr = tt.rowsTotal.MakeChild()
+	tt.rowSizes = tt.rowSizes[:0]
 }
 
 func (tt *trafficTracker[T]) DumpStats(total bool) *api_service_protos.TReadSplitsResponse_TStats {
@@ -114,11 +140,12 @@ func (tt *trafficTracker[T]) DumpStats(total bool) *api_service_protos.TReadSpli
 	return result
 }
 
-func newTrafficTracker[T Acceptor](pagination *config.TPagingConfig) *trafficTracker[T] {
+func newTrafficTracker[T Acceptor](pagination *config.TPagingConfig, keepRowSizes bool) *trafficTracker[T] {
 	tt := &trafficTracker[T]{
-		pagination: pagination,
-		bytesTotal: utils.NewCounter[uint64](),
-		rowsTotal:  utils.NewCounter[uint64](),
+		pagination:   pagination,
+		bytesTotal:   utils.NewCounter[uint64](),
+		rowsTotal:    utils.NewCounter[uint64](),
+		keepRowSizes: keepRowSizes,
 	}
 
 	tt.refreshCounters()
@@ -18,7 +18,7 @@ func TestTrafficTracker(t *testing.T) {
 			RowsPerPage: 2,
 		}
 
-		tt := newTrafficTracker[any](cfg)
+		tt := newTrafficTracker[any](cfg, false)
 
 		col1Acceptor := new(int32)
 		col2Acceptor := new(string)
@@ -88,7 +88,7 @@ func TestTrafficTracker(t *testing.T) {
 			BytesPerPage: 40,
 		}
 
-		tt := newTrafficTracker[any](cfg)
+		tt := newTrafficTracker[any](cfg, false)
 
 		col1Acceptor := new(uint64)
 		col2Acceptor := new([]byte)
@@ -128,7 +128,7 @@ func TestTrafficTracker(t *testing.T) {
 			BytesPerPage: 1,
 		}
 
-		tt := newTrafficTracker[any](cfg)
+		tt := newTrafficTracker[any](cfg, false)
 		col1Acceptor := new(int32)
 		acceptors := []any{col1Acceptor}
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/apache/arrow/go/v13/arrow/memory"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
@@ -19,6 +20,7 @@ import (
 	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
 	"github.com/ydb-platform/fq-connector-go/app/server/observation"
 	"github.com/ydb-platform/fq-connector-go/app/server/paging"
+	"github.com/ydb-platform/fq-connector-go/app/server/streaming"
 	"github.com/ydb-platform/fq-connector-go/app/server/utils"
 	"github.com/ydb-platform/fq-connector-go/common"
 	"github.com/ydb-platform/fq-connector-go/library/go/core/metrics/solomon"
@@ -33,7 +35,45 @@ type serviceConnector struct {
 	logger               *zap.Logger
 }
 
-func (*serviceConnector) ListTables(_ *api_service_protos.TListTablesRequest, _ api_service.Connector_ListTablesServer) error {
+func (s *serviceConnector) ListTables(
+	request *api_service_protos.TListTablesRequest,
+	stream api_service.Connector_ListTablesServer,
+) error {
+	logger := utils.LoggerMustFromContext(stream.Context())
+	logger = common.AnnotateLoggerWithDataSourceInstance(logger, request.DataSourceInstance)
+	logger.Info("request handling started", zap.String("pattern", request.GetPattern()))
+
+	if err := ValidateListTablesRequest(logger, request); err != nil {
+		return s.doListTablesErrorResponse(logger, stream, request, err)
+	}
+
+	if err := s.dataSourceCollection.ListTables(logger, stream, request); err != nil {
+		return s.doListTablesErrorResponse(logger, stream, request, err)
+	}
+
+	logger.Info("request handling finished")
+
+	return nil
+}
+
+func (*serviceConnector) doListTablesErrorResponse(
+	logger *zap.Logger,
+	stream api_service.Connector_ListTablesServer,
+	request *api_service_protos.TListTablesRequest,
+	err error,
+) error {
+	logger.Error("request handling failed", zap.Error(err))
+
+	response := &api_service_protos.TListTablesResponse{
+		Error: common.NewAPIErrorFromStdError(err, request.GetDataSourceInstance().GetKind()),
+	}
+
+	if err := stream.Send(response); err != nil {
+		logger.Error("send channel failed", zap.Error(err))
+
+		return err
+	}
+
 	return nil
 }
 
@@ -159,26 +199,61 @@ func (s *serviceConnector) doReadSplits(
 		return logger, fmt.Errorf("validate read splits request: %w", err)
 	}
 
-	for _, split := range request.Splits {
-		splitLogger := common.
-			AnnotateLoggerWithDataSourceInstance(logger, split.Select.DataSourceInstance).
-			With(zap.Uint64("split_sequential_id", split.Id))
+	multiplexer := streaming.NewReadSplitsStreamMultiplexer(stream)
 
-		err := s.dataSourceCollection.ReadSplit(
-			splitLogger,
-			stream,
-			request,
-			split,
-		)
+	// Splits are read one by one unless the client allows to interleave the data of different splits
+	if request.Mode != api_service_protos.TReadSplitsRequest_UNORDERED {
+		for i, split := range request.Splits {
+			splitLogger := makeSplitLogger(logger, split)
+
+			err := s.dataSourceCollection.ReadSplit(
+				splitLogger,
+				multiplexer.MakeSplitStream(stream.Context(), uint32(i)),
+				request,
+				split,
+			)
 
-		if err != nil {
-			return splitLogger, fmt.Errorf("read split %d: %w", split.Id, err)
+			if err != nil {
+				return splitLogger, fmt.Errorf("read split %d: %w", split.Id, err)
+			}
 		}
+
+		return logger, nil
+	}
+
+	// The failure of any split cancels the reading of the others
+	group, ctx := errgroup.WithContext(stream.Context())
+	group.SetLimit(int(s.cfg.ConnectorServer.MaxConcurrentSplits))
+
+	logger.Debug("reading splits concurrently", zap.Uint32("max_concurrent_splits", s.cfg.ConnectorServer.MaxConcurrentSplits))
+
+	for i, split := range request.Splits {
+		splitLogger := makeSplitLogger(logger, split)
+		splitStream := multiplexer.MakeSplitStream(ctx, uint32(i))
+		split := split
+
+		group.Go(func() error {
+			if err := s.dataSourceCollection.ReadSplit(splitLogger, splitStream, request, split); err != nil {
+				return fmt.Errorf("read split %d: %w", split.Id, err)
+			}
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return logger, err
 	}
 
 	return logger, nil
 }
 
+func makeSplitLogger(logger *zap.Logger, split *api_service_protos.TSplit) *zap.Logger {
+	return common.
+		AnnotateLoggerWithDataSourceInstance(logger, split.Select.DataSourceInstance).
+		With(zap.Uint64("split_sequential_id", split.Id))
+}
+
 func (s *serviceConnector) Start() error {
 	s.logger.Info("starting GRPC server", zap.String("address", s.listener.Addr().String()))
 
@@ -273,12 +348,14 @@ func newServiceConnector(
 	reflection.Register(grpcServer)
 
 	dataSourceCollection, err := NewDataSourceCollection(
+		logger,
 		queryLoggerFactory,
 		memory.DefaultAllocator,
 		paging.NewReadLimiterFactory(cfg.Datasources),
 		conversion.NewCollection(cfg.Conversion),
 		observationStorage,
 		cfg,
+		registry,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("new data source collection: %w", err)
@@ -70,6 +70,7 @@ func (s *ReadSplitsStreamer[T]) sendResultToStream(result *paging.ReadResult[T])
 	}
 
 	resp.Stats = result.Stats
+	resp.Continuation = result.Continuation
 
 	// if stream is finished, assign successful operation code
 	if result.IsTerminalMessage {
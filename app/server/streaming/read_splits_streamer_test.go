@@ -98,6 +98,9 @@ func (*streamMock) makeSendMatcher(
 		require.NotNil(t, response.Stats)
 		require.Equal(t, uint64(len(expectedColumnarBlock[0])), response.Stats.Rows)
 
+		// Check continuation token
+		require.NotEmpty(t, response.GetContinuation().GetDescription())
+
 		// TODO: come up with more elegant way of expected data size computing
 		var expectedBytes int
 
@@ -274,11 +277,17 @@ func (tc testCaseStreaming) execute(t *testing.T) {
 		split.Select.What)
 	require.NoError(t, err)
 
-	pagingCfg := &config.TPagingConfig{RowsPerPage: uint64(tc.rowsPerPage)}
+	pagingCfg := &config.TPagingConfig{
+		RowsPerPage:             uint64(tc.rowsPerPage),
+		ContinuationTokenSecret: "a-secret-that-is-at-least-32-bytes-long",
+	}
 	readLimiterFactory := paging.NewReadLimiterFactory(nil)
 	readLimiter := readLimiterFactory.MakeReadLimiter(logger, split.Select.DataSourceInstance.Kind)
 
-	sinkFactory := paging.NewSinkFactory(ctx, logger, pagingCfg, columnarBufferFactory, readLimiter)
+	continuationToken, err := paging.MakeContinuationToken(split, &api_service_protos.TContinuation{}, pagingCfg.ContinuationTokenSecret)
+	require.NoError(t, err)
+
+	sinkFactory := paging.NewSinkFactory(ctx, logger, pagingCfg, columnarBufferFactory, readLimiter, continuationToken, nil, nil)
 
 	request := &api_service_protos.TReadSplitsRequest{}
 	streamer := NewReadSplitsStreamer(logger, "test-query-id", stream, request, split, sinkFactory, dataSource)
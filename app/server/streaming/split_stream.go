@@ -0,0 +1,60 @@
+package streaming
+
+import (
+	"context"
+	"sync"
+
+	api_service "github.com/ydb-platform/fq-connector-go/api/service"
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+// ReadSplitsStreamMultiplexer shares the response stream of a ReadSplits request
+// between the splits that are read concurrently. GRPC streams do not allow concurrent
+// calls of Send, so every split stream sends the responses under the common lock.
+type ReadSplitsStreamMultiplexer struct {
+	stream api_service.Connector_ReadSplitsServer
+	mutex  sync.Mutex
+}
+
+// MakeSplitStream returns the stream for the split with the given index within the request.
+// The responses sent to this stream are tagged with the split index.
+// Context allows to cancel the reading of the split independently from the request.
+func (m *ReadSplitsStreamMultiplexer) MakeSplitStream(
+	ctx context.Context,
+	splitIndex uint32,
+) api_service.Connector_ReadSplitsServer {
+	return &splitStream{
+		Connector_ReadSplitsServer: m.stream,
+		ctx:                        ctx,
+		multiplexer:                m,
+		splitIndex:                 splitIndex,
+	}
+}
+
+func (m *ReadSplitsStreamMultiplexer) send(response *api_service_protos.TReadSplitsResponse) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.stream.Send(response)
+}
+
+func NewReadSplitsStreamMultiplexer(stream api_service.Connector_ReadSplitsServer) *ReadSplitsStreamMultiplexer {
+	return &ReadSplitsStreamMultiplexer{stream: stream}
+}
+
+var _ api_service.Connector_ReadSplitsServer = (*splitStream)(nil)
+
+type splitStream struct {
+	api_service.Connector_ReadSplitsServer
+	ctx         context.Context
+	multiplexer *ReadSplitsStreamMultiplexer
+	splitIndex  uint32
+}
+
+func (s *splitStream) Context() context.Context { return s.ctx }
+
+func (s *splitStream) Send(response *api_service_protos.TReadSplitsResponse) error {
+	response.SplitIndexNumber = s.splitIndex
+
+	return s.multiplexer.send(response)
+}
@@ -0,0 +1,63 @@
+package streaming
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+func TestReadSplitsStreamMultiplexer(t *testing.T) {
+	const (
+		splits            = 8
+		responsesPerSplit = 100
+	)
+
+	var (
+		concurrentSends atomic.Int32
+		received        [splits]int
+	)
+
+	stream := &streamMock{}
+	stream.On("Send", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		// The calls of Send must never overlap
+		require.EqualValues(t, 1, concurrentSends.Add(1))
+		defer concurrentSends.Add(-1)
+
+		response := args.Get(0).(*api_service_protos.TReadSplitsResponse)
+		received[response.SplitIndexNumber]++
+	})
+
+	multiplexer := NewReadSplitsStreamMultiplexer(stream)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < splits; i++ {
+		splitStream := multiplexer.MakeSplitStream(ctx, uint32(i))
+		require.Equal(t, ctx, splitStream.Context())
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < responsesPerSplit; j++ {
+				require.NoError(t, splitStream.Send(&api_service_protos.TReadSplitsResponse{}))
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	for i := 0; i < splits; i++ {
+		require.Equal(t, responsesPerSplit, received[i], "split %d", i)
+	}
+}
@@ -3,6 +3,8 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
 
 	"github.com/apache/arrow/go/v13/arrow/array"
 
@@ -97,3 +99,164 @@ func AppendValueToArrowBuilderNullable[IN common.ValueType, OUT common.ValueType
 
 	return nil
 }
+
+// MakeListAcceptor makes the acceptor for the array of items, each accepted just like the itemAcceptor:
+// *[]T for the `new(T)` item acceptor, or **[]T if the array itself is nullable.
+func MakeListAcceptor(itemAcceptor any, nullable bool) any {
+	sliceType := reflect.SliceOf(reflect.TypeOf(itemAcceptor).Elem())
+
+	if nullable {
+		return reflect.New(reflect.PointerTo(sliceType)).Interface()
+	}
+
+	return reflect.New(sliceType).Interface()
+}
+
+// MakeListAppender makes the appender for the acceptor produced by MakeListAcceptor;
+// every item of the array is passed to the itemAppender along with the builder of list values.
+func MakeListAppender(
+	itemAppender func(acceptor any, builder array.Builder) error,
+	nullable bool,
+) func(acceptor any, builder array.Builder) error {
+	return func(acceptor any, builder array.Builder) error {
+		items := reflect.ValueOf(acceptor).Elem()
+
+		if nullable {
+			if items.IsNil() {
+				builder.AppendNull()
+
+				return nil
+			}
+
+			items = items.Elem()
+		}
+
+		//nolint:forcetypeassert
+		listBuilder := builder.(*array.ListBuilder)
+		listBuilder.Append(true)
+
+		for i := 0; i < items.Len(); i++ {
+			if err := itemAppender(items.Index(i).Addr().Interface(), listBuilder.ValueBuilder()); err != nil {
+				return fmt.Errorf("append item #%d: %w", i, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// MakeMapAcceptor makes the acceptor for the map with keys and values accepted like the keyAcceptor and valueAcceptor:
+// *map[K]V for the `new(K)` and `new(V)` acceptors.
+func MakeMapAcceptor(keyAcceptor, valueAcceptor any) any {
+	mapType := reflect.MapOf(reflect.TypeOf(keyAcceptor).Elem(), reflect.TypeOf(valueAcceptor).Elem())
+
+	return reflect.New(mapType).Interface()
+}
+
+// MakeMapAppender makes the appender for the acceptor produced by MakeMapAcceptor.
+// Entries are appended in the order of their keys to make the output deterministic.
+func MakeMapAppender(
+	keyAppender func(acceptor any, builder array.Builder) error,
+	valueAppender func(acceptor any, builder array.Builder) error,
+) func(acceptor any, builder array.Builder) error {
+	return func(acceptor any, builder array.Builder) error {
+		entries := reflect.ValueOf(acceptor).Elem()
+
+		//nolint:forcetypeassert
+		mapBuilder := builder.(*array.MapBuilder)
+		mapBuilder.Append(true)
+
+		keys := entries.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return lessReflectValues(keys[i], keys[j]) })
+
+		key := reflect.New(entries.Type().Key())
+		value := reflect.New(entries.Type().Elem())
+
+		for _, k := range keys {
+			key.Elem().Set(k)
+			value.Elem().Set(entries.MapIndex(k))
+
+			if err := keyAppender(key.Interface(), mapBuilder.KeyBuilder()); err != nil {
+				return fmt.Errorf("append key %v: %w", k, err)
+			}
+
+			if err := valueAppender(value.Interface(), mapBuilder.ItemBuilder()); err != nil {
+				return fmt.Errorf("append value for key %v: %w", k, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+func lessReflectValues(lhs, rhs reflect.Value) bool {
+	switch {
+	case lhs.CanInt():
+		return lhs.Int() < rhs.Int()
+	case lhs.CanUint():
+		return lhs.Uint() < rhs.Uint()
+	case lhs.CanFloat():
+		return lhs.Float() < rhs.Float()
+	case lhs.Kind() == reflect.String:
+		return lhs.String() < rhs.String()
+	default:
+		return fmt.Sprint(lhs.Interface()) < fmt.Sprint(rhs.Interface())
+	}
+}
+
+// AppendItemsToStructBuilder appends the dynamically typed items (like the elements of a tuple) to the struct fields.
+// Every item is put into a new acceptor of the same type as the corresponding field acceptor
+// and then passed to the field appender.
+func AppendItemsToStructBuilder(
+	items []any,
+	fieldAcceptors []any,
+	fieldAppenders []func(acceptor any, builder array.Builder) error,
+	builder *array.StructBuilder,
+) error {
+	if len(items) != len(fieldAppenders) {
+		return fmt.Errorf("expected %d items, got %d", len(fieldAppenders), len(items))
+	}
+
+	builder.Append(true)
+
+	for i, item := range items {
+		acceptor := reflect.New(reflect.TypeOf(fieldAcceptors[i]).Elem())
+
+		if err := assignItem(acceptor.Elem(), item); err != nil {
+			return fmt.Errorf("assign item #%d: %w", i, err)
+		}
+
+		if err := fieldAppenders[i](acceptor.Interface(), builder.FieldBuilder(i)); err != nil {
+			return fmt.Errorf("append item #%d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// assignItem puts the item into the acceptor value taking into account that either of them may be a pointer
+func assignItem(dst reflect.Value, item any) error {
+	if item == nil {
+		// the zero value of a pointer acceptor stands for NULL
+		return nil
+	}
+
+	src := reflect.ValueOf(item)
+
+	switch {
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+	case dst.Kind() == reflect.Pointer && src.Type().AssignableTo(dst.Type().Elem()):
+		ptr := reflect.New(dst.Type().Elem())
+		ptr.Elem().Set(src)
+		dst.Set(ptr)
+	case src.Kind() == reflect.Pointer && src.Type().Elem().AssignableTo(dst.Type()):
+		if !src.IsNil() {
+			dst.Set(src.Elem())
+		}
+	default:
+		return fmt.Errorf("value of type %T cannot be assigned to %v: %w", item, dst.Type(), common.ErrDataTypeNotSupported)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/v13/arrow"
+	"github.com/apache/arrow/go/v13/arrow/array"
+	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ydb-platform/fq-connector-go/app/config"
+	"github.com/ydb-platform/fq-connector-go/app/server/conversion"
+	"github.com/ydb-platform/fq-connector-go/common"
+	"github.com/ydb-platform/fq-connector-go/library/go/ptr"
+)
+
+func TestListAppender(t *testing.T) {
+	cc := conversion.NewCollection(&config.TConversionConfig{})
+
+	t.Run("non-nullable", func(t *testing.T) {
+		acceptor := MakeListAcceptor(new(int32), false)
+		require.IsType(t, new([]int32), acceptor)
+
+		appender := MakeListAppender(MakeAppender[int32, int32, *array.Int32Builder](cc.Int32()), false)
+
+		builder := array.NewListBuilder(memory.NewGoAllocator(), arrow.PrimitiveTypes.Int32)
+		defer builder.Release()
+
+		for _, value := range [][]int32{{1, 2}, {}, {3}} {
+			*acceptor.(*[]int32) = value
+			require.NoError(t, appender(acceptor, builder))
+		}
+
+		result := builder.NewListArray()
+		defer result.Release()
+
+		require.Equal(t, 3, result.Len())
+		require.Equal(t, "[1,2]", result.ValueStr(0))
+		require.Equal(t, "[]", result.ValueStr(1))
+		require.Equal(t, "[3]", result.ValueStr(2))
+	})
+
+	t.Run("nullable", func(t *testing.T) {
+		acceptor := MakeListAcceptor(new(*int32), true)
+		require.IsType(t, new(*[]*int32), acceptor)
+
+		appender := MakeListAppender(MakeAppenderNullable[int32, int32, *array.Int32Builder](cc.Int32()), true)
+
+		builder := array.NewListBuilder(memory.NewGoAllocator(), arrow.PrimitiveTypes.Int32)
+		defer builder.Release()
+
+		for _, value := range []*[]*int32{{ptr.Int32(1), nil}, nil} {
+			*acceptor.(**[]*int32) = value
+			require.NoError(t, appender(acceptor, builder))
+		}
+
+		result := builder.NewListArray()
+		defer result.Release()
+
+		require.Equal(t, 2, result.Len())
+		require.Equal(t, "[1,null]", result.ValueStr(0))
+		require.True(t, result.IsNull(1))
+	})
+}
+
+func TestAppendItemsToStructBuilder(t *testing.T) {
+	cc := conversion.NewCollection(&config.TConversionConfig{})
+
+	fieldAcceptors := []any{new(int32), new(*int32)}
+	fieldAppenders := []func(acceptor any, builder array.Builder) error{
+		MakeAppender[int32, int32, *array.Int32Builder](cc.Int32()),
+		MakeAppenderNullable[int32, int32, *array.Int32Builder](cc.Int32()),
+	}
+
+	structType := arrow.StructOf(
+		arrow.Field{Name: "field0", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		arrow.Field{Name: "field1", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	)
+
+	builder := array.NewStructBuilder(memory.NewGoAllocator(), structType)
+	defer builder.Release()
+
+	// items may be passed both by value and by pointer
+	require.NoError(t, AppendItemsToStructBuilder([]any{int32(1), int32(2)}, fieldAcceptors, fieldAppenders, builder))
+	require.NoError(t, AppendItemsToStructBuilder([]any{ptr.Int32(3), nil}, fieldAcceptors, fieldAppenders, builder))
+
+	result := builder.NewStructArray()
+	defer result.Release()
+
+	require.Equal(t, 2, result.Len())
+	require.Equal(t, `{"field0":1,"field1":2}`, result.ValueStr(0))
+	require.Equal(t, `{"field0":3,"field1":null}`, result.ValueStr(1))
+
+	require.Error(t, AppendItemsToStructBuilder([]any{int32(1)}, fieldAcceptors, fieldAppenders, builder))
+	require.ErrorIs(t, AppendItemsToStructBuilder([]any{"1", int32(2)}, fieldAcceptors, fieldAppenders, builder),
+		common.ErrDataTypeNotSupported)
+}
@@ -19,6 +19,14 @@ func (c *Counter[T]) Add(delta T) {
 	c.value += delta
 }
 
+func (c *Counter[T]) Sub(delta T) {
+	if c.parent != nil {
+		c.parent.value -= delta
+	}
+
+	c.value -= delta
+}
+
 func (c *Counter[T]) Value() T {
 	return c.value
 }
@@ -2,6 +2,7 @@ package server
 
 import (
 	"fmt"
+	"regexp"
 
 	"go.uber.org/zap"
 
@@ -22,6 +23,18 @@ func ValidateDescribeTableRequest(logger *zap.Logger, request *api_service_proto
 	return nil
 }
 
+func ValidateListTablesRequest(logger *zap.Logger, request *api_service_protos.TListTablesRequest) error {
+	if err := validateDataSourceInstance(logger, request.GetDataSourceInstance()); err != nil {
+		return fmt.Errorf("validate data source instance: %w", err)
+	}
+
+	if _, err := regexp.Compile(request.GetPattern()); err != nil {
+		return fmt.Errorf("invalid pattern '%s': %w", request.GetPattern(), common.ErrInvalidRequest)
+	}
+
+	return nil
+}
+
 func ValidateListSplitsRequest(logger *zap.Logger, request *api_service_protos.TListSplitsRequest) error {
 	if len(request.Selects) == 0 {
 		return fmt.Errorf("empty select list: %w", common.ErrInvalidRequest)
@@ -40,7 +53,13 @@ func ValidateListSplitsRequest(logger *zap.Logger, request *api_service_protos.T
 				if request.MaxSplitCount != 3 {
 					return fmt.Errorf("invalid max split count: %d", request.MaxSplitCount)
 				}
-			case api_common.EGenericDataSourceKind_YDB:
+			case api_common.EGenericDataSourceKind_YDB,
+				api_common.EGenericDataSourceKind_CLICKHOUSE,
+				api_common.EGenericDataSourceKind_POSTGRESQL,
+				api_common.EGenericDataSourceKind_PROMETHEUS:
+			case api_common.EGenericDataSourceKind_GREENPLUM:
+				// there is no TID Range Scan, and the same `ctid` values are found on every segment
+				return fmt.Errorf("max split count is not supported for Greenplum tables: %w", common.ErrInvalidRequest)
 			default:
 				return fmt.Errorf("unsupported data source kind: %s", slct.DataSourceInstance.Kind)
 			}
@@ -63,6 +82,11 @@ func ValidateReadSplitsRequest(logger *zap.Logger, request *api_service_protos.T
 		return fmt.Errorf("splits are empty: %w", common.ErrInvalidRequest)
 	}
 
+	// Continuation token is bound to a single split
+	if request.Continuation != nil && len(request.Splits) != 1 {
+		return fmt.Errorf("continuation is allowed only for a single split: %w", common.ErrInvalidRequest)
+	}
+
 	for i, split := range request.Splits {
 		if err := validateSplit(logger, split); err != nil {
 			return fmt.Errorf("validate split #%d: %w", i, err)
@@ -89,6 +113,32 @@ func validateSelect(logger *zap.Logger, slct *api_service_protos.TSelect) error
 		return fmt.Errorf("validate data source instance: %w", err)
 	}
 
+	if err := validateOrderBy(slct.GetOrderBy()); err != nil {
+		return fmt.Errorf("validate order by: %w", err)
+	}
+
+	return nil
+}
+
+func validateOrderBy(orderBy *api_service_protos.TSelect_TOrderBy) error {
+	if orderBy == nil {
+		return nil
+	}
+
+	if len(orderBy.Keys) == 0 {
+		return fmt.Errorf("empty list of sort keys: %w", common.ErrInvalidRequest)
+	}
+
+	for i, key := range orderBy.Keys {
+		if key.Column == "" {
+			return fmt.Errorf("empty column name in sort key #%d: %w", i, common.ErrInvalidRequest)
+		}
+
+		if key.Order == api_service_protos.TSelect_TOrderBy_SORT_ORDER_UNSPECIFIED {
+			return fmt.Errorf("unspecified sort order for column '%s': %w", key.Column, common.ErrInvalidRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -105,7 +155,9 @@ func validateDataSourceInstance(logger *zap.Logger, dsi *api_common.TGenericData
 	case api_common.EGenericDataSourceKind_DATA_SOURCE_KIND_UNSPECIFIED:
 		return fmt.Errorf("empty kind: %w", common.ErrInvalidRequest)
 	case api_common.EGenericDataSourceKind_LOGGING:
-	case api_common.EGenericDataSourceKind_ORACLE:
+	case api_common.EGenericDataSourceKind_ICEBERG:
+		// The warehouse location is used instead of the endpoint, the namespace may be empty
+	case api_common.EGenericDataSourceKind_ORACLE, api_common.EGenericDataSourceKind_PROMETHEUS:
 		validators = append(validators, validateEndpoint, validateUseTLS(logger))
 	default:
 		validators = append(validators, validateEndpoint, validateDatabase, validateUseTLS(logger))
@@ -142,13 +194,22 @@ func validateDataSourceOptions(dsi *api_common.TGenericDataSourceInstance) error
 		if dsi.GetLoggingOptions().GetFolderId() == "" {
 			return fmt.Errorf("folder_id field is empty: %w", common.ErrInvalidRequest)
 		}
+	case api_common.EGenericDataSourceKind_ICEBERG:
+		if dsi.GetIcebergOptions().GetCatalog().GetHadoop() == nil {
+			return fmt.Errorf("catalog field is empty or unsupported: %w", common.ErrInvalidRequest)
+		}
+
+		if dsi.GetIcebergOptions().GetWarehouse().GetS3().GetUri() == "" {
+			return fmt.Errorf("warehouse.s3.uri field is empty: %w", common.ErrInvalidRequest)
+		}
 	case api_common.EGenericDataSourceKind_CLICKHOUSE,
 		api_common.EGenericDataSourceKind_S3,
 		api_common.EGenericDataSourceKind_YDB,
 		api_common.EGenericDataSourceKind_MYSQL,
 		api_common.EGenericDataSourceKind_MONGO_DB,
 		api_common.EGenericDataSourceKind_REDIS,
-		api_common.EGenericDataSourceKind_OPENSEARCH:
+		api_common.EGenericDataSourceKind_OPENSEARCH,
+		api_common.EGenericDataSourceKind_PROMETHEUS:
 	default:
 		return fmt.Errorf("unsupported data source %s: %w", dsi.GetKind().String(), common.ErrInvalidRequest)
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/apache/arrow/go/v13/arrow"
 	"github.com/apache/arrow/go/v13/arrow/ipc"
+	"google.golang.org/protobuf/proto"
 
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 )
@@ -20,6 +21,21 @@ func ListSplitsResponsesToSplits(in []*api_service_protos.TListSplitsResponse) [
 	return out
 }
 
+// SelectWithoutOrderBy returns a copy of the select with ORDER BY removed.
+// Data sources that cannot sort rows return their splits this way, so YDB sorts the rows itself.
+// LIMIT and OFFSET are removed too, because they cannot be applied to unordered rows.
+func SelectWithoutOrderBy(slct *api_service_protos.TSelect) *api_service_protos.TSelect {
+	if slct.GetOrderBy() == nil {
+		return slct
+	}
+
+	out := proto.Clone(slct).(*api_service_protos.TSelect)
+	out.OrderBy = nil
+	out.Limit = nil
+
+	return out
+}
+
 func SchemaToSelectWhatItems(
 	schema *api_service_protos.TSchema,
 	whitelist map[string]struct{},
@@ -27,7 +27,7 @@ type ArrowArrayType[VT ValueType] interface {
 		*array.Int8 | *array.Int16 | *array.Int32 | *array.Int64 |
 		*array.Uint8 | *array.Uint16 | *array.Uint32 | *array.Uint64 |
 		*array.Float32 | *array.Float64 |
-		*array.String | *array.Binary
+		*array.String | *array.Binary | *array.FixedSizeBinary
 
 	Len() int
 	Value(int) VT
@@ -40,14 +40,14 @@ type ArrowBuilder[VT ValueType] interface {
 }
 
 func SelectWhatToArrowSchema(selectWhat *api_service_protos.TSelect_TWhat) (*arrow.Schema, error) {
-	fields := make([]arrow.Field, 0, len(selectWhat.Items))
+	ydbColumns, err := SelectWhatToYDBColumns(selectWhat)
+	if err != nil {
+		return nil, err
+	}
 
-	for i, item := range selectWhat.Items {
-		column := item.GetColumn()
-		if column == nil {
-			return nil, fmt.Errorf("item #%d (%v) is not a column", i, item)
-		}
+	fields := make([]arrow.Field, 0, len(ydbColumns))
 
+	for _, column := range ydbColumns {
 		field, err := ydbTypeToArrowField(column.GetType(), column)
 		if err != nil {
 			return nil, err
@@ -80,6 +80,7 @@ func YdbTypesToArrowBuilders(ydbTypes []*Ydb.Type, arrowAllocator memory.Allocat
 	return builders, nil
 }
 
+//nolint:funlen,gocyclo
 func ydbTypeToArrowBuilder(ydbType *Ydb.Type, arrowAllocator memory.Allocator) (array.Builder, error) {
 	var (
 		builder array.Builder
@@ -102,6 +103,34 @@ func ydbTypeToArrowBuilder(ydbType *Ydb.Type, arrowAllocator memory.Allocator) (
 		if err != nil {
 			return nil, fmt.Errorf("tagged YDB type to Arrow builder: %w", err)
 		}
+	case *Ydb.Type_DecimalType:
+		builder = array.NewFixedSizeBinaryBuilder(arrowAllocator, &arrow.FixedSizeBinaryType{ByteWidth: DecimalSize})
+	case *Ydb.Type_ListType:
+		itemField, err := ydbTypeToArrowField(t.ListType.Item, &Ydb.Column{Name: "item"})
+		if err != nil {
+			return nil, fmt.Errorf("map YDB type to Arrow field for list item: %w", err)
+		}
+
+		builder = array.NewListBuilder(arrowAllocator, itemField.Type)
+	case *Ydb.Type_DictType:
+		keyField, err := ydbTypeToArrowField(t.DictType.Key, &Ydb.Column{Name: "key"})
+		if err != nil {
+			return nil, fmt.Errorf("map YDB type to Arrow field for dict key: %w", err)
+		}
+
+		payloadField, err := ydbTypeToArrowField(t.DictType.Payload, &Ydb.Column{Name: "value"})
+		if err != nil {
+			return nil, fmt.Errorf("map YDB type to Arrow field for dict payload: %w", err)
+		}
+
+		builder = array.NewMapBuilder(arrowAllocator, keyField.Type, payloadField.Type, false)
+	case *Ydb.Type_TupleType:
+		structType, err := tupleToArrowStructType(t.TupleType)
+		if err != nil {
+			return nil, fmt.Errorf("map YDB tuple type to Arrow struct type: %w", err)
+		}
+
+		builder = array.NewStructBuilder(arrowAllocator, structType)
 	case *Ydb.Type_StructType:
 		fields := make([]arrow.Field, 0, len(t.StructType.Members))
 
@@ -119,7 +148,7 @@ func ydbTypeToArrowBuilder(ydbType *Ydb.Type, arrowAllocator memory.Allocator) (
 		builder = array.NewStructBuilder(arrowAllocator, structType)
 	default:
 		err := fmt.Errorf(
-			"only primitive, optional, tagged and struct types are supported, got '%T' instead: %w",
+			"only primitive, decimal, optional, tagged, list, dict, tuple and struct types are supported, got '%T' instead: %w",
 			t, ErrDataTypeNotSupported,
 		)
 
@@ -180,8 +209,12 @@ func ydbTypeIdToArrowBuilder(typeID Ydb.Type_PrimitiveTypeId, arrowAllocator mem
 		builder = array.NewUint32Builder(arrowAllocator)
 	case Ydb.Type_TIMESTAMP:
 		builder = array.NewUint64Builder(arrowAllocator)
+	case Ydb.Type_INTERVAL:
+		builder = array.NewInt64Builder(arrowAllocator)
 	case Ydb.Type_JSON_DOCUMENT:
 		builder = array.NewBinaryBuilder(arrowAllocator, arrow.BinaryTypes.Binary)
+	case Ydb.Type_UUID:
+		builder = array.NewFixedSizeBinaryBuilder(arrowAllocator, &arrow.FixedSizeBinaryType{ByteWidth: UUIDSize})
 	default:
 		return nil, fmt.Errorf("register type '%v': %w", typeID, ErrDataTypeNotSupported)
 	}
@@ -192,7 +225,7 @@ func ydbTypeIdToArrowBuilder(typeID Ydb.Type_PrimitiveTypeId, arrowAllocator mem
 	return builder, nil
 }
 
-//nolint:gocyclo
+//nolint:funlen,gocyclo
 func ydbTypeToArrowField(ydbType *Ydb.Type, column *Ydb.Column) (arrow.Field, error) {
 	// Reference table: https://github.com/ydb-platform/fq-connector-go/blob/main/docs/type_mapping_table.md
 	var (
@@ -216,6 +249,36 @@ func ydbTypeToArrowField(ydbType *Ydb.Type, column *Ydb.Column) (arrow.Field, er
 		if err != nil {
 			return arrow.Field{}, fmt.Errorf("tagged YDB type to arrow field: %w", err)
 		}
+	case *Ydb.Type_DecimalType:
+		// YDB Decimal is a 128-bit little-endian integer scaled according to the type parameters
+		field = arrow.Field{Name: column.Name, Type: &arrow.FixedSizeBinaryType{ByteWidth: DecimalSize}}
+	case *Ydb.Type_ListType:
+		itemField, err := ydbTypeToArrowField(t.ListType.Item, &Ydb.Column{Name: "item"})
+		if err != nil {
+			return arrow.Field{}, fmt.Errorf("map YDB type to Arrow field for list item: %w", err)
+		}
+
+		// Arrow list items are always nullable, so both List<T> and List<Optional<T>> share the same layout
+		field = arrow.Field{Name: column.Name, Type: arrow.ListOf(itemField.Type)}
+	case *Ydb.Type_DictType:
+		keyField, err := ydbTypeToArrowField(t.DictType.Key, &Ydb.Column{Name: "key"})
+		if err != nil {
+			return arrow.Field{}, fmt.Errorf("map YDB type to Arrow field for dict key: %w", err)
+		}
+
+		payloadField, err := ydbTypeToArrowField(t.DictType.Payload, &Ydb.Column{Name: "value"})
+		if err != nil {
+			return arrow.Field{}, fmt.Errorf("map YDB type to Arrow field for dict payload: %w", err)
+		}
+
+		field = arrow.Field{Name: column.Name, Type: arrow.MapOf(keyField.Type, payloadField.Type)}
+	case *Ydb.Type_TupleType:
+		structType, err := tupleToArrowStructType(t.TupleType)
+		if err != nil {
+			return arrow.Field{}, fmt.Errorf("map YDB tuple type to Arrow struct type: %w", err)
+		}
+
+		field = arrow.Field{Name: column.Name, Type: structType, Nullable: true}
 	case *Ydb.Type_StructType:
 		fields := make([]arrow.Field, 0, len(t.StructType.Members))
 
@@ -236,7 +299,7 @@ func ydbTypeToArrowField(ydbType *Ydb.Type, column *Ydb.Column) (arrow.Field, er
 		}
 	default:
 		err := fmt.Errorf(
-			"only primitive, optional, tagged and struct types are supported, got '%T' instead: %w",
+			"only primitive, decimal, optional, tagged, list, dict, tuple and struct types are supported, got '%T' instead: %w",
 			t, ErrDataTypeNotSupported,
 		)
 
@@ -246,6 +309,23 @@ func ydbTypeToArrowField(ydbType *Ydb.Type, column *Ydb.Column) (arrow.Field, er
 	return field, nil
 }
 
+// tupleToArrowStructType represents the tuple as a struct with the fields named after the element positions
+func tupleToArrowStructType(tupleType *Ydb.TupleType) (*arrow.StructType, error) {
+	fields := make([]arrow.Field, 0, len(tupleType.Elements))
+
+	for i, element := range tupleType.Elements {
+		field, err := ydbTypeToArrowField(element, &Ydb.Column{Name: fmt.Sprintf("field%d", i)})
+		if err != nil {
+			return nil, fmt.Errorf("map YDB type to Arrow field for tuple element #%d: %w", i, err)
+		}
+
+		field.Nullable = true
+		fields = append(fields, field)
+	}
+
+	return arrow.StructOf(fields...), nil
+}
+
 //nolint:gocyclo
 func ydbTypeIdToArrowField(typeID Ydb.Type_PrimitiveTypeId, column *Ydb.Column) (arrow.Field, error) {
 	var field arrow.Field
@@ -289,8 +369,12 @@ func ydbTypeIdToArrowField(typeID Ydb.Type_PrimitiveTypeId, column *Ydb.Column)
 		field = arrow.Field{Name: column.Name, Type: arrow.PrimitiveTypes.Uint32}
 	case Ydb.Type_TIMESTAMP:
 		field = arrow.Field{Name: column.Name, Type: arrow.PrimitiveTypes.Uint64}
+	case Ydb.Type_INTERVAL:
+		field = arrow.Field{Name: column.Name, Type: arrow.PrimitiveTypes.Int64}
 	case Ydb.Type_JSON_DOCUMENT:
 		field = arrow.Field{Name: column.Name, Type: arrow.BinaryTypes.Binary}
+	case Ydb.Type_UUID:
+		field = arrow.Field{Name: column.Name, Type: &arrow.FixedSizeBinaryType{ByteWidth: UUIDSize}}
 	default:
 		return arrow.Field{}, fmt.Errorf("register type '%v': %w", typeID, ErrDataTypeNotSupported)
 	}
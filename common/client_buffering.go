@@ -7,6 +7,7 @@ import (
 
 	"go.uber.org/zap"
 
+	api_common "github.com/ydb-platform/fq-connector-go/api/common"
 	api_service "github.com/ydb-platform/fq-connector-go/api/service"
 	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
 	"github.com/ydb-platform/fq-connector-go/app/config"
@@ -16,6 +17,27 @@ type ClientBuffering struct {
 	clientBasic
 }
 
+func (c *ClientBuffering) ListTables(
+	ctx context.Context,
+	dsi *api_common.TGenericDataSourceInstance,
+	pattern string,
+) ([]*api_service_protos.TListTablesResponse, error) {
+	request := &api_service_protos.TListTablesRequest{
+		DataSourceInstance: dsi,
+	}
+
+	if pattern != "" {
+		request.Filtering = &api_service_protos.TListTablesRequest_Pattern{Pattern: pattern}
+	}
+
+	rcvStream, err := c.client.ListTables(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+
+	return dumpStream[*api_service_protos.TListTablesResponse](rcvStream)
+}
+
 func (c *ClientBuffering) ListSplits(
 	ctx context.Context,
 	slct *api_service_protos.TSelect,
@@ -16,7 +16,9 @@ import (
 )
 
 type StreamResponse interface {
-	*api_service_protos.TListSplitsResponse | *api_service_protos.TReadSplitsResponse
+	*api_service_protos.TListTablesResponse |
+		*api_service_protos.TListSplitsResponse |
+		*api_service_protos.TReadSplitsResponse
 
 	GetError() *api_service_protos.TError
 }
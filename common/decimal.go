@@ -0,0 +1,131 @@
+package common
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+)
+
+// DecimalMaxPrecision is the maximum number of digits YDB Decimal can keep.
+const DecimalMaxPrecision = 35
+
+// DecimalSize is the size of YDB Decimal value in bytes; it is passed in Arrow as a fixed size binary.
+const DecimalSize = 16
+
+var (
+	decimalInf = new(big.Int).Exp(big.NewInt(10), big.NewInt(DecimalMaxPrecision), nil)
+	decimalNaN = new(big.Int).Add(decimalInf, big.NewInt(1))
+
+	two128 = new(big.Int).Lsh(big.NewInt(1), 128)
+)
+
+func MakeDecimalType(precision, scale uint32) *Ydb.Type {
+	return &Ydb.Type{Type: &Ydb.Type_DecimalType{DecimalType: &Ydb.DecimalType{Precision: precision, Scale: scale}}}
+}
+
+// DecimalInf returns the special YDB Decimal values representing infinities
+func DecimalInf(negative bool) *big.Int {
+	if negative {
+		return new(big.Int).Neg(decimalInf)
+	}
+
+	return new(big.Int).Set(decimalInf)
+}
+
+// DecimalNaN returns the special YDB Decimal value representing NaN
+func DecimalNaN() *big.Int {
+	return new(big.Int).Set(decimalNaN)
+}
+
+// RescaleDecimal converts the value `unscaled * 10^exp` into the unscaled integer of the given scale.
+func RescaleDecimal(unscaled *big.Int, exp int32, scale uint32) (*big.Int, error) {
+	shift := int64(exp) + int64(scale)
+
+	if shift >= 0 {
+		multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(shift), nil)
+
+		return new(big.Int).Mul(unscaled, multiplier), nil
+	}
+
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(-shift), nil)
+
+	result, remainder := new(big.Int).QuoRem(unscaled, divisor, new(big.Int))
+	if remainder.Sign() != 0 {
+		return nil, fmt.Errorf("value has more than %d fractional digits: %w", scale, ErrValueOutOfTypeBounds)
+	}
+
+	return result, nil
+}
+
+// DecimalToBytes serializes the unscaled decimal value into
+// the 128-bit little-endian two's complement integer used by YDB.
+func DecimalToBytes(unscaled *big.Int, precision uint32) ([]byte, error) {
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil)
+
+	if new(big.Int).Abs(unscaled).Cmp(limit) >= 0 && !isSpecialDecimal(unscaled) {
+		return nil, fmt.Errorf("value %v does not fit into %d digits: %w", unscaled, precision, ErrValueOutOfTypeBounds)
+	}
+
+	value := unscaled
+	if value.Sign() < 0 {
+		value = new(big.Int).Add(value, two128)
+	}
+
+	var buf [DecimalSize]byte
+
+	value.FillBytes(buf[:])
+
+	// big.Int is serialized in big-endian order
+	for i, j := 0, DecimalSize-1; i < j; i, j = i+1, j-1 {
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+
+	return buf[:], nil
+}
+
+// DecimalFromHalves restores the unscaled decimal value from the halves kept in Ydb.Value
+func DecimalFromHalves(low, high uint64) *big.Int {
+	var buf [DecimalSize]byte
+
+	binary.BigEndian.PutUint64(buf[:8], high)
+	binary.BigEndian.PutUint64(buf[8:], low)
+
+	value := new(big.Int).SetBytes(buf[:])
+	if high>>63 == 1 {
+		value.Sub(value, two128)
+	}
+
+	return value
+}
+
+// DecimalToString renders the unscaled decimal value of the given scale in a plain notation
+func DecimalToString(unscaled *big.Int, scale uint32) (string, error) {
+	if isSpecialDecimal(unscaled) {
+		return "", fmt.Errorf("special decimal value %v has no plain notation: %w", unscaled, ErrValueOutOfTypeBounds)
+	}
+
+	digits := new(big.Int).Abs(unscaled).String()
+
+	if scale > 0 {
+		if len(digits) <= int(scale) {
+			digits = strings.Repeat("0", int(scale)-len(digits)+1) + digits
+		}
+
+		digits = digits[:len(digits)-int(scale)] + "." + digits[len(digits)-int(scale):]
+	}
+
+	if unscaled.Sign() < 0 {
+		return "-" + digits, nil
+	}
+
+	return digits, nil
+}
+
+func isSpecialDecimal(unscaled *big.Int) bool {
+	abs := new(big.Int).Abs(unscaled)
+
+	return abs.Cmp(decimalInf) == 0 || (unscaled.Sign() > 0 && abs.Cmp(decimalNaN) == 0)
+}
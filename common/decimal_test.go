@@ -0,0 +1,75 @@
+package common
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecimal(t *testing.T) {
+	t.Run("serialization", func(t *testing.T) {
+		for _, value := range []int64{0, 1, -1, 1234, -1234} {
+			data, err := DecimalToBytes(big.NewInt(value), 10)
+			require.NoError(t, err)
+			require.Len(t, data, DecimalSize)
+
+			low := binary.LittleEndian.Uint64(data[:8])
+			high := binary.LittleEndian.Uint64(data[8:])
+			require.Zero(t, big.NewInt(value).Cmp(DecimalFromHalves(low, high)))
+		}
+	})
+
+	t.Run("precision overflow", func(t *testing.T) {
+		_, err := DecimalToBytes(big.NewInt(1000), 3)
+		require.True(t, errors.Is(err, ErrValueOutOfTypeBounds))
+
+		// special values are always allowed
+		_, err = DecimalToBytes(DecimalNaN(), 3)
+		require.NoError(t, err)
+
+		_, err = DecimalToBytes(DecimalInf(true), 3)
+		require.NoError(t, err)
+	})
+
+	t.Run("rescale", func(t *testing.T) {
+		// 12.3 -> 12.300
+		value, err := RescaleDecimal(big.NewInt(123), -1, 3)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(12300), value)
+
+		// 1.2300 -> 1.23
+		value, err = RescaleDecimal(big.NewInt(12300), -4, 2)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(123), value)
+
+		// 1.234 cannot be represented with two fractional digits
+		_, err = RescaleDecimal(big.NewInt(1234), -3, 2)
+		require.True(t, errors.Is(err, ErrValueOutOfTypeBounds))
+	})
+
+	t.Run("string", func(t *testing.T) {
+		testCases := []struct {
+			unscaled int64
+			scale    uint32
+			expected string
+		}{
+			{unscaled: 1234, scale: 2, expected: "12.34"},
+			{unscaled: -1234, scale: 2, expected: "-12.34"},
+			{unscaled: 5, scale: 3, expected: "0.005"},
+			{unscaled: -5, scale: 1, expected: "-0.5"},
+			{unscaled: 42, scale: 0, expected: "42"},
+		}
+
+		for _, tc := range testCases {
+			actual, err := DecimalToString(big.NewInt(tc.unscaled), tc.scale)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		}
+
+		_, err := DecimalToString(DecimalNaN(), 2)
+		require.True(t, errors.Is(err, ErrValueOutOfTypeBounds))
+	})
+}
@@ -359,6 +359,66 @@ func newAPIErrorFromOpenSearchError(err error) *api_service_protos.TError {
 	}
 }
 
+func newAPIErrorFromPrometheusError(err error) *api_service_protos.TError {
+	if err == nil {
+		return nil
+	}
+
+	var status ydb_proto.StatusIds_StatusCode
+
+	errMsg := err.Error()
+
+	switch {
+	case strings.Contains(errMsg, "connection refused") || strings.Contains(errMsg, "no such host"):
+		status = ydb_proto.StatusIds_UNAVAILABLE
+	case strings.Contains(errMsg, "unexpected status code: 401") || strings.Contains(errMsg, "unexpected status code: 403"):
+		status = ydb_proto.StatusIds_UNAUTHORIZED
+	case strings.Contains(errMsg, "bad_data"):
+		status = ydb_proto.StatusIds_BAD_REQUEST
+	case strings.Contains(errMsg, "timeout") || strings.Contains(errMsg, "unavailable"):
+		status = ydb_proto.StatusIds_UNAVAILABLE
+	default:
+		// Leave the rest of the errors to the common connector logic
+		return nil
+	}
+
+	return &api_service_protos.TError{
+		Status:  status,
+		Message: errMsg,
+	}
+}
+
+func newAPIErrorFromIcebergError(err error) *api_service_protos.TError {
+	if err == nil {
+		return nil
+	}
+
+	var status ydb_proto.StatusIds_StatusCode
+
+	errMsg := err.Error()
+
+	switch {
+	case errors.Is(err, ErrMethodNotSupported):
+		// Delete files, formats other than Parquet and so on
+		status = ydb_proto.StatusIds_UNSUPPORTED
+	case strings.Contains(errMsg, "connection refused") || strings.Contains(errMsg, "no such host"):
+		status = ydb_proto.StatusIds_UNAVAILABLE
+	case strings.Contains(errMsg, "AccessDenied") || strings.Contains(errMsg, "InvalidAccessKeyId") ||
+		strings.Contains(errMsg, "SignatureDoesNotMatch"):
+		status = ydb_proto.StatusIds_UNAUTHORIZED
+	case strings.Contains(errMsg, "NoSuchBucket"):
+		status = ydb_proto.StatusIds_NOT_FOUND
+	default:
+		// Leave the rest of the errors to the common connector logic
+		return nil
+	}
+
+	return &api_service_protos.TError{
+		Status:  status,
+		Message: errMsg,
+	}
+}
+
 //nolint:gocyclo
 func newAPIErrorFromConnectorError(err error) *api_service_protos.TError {
 	var status ydb_proto.StatusIds_StatusCode
@@ -435,6 +495,10 @@ func NewAPIErrorFromStdError(err error, kind api_common.EGenericDataSourceKind)
 		apiError = newAPIErrorFromRedisError(err)
 	case api_common.EGenericDataSourceKind_OPENSEARCH:
 		apiError = newAPIErrorFromOpenSearchError(err)
+	case api_common.EGenericDataSourceKind_PROMETHEUS:
+		apiError = newAPIErrorFromPrometheusError(err)
+	case api_common.EGenericDataSourceKind_ICEBERG:
+		apiError = newAPIErrorFromIcebergError(err)
 	default:
 		panic(fmt.Sprintf("Unexpected data source kind: %v", api_common.EGenericDataSourceKind_name[int32(kind)]))
 	}
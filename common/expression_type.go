@@ -0,0 +1,256 @@
+package common
+
+import (
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+// InferExpressionType computes the type of the expression result in terms of YDB type system.
+// The types of the columns referenced by the expression are taken from columnTypes.
+func InferExpressionType(
+	expression *api_service_protos.TExpression,
+	columnTypes map[string]*Ydb.Type,
+) (*Ydb.Type, error) {
+	ydbType, err := inferExpressionType(expression, columnTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if isNullType(ydbType) {
+		return nil, fmt.Errorf("type of NULL cannot be inferred: %w", ErrUnsupportedExpression)
+	}
+
+	return ydbType, nil
+}
+
+func inferExpressionType(
+	expression *api_service_protos.TExpression,
+	columnTypes map[string]*Ydb.Type,
+) (*Ydb.Type, error) {
+	switch e := expression.GetPayload().(type) {
+	case *api_service_protos.TExpression_Column:
+		ydbType, exists := columnTypes[e.Column]
+		if !exists {
+			return nil, fmt.Errorf("type of column '%s' is unknown: %w", e.Column, ErrInvalidRequest)
+		}
+
+		return ydbType, nil
+	case *api_service_protos.TExpression_TypedValue:
+		return e.TypedValue.GetType(), nil
+	case *api_service_protos.TExpression_Null:
+		return &Ydb.Type{Type: &Ydb.Type_NullType{}}, nil
+	case *api_service_protos.TExpression_ArithmeticalExpression:
+		return inferArithmeticalExpressionType(e.ArithmeticalExpression, columnTypes)
+	case *api_service_protos.TExpression_If:
+		return inferIfType(e.If, columnTypes)
+	case *api_service_protos.TExpression_Cast:
+		if e.Cast.GetType().GetTypeId() == Ydb.Type_PRIMITIVE_TYPE_ID_UNSPECIFIED {
+			return nil, fmt.Errorf("cast to %v: %w", e.Cast.GetType(), ErrUnsupportedExpression)
+		}
+
+		// CAST returns NULL if the value cannot be converted
+		return MakeOptionalType(e.Cast.GetType()), nil
+	case *api_service_protos.TExpression_DateTimeFunction:
+		return inferDateTimeFunctionType(e.DateTimeFunction, columnTypes)
+	default:
+		return nil, fmt.Errorf("type: %T: %w", e, ErrUnimplementedExpression)
+	}
+}
+
+func inferDateTimeFunctionType(
+	expression *api_service_protos.TExpression_TDateTimeFunction,
+	columnTypes map[string]*Ydb.Type,
+) (*Ydb.Type, error) {
+	valueType, err := inferExpressionType(expression.GetValue(), columnTypes)
+	if err != nil {
+		return nil, fmt.Errorf("infer value type: %w", err)
+	}
+
+	item, optional := unwrapOptionalType(valueType)
+
+	switch item.GetTypeId() {
+	case Ydb.Type_DATE, Ydb.Type_DATETIME, Ydb.Type_TIMESTAMP:
+	default:
+		return nil, fmt.Errorf("%v of %v: %w", expression.Function, valueType, ErrUnsupportedExpression)
+	}
+
+	var typeID Ydb.Type_PrimitiveTypeId
+
+	switch expression.Function {
+	case api_service_protos.TExpression_TDateTimeFunction_TO_DATE:
+		typeID = Ydb.Type_DATE
+	case api_service_protos.TExpression_TDateTimeFunction_GET_YEAR:
+		typeID = Ydb.Type_UINT16
+	case api_service_protos.TExpression_TDateTimeFunction_GET_MONTH,
+		api_service_protos.TExpression_TDateTimeFunction_GET_DAY_OF_MONTH,
+		api_service_protos.TExpression_TDateTimeFunction_GET_HOUR:
+		typeID = Ydb.Type_UINT8
+	default:
+		return nil, fmt.Errorf("function %d: %w", expression.Function, ErrUnimplementedExpression)
+	}
+
+	if optional {
+		return MakeOptionalType(MakePrimitiveType(typeID)), nil
+	}
+
+	return MakePrimitiveType(typeID), nil
+}
+
+func inferArithmeticalExpressionType(
+	expression *api_service_protos.TExpression_TArithmeticalExpression,
+	columnTypes map[string]*Ydb.Type,
+) (*Ydb.Type, error) {
+	leftType, err := inferExpressionType(expression.GetLeftValue(), columnTypes)
+	if err != nil {
+		return nil, fmt.Errorf("infer left expression type: %w", err)
+	}
+
+	rightType, err := inferExpressionType(expression.GetRightValue(), columnTypes)
+	if err != nil {
+		return nil, fmt.Errorf("infer right expression type: %w", err)
+	}
+
+	left, leftOptional := unwrapOptionalType(leftType)
+	right, rightOptional := unwrapOptionalType(rightType)
+
+	typeID, err := commonNumericType(left.GetTypeId(), right.GetTypeId())
+	if err != nil {
+		return nil, fmt.Errorf("%v of %v and %v: %w", expression.Operation, leftType, rightType, err)
+	}
+
+	optional := leftOptional || rightOptional
+
+	switch expression.Operation {
+	case api_service_protos.TExpression_TArithmeticalExpression_MUL,
+		api_service_protos.TExpression_TArithmeticalExpression_ADD,
+		api_service_protos.TExpression_TArithmeticalExpression_SUB:
+	case api_service_protos.TExpression_TArithmeticalExpression_DIV,
+		api_service_protos.TExpression_TArithmeticalExpression_MOD:
+		// integer division by zero returns NULL
+		optional = optional || isIntegerType(typeID)
+	case api_service_protos.TExpression_TArithmeticalExpression_BIT_AND,
+		api_service_protos.TExpression_TArithmeticalExpression_BIT_OR,
+		api_service_protos.TExpression_TArithmeticalExpression_BIT_XOR:
+		if !isIntegerType(typeID) {
+			return nil, fmt.Errorf("%v of %v: %w", expression.Operation, typeID, ErrUnsupportedExpression)
+		}
+	default:
+		return nil, fmt.Errorf("operation %d: %w", expression.Operation, ErrUnimplementedArithmeticalExpression)
+	}
+
+	if optional {
+		return MakeOptionalType(MakePrimitiveType(typeID)), nil
+	}
+
+	return MakePrimitiveType(typeID), nil
+}
+
+func inferIfType(
+	expression *api_service_protos.TExpression_TIf,
+	columnTypes map[string]*Ydb.Type,
+) (*Ydb.Type, error) {
+	thenType, err := inferExpressionType(expression.GetThenExpression(), columnTypes)
+	if err != nil {
+		return nil, fmt.Errorf("infer then expression type: %w", err)
+	}
+
+	elseType, err := inferExpressionType(expression.GetElseExpression(), columnTypes)
+	if err != nil {
+		return nil, fmt.Errorf("infer else expression type: %w", err)
+	}
+
+	switch {
+	case isNullType(thenType) && isNullType(elseType):
+		return thenType, nil
+	case isNullType(thenType):
+		return makeOptionalTypeIfNeeded(elseType), nil
+	case isNullType(elseType):
+		return makeOptionalTypeIfNeeded(thenType), nil
+	}
+
+	thenItem, thenOptional := unwrapOptionalType(thenType)
+	elseItem, elseOptional := unwrapOptionalType(elseType)
+
+	if !proto.Equal(thenItem, elseItem) {
+		return nil, fmt.Errorf(
+			"branches of IF have different types %v and %v: %w", thenType, elseType, ErrUnsupportedExpression)
+	}
+
+	if thenOptional || elseOptional {
+		return MakeOptionalType(thenItem), nil
+	}
+
+	return thenItem, nil
+}
+
+func isNullType(ydbType *Ydb.Type) bool {
+	_, ok := ydbType.GetType().(*Ydb.Type_NullType)
+
+	return ok
+}
+
+func unwrapOptionalType(ydbType *Ydb.Type) (*Ydb.Type, bool) {
+	if optionalType := ydbType.GetOptionalType(); optionalType != nil {
+		return optionalType.Item, true
+	}
+
+	return ydbType, false
+}
+
+func makeOptionalTypeIfNeeded(ydbType *Ydb.Type) *Ydb.Type {
+	if ydbType.GetOptionalType() != nil {
+		return ydbType
+	}
+
+	return MakeOptionalType(ydbType)
+}
+
+// integer types ordered by their size; the signed type goes first
+var integerTypes = []Ydb.Type_PrimitiveTypeId{
+	Ydb.Type_INT8, Ydb.Type_UINT8,
+	Ydb.Type_INT16, Ydb.Type_UINT16,
+	Ydb.Type_INT32, Ydb.Type_UINT32,
+	Ydb.Type_INT64, Ydb.Type_UINT64,
+}
+
+func isIntegerType(typeID Ydb.Type_PrimitiveTypeId) bool {
+	return slices.Contains(integerTypes, typeID)
+}
+
+func isNumericType(typeID Ydb.Type_PrimitiveTypeId) bool {
+	return typeID == Ydb.Type_FLOAT || typeID == Ydb.Type_DOUBLE || isIntegerType(typeID)
+}
+
+func isSignedIntegerType(typeID Ydb.Type_PrimitiveTypeId) bool {
+	return slices.Index(integerTypes, typeID)%2 == 0
+}
+
+// commonNumericType returns the type that both operands are converted to in arithmetical expressions
+func commonNumericType(left, right Ydb.Type_PrimitiveTypeId) (Ydb.Type_PrimitiveTypeId, error) {
+	switch {
+	case left == Ydb.Type_DOUBLE || right == Ydb.Type_DOUBLE:
+		if isNumericType(left) && isNumericType(right) {
+			return Ydb.Type_DOUBLE, nil
+		}
+	case left == Ydb.Type_FLOAT || right == Ydb.Type_FLOAT:
+		if isNumericType(left) && isNumericType(right) {
+			return Ydb.Type_FLOAT, nil
+		}
+	case isIntegerType(left) && isIntegerType(right):
+		// the wider type wins, the signed one wins over the unsigned one of the same size
+		leftIndex, rightIndex := slices.Index(integerTypes, left), slices.Index(integerTypes, right)
+		if leftIndex/2 == rightIndex/2 && (isSignedIntegerType(left) || isSignedIntegerType(right)) {
+			return integerTypes[leftIndex/2*2], nil
+		}
+
+		return integerTypes[max(leftIndex, rightIndex)], nil
+	}
+
+	return Ydb.Type_PRIMITIVE_TYPE_ID_UNSPECIFIED, ErrUnsupportedExpression
+}
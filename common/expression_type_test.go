@@ -0,0 +1,172 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+
+	api_service_protos "github.com/ydb-platform/fq-connector-go/api/service/protos"
+)
+
+func TestInferExpressionType(t *testing.T) {
+	type testCase struct {
+		name       string
+		expression *api_service_protos.TExpression
+		output     *Ydb.Type
+		err        error
+	}
+
+	column := func(name string) *api_service_protos.TExpression {
+		return &api_service_protos.TExpression{Payload: &api_service_protos.TExpression_Column{Column: name}}
+	}
+
+	value := func(typeID Ydb.Type_PrimitiveTypeId, v any) *api_service_protos.TExpression {
+		return &api_service_protos.TExpression{
+			Payload: &api_service_protos.TExpression_TypedValue{TypedValue: MakeTypedValue(MakePrimitiveType(typeID), v)},
+		}
+	}
+
+	null := &api_service_protos.TExpression{
+		Payload: &api_service_protos.TExpression_Null{Null: &api_service_protos.TExpression_TNull{}},
+	}
+
+	arithmetical := func(
+		op api_service_protos.TExpression_TArithmeticalExpression_EOperation,
+		left, right *api_service_protos.TExpression,
+	) *api_service_protos.TExpression {
+		return &api_service_protos.TExpression{
+			Payload: &api_service_protos.TExpression_ArithmeticalExpression{
+				ArithmeticalExpression: &api_service_protos.TExpression_TArithmeticalExpression{
+					Operation: op, LeftValue: left, RightValue: right,
+				},
+			},
+		}
+	}
+
+	ifExpression := func(then, els *api_service_protos.TExpression) *api_service_protos.TExpression {
+		return &api_service_protos.TExpression{
+			Payload: &api_service_protos.TExpression_If{
+				If: &api_service_protos.TExpression_TIf{
+					Predicate: &api_service_protos.TPredicate{
+						Payload: &api_service_protos.TPredicate_IsNull{
+							IsNull: &api_service_protos.TPredicate_TIsNull{Value: column("price")},
+						},
+					},
+					ThenExpression: then,
+					ElseExpression: els,
+				},
+			},
+		}
+	}
+
+	dateTimeFunction := func(
+		function api_service_protos.TExpression_TDateTimeFunction_EFunction,
+		value *api_service_protos.TExpression,
+	) *api_service_protos.TExpression {
+		return &api_service_protos.TExpression{
+			Payload: &api_service_protos.TExpression_DateTimeFunction{
+				DateTimeFunction: &api_service_protos.TExpression_TDateTimeFunction{Function: function, Value: value},
+			},
+		}
+	}
+
+	columnTypes := map[string]*Ydb.Type{
+		"price": MakeOptionalType(MakePrimitiveType(Ydb.Type_DOUBLE)),
+		"qty":   MakePrimitiveType(Ydb.Type_INT32),
+		"code":  MakePrimitiveType(Ydb.Type_UINT8),
+		"name":  MakePrimitiveType(Ydb.Type_UTF8),
+		"ts":    MakeOptionalType(MakePrimitiveType(Ydb.Type_TIMESTAMP)),
+	}
+
+	tcs := []testCase{
+		{
+			name:       "column",
+			expression: column("qty"),
+			output:     MakePrimitiveType(Ydb.Type_INT32),
+		},
+		{
+			name:       "unknown column",
+			expression: column("created_at"),
+			err:        ErrInvalidRequest,
+		},
+		{
+			name:       "optional floating point multiplication",
+			expression: arithmetical(api_service_protos.TExpression_TArithmeticalExpression_MUL, column("price"), column("qty")),
+			output:     MakeOptionalType(MakePrimitiveType(Ydb.Type_DOUBLE)),
+		},
+		{
+			name:       "integer widening",
+			expression: arithmetical(api_service_protos.TExpression_TArithmeticalExpression_ADD, column("code"), value(Ydb.Type_INT64, int64(1))),
+			output:     MakePrimitiveType(Ydb.Type_INT64),
+		},
+		{
+			name:       "integer division",
+			expression: arithmetical(api_service_protos.TExpression_TArithmeticalExpression_DIV, column("qty"), column("code")),
+			output:     MakeOptionalType(MakePrimitiveType(Ydb.Type_INT32)),
+		},
+		{
+			name:       "bitwise operation over floating point",
+			expression: arithmetical(api_service_protos.TExpression_TArithmeticalExpression_BIT_AND, column("price"), column("qty")),
+			err:        ErrUnsupportedExpression,
+		},
+		{
+			name:       "arithmetic over strings",
+			expression: arithmetical(api_service_protos.TExpression_TArithmeticalExpression_ADD, column("name"), column("qty")),
+			err:        ErrUnsupportedExpression,
+		},
+		{
+			name:       "if with null branch",
+			expression: ifExpression(null, column("qty")),
+			output:     MakeOptionalType(MakePrimitiveType(Ydb.Type_INT32)),
+		},
+		{
+			name:       "if with different branches",
+			expression: ifExpression(column("name"), column("qty")),
+			err:        ErrUnsupportedExpression,
+		},
+		{
+			name: "cast",
+			expression: &api_service_protos.TExpression{
+				Payload: &api_service_protos.TExpression_Cast{
+					Cast: &api_service_protos.TExpression_TCast{Value: column("name"), Type: MakePrimitiveType(Ydb.Type_DATE)},
+				},
+			},
+			output: MakeOptionalType(MakePrimitiveType(Ydb.Type_DATE)),
+		},
+		{
+			name:       "date time function",
+			expression: dateTimeFunction(api_service_protos.TExpression_TDateTimeFunction_GET_YEAR, column("ts")),
+			output:     MakeOptionalType(MakePrimitiveType(Ydb.Type_UINT16)),
+		},
+		{
+			name:       "date time function over integer",
+			expression: dateTimeFunction(api_service_protos.TExpression_TDateTimeFunction_TO_DATE, column("qty")),
+			err:        ErrUnsupportedExpression,
+		},
+		{
+			name:       "null",
+			expression: null,
+			err:        ErrUnsupportedExpression,
+		},
+	}
+
+	for _, tc := range tcs {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			output, err := InferExpressionType(tc.expression, columnTypes)
+			if tc.err != nil {
+				require.True(t, errors.Is(err, tc.err), err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tc.output, output), output)
+		})
+	}
+}
@@ -41,6 +41,26 @@ func TimeToYDBTimestamp(t *time.Time) (uint64, error) {
 	return uint64(seconds), nil
 }
 
+// maxYDBInterval is the exclusive upper bound of the absolute value of YDB Interval in microseconds
+const maxYDBInterval = 4291747200000000
+
+const microsecondsPerDay = int64(24 * time.Hour / time.Microsecond)
+
+// DaysAndMicrosecondsToYDBInterval makes the YDB Interval value (in microseconds) of the sum
+// of the given number of days (considered to be exactly 24 hours long) and microseconds.
+func DaysAndMicrosecondsToYDBInterval(days, microseconds int64) (int64, error) {
+	if days > maxYDBInterval/microsecondsPerDay || days < -maxYDBInterval/microsecondsPerDay {
+		return 0, fmt.Errorf("convert interval of %d days to YDB Interval: %w", days, ErrValueOutOfTypeBounds)
+	}
+
+	result := days*microsecondsPerDay + microseconds
+	if result >= maxYDBInterval || result <= -maxYDBInterval {
+		return 0, fmt.Errorf("convert interval of %d microseconds to YDB Interval: %w", result, ErrValueOutOfTypeBounds)
+	}
+
+	return result, nil
+}
+
 type ydbTime interface {
 	uint16 | uint32 | uint64
 }
@@ -145,3 +145,51 @@ func TestTimeToYDBTimestamp(t *testing.T) {
 		})
 	}
 }
+
+func TestDaysAndMicrosecondsToYDBInterval(t *testing.T) {
+	type testCase struct {
+		days         int64
+		microseconds int64
+		output       int64
+		err          error
+	}
+
+	tcs := []testCase{
+		{
+			days:         1,
+			microseconds: (3 * time.Hour).Microseconds(),
+			output:       (27 * time.Hour).Microseconds(),
+			err:          nil,
+		},
+		{
+			days:         -1,
+			microseconds: 1,
+			output:       -(24 * time.Hour).Microseconds() + 1,
+			err:          nil,
+		},
+		{
+			days:         49673,
+			microseconds: 0,
+			output:       0,
+			err:          ErrValueOutOfTypeBounds,
+		},
+		{
+			// days are checked before multiplication to avoid overflow
+			days:         999999999,
+			microseconds: 0,
+			output:       0,
+			err:          ErrValueOutOfTypeBounds,
+		},
+	}
+
+	for _, tc := range tcs {
+		output, err := DaysAndMicrosecondsToYDBInterval(tc.days, tc.microseconds)
+		require.Equal(t, tc.output, output)
+
+		if tc.err != nil {
+			require.True(t, errors.Is(err, tc.err))
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package common
+
+import (
+	"encoding/binary"
+
+	"github.com/google/uuid"
+)
+
+// UUIDSize is the size of YDB Uuid value in bytes; it is passed in Arrow as a fixed size binary.
+const UUIDSize = 16
+
+// UUIDToYDBBytes converts the UUID from its canonical (RFC 4122) byte order into the one used by YDB,
+// where the first three groups are stored in little-endian order (like Python's `uuid.UUID.bytes_le`).
+func UUIDToYDBBytes(value [UUIDSize]byte) []byte {
+	return []byte{
+		value[3], value[2], value[1], value[0],
+		value[5], value[4],
+		value[7], value[6],
+		value[8], value[9], value[10], value[11], value[12], value[13], value[14], value[15],
+	}
+}
+
+// UUIDFromHalves restores the UUID in its canonical byte order from the halves kept in Ydb.Value
+func UUIDFromHalves(low, high uint64) uuid.UUID {
+	var buf [UUIDSize]byte
+
+	binary.LittleEndian.PutUint64(buf[:8], low)
+	binary.LittleEndian.PutUint64(buf[8:], high)
+
+	// the byte order swap is an involution, so the same function is used to restore the canonical order
+	return uuid.UUID(UUIDToYDBBytes(buf))
+}
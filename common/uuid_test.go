@@ -0,0 +1,22 @@
+package common
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUUID(t *testing.T) {
+	value := uuid.MustParse("00112233-4455-6677-8899-aabbccddeeff")
+
+	data := UUIDToYDBBytes(value)
+	require.Equal(t, []byte{
+		0x33, 0x22, 0x11, 0x00, 0x55, 0x44, 0x77, 0x66, 0x88, 0x99, 0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff,
+	}, data)
+
+	low := binary.LittleEndian.Uint64(data[:8])
+	high := binary.LittleEndian.Uint64(data[8:])
+	require.Equal(t, value, UUIDFromHalves(low, high))
+}
@@ -30,6 +30,14 @@ func MakeStructType(ydbTypeMembers []*Ydb.StructMember) *Ydb.Type {
 	return &Ydb.Type{Type: &Ydb.Type_StructType{StructType: &Ydb.StructType{Members: ydbTypeMembers}}}
 }
 
+func MakeTupleType(elements []*Ydb.Type) *Ydb.Type {
+	return &Ydb.Type{Type: &Ydb.Type_TupleType{TupleType: &Ydb.TupleType{Elements: elements}}}
+}
+
+func MakeDictType(key, payload *Ydb.Type) *Ydb.Type {
+	return &Ydb.Type{Type: &Ydb.Type_DictType{DictType: &Ydb.DictType{Key: key, Payload: payload}}}
+}
+
 func MakeTypedValue(ydbType *Ydb.Type, value any) *Ydb.TypedValue {
 	out := &Ydb.TypedValue{Type: ydbType, Value: &Ydb.Value{}}
 
@@ -56,28 +64,63 @@ func MakeTypedValue(ydbType *Ydb.Type, value any) *Ydb.TypedValue {
 }
 
 func SelectWhatToYDBTypes(selectWhat *api_service_protos.TSelect_TWhat) ([]*Ydb.Type, error) {
-	var ydbTypes []*Ydb.Type
+	ydbColumns, err := SelectWhatToYDBColumns(selectWhat)
+	if err != nil {
+		return nil, err
+	}
+
+	return YDBColumnsToYDBTypes(ydbColumns), nil
+}
 
-	for i, item := range selectWhat.Items {
-		ydbType := item.GetColumn().GetType()
-		if ydbType == nil {
-			return nil, fmt.Errorf("item #%d (%v) is not a column", i, item)
+// SelectWhatToYDBColumns returns the names and the types of the result columns.
+// The types of the computed columns are inferred from their expressions if not given explicitly.
+func SelectWhatToYDBColumns(selectWhat *api_service_protos.TSelect_TWhat) ([]*Ydb.Column, error) {
+	var (
+		ydbColumns  []*Ydb.Column
+		columnTypes = make(map[string]*Ydb.Type, len(selectWhat.GetItems()))
+	)
+
+	for _, item := range selectWhat.GetItems() {
+		if column := item.GetColumn(); column != nil {
+			columnTypes[column.Name] = column.Type
 		}
+	}
+
+	for i, item := range selectWhat.GetItems() {
+		switch payload := item.GetPayload().(type) {
+		case *api_service_protos.TSelect_TWhat_TItem_Column:
+			ydbColumns = append(ydbColumns, payload.Column)
+		case *api_service_protos.TSelect_TWhat_TItem_ComputedColumn:
+			computedColumn := payload.ComputedColumn
+
+			ydbType := computedColumn.GetType()
+			if ydbType == nil {
+				var err error
+
+				ydbType, err = InferExpressionType(computedColumn.GetExpression(), columnTypes)
+				if err != nil {
+					return nil, fmt.Errorf("infer type of computed column '%s': %w", computedColumn.GetName(), err)
+				}
+			}
 
-		ydbTypes = append(ydbTypes, ydbType)
+			ydbColumns = append(ydbColumns, &Ydb.Column{Name: computedColumn.GetName(), Type: ydbType})
+		default:
+			return nil, fmt.Errorf("item #%d (%v) is neither a column, nor a computed column", i, item)
+		}
 	}
 
-	return ydbTypes, nil
+	return ydbColumns, nil
 }
 
-func SelectWhatToYDBColumns(selectWhat *api_service_protos.TSelect_TWhat) []*Ydb.Column {
-	var ydbColumns []*Ydb.Column
-
-	for _, item := range selectWhat.Items {
-		ydbColumns = append(ydbColumns, item.GetColumn())
+// SelectWhatHasComputedColumns reports whether any expression must be evaluated to obtain the result columns
+func SelectWhatHasComputedColumns(selectWhat *api_service_protos.TSelect_TWhat) bool {
+	for _, item := range selectWhat.GetItems() {
+		if item.GetComputedColumn() != nil {
+			return true
+		}
 	}
 
-	return ydbColumns
+	return false
 }
 
 func YDBColumnsToYDBTypes(ydbColumns []*Ydb.Column) []*Ydb.Type {
@@ -156,7 +199,7 @@ func TypesEqual(lhs, rhs *Ydb.Type) bool {
 		return rhsType != nil && variantsEqual(rhsType, lhsType.VariantType)
 	case *Ydb.Type_TaggedType:
 		rhsType := rhs.GetTaggedType()
-		return rhsType.Tag == lhsType.TaggedType.Tag &&
+		return rhsType != nil && rhsType.Tag == lhsType.TaggedType.Tag &&
 			TypesEqual(rhsType.Type, lhsType.TaggedType.Type)
 	case *Ydb.Type_VoidType:
 		return rhs.GetVoidType() != structpb.NullValue(0)
@@ -112,6 +112,14 @@ class YDBProtoFile:
 
         return import_line_pos
 
+    def override(self, filepath: Path):
+        """
+        Replaces the content of YDB's protofile with its extended copy
+        kept in connector repository until the changes reach YDB.
+        """
+        with open(filepath, "r") as f:
+            self.src_patched = f.read()
+
     def patch(self):
         with open(self.filepath, "w") as f:
             f.write(self.src_patched)
@@ -150,6 +158,16 @@ source_params = [
     ),
 ]
 
+# Connector's protofiles that extend their YDB counterparts.
+# The extensions must be upstreamed to YDB, but until then
+# the copies are used instead of the YDB's protofiles during the code generation.
+override_params = [
+    (
+        "ydb/library/yql/providers/generic/connector/api/service/protos/connector.proto",
+        "api/service/protos/connector.proto",
+    ),
+]
+
 
 def __call_subprocess(cmd: List[str]):
     formatted = "\n".join(map(str, cmd))
@@ -267,6 +285,11 @@ def main():
         for param in source_params
     ]
 
+    for ydb_path, connector_path in override_params:
+        for f in ydb_source_files:
+            if f.filepath == ydb_github_root.joinpath(ydb_path):
+                f.override(connector_github_root.joinpath(connector_path))
+
     # Patch YDB sources
     for f in ydb_source_files:
         f.patch()
@@ -103,10 +103,28 @@ clickhouse client -n <<-EOSQL
     CREATE TABLE connector.arrays (
         id Int32,
         col_01_int32 Nullable(Int32),
-        col_02_array Array(DateTime)
+        col_02_array Array(DateTime),
+        col_03_array_nullable Array(Nullable(String))
     ) ENGINE = MergeTree ORDER BY id;
     INSERT INTO connector.arrays (*) VALUES 
-        (1, 10, []) \
-        (2, 20, ['1950-05-27 01:02:03']) \
-        (3, 30, ['1950-05-27 01:02:03', '2023-03-21 11:21:31']);
+        (1, 10, [], []) \
+        (2, 20, ['1988-11-20 12:55:28'], ['a', NULL]) \
+        (3, 30, ['1988-11-20 12:55:28', '2023-03-21 11:21:31'], [NULL, 'b', 'c']);
+EOSQL
+
+clickhouse client -n <<-EOSQL
+    DROP TABLE IF EXISTS connector.extended_types;
+    CREATE TABLE connector.extended_types (
+        id Int32,
+        col_01_decimal Nullable(Decimal(10, 2)),
+        col_02_decimal_wide Nullable(Decimal(40, 3)),
+        col_03_uuid Nullable(UUID),
+        col_04_enum Enum8('a' = 1, 'b' = 2),
+        col_05_ipv4 Nullable(IPv4),
+        col_06_low_cardinality LowCardinality(String)
+    ) ENGINE = MergeTree ORDER BY id;
+    INSERT INTO connector.extended_types (*) VALUES 
+        (1, 12.34, 3.141, '00112233-4455-6677-8899-aabbccddeeff', 'a', '192.168.0.1', 'x') \
+        (2, -0.5, -100, '6b0a6a4e-ad35-4a55-9d2d-5d0ac9f4f0b6', 'b', '10.0.0.255', 'y') \
+        (3, NULL, NULL, NULL, 'a', NULL, 'x');
 EOSQL
@@ -19,13 +19,17 @@ type Suite struct {
 }
 
 func (s *Suite) TestSelect() {
-	testCaseNames := []string{"simple", "primitives", "optionals"}
+	testCaseNames := []string{"simple", "primitives", "optionals", "arrays", "extended_types"}
 
 	for _, tableName := range testCaseNames {
 		s.ValidateTable(s.dataSource, tables[tableName])
 	}
 }
 
+func (s *Suite) TestListTables() {
+	s.ValidateListTables(s.dataSource, "^(simple|primitives|optionals)$", []string{"simple", "primitives", "optionals"})
+}
+
 func (s *Suite) TestDatetimeFormatYQL() {
 	s.ValidateTable(
 		s.dataSource,
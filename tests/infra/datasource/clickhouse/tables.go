@@ -1,10 +1,12 @@
 package clickhouse
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow/array"
 	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/google/uuid"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
@@ -577,47 +579,93 @@ var tables = map[string]*test_utils.Table[int32, *array.Int32Builder]{
 		},
 	},
 
-	"array": {
-		Name:                  "array",
+	"arrays": {
+		Name:                  "arrays",
 		IDArrayBuilderFactory: newInt32IDArrayBuilder(memPool),
 		Schema: &test_utils.TableSchema{
 			Columns: map[string]*Ydb.Type{
-				"id":  common.MakePrimitiveType(Ydb.Type_INT32),
-				"col": common.MakeListType(common.MakePrimitiveType(Ydb.Type_DATETIME)),
+				"id":           common.MakePrimitiveType(Ydb.Type_INT32),
+				"col_01_int32": common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT32)),
+				// date/time values may exceed YQL type bounds, so list items are optional
+				"col_02_array": common.MakeListType(common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_DATETIME))),
+				"col_03_array_nullable": common.MakeListType(
+					common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_STRING))),
 			},
 		},
 		Records: []*test_utils.Record[int32, *array.Int32Builder]{
 			{
 				Columns: map[string]any{
-					"id": []int32{1, 2, 3, 4},
-					"col": [][]*uint32{
+					"id":           []int32{1, 2, 3},
+					"col_01_int32": []*int32{ptr.Int32(10), ptr.Int32(20), ptr.Int32(30)},
+					"col_02_array": [][]*uint32{
 						{},
 						{
-							ptr.Uint32(common.MustTimeToYDBType[uint32](
-								common.TimeToYDBDatetime, time.Date(1970, 01, 01, 0, 0, 0, 0, time.UTC))),
-						},
-						{
-							ptr.Uint32(common.MustTimeToYDBType[uint32](
-								common.TimeToYDBDatetime, time.Date(1970, 01, 01, 0, 0, 0, 0, time.UTC))),
 							ptr.Uint32(common.MustTimeToYDBType[uint32](
 								common.TimeToYDBDatetime, time.Date(1988, 11, 20, 12, 55, 28, 0, time.UTC))),
 						},
 						{
-							ptr.Uint32(common.MustTimeToYDBType[uint32](
-								common.TimeToYDBDatetime, time.Date(1970, 01, 01, 0, 0, 0, 0, time.UTC))),
 							ptr.Uint32(common.MustTimeToYDBType[uint32](
 								common.TimeToYDBDatetime, time.Date(1988, 11, 20, 12, 55, 28, 0, time.UTC))),
 							ptr.Uint32(common.MustTimeToYDBType[uint32](
 								common.TimeToYDBDatetime, time.Date(2023, 03, 21, 11, 21, 31, 0, time.UTC))),
 						},
 					},
-					// "col": [][]time.Time{{}, {time.Now()}, {time.Now(), time.Now()}, {time.Now(), time.Now(), time.Now()}},
+					"col_03_array_nullable": [][]*[]byte{
+						{},
+						{ptr.T([]byte("a")), nil},
+						{nil, ptr.T([]byte("b")), ptr.T([]byte("c"))},
+					},
+				},
+			},
+		},
+	},
+	"extended_types": {
+		Name:                  "extended_types",
+		IDArrayBuilderFactory: newInt32IDArrayBuilder(memPool),
+		Schema: &test_utils.TableSchema{
+			Columns: map[string]*Ydb.Type{
+				"id":                     common.MakePrimitiveType(Ydb.Type_INT32),
+				"col_01_decimal":         common.MakeOptionalType(common.MakeDecimalType(10, 2)),
+				"col_02_decimal_wide":    common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+				"col_03_uuid":            common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UUID)),
+				"col_04_enum":            common.MakePrimitiveType(Ydb.Type_UTF8),
+				"col_05_ipv4":            common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+				"col_06_low_cardinality": common.MakePrimitiveType(Ydb.Type_STRING),
+			},
+		},
+		Records: []*test_utils.Record[int32, *array.Int32Builder]{
+			{
+				Columns: map[string]any{
+					"id": []int32{1, 2, 3},
+					"col_01_decimal": []*[]byte{
+						ptr.T(mustMakeDecimal(1234, 10)),
+						ptr.T(mustMakeDecimal(-50, 10)),
+						nil,
+					},
+					"col_02_decimal_wide": []*string{ptr.String("3.141"), ptr.String("-100.000"), nil},
+					"col_03_uuid": []*[]byte{
+						ptr.T(common.UUIDToYDBBytes(uuid.MustParse("00112233-4455-6677-8899-aabbccddeeff"))),
+						ptr.T(common.UUIDToYDBBytes(uuid.MustParse("6b0a6a4e-ad35-4a55-9d2d-5d0ac9f4f0b6"))),
+						nil,
+					},
+					"col_04_enum":            []string{"a", "b", "a"},
+					"col_05_ipv4":            []*string{ptr.String("192.168.0.1"), ptr.String("10.0.0.255"), nil},
+					"col_06_low_cardinality": [][]byte{[]byte("x"), []byte("y"), []byte("x")},
 				},
 			},
 		},
 	},
 }
 
+func mustMakeDecimal(unscaled int64, precision uint32) []byte {
+	data, err := common.DecimalToBytes(big.NewInt(unscaled), precision)
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
 func pushdownSchema() *test_utils.TableSchema {
 	return &test_utils.TableSchema{
 		Columns: map[string]*Ydb.Type{
@@ -47,6 +47,28 @@ INSERT INTO primitives VALUES
 
 SELECT * FROM primitives;
 
+DROP TABLE IF EXISTS extended_types;
+CREATE TABLE extended_types (
+    id INTEGER PRIMARY KEY,
+    col_01_decimal DECIMAL(10, 2),
+    col_02_numeric NUMERIC(38, 4),
+    col_03_money MONEY,
+    col_04_smallmoney SMALLMONEY,
+    col_05_uniqueidentifier UNIQUEIDENTIFIER,
+    col_06_datetimeoffset DATETIMEOFFSET(7),
+    col_07_time TIME(7),
+    col_08_xml XML
+);
+
+INSERT INTO extended_types VALUES
+    (1, 12.34, 3.1415, 12.5, -1.25, '00112233-4455-6677-8899-AABBCCDDEEFF',
+    '1988-11-20 12:55:28.123 +03:00', '12:55:28.1231230', '<a>1</a>'),
+    (2, -0.5, -100, -922337203685477.5808, 214748.3647, '6B0A6A4E-AD35-4A55-9D2D-5D0AC9F4F0B6',
+    '2023-03-21 11:21:31 -01:00', '00:00:01', '<b/>'),
+    (3, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL);
+
+SELECT * FROM extended_types;
+
 DROP TABLE IF EXISTS datetimes;
 CREATE TABLE datetimes (
     id INTEGER PRIMARY KEY,
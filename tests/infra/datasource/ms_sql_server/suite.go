@@ -21,7 +21,7 @@ type Suite struct {
 }
 
 func (s *Suite) TestSelect() {
-	testCaseNames := []string{"simple", "primitives"}
+	testCaseNames := []string{"simple", "primitives", "extended_types"}
 
 	for _, testCase := range testCaseNames {
 		s.ValidateTable(s.dataSource, tables[testCase])
@@ -1,10 +1,12 @@
 package ms_sql_server
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow/array"
 	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/google/uuid"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
 
@@ -138,6 +140,66 @@ var tables = map[string]*test_utils.Table[int32, *array.Int32Builder]{
 			},
 		},
 	},
+	"extended_types": {
+		Name:                  "extended_types",
+		IDArrayBuilderFactory: newInt32IDArrayBuilder(memPool),
+		Schema: &test_utils.TableSchema{
+			Columns: map[string]*Ydb.Type{
+				"id":                      common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT32)),
+				"col_01_decimal":          common.MakeOptionalType(common.MakeDecimalType(10, 2)),
+				"col_02_numeric":          common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+				"col_03_money":            common.MakeOptionalType(common.MakeDecimalType(19, 4)),
+				"col_04_smallmoney":       common.MakeOptionalType(common.MakeDecimalType(10, 4)),
+				"col_05_uniqueidentifier": common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UUID)),
+				"col_06_datetimeoffset":   common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_TIMESTAMP)),
+				"col_07_time":             common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INTERVAL)),
+				"col_08_xml":              common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+			},
+		},
+		Records: []*test_utils.Record[int32, *array.Int32Builder]{
+			{
+				Columns: map[string]any{
+					"id": []*int32{ptr.Int32(1), ptr.Int32(2), ptr.Int32(3)},
+					"col_01_decimal": []*[]byte{
+						ptr.T(mustMakeDecimal(1234, 10)),
+						ptr.T(mustMakeDecimal(-50, 10)),
+						nil,
+					},
+					// numeric(38,4) exceeds the precision of YDB Decimal
+					"col_02_numeric": []*string{ptr.String("3.1415"), ptr.String("-100.0000"), nil},
+					"col_03_money": []*[]byte{
+						ptr.T(mustMakeDecimal(125000, 19)),
+						ptr.T(mustMakeDecimal(-9223372036854775808, 19)),
+						nil,
+					},
+					"col_04_smallmoney": []*[]byte{
+						ptr.T(mustMakeDecimal(-12500, 10)),
+						ptr.T(mustMakeDecimal(2147483647, 10)),
+						nil,
+					},
+					"col_05_uniqueidentifier": []*[]byte{
+						ptr.T(common.UUIDToYDBBytes(uuid.MustParse("00112233-4455-6677-8899-aabbccddeeff"))),
+						ptr.T(common.UUIDToYDBBytes(uuid.MustParse("6b0a6a4e-ad35-4a55-9d2d-5d0ac9f4f0b6"))),
+						nil,
+					},
+					// datetimeoffset values are normalized to UTC
+					"col_06_datetimeoffset": []*uint64{
+						ptr.Uint64(common.MustTimeToYDBType(common.TimeToYDBTimestamp,
+							time.Date(1988, 11, 20, 9, 55, 28, 123000000, time.UTC))),
+						ptr.Uint64(common.MustTimeToYDBType(common.TimeToYDBTimestamp,
+							time.Date(2023, 03, 21, 12, 21, 31, 0, time.UTC))),
+						nil,
+					},
+					"col_07_time": []*int64{
+						ptr.Int64((12*time.Hour + 55*time.Minute + 28*time.Second + 123123*time.Microsecond).Microseconds()),
+						ptr.Int64(time.Second.Microseconds()),
+						nil,
+					},
+					"col_08_xml": []*string{ptr.String("<a>1</a>"), ptr.String("<b/>"), nil},
+				},
+			},
+		},
+	},
 	"datetime_format_yql": {
 		Name:                  "datetimes",
 		IDArrayBuilderFactory: newInt32IDArrayBuilder(memPool),
@@ -444,6 +506,15 @@ func pushdownSchemaYdb() *test_utils.TableSchema {
 	}
 }
 
+func mustMakeDecimal(unscaled int64, precision uint32) []byte {
+	data, err := common.DecimalToBytes(big.NewInt(unscaled), precision)
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
 func newInt32IDArrayBuilder(pool memory.Allocator) func() *array.Int32Builder {
 	return func() *array.Int32Builder {
 		return array.NewInt32Builder(pool)
@@ -368,6 +368,24 @@ func (s *Suite) TestPushdownRegex() {
 	}
 }
 
+func (s *Suite) TestPushdownFullTextMatch() {
+	testCases := []*api_service_protos.TPredicate_FullTextMatch{
+		tests_utils.MakePredicateFullTextMatch(api_service_protos.TPredicate_TFullTextMatch_MATCH, "great toast", "a"),
+		tests_utils.MakePredicateFullTextMatch(api_service_protos.TPredicate_TFullTextMatch_MATCH_PHRASE, "toast is", "a"),
+		tests_utils.MakePredicateFullTextMatch(api_service_protos.TPredicate_TFullTextMatch_QUERY_STRING, "a:toast AND great"),
+	}
+
+	for _, testCase := range testCases {
+		s.ValidateTable(
+			s.dataSource,
+			tables["pushdown_regex"],
+			suite.WithPredicate(&api_service_protos.TPredicate{
+				Payload: testCase,
+			}),
+		)
+	}
+}
+
 func NewSuite(
 	baseSuite *suite.Base[string, *array.StringBuilder],
 ) *Suite {
@@ -381,3 +399,27 @@ func NewSuite(
 
 	return result
 }
+
+// ScoreSuite checks the virtual `_score` column, which is exposed only if the server is configured to
+type ScoreSuite struct {
+	*suite.Base[string, *array.StringBuilder]
+	dataSource *datasource.DataSource
+}
+
+func (s *ScoreSuite) TestReadScore() {
+	s.ValidateTable(s.dataSource, tables["score"])
+}
+
+func NewScoreSuite(
+	baseSuite *suite.Base[string, *array.StringBuilder],
+) *ScoreSuite {
+	ds, err := deriveDataSourceFromDockerCompose(baseSuite.EndpointDeterminer)
+	baseSuite.Require().NoError(err)
+
+	result := &ScoreSuite{
+		Base:       baseSuite,
+		dataSource: ds,
+	}
+
+	return result
+}
@@ -626,6 +626,25 @@ var tables = map[string]*test_utils.Table[string, *array.StringBuilder]{
 			},
 		}},
 	},
+	"score": {
+		Name:                  "pushdown_regex",
+		IDArrayBuilderFactory: newStringIDArrayBuilder(memPool),
+		Schema: &test_utils.TableSchema{
+			Columns: map[string]*Ydb.Type{
+				"_id":    testIdType,
+				"a":      common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+				"_score": common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_FLOAT)),
+			},
+		},
+		Records: []*test_utils.Record[string, *array.StringBuilder]{{
+			Columns: map[string]any{
+				"_id": []string{"0"},
+				"a":   []*string{ptr.String("toast is great")},
+				// every document matches the `match_all` query with the same score
+				"_score": []*float32{ptr.Float32(1)},
+			},
+		}},
+	},
 }
 
 func newStringIDArrayBuilder(pool memory.Allocator) func() *array.StringBuilder {
@@ -205,6 +205,37 @@ VALUES
 );
 
 
+exit;
+EOF
+
+echo Creating table EXTENDED_TYPES
+"$ORACLE_HOME"/bin/sqlplus -s system/password << EOF
+whenever sqlerror exit sql.sqlcode;
+
+CREATE TABLE "C##ADMIN".extended_types (
+	id INTEGER NOT NULL PRIMARY KEY,
+	col_01_number_int NUMBER(10),
+	col_02_number_decimal NUMBER(10,2),
+	col_03_number NUMBER,
+	col_04_interval_ym INTERVAL YEAR TO MONTH,
+	col_05_interval_ds INTERVAL DAY TO SECOND,
+	col_06_timestamp_tz TIMESTAMP(3) WITH TIME ZONE,
+	col_07_raw RAW(16)
+);
+
+INSERT INTO "C##ADMIN".extended_types VALUES
+	(1, 1234567890, 12.34, 3.14159,
+		INTERVAL '1-2' YEAR TO MONTH,
+		INTERVAL '1 02:03:04.000005' DAY TO SECOND,
+		TO_TIMESTAMP_TZ('1988-11-20 12:55:28.123 +03:00', 'YYYY-mm-dd HH24:MI:SS.FF TZH:TZM'),
+		HEXTORAW('DEADBEEF')),
+	(2, -42, -0.5, -100,
+		INTERVAL '0-6' YEAR TO MONTH,
+		INTERVAL '-1 03:00:00' DAY TO SECOND,
+		TO_TIMESTAMP_TZ('2023-03-21 11:21:31.000 -01:00', 'YYYY-mm-dd HH24:MI:SS.FF TZH:TZM'),
+		HEXTORAW('01')),
+	(3, NULL, NULL, NULL, NULL, NULL, NULL, NULL);
+
 exit;
 EOF
 
@@ -1,6 +1,7 @@
 package oracle
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow/array"
@@ -250,6 +251,59 @@ var tables = map[string]*test_utils.Table[int64, *array.Int64Builder]{
 			},
 		},
 	},
+	"extended_types": {
+		Name:                  "EXTENDED_TYPES",
+		IDArrayBuilderFactory: newInt64IDArrayBuilder(memPool),
+		Schema: &test_utils.TableSchema{
+			Columns: map[string]*Ydb.Type{
+				"ID":                    common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT64)),
+				"COL_01_NUMBER_INT":     common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT64)),
+				"COL_02_NUMBER_DECIMAL": common.MakeOptionalType(common.MakeDecimalType(10, 2)),
+				"COL_03_NUMBER":         common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+				"COL_04_INTERVAL_YM":    common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INTERVAL)),
+				"COL_05_INTERVAL_DS":    common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INTERVAL)),
+				"COL_06_TIMESTAMP_TZ":   common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_TIMESTAMP)),
+				"COL_07_RAW":            common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_STRING)),
+			},
+		},
+		Records: []*test_utils.Record[int64, *array.Int64Builder]{
+			{
+				Columns: map[string]any{
+					"ID":                []*int64{ptr.Int64(1), ptr.Int64(2), ptr.Int64(3)},
+					"COL_01_NUMBER_INT": []*int64{ptr.Int64(1234567890), ptr.Int64(-42), nil},
+					"COL_02_NUMBER_DECIMAL": []*[]byte{
+						ptr.T(mustMakeDecimal(1234, 10)),
+						ptr.T(mustMakeDecimal(-50, 10)),
+						nil,
+					},
+					"COL_03_NUMBER": []*string{ptr.String("3.14159"), ptr.String("-100"), nil},
+					// months are considered to be 30 days long
+					"COL_04_INTERVAL_YM": []*int64{
+						ptr.Int64((14 * 30 * 24 * time.Hour).Microseconds()),
+						ptr.Int64((6 * 30 * 24 * time.Hour).Microseconds()),
+						nil,
+					},
+					"COL_05_INTERVAL_DS": []*int64{
+						ptr.Int64((26*time.Hour + 3*time.Minute + 4*time.Second + 5*time.Microsecond).Microseconds()),
+						ptr.Int64((-27 * time.Hour).Microseconds()),
+						nil,
+					},
+					"COL_06_TIMESTAMP_TZ": []*uint64{
+						ptr.Uint64(common.MustTimeToYDBType(common.TimeToYDBTimestamp,
+							time.Date(1988, 11, 20, 9, 55, 28, 123000000, time.UTC))),
+						ptr.Uint64(common.MustTimeToYDBType(common.TimeToYDBTimestamp,
+							time.Date(2023, 03, 21, 12, 21, 31, 0, time.UTC))),
+						nil,
+					},
+					"COL_07_RAW": []*[]byte{
+						ptr.T([]byte{0xDE, 0xAD, 0xBE, 0xEF}),
+						ptr.T([]byte{0x01}),
+						nil,
+					},
+				},
+			},
+		},
+	},
 	"datetime_format_yql": {
 		Name:                  "DATETIMES",
 		IDArrayBuilderFactory: newInt64IDArrayBuilder(memPool),
@@ -604,6 +658,15 @@ func pushdownSchemaYdb() *test_utils.TableSchema {
 	}
 }
 
+func mustMakeDecimal(unscaled int64, precision uint32) []byte {
+	data, err := common.DecimalToBytes(big.NewInt(unscaled), precision)
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
 func newInt64IDArrayBuilder(pool memory.Allocator) func() *array.Int64Builder {
 	return func() *array.Int64Builder {
 		return array.NewInt64Builder(pool)
@@ -89,4 +89,36 @@ psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" <<-E
         (2, 20, 'b'), \
         (3, 30, 'c'), \
         (4, NULL, NULL);
-EOSQL
\ No newline at end of file
+EOSQL
+psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" <<-EOSQL
+    DROP TABLE IF EXISTS extended_types;
+    CREATE TABLE extended_types (
+        id int,
+        col_01_numeric numeric(10, 2),
+        col_02_numeric_unconstrained numeric,
+        col_03_time time,
+        col_04_interval interval,
+        col_05_jsonb jsonb
+    );
+    INSERT INTO extended_types VALUES (
+        1, 12.34, 3.14159265358979323846264338327950288419716939937510, '12:55:28.123',
+        '1 day 02:03:04', '{"name": "James Holden", "age": 35}'::jsonb
+        );
+    INSERT INTO extended_types VALUES (
+        2, -0.5, -100, '23:59:59.999999',
+        '-3 hours', '[1, 2, 3]'::jsonb
+        );
+    INSERT INTO extended_types VALUES (3, NULL, NULL, NULL, NULL, NULL);
+EOSQL
+
+psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "$POSTGRES_DB" <<-EOSQL
+    DROP TABLE IF EXISTS arrays;
+    CREATE TABLE arrays (
+        id int,
+        col_01_int_array integer[],
+        col_02_text_array text[]
+    );
+    INSERT INTO arrays VALUES (1, '{1, NULL, 3}', '{"a", "b"}');
+    INSERT INTO arrays VALUES (2, '{}', NULL);
+    INSERT INTO arrays VALUES (3, NULL, '{NULL}');
+EOSQL
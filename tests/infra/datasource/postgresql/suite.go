@@ -21,13 +21,17 @@ type Suite struct {
 }
 
 func (s *Suite) TestSelect() {
-	testCaseNames := []string{"simple", "primitives"}
+	testCaseNames := []string{"simple", "primitives", "extended_types", "arrays"}
 
 	for _, testCase := range testCaseNames {
 		s.ValidateTable(s.dataSource, tables[testCase])
 	}
 }
 
+func (s *Suite) TestListTables() {
+	s.ValidateListTables(s.dataSource, "^(simple|primitives)$", []string{"simple", "primitives"})
+}
+
 func (s *Suite) TestDatetimeFormatYQL() {
 	s.ValidateTable(
 		s.dataSource,
@@ -1,6 +1,7 @@
 package postgresql
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow/array"
@@ -216,6 +217,82 @@ var tables = map[string]*test_utils.Table[int32, *array.Int32Builder]{
 			},
 		},
 	},
+	"extended_types": {
+		Name:                  "extended_types",
+		IDArrayBuilderFactory: newInt32IDArrayBuilder(memPool),
+		Schema: &test_utils.TableSchema{
+			Columns: map[string]*Ydb.Type{
+				"id":                           common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT32)),
+				"col_01_numeric":               common.MakeOptionalType(common.MakeDecimalType(10, 2)),
+				"col_02_numeric_unconstrained": common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+				"col_03_time":                  common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INTERVAL)),
+				"col_04_interval":              common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INTERVAL)),
+				"col_05_jsonb":                 common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_JSON_DOCUMENT)),
+			},
+		},
+		Records: []*test_utils.Record[int32, *array.Int32Builder]{
+			{
+				Columns: map[string]any{
+					"id": []*int32{ptr.Int32(1), ptr.Int32(2), ptr.Int32(3)},
+					"col_01_numeric": []*[]byte{
+						ptr.T(mustMakeDecimal(1234, 10)),
+						ptr.T(mustMakeDecimal(-50, 10)),
+						nil,
+					},
+					"col_02_numeric_unconstrained": []*string{
+						ptr.String("3.14159265358979323846264338327950288419716939937510"),
+						ptr.String("-100"),
+						nil,
+					},
+					"col_03_time": []*int64{
+						ptr.Int64((12*time.Hour + 55*time.Minute + 28*time.Second + 123*time.Millisecond).Microseconds()),
+						ptr.Int64((24*time.Hour - time.Microsecond).Microseconds()),
+						nil,
+					},
+					"col_04_interval": []*int64{
+						ptr.Int64((26*time.Hour + 3*time.Minute + 4*time.Second).Microseconds()),
+						ptr.Int64((-3 * time.Hour).Microseconds()),
+						nil,
+					},
+					"col_05_jsonb": []*[]byte{
+						ptr.T([]byte(`{"age": 35, "name": "James Holden"}`)),
+						ptr.T([]byte(`[1, 2, 3]`)),
+						nil,
+					},
+				},
+			},
+		},
+	},
+	"arrays": {
+		Name:                  "arrays",
+		IDArrayBuilderFactory: newInt32IDArrayBuilder(memPool),
+		Schema: &test_utils.TableSchema{
+			Columns: map[string]*Ydb.Type{
+				"id": common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT32)),
+				"col_01_int_array": common.MakeOptionalType(common.MakeListType(
+					common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_INT32)))),
+				"col_02_text_array": common.MakeOptionalType(common.MakeListType(
+					common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)))),
+			},
+		},
+		Records: []*test_utils.Record[int32, *array.Int32Builder]{
+			{
+				Columns: map[string]any{
+					"id": []*int32{ptr.Int32(1), ptr.Int32(2), ptr.Int32(3)},
+					"col_01_int_array": []*[]*int32{
+						{ptr.Int32(1), nil, ptr.Int32(3)},
+						{},
+						nil,
+					},
+					"col_02_text_array": []*[]*string{
+						{ptr.String("a"), ptr.String("b")},
+						nil,
+						{nil},
+					},
+				},
+			},
+		},
+	},
 	"datetime_format_yql": {
 		Name:                  "datetime",
 		IDArrayBuilderFactory: newInt32IDArrayBuilder(memPool),
@@ -501,6 +578,15 @@ func pushdownSchemaYdb() *test_utils.TableSchema {
 	}
 }
 
+func mustMakeDecimal(unscaled int64, precision uint32) []byte {
+	data, err := common.DecimalToBytes(big.NewInt(unscaled), precision)
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
 func newInt32IDArrayBuilder(pool memory.Allocator) func() *array.Int32Builder {
 	return func() *array.Int32Builder {
 		return array.NewInt32Builder(pool)
@@ -80,6 +80,20 @@ set -x
         (2, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL);
     COMMIT;
 
+    CREATE TABLE extended_types (
+        id Int32 NOT NULL,
+        col_01_decimal Decimal(22,9),
+        col_02_uuid Uuid,
+        col_03_dynumber DyNumber,
+        PRIMARY KEY (id)
+    );
+    COMMIT;
+    INSERT INTO extended_types (id, col_01_decimal, col_02_uuid, col_03_dynumber)
+    VALUES
+        (1, Decimal("-3.141592653", 22, 9), Uuid("00112233-4455-6677-8899-aabbccddeeff"), DyNumber("123")),
+        (2, NULL, NULL, NULL);
+    COMMIT;
+
 
     CREATE TABLE datetime (
         id Int32 NOT NULL,
@@ -23,7 +23,7 @@ type Suite struct {
 }
 
 func (s *Suite) TestSelect() {
-	testCaseNames := []string{"simple", "primitives", "optionals"}
+	testCaseNames := []string{"simple", "primitives", "optionals", "extended_types"}
 
 	for _, tableName := range testCaseNames {
 		s.ValidateTable(s.dataSource, tables[tableName])
@@ -1,10 +1,12 @@
 package ydb
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/apache/arrow/go/v13/arrow/array"
 	"github.com/apache/arrow/go/v13/arrow/memory"
+	"github.com/google/uuid"
 	"golang.org/x/exp/constraints"
 
 	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
@@ -179,6 +181,36 @@ var tables = map[string]*test_utils.Table[int32, *array.Int32Builder]{
 		},
 	},
 
+	"extended_types": {
+		Name:                  "extended_types",
+		IDArrayBuilderFactory: newInt32IDArrayBuilder(memPool),
+		Schema: &test_utils.TableSchema{
+			Columns: map[string]*Ydb.Type{
+				"id":              common.MakePrimitiveType(Ydb.Type_INT32),
+				"col_01_decimal":  common.MakeOptionalType(common.MakeDecimalType(22, 9)),
+				"col_02_uuid":     common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UUID)),
+				"col_03_dynumber": common.MakeOptionalType(common.MakePrimitiveType(Ydb.Type_UTF8)),
+			},
+		},
+		Records: []*test_utils.Record[int32, *array.Int32Builder]{
+			{
+				Columns: map[string]any{
+					"id": []int32{1, 2},
+					"col_01_decimal": []*[]byte{
+						ptr.T(mustMakeDecimal(-3141592653, 22)),
+						nil,
+					},
+					"col_02_uuid": []*[]byte{
+						ptr.T(common.UUIDToYDBBytes(uuid.MustParse("00112233-4455-6677-8899-aabbccddeeff"))),
+						nil,
+					},
+					// DyNumber is passed in its textual representation
+					"col_03_dynumber": []*string{ptr.String(".123e3"), nil},
+				},
+			},
+		},
+	},
+
 	"datetime_format_yql": {
 		Name:                  "datetime",
 		IDArrayBuilderFactory: newInt32IDArrayBuilder(memPool),
@@ -765,6 +797,15 @@ func makeRange[T constraints.Integer](minValue, maxValue T) []T {
 	return result
 }
 
+func mustMakeDecimal(unscaled int64, precision uint32) []byte {
+	data, err := common.DecimalToBytes(big.NewInt(unscaled), precision)
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
 func newInt32IDArrayBuilder(pool memory.Allocator) func() *array.Int32Builder {
 	return func() *array.Int32Builder {
 		return array.NewInt32Builder(pool)
@@ -118,4 +118,10 @@ func TestRedis(t *testing.T) {
 func TestOpenSearch(t *testing.T) {
 	state.SkipSuiteIfNotEnabled(t)
 	testify_suite.Run(t, opensearch.NewSuite(suite.NewBase[string, *array.StringBuilder](t, state, "OpenSearch")))
+
+	option := suite.WithEmbeddedOptions(server.WithOpenSearchScoreColumn(true))
+	testify_suite.Run(
+		t,
+		opensearch.NewScoreSuite(suite.NewBase[string, *array.StringBuilder](t, state, "OpenSearch_score", option)),
+	)
 }
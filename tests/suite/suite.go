@@ -197,6 +197,32 @@ func (b *Base[ID, IDBUILDER]) doValidateTableMetadata(
 	table.MatchSchema(b.T(), schema)
 }
 
+// ValidateListTables checks that the tables matching the pattern
+// are listed by the connector for every data source instance
+func (b *Base[ID, IDBUILDER]) ValidateListTables(
+	ds *datasource.DataSource,
+	pattern string,
+	expectedTables []string,
+) {
+	for _, dsi := range ds.Instances {
+		ctx, cancel := context.WithTimeout(test_utils.NewContextWithTestName(), 60*time.Second)
+
+		responses, err := b.Connector.ClientBuffering().ListTables(ctx, dsi, pattern)
+		b.Require().NoError(err)
+
+		var actualTables []string
+
+		for _, response := range responses {
+			b.Require().Equal(Ydb.StatusIds_SUCCESS, response.Error.Status, response.Error.String())
+			actualTables = append(actualTables, response.Tables...)
+		}
+
+		b.Require().ElementsMatch(expectedTables, actualTables)
+
+		cancel()
+	}
+}
+
 func (b *Base[ID, IDBUILDER]) ValidateTable(
 	ds *datasource.DataSource,
 	table *test_utils.Table[ID, IDBUILDER],
@@ -2,7 +2,9 @@ package utils
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"sort"
 	"strings"
 	"testing"
@@ -54,6 +56,8 @@ func (r *Record[ID, IDBUILDER]) MatchRecord(
 			matchColumns(t, arrowField, r.Columns[arrowField.Name], recordWithRowsSorted.Column(i), false)
 		case *Ydb.Type_OptionalType:
 			matchColumns(t, arrowField, r.Columns[arrowField.Name], recordWithRowsSorted.Column(i), true)
+		case *Ydb.Type_ListType:
+			matchColumns(t, arrowField, r.Columns[arrowField.Name], recordWithRowsSorted.Column(i), false)
 		default:
 			require.FailNow(t, fmt.Sprintf("unexpected YDB type: %v", ydbType))
 		}
@@ -187,6 +191,8 @@ func sortTableByID[ID TableIDTypes, IDBUILDER ArrowIDBuilder[ID]](table arrow.Re
 			processColumn[string, *array.String](table, colIdx, restCols)
 		case *array.Binary:
 			processColumn[[]byte, *array.Binary](table, colIdx, restCols)
+		case *array.FixedSizeBinary:
+			processColumn[[]byte, *array.FixedSizeBinary](table, colIdx, restCols)
 		//nolint:revive
 		case *array.Struct:
 			// Обработка для структурных типов
@@ -236,6 +242,15 @@ func sortTableByID[ID TableIDTypes, IDBUILDER ArrowIDBuilder[ID]](table arrow.Re
 					restCols[rowIdx][colIdx-1] = structData
 				}
 			}
+		case *array.List:
+			// lists are kept in their JSON representation that can be appended back to the list builder
+			for rowIdx := 0; rowIdx < int(table.NumRows()); rowIdx++ {
+				if len(restCols[rowIdx]) == 0 {
+					restCols[rowIdx] = make([]any, table.NumCols()-1)
+				}
+
+				restCols[rowIdx][colIdx-1] = col.ValueStr(rowIdx)
+			}
 		default:
 			panic(fmt.Sprintf("UNSUPPORTED TYPE: %T", table.Column(colIdx)))
 		}
@@ -298,10 +313,15 @@ func sortTableByID[ID TableIDTypes, IDBUILDER ArrowIDBuilder[ID]](table arrow.Re
 					restBuilders[colIdx] = array.NewNullBuilder(pool)
 				case *array.Binary:
 					restBuilders[colIdx] = array.NewBinaryBuilder(pool, arrow.BinaryTypes.Binary)
+				case *array.FixedSizeBinary:
+					restBuilders[colIdx] = array.NewFixedSizeBinaryBuilder(pool, table.Column(colIdx+1).DataType().(*arrow.FixedSizeBinaryType))
 				case *array.Struct:
 					// Создаем новый StructBuilder на основе существующего типа
 					structType := table.Column(colIdx + 1).DataType().(*arrow.StructType)
 					restBuilders[colIdx] = array.NewStructBuilder(pool, structType)
+				case *array.List:
+					listType := table.Column(colIdx + 1).DataType().(*arrow.ListType)
+					restBuilders[colIdx] = array.NewListBuilder(pool, listType.Elem())
 				default:
 					panic(fmt.Sprintf("UNSUPPORTED TYPE: %T", table.Column(colIdx+1)))
 				}
@@ -334,6 +354,8 @@ func sortTableByID[ID TableIDTypes, IDBUILDER ArrowIDBuilder[ID]](table arrow.Re
 				builder.AppendNull()
 			case *array.BinaryBuilder:
 				appendToBuilder(builder, val)
+			case *array.FixedSizeBinaryBuilder:
+				appendToBuilder(builder, val)
 			case *array.StructBuilder:
 				// Обработка структуры
 				if val == nil {
@@ -420,6 +442,10 @@ func sortTableByID[ID TableIDTypes, IDBUILDER ArrowIDBuilder[ID]](table arrow.Re
 						}
 					}
 				}
+			case *array.ListBuilder:
+				if err := builder.AppendValueFromString(val.(string)); err != nil {
+					panic(fmt.Sprintf("append list value %v: %v", val, err))
+				}
 			default:
 				panic(fmt.Sprintf("UNSUPPORTED BUILDER TYPE: %T", builder))
 			}
@@ -475,8 +501,12 @@ func matchColumns(t *testing.T, arrowField arrow.Field, expected any, actual arr
 		}
 	case arrow.BINARY:
 		matchArrays[[]byte, *array.Binary](t, arrowField.Name, expected, actual, optional)
+	case arrow.FIXED_SIZE_BINARY:
+		matchArrays[[]byte, *array.FixedSizeBinary](t, arrowField.Name, expected, actual, optional)
 	case arrow.STRUCT:
 		matchStructArrays(t, arrowField.Name, expected, actual.(*array.Struct), optional)
+	case arrow.LIST:
+		matchListArrays(t, arrowField.Name, expected, actual.(*array.List))
 	default:
 		require.FailNow(t, fmt.Sprintf("unexpected arrow type: %v", arrowField.Type.ID().String()))
 	}
@@ -576,6 +606,37 @@ func matchStructArrays(
 	}
 }
 
+// matchListArrays compares lists in their JSON representation. Expected values are provided as
+// a slice of rows, where every row is a slice of items ([][]*T), or a pointer to it for optional lists ([]*[]*T).
+func matchListArrays(t *testing.T, columnName string, expectedRaw any, actual *array.List) {
+	expected := reflect.ValueOf(expectedRaw)
+	require.Equal(t, reflect.Slice, expected.Kind(), fmt.Sprintf("invalid type for list column %v: %T", columnName, expectedRaw))
+	require.Equal(t, expected.Len(), actual.Len(),
+		fmt.Sprintf("list column:  %v\nexpected length: %d\nactual length:  %d\n", columnName, expected.Len(), actual.Len()))
+
+	for i := 0; i < expected.Len(); i++ {
+		row := expected.Index(i)
+
+		if row.Kind() == reflect.Pointer {
+			if row.IsNil() {
+				require.True(t, actual.IsNull(i), fmt.Sprintf("list column: %v\nexpected NULL at index %d", columnName, i))
+
+				continue
+			}
+
+			row = row.Elem()
+		}
+
+		require.False(t, actual.IsNull(i), fmt.Sprintf("list column: %v\nexpected non-NULL at index %d", columnName, i))
+
+		expectedJSON, err := json.Marshal(row.Interface())
+		require.NoError(t, err)
+
+		require.JSONEq(t, string(expectedJSON), actual.ValueStr(i),
+			fmt.Sprintf("list column: %v\nvalues mismatch at index %d", columnName, i))
+	}
+}
+
 func matchArrays[EXPECTED common.ValueType, ACTUAL common.ArrowArrayType[EXPECTED]](
 	t *testing.T,
 	columnName string,
@@ -146,4 +146,33 @@ func TestSortTableByID(t *testing.T) {
 			}
 		}
 	})
+	t.Run("Test with list values", func(t *testing.T) {
+		idBuilder := array.NewInt32Builder(pool)
+		idBuilder.AppendValues([]int32{2, 1, 3}, nil)
+		idArr := idBuilder.NewArray()
+
+		defer idArr.Release()
+
+		listBuilder := array.NewListBuilder(pool, arrow.PrimitiveTypes.Int32)
+		listBuilder.Append(true)
+		listBuilder.ValueBuilder().(*array.Int32Builder).AppendValues([]int32{20, 21}, nil)
+		listBuilder.Append(true)
+		listBuilder.AppendNull()
+		listArr := listBuilder.NewArray()
+
+		defer listArr.Release()
+
+		schema := arrow.NewSchema([]arrow.Field{
+			{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "value", Type: arrow.ListOf(arrow.PrimitiveTypes.Int32)},
+		}, nil)
+		table := array.NewRecord(schema, []arrow.Array{idArr, listArr}, 3)
+		newIDBuilder := NewInt32IDArrayBuilder(pool)
+
+		sortedTable := sortTableByID[int32, *array.Int32Builder](table, newIDBuilder)
+
+		require.Equal(t, int64(3), sortedTable.NumRows())
+
+		matchListArrays(t, "value", []*[]*int32{{}, {ptr.Int32(20), ptr.Int32(21)}, nil}, sortedTable.Column(1).(*array.List))
+	})
 }
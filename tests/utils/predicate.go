@@ -122,6 +122,20 @@ func MakePredicateRegexpColumn(columnName, pattern string) *api_service_protos.T
 	}
 }
 
+func MakePredicateFullTextMatch(
+	mode api_service_protos.TPredicate_TFullTextMatch_EMode,
+	query string,
+	columnNames ...string,
+) *api_service_protos.TPredicate_FullTextMatch {
+	return &api_service_protos.TPredicate_FullTextMatch{
+		FullTextMatch: &api_service_protos.TPredicate_TFullTextMatch{
+			Mode:    mode,
+			Columns: columnNames,
+			Query:   query,
+		},
+	}
+}
+
 func MakePredicateRegexpIfCastColumn(
 	columnName string,
 	targetTypeId Ydb.Type_PrimitiveTypeId,